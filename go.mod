@@ -16,6 +16,7 @@ require (
 	github.com/redis/go-redis/v9 v9.17.3
 	github.com/wneessen/go-mail v0.7.2
 	golang.org/x/crypto v0.46.0
+	golang.org/x/text v0.32.0
 )
 
 require (
@@ -36,5 +37,4 @@ require (
 	go.uber.org/multierr v1.11.0 // indirect
 	golang.org/x/sync v0.19.0 // indirect
 	golang.org/x/sys v0.39.0 // indirect
-	golang.org/x/text v0.32.0 // indirect
 )
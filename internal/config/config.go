@@ -3,8 +3,10 @@ package config
 import (
 	"log"
 	"log/slog"
+	"net/netip"
 	"os"
 	"strconv"
+	"strings"
 )
 
 type SMTP struct {
@@ -15,16 +17,81 @@ type SMTP struct {
 	Sender   string `mapstructure:"SMTP_SENDER"`
 }
 
+// Reviews configures who may review products and how ratings are averaged.
+type Reviews struct {
+	VerifiedOnly   bool    // Only users with a delivered order containing the product may review it
+	VerifiedWeight float64 // Weight of verified-purchase reviews in avg_rating (1 = same as other reviews)
+}
+
+// Audit configures the admin audit log.
+type Audit struct {
+	RetentionDays int // Entries older than this are purged daily (0 = keep forever)
+}
+
+// TwoFactor configures TOTP two-factor authentication.
+type TwoFactor struct {
+	Issuer            string // Name shown in authenticator apps
+	RequiredForAdmins bool   // Staff must enable 2FA and sign in with it before using the admin area
+}
+
+// LoginProtection configures brute-force protection of the login endpoints.
+type LoginProtection struct {
+	MaxAccountFailures int // Failed logins before an account is locked
+	MaxIPFailures      int // Failed logins from one IP, across all accounts, before the IP is locked
+	LockoutMinutes     int
+	BackoffBaseSeconds int // Delay after the first failure; it doubles with each further failure
+}
+
+// JWTKeys configures asymmetric (RS256 or EdDSA) signing of JWTs, so other services can verify tokens
+// with the public keys published at /.well-known/jwks.json. Without a signing key, tokens are signed
+// with JWTSecret (HS256).
+type JWTKeys struct {
+	SigningKeyID string // kid of the key that signs new tokens
+	SigningKey   string // PEM private key; alternatively KeysDir holds it as {SigningKeyID}.pem
+	KeysDir      string // Directory of {kid}.pem private or public keys; every key in it verifies tokens
+}
+
+// OIDC configures social login through an OpenID Connect provider such as Google.
+type OIDC struct {
+	Provider     string // Name stored with linked identities, e.g. "google"
+	IssuerURL    string // Discovery is read from {IssuerURL}/.well-known/openid-configuration; empty disables OIDC login
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string // Frontend page that receives the code and state and posts them to /api/v1/auth/oidc/callback
+}
+
+// Delivery configures delivery pricing.
+type Delivery struct {
+	DefaultWeightGrams int // Shipping weight assumed for products without one
+}
+
+// Couriers configures the courier integrations that ship parcels and report their tracking status.
+type Couriers struct {
+	PollIntervalMinutes int    // How often open shipments are tracked with their courier (0 disables polling)
+	FakeEnabled         bool   // Registers the "fake" courier, which simulates deliveries for local testing
+	FakeStepSeconds     int    // Time a fake parcel takes to move on to its next status
+	FakeWebhookSecret   string // HMAC key of fake courier webhooks; empty rejects them
+}
+
 type Config struct {
-	ServerPort    string
-	DBURL         string
-	JWTSecret     string
-	RedisHost     string
-	RedisPort     string
-	RedisPassword string
-	RedisDB       int
-	SMTP          SMTP   `mapstructure:"smtp"`
-	BaseURL       string `mapstructure:"SERVER_BASE_URL"` // Add this field with the correct mapstructure tag
+	ServerPort      string
+	TrustedProxies  []netip.Prefix // Reverse proxies whose X-Forwarded-For/X-Real-IP headers give the client address
+	DBURL           string
+	JWTSecret       string
+	JWTKeys         JWTKeys
+	RedisHost       string
+	RedisPort       string
+	RedisPassword   string
+	RedisDB         int
+	SMTP            SMTP   `mapstructure:"smtp"`
+	BaseURL         string `mapstructure:"SERVER_BASE_URL"` // Add this field with the correct mapstructure tag
+	Reviews         Reviews
+	Audit           Audit
+	TwoFactor       TwoFactor
+	LoginProtection LoginProtection
+	OIDC            OIDC
+	Delivery        Delivery
+	Couriers        Couriers
 }
 
 func LoadConfig() *Config {
@@ -37,6 +104,11 @@ func LoadConfig() *Config {
 		RedisPassword: getEnvOrDefault("REDIS_PASSWORD", ""),
 		RedisDB:       getEnvAsInt("REDIS_DB", 0),
 		BaseURL:       getEnvOrDefault("SERVER_BASE_URL", "http://localhost:3000"), // Provide a default value like localhost for dev
+		JWTKeys: JWTKeys{
+			SigningKeyID: getEnvOrDefault("JWT_SIGNING_KEY_ID", ""),
+			SigningKey:   getEnvOrDefault("JWT_SIGNING_KEY", ""),
+			KeysDir:      getEnvOrDefault("JWT_KEYS_DIR", ""),
+		},
 		// Load SMTP configuration
 		SMTP: SMTP{
 			Host:     getEnvOrDefault("SMTP_HOST", ""), // Provide a default if needed, maybe empty string
@@ -45,11 +117,55 @@ func LoadConfig() *Config {
 			Password: getEnvOrDefault("SMTP_PASSWORD", ""),
 			Sender:   getEnvOrDefault("SMTP_SENDER", ""),
 		},
+		Reviews: Reviews{
+			VerifiedOnly:   getEnvAsBool("REVIEWS_VERIFIED_ONLY", false),
+			VerifiedWeight: getEnvAsFloat("REVIEWS_VERIFIED_WEIGHT", 1),
+		},
+		Audit: Audit{
+			RetentionDays: getEnvAsInt("AUDIT_LOG_RETENTION_DAYS", 365),
+		},
+		TwoFactor: TwoFactor{
+			Issuer:            getEnvOrDefault("TWO_FACTOR_ISSUER", "DzTech"),
+			RequiredForAdmins: getEnvAsBool("TWO_FACTOR_REQUIRED_FOR_ADMINS", false),
+		},
+		TrustedProxies: getEnvAsPrefixes("TRUSTED_PROXIES"),
+		LoginProtection: LoginProtection{
+			MaxAccountFailures: getEnvAsInt("LOGIN_MAX_FAILED_ATTEMPTS", 5),
+			MaxIPFailures:      getEnvAsInt("LOGIN_MAX_FAILED_ATTEMPTS_PER_IP", 20),
+			LockoutMinutes:     getEnvAsInt("LOGIN_LOCKOUT_MINUTES", 15),
+			BackoffBaseSeconds: getEnvAsInt("LOGIN_BACKOFF_BASE_SECONDS", 1),
+		},
+		OIDC: OIDC{
+			Provider:     getEnvOrDefault("OIDC_PROVIDER", "google"),
+			IssuerURL:    getEnvOrDefault("OIDC_ISSUER_URL", ""),
+			ClientID:     getEnvOrDefault("OIDC_CLIENT_ID", ""),
+			ClientSecret: getEnvOrDefault("OIDC_CLIENT_SECRET", ""),
+			RedirectURL:  getEnvOrDefault("OIDC_REDIRECT_URL", "http://localhost:3000/auth/callback"),
+		},
+		Delivery: Delivery{
+			DefaultWeightGrams: getEnvAsInt("DELIVERY_DEFAULT_WEIGHT_GRAMS", 1000),
+		},
+		Couriers: Couriers{
+			PollIntervalMinutes: getEnvAsInt("COURIER_POLL_INTERVAL_MINUTES", 15),
+			FakeEnabled:         getEnvAsBool("FAKE_COURIER_ENABLED", false),
+			FakeStepSeconds:     getEnvAsInt("FAKE_COURIER_STEP_SECONDS", 120),
+			FakeWebhookSecret:   getEnvOrDefault("FAKE_COURIER_WEBHOOK_SECRET", ""),
+		},
+	}
+
+	if cfg.Reviews.VerifiedWeight <= 0 {
+		slog.Warn("REVIEWS_VERIFIED_WEIGHT must be positive, using 1", "value", cfg.Reviews.VerifiedWeight)
+		cfg.Reviews.VerifiedWeight = 1
 	}
 
-	if cfg.JWTSecret == "" {
-		slog.Error("JWT_SECRET environment variable is required")
-		panic("JWT_SECRET environment variable is required")
+	if cfg.Delivery.DefaultWeightGrams <= 0 {
+		slog.Warn("DELIVERY_DEFAULT_WEIGHT_GRAMS must be positive, using 1000", "value", cfg.Delivery.DefaultWeightGrams)
+		cfg.Delivery.DefaultWeightGrams = 1000
+	}
+
+	if cfg.JWTSecret == "" && cfg.JWTKeys.SigningKeyID == "" {
+		slog.Error("JWT_SECRET or JWT_SIGNING_KEY_ID environment variable is required")
+		panic("JWT_SECRET or JWT_SIGNING_KEY_ID environment variable is required")
 	}
 
 	return cfg
@@ -73,3 +189,49 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		boolValue, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Printf("Warning: Could not parse environment variable %s as boolean, using default %t", key, defaultValue)
+			return defaultValue
+		}
+		return boolValue
+	}
+	return defaultValue
+}
+
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		floatValue, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			log.Printf("Warning: Could not parse environment variable %s as float, using default %g", key, defaultValue)
+			return defaultValue
+		}
+		return floatValue
+	}
+	return defaultValue
+}
+
+// getEnvAsPrefixes reads a comma-separated list of IP addresses and CIDR ranges. Invalid entries are skipped.
+func getEnvAsPrefixes(key string) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, entry := range strings.Split(os.Getenv(key), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(entry)
+		if err != nil {
+			log.Printf("Warning: Could not parse %q in environment variable %s as an IP address or CIDR range, ignoring it", entry, key)
+			continue
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+	}
+	return prefixes
+}
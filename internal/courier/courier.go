@@ -0,0 +1,121 @@
+package courier
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// Shipment statuses. Each courier maps its own statuses onto these.
+const (
+	StatusCreated        = "created"          // Registered with the courier, not yet picked up
+	StatusInTransit      = "in_transit"       // Picked up and on its way to the destination wilaya
+	StatusOutForDelivery = "out_for_delivery" // With the driver, or waiting at the stop desk
+	StatusDelivered      = "delivered"
+	StatusFailedAttempt  = "failed_attempt" // The customer could not be reached; the courier will try again
+	StatusReturned       = "returned"       // Sent back to the shop
+	StatusCancelled      = "cancelled"
+)
+
+var (
+	ErrShipmentNotFound = errors.New("shipment not found at courier")
+	ErrNotCancellable   = errors.New("shipment can no longer be cancelled")
+	ErrInvalidWebhook   = errors.New("invalid courier webhook")
+)
+
+// IsFinalStatus reports whether a shipment in status will not change any more.
+func IsFinalStatus(status string) bool {
+	return status == StatusDelivered || status == StatusReturned || status == StatusCancelled
+}
+
+func isStatus(status string) bool {
+	switch status {
+	case StatusCreated, StatusInTransit, StatusOutForDelivery, StatusDelivered, StatusFailedAttempt, StatusReturned, StatusCancelled:
+		return true
+	}
+	return false
+}
+
+// ShipmentRequest describes a parcel to hand over to a courier.
+type ShipmentRequest struct {
+	Reference      string // Our order ID, printed on the label
+	RecipientName  string
+	PhoneNumber1   string
+	PhoneNumber2   *string
+	WilayaCode     int16
+	WilayaName     string
+	CommuneCode    int32
+	CommuneName    string
+	DeliveryType   string // "home" or "stop_desk"
+	WeightGrams    int64
+	CODAmountCents int64 // Cash to collect from the recipient
+	Notes          *string
+}
+
+// Shipment is a parcel registered with a courier.
+type Shipment struct {
+	TrackingNumber string
+	CreatedAt      time.Time
+}
+
+// Label is a printable shipping label.
+type Label struct {
+	ContentType string
+	Data        []byte
+}
+
+// TrackingEvent is a status change of a shipment reported by a courier.
+type TrackingEvent struct {
+	TrackingNumber string
+	Status         string
+	Description    string
+	OccurredAt     time.Time
+}
+
+// Courier is a delivery company integration.
+type Courier interface {
+	// CreateShipment registers a parcel with the courier and returns its tracking number.
+	CreateShipment(ctx context.Context, req ShipmentRequest) (*Shipment, error)
+	// GetLabel returns the shipping label of a parcel.
+	GetLabel(ctx context.Context, trackingNumber string) (*Label, error)
+	// Track returns the status history of a parcel, oldest first.
+	Track(ctx context.Context, trackingNumber string) ([]TrackingEvent, error)
+	// CancelShipment withdraws a parcel that has not been picked up yet.
+	CancelShipment(ctx context.Context, trackingNumber string) error
+	// ParseWebhook authenticates a status notification sent by the courier and returns the events it carries.
+	// It returns ErrInvalidWebhook if the request was not sent by the courier or cannot be read.
+	ParseWebhook(r *http.Request) ([]TrackingEvent, error)
+}
+
+// Registry holds the configured couriers by name. Delivery services refer to couriers by these names.
+type Registry struct {
+	couriers map[string]Courier
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{couriers: make(map[string]Courier)}
+}
+
+// Register makes a courier available under name. It must be called before requests are served.
+func (r *Registry) Register(name string, c Courier) {
+	r.couriers[name] = c
+}
+
+// Get returns the courier registered under name.
+func (r *Registry) Get(name string) (Courier, bool) {
+	c, ok := r.couriers[name]
+	return c, ok
+}
+
+// Names returns the names of the registered couriers, sorted.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.couriers))
+	for name := range r.couriers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,181 @@
+package courier
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FakeWebhookSignatureHeader carries the hex HMAC-SHA256 of a fake courier webhook body.
+const FakeWebhookSignatureHeader = "X-Fake-Courier-Signature"
+
+// fakeProgress is the route every fake parcel takes, one step per step duration.
+var fakeProgress = []struct {
+	status      string
+	description string
+}{
+	{StatusCreated, "Shipment registered"},
+	{StatusInTransit, "Picked up by the courier"},
+	{StatusOutForDelivery, "Out for delivery"},
+	{StatusDelivered, "Delivered to the recipient"},
+}
+
+// FakeCourier simulates a courier for local testing. Parcels advance one status per step until they
+// are delivered. The creation time is encoded in the tracking number, so parcels keep progressing
+// across restarts; labels and cancellations are only kept in memory.
+// Webhooks take a JSON body {"tracking_number", "status", "description", "occurred_at"} signed with
+// the webhook secret, which lets other statuses such as failed_attempt or returned be tried out.
+type FakeCourier struct {
+	step          time.Duration
+	webhookSecret []byte
+
+	mu        sync.Mutex
+	requests  map[string]ShipmentRequest
+	cancelled map[string]time.Time
+}
+
+// NewFakeCourier creates a FakeCourier whose parcels advance every step. An empty webhook secret
+// rejects every webhook.
+func NewFakeCourier(step time.Duration, webhookSecret string) *FakeCourier {
+	return &FakeCourier{
+		step:          step,
+		webhookSecret: []byte(webhookSecret),
+		requests:      make(map[string]ShipmentRequest),
+		cancelled:     make(map[string]time.Time),
+	}
+}
+
+func (c *FakeCourier) CreateShipment(ctx context.Context, req ShipmentRequest) (*Shipment, error) {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, fmt.Errorf("failed to generate tracking number: %w", err)
+	}
+	createdAt := time.Now().Truncate(time.Second)
+	trackingNumber := fmt.Sprintf("FAKE-%d-%s", createdAt.Unix(), strings.ToUpper(hex.EncodeToString(suffix)))
+
+	c.mu.Lock()
+	c.requests[trackingNumber] = req
+	c.mu.Unlock()
+	return &Shipment{TrackingNumber: trackingNumber, CreatedAt: createdAt}, nil
+}
+
+func (c *FakeCourier) GetLabel(ctx context.Context, trackingNumber string) (*Label, error) {
+	if _, err := fakeCreatedAt(trackingNumber); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	req, ok := c.requests[trackingNumber]
+	c.mu.Unlock()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "FAKE COURIER\nTracking: %s\n", trackingNumber)
+	if ok {
+		fmt.Fprintf(&b, "Order: %s\nTo: %s\nPhone: %s\n%s, %s (%d)\nDelivery: %s\nWeight: %d g\nCash on delivery: %d.%02d DZD\n",
+			req.Reference, req.RecipientName, req.PhoneNumber1, req.CommuneName, req.WilayaName, req.WilayaCode,
+			req.DeliveryType, req.WeightGrams, req.CODAmountCents/100, req.CODAmountCents%100)
+	}
+	return &Label{ContentType: "text/plain; charset=utf-8", Data: []byte(b.String())}, nil
+}
+
+func (c *FakeCourier) Track(ctx context.Context, trackingNumber string) ([]TrackingEvent, error) {
+	createdAt, err := fakeCreatedAt(trackingNumber)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	cancelledAt, cancelled := c.cancelled[trackingNumber]
+	c.mu.Unlock()
+
+	now := time.Now()
+	var events []TrackingEvent
+	for i, p := range fakeProgress {
+		occurredAt := createdAt.Add(time.Duration(i) * c.step)
+		if occurredAt.After(now) || (cancelled && occurredAt.After(cancelledAt)) {
+			break
+		}
+		events = append(events, TrackingEvent{TrackingNumber: trackingNumber, Status: p.status, Description: p.description, OccurredAt: occurredAt})
+	}
+	if cancelled {
+		events = append(events, TrackingEvent{TrackingNumber: trackingNumber, Status: StatusCancelled, Description: "Cancelled by the shop", OccurredAt: cancelledAt})
+	}
+	return events, nil
+}
+
+func (c *FakeCourier) CancelShipment(ctx context.Context, trackingNumber string) error {
+	createdAt, err := fakeCreatedAt(trackingNumber)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if !now.Before(createdAt.Add(c.step)) {
+		return ErrNotCancellable // Already picked up
+	}
+	c.mu.Lock()
+	c.cancelled[trackingNumber] = now
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *FakeCourier) ParseWebhook(r *http.Request) ([]TrackingEvent, error) {
+	if len(c.webhookSecret) == 0 {
+		return nil, fmt.Errorf("%w: no webhook secret configured", ErrInvalidWebhook)
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, 64<<10))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+	signature, err := hex.DecodeString(r.Header.Get(FakeWebhookSignatureHeader))
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidWebhook)
+	}
+	mac := hmac.New(sha256.New, c.webhookSecret)
+	mac.Write(body)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, fmt.Errorf("%w: signature mismatch", ErrInvalidWebhook)
+	}
+
+	var payload struct {
+		TrackingNumber string    `json:"tracking_number"`
+		Status         string    `json:"status"`
+		Description    string    `json:"description"`
+		OccurredAt     time.Time `json:"occurred_at"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+	if payload.TrackingNumber == "" || !isStatus(payload.Status) {
+		return nil, fmt.Errorf("%w: tracking_number and a known status are required", ErrInvalidWebhook)
+	}
+	if payload.OccurredAt.IsZero() {
+		payload.OccurredAt = time.Now()
+	}
+	return []TrackingEvent{{
+		TrackingNumber: payload.TrackingNumber,
+		Status:         payload.Status,
+		Description:    payload.Description,
+		OccurredAt:     payload.OccurredAt,
+	}}, nil
+}
+
+// fakeCreatedAt reads the creation time out of a FAKE-{unix seconds}-{suffix} tracking number.
+func fakeCreatedAt(trackingNumber string) (time.Time, error) {
+	parts := strings.Split(trackingNumber, "-")
+	if len(parts) != 3 || parts[0] != "FAKE" {
+		return time.Time{}, ErrShipmentNotFound
+	}
+	seconds, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return time.Time{}, ErrShipmentNotFound
+	}
+	return time.Unix(seconds, 0), nil
+}
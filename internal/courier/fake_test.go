@@ -0,0 +1,96 @@
+package courier
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFakeCourierParseWebhook(t *testing.T) {
+	const secret = "webhook-secret"
+	sign := func(body string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(body))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+	occurredAt := time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		noSecret  bool
+		body      string
+		signature string // Defaults to the body signed with the secret
+		unsigned  bool
+		want      *TrackingEvent
+		wantErr   bool
+	}{
+		{
+			name: "valid notification",
+			body: `{"tracking_number":"FAKE-1-ab","status":"failed_attempt","description":"Customer absent","occurred_at":"2026-03-14T09:30:00Z"}`,
+			want: &TrackingEvent{TrackingNumber: "FAKE-1-ab", Status: StatusFailedAttempt, Description: "Customer absent", OccurredAt: occurredAt},
+		},
+		{
+			name: "missing occurred_at defaults to now",
+			body: `{"tracking_number":"FAKE-1-ab","status":"returned"}`,
+			want: &TrackingEvent{TrackingNumber: "FAKE-1-ab", Status: StatusReturned},
+		},
+		{name: "no secret configured", noSecret: true, body: `{"tracking_number":"FAKE-1-ab","status":"returned"}`, wantErr: true},
+		{name: "signature mismatch", body: `{"tracking_number":"FAKE-1-ab","status":"returned"}`, signature: sign("other body"), wantErr: true},
+		{name: "malformed signature", body: `{"tracking_number":"FAKE-1-ab","status":"returned"}`, signature: "not-hex", wantErr: true},
+		{name: "missing signature", body: `{"tracking_number":"FAKE-1-ab","status":"returned"}`, unsigned: true, wantErr: true},
+		{name: "invalid JSON", body: `{"tracking_number":`, wantErr: true},
+		{name: "missing tracking number", body: `{"status":"returned"}`, wantErr: true},
+		{name: "unknown status", body: `{"tracking_number":"FAKE-1-ab","status":"lost"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			courierSecret := secret
+			if tt.noSecret {
+				courierSecret = ""
+			}
+			c := NewFakeCourier(time.Minute, courierSecret)
+
+			req := httptest.NewRequest("POST", "/api/v1/webhooks/couriers/fake", strings.NewReader(tt.body))
+			switch {
+			case tt.unsigned:
+			case tt.signature != "":
+				req.Header.Set(FakeWebhookSignatureHeader, tt.signature)
+			default:
+				req.Header.Set(FakeWebhookSignatureHeader, sign(tt.body))
+			}
+
+			before := time.Now()
+			events, err := c.ParseWebhook(req)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidWebhook) {
+					t.Fatalf("ParseWebhook() error = %v, want ErrInvalidWebhook", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseWebhook() error = %v", err)
+			}
+			if len(events) != 1 {
+				t.Fatalf("ParseWebhook() returned %d events, want 1", len(events))
+			}
+
+			got := events[0]
+			if got.TrackingNumber != tt.want.TrackingNumber || got.Status != tt.want.Status || got.Description != tt.want.Description {
+				t.Errorf("ParseWebhook() = %+v, want %+v", got, *tt.want)
+			}
+			if tt.want.OccurredAt.IsZero() {
+				if got.OccurredAt.Before(before) || got.OccurredAt.After(time.Now()) {
+					t.Errorf("ParseWebhook() OccurredAt = %v, want the time of the call", got.OccurredAt)
+				}
+			} else if !got.OccurredAt.Equal(tt.want.OccurredAt) {
+				t.Errorf("ParseWebhook() OccurredAt = %v, want %v", got.OccurredAt, tt.want.OccurredAt)
+			}
+		})
+	}
+}
@@ -169,6 +169,110 @@ func (q *Queries) GetLowStockProducts(ctx context.Context, stockQuantity int32)
 	return items, nil
 }
 
+const getLowStockProductsByLocation = `-- name: GetLowStockProductsByLocation :many
+SELECT
+    p.id AS product_id,
+    p.name AS product_name,
+    sl.id AS location_id,
+    sl.code AS location_code,
+    sl.name AS location_name,
+    COALESCE(psl.quantity, 0)::INT AS quantity
+FROM products p
+CROSS JOIN stock_locations sl
+LEFT JOIN product_stock_levels psl ON psl.product_id = p.id AND psl.location_id = sl.id
+WHERE
+    p.deleted_at IS NULL
+    AND sl.is_active
+    AND ($1::UUID = '00000000-0000-0000-0000-000000000000' OR sl.id = $1::UUID)
+    AND COALESCE(psl.quantity, 0) < $2::INT
+ORDER BY
+    sl.priority, sl.code, quantity ASC
+`
+
+type GetLowStockProductsByLocationParams struct {
+	LocationID uuid.UUID `json:"location_id"`
+	Threshold  int32     `json:"threshold"`
+}
+
+type GetLowStockProductsByLocationRow struct {
+	ProductID    uuid.UUID `json:"product_id"`
+	ProductName  string    `json:"product_name"`
+	LocationID   uuid.UUID `json:"location_id"`
+	LocationCode string    `json:"location_code"`
+	LocationName string    `json:"location_name"`
+	Quantity     int32     `json:"quantity"`
+}
+
+// Retrieves the stock of each product at each active location that is below a threshold,
+// optionally limited to one location (pass the zero UUID for all locations).
+func (q *Queries) GetLowStockProductsByLocation(ctx context.Context, arg GetLowStockProductsByLocationParams) ([]GetLowStockProductsByLocationRow, error) {
+	rows, err := q.db.Query(ctx, getLowStockProductsByLocation, arg.LocationID, arg.Threshold)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetLowStockProductsByLocationRow
+	for rows.Next() {
+		var i GetLowStockProductsByLocationRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.ProductName,
+			&i.LocationID,
+			&i.LocationCode,
+			&i.LocationName,
+			&i.Quantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMarginSummary = `-- name: GetMarginSummary :one
+SELECT
+    COALESCE(SUM(oi.quantity * oi.price_cents), 0)::BIGINT AS total_revenue_cents,
+    COALESCE(SUM(oi.quantity * oi.price_cents) FILTER (WHERE oi.unit_cost_cents IS NOT NULL), 0)::BIGINT AS costed_revenue_cents,
+    COALESCE(SUM(oi.quantity * oi.unit_cost_cents), 0)::BIGINT AS total_cost_cents,
+    COALESCE(SUM(oi.quantity) FILTER (WHERE oi.unit_cost_cents IS NULL), 0)::BIGINT AS uncosted_units
+FROM
+    orders o
+JOIN
+    order_items oi ON o.id = oi.order_id
+WHERE
+    o.status = 'delivered'
+    AND o.created_at BETWEEN $1 AND $2
+`
+
+type GetMarginSummaryParams struct {
+	StartDate pgtype.Timestamptz `json:"start_date"`
+	EndDate   pgtype.Timestamptz `json:"end_date"`
+}
+
+type GetMarginSummaryRow struct {
+	TotalRevenueCents  int64 `json:"total_revenue_cents"`
+	CostedRevenueCents int64 `json:"costed_revenue_cents"`
+	TotalCostCents     int64 `json:"total_cost_cents"`
+	UncostedUnits      int64 `json:"uncosted_units"`
+}
+
+// Calculates revenue, cost of goods sold and gross margin for delivered orders within a given time range.
+// Items sold before their cost was known are reported separately and excluded from the margin.
+func (q *Queries) GetMarginSummary(ctx context.Context, arg GetMarginSummaryParams) (GetMarginSummaryRow, error) {
+	row := q.db.QueryRow(ctx, getMarginSummary, arg.StartDate, arg.EndDate)
+	var i GetMarginSummaryRow
+	err := row.Scan(
+		&i.TotalRevenueCents,
+		&i.CostedRevenueCents,
+		&i.TotalCostCents,
+		&i.UncostedUnits,
+	)
+	return i, err
+}
+
 const getNewCustomersCount = `-- name: GetNewCustomersCount :one
 
 SELECT
@@ -303,6 +407,75 @@ func (q *Queries) GetOrdersByStatusWithinTimeRange(ctx context.Context, arg GetO
 	return items, nil
 }
 
+const getProductMargins = `-- name: GetProductMargins :many
+SELECT
+    p.id AS product_id,
+    p.name AS product_name,
+    SUM(oi.quantity)::BIGINT AS units_sold,
+    SUM(oi.quantity * oi.price_cents)::BIGINT AS revenue_cents,
+    SUM(oi.quantity * oi.unit_cost_cents)::BIGINT AS cost_cents,
+    (SUM(oi.quantity * oi.price_cents) - SUM(oi.quantity * oi.unit_cost_cents))::BIGINT AS margin_cents
+FROM
+    order_items oi
+JOIN
+    orders o ON oi.order_id = o.id
+JOIN
+    products p ON oi.product_id = p.id
+WHERE
+    o.status = 'delivered'
+    AND oi.unit_cost_cents IS NOT NULL
+    AND o.created_at BETWEEN $1 AND $2
+GROUP BY
+    p.id, p.name
+ORDER BY
+    margin_cents DESC
+LIMIT $3
+`
+
+type GetProductMarginsParams struct {
+	StartDate pgtype.Timestamptz `json:"start_date"`
+	EndDate   pgtype.Timestamptz `json:"end_date"`
+	Limits    int32              `json:"limits"`
+}
+
+type GetProductMarginsRow struct {
+	ProductID    uuid.UUID `json:"product_id"`
+	ProductName  string    `json:"product_name"`
+	UnitsSold    int64     `json:"units_sold"`
+	RevenueCents int64     `json:"revenue_cents"`
+	CostCents    int64     `json:"cost_cents"`
+	MarginCents  int64     `json:"margin_cents"`
+}
+
+// Retrieves the N products with the highest gross margin for delivered orders within a given time range.
+// Only items with a known cost are included.
+func (q *Queries) GetProductMargins(ctx context.Context, arg GetProductMarginsParams) ([]GetProductMarginsRow, error) {
+	rows, err := q.db.Query(ctx, getProductMargins, arg.StartDate, arg.EndDate, arg.Limits)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetProductMarginsRow
+	for rows.Next() {
+		var i GetProductMarginsRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.ProductName,
+			&i.UnitsSold,
+			&i.RevenueCents,
+			&i.CostCents,
+			&i.MarginCents,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getProductReviewStats = `-- name: GetProductReviewStats :one
 SELECT
     avg_rating,
@@ -0,0 +1,177 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: audit_log.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const countAuditLog = `-- name: CountAuditLog :one
+SELECT COUNT(*)
+FROM audit_log
+WHERE ($1::UUID = '00000000-0000-0000-0000-000000000000' OR actor_id = $1::UUID)
+  AND ($2::TEXT = '' OR entity_type = $2::TEXT)
+  AND ($3::TEXT = '' OR entity_id = $3::TEXT)
+  AND ($4::TEXT = '' OR action = $4::TEXT)
+  AND ($5::TIMESTAMPTZ IS NULL OR created_at >= $5::TIMESTAMPTZ)
+  AND ($6::TIMESTAMPTZ IS NULL OR created_at < $6::TIMESTAMPTZ)
+`
+
+type CountAuditLogParams struct {
+	ActorID    uuid.UUID          `json:"actor_id"`
+	EntityType string             `json:"entity_type"`
+	EntityID   string             `json:"entity_id"`
+	Action     string             `json:"action"`
+	FromTime   pgtype.Timestamptz `json:"from_time"`
+	ToTime     pgtype.Timestamptz `json:"to_time"`
+}
+
+// Counts the audit log entries matching the same filters as ListAuditLog.
+func (q *Queries) CountAuditLog(ctx context.Context, arg CountAuditLogParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countAuditLog,
+		arg.ActorID,
+		arg.EntityType,
+		arg.EntityID,
+		arg.Action,
+		arg.FromTime,
+		arg.ToTime,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createAuditLogEntry = `-- name: CreateAuditLogEntry :exec
+INSERT INTO audit_log (actor_id, actor_email, action, entity_type, entity_id, before, after, diff, request_id, ip_address)
+VALUES (
+    NULLIF($1::UUID, '00000000-0000-0000-0000-000000000000'),
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    $10
+)
+`
+
+type CreateAuditLogEntryParams struct {
+	ActorID    uuid.UUID `json:"actor_id"`
+	ActorEmail string    `json:"actor_email"`
+	Action     string    `json:"action"`
+	EntityType string    `json:"entity_type"`
+	EntityID   string    `json:"entity_id"`
+	Before     []byte    `json:"before"`
+	After      []byte    `json:"after"`
+	Diff       []byte    `json:"diff"`
+	RequestID  string    `json:"request_id"`
+	IpAddress  string    `json:"ip_address"`
+}
+
+// Records a mutating admin action.
+// Pass the zero UUID for actor_id when the actor is unknown; it is stored as NULL.
+func (q *Queries) CreateAuditLogEntry(ctx context.Context, arg CreateAuditLogEntryParams) error {
+	_, err := q.db.Exec(ctx, createAuditLogEntry,
+		arg.ActorID,
+		arg.ActorEmail,
+		arg.Action,
+		arg.EntityType,
+		arg.EntityID,
+		arg.Before,
+		arg.After,
+		arg.Diff,
+		arg.RequestID,
+		arg.IpAddress,
+	)
+	return err
+}
+
+const deleteAuditLogBefore = `-- name: DeleteAuditLogBefore :execrows
+DELETE FROM audit_log
+WHERE created_at < $1
+`
+
+// Deletes the audit log entries older than the retention cutoff.
+func (q *Queries) DeleteAuditLogBefore(ctx context.Context, cutoff pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAuditLogBefore, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listAuditLog = `-- name: ListAuditLog :many
+SELECT id, actor_id, actor_email, action, entity_type, entity_id, before, after, diff, request_id, ip_address, created_at
+FROM audit_log
+WHERE ($1::UUID = '00000000-0000-0000-0000-000000000000' OR actor_id = $1::UUID)
+  AND ($2::TEXT = '' OR entity_type = $2::TEXT)
+  AND ($3::TEXT = '' OR entity_id = $3::TEXT)
+  AND ($4::TEXT = '' OR action = $4::TEXT)
+  AND ($5::TIMESTAMPTZ IS NULL OR created_at >= $5::TIMESTAMPTZ)
+  AND ($6::TIMESTAMPTZ IS NULL OR created_at < $6::TIMESTAMPTZ)
+ORDER BY created_at DESC
+LIMIT $8 OFFSET $7
+`
+
+type ListAuditLogParams struct {
+	ActorID    uuid.UUID          `json:"actor_id"`
+	EntityType string             `json:"entity_type"`
+	EntityID   string             `json:"entity_id"`
+	Action     string             `json:"action"`
+	FromTime   pgtype.Timestamptz `json:"from_time"`
+	ToTime     pgtype.Timestamptz `json:"to_time"`
+	PageOffset int32              `json:"page_offset"`
+	PageLimit  int32              `json:"page_limit"`
+}
+
+// Retrieves audit log entries, newest first.
+// The zero UUID and empty strings disable their filter; from_time/to_time are optional.
+func (q *Queries) ListAuditLog(ctx context.Context, arg ListAuditLogParams) ([]AuditLog, error) {
+	rows, err := q.db.Query(ctx, listAuditLog,
+		arg.ActorID,
+		arg.EntityType,
+		arg.EntityID,
+		arg.Action,
+		arg.FromTime,
+		arg.ToTime,
+		arg.PageOffset,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditLog
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.ActorID,
+			&i.ActorEmail,
+			&i.Action,
+			&i.EntityType,
+			&i.EntityID,
+			&i.Before,
+			&i.After,
+			&i.Diff,
+			&i.RequestID,
+			&i.IpAddress,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
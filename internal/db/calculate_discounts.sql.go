@@ -139,6 +139,7 @@ SELECT
     p.brand,
     p.image_urls,
     p.spec_highlights,
+    p.weight_grams,
     p.created_at,
     p.updated_at,
     p.deleted_at,
@@ -172,6 +173,7 @@ type GetProductWithDiscountInfoRow struct {
 	Brand                        string             `json:"brand"`
 	ImageUrls                    []byte             `json:"image_urls"`
 	SpecHighlights               []byte             `json:"spec_highlights"`
+	WeightGrams                  *int32             `json:"weight_grams"`
 	CreatedAt                    pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt                    pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt                    pgtype.Timestamptz `json:"deleted_at"`
@@ -200,6 +202,7 @@ func (q *Queries) GetProductWithDiscountInfo(ctx context.Context, id uuid.UUID)
 		&i.Brand,
 		&i.ImageUrls,
 		&i.SpecHighlights,
+		&i.WeightGrams,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
@@ -229,6 +232,7 @@ SELECT
     p.brand,
     p.image_urls,
     p.spec_highlights,
+    p.weight_grams,
     p.created_at,
     p.updated_at,
     p.deleted_at,
@@ -262,6 +266,7 @@ type GetProductWithDiscountInfoBySlugRow struct {
 	Brand                        string             `json:"brand"`
 	ImageUrls                    []byte             `json:"image_urls"`
 	SpecHighlights               []byte             `json:"spec_highlights"`
+	WeightGrams                  *int32             `json:"weight_grams"`
 	CreatedAt                    pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt                    pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt                    pgtype.Timestamptz `json:"deleted_at"`
@@ -292,6 +297,7 @@ func (q *Queries) GetProductWithDiscountInfoBySlug(ctx context.Context, slug str
 		&i.Brand,
 		&i.ImageUrls,
 		&i.SpecHighlights,
+		&i.WeightGrams,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
@@ -320,6 +326,7 @@ SELECT
     p.brand,
     p.image_urls,
     p.spec_highlights,
+    p.weight_grams,
     p.created_at,
     p.updated_at,
     p.deleted_at,
@@ -361,6 +368,7 @@ type GetProductsWithDiscountInfoRow struct {
 	Brand                        string             `json:"brand"`
 	ImageUrls                    []byte             `json:"image_urls"`
 	SpecHighlights               []byte             `json:"spec_highlights"`
+	WeightGrams                  *int32             `json:"weight_grams"`
 	CreatedAt                    pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt                    pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt                    pgtype.Timestamptz `json:"deleted_at"`
@@ -395,6 +403,7 @@ func (q *Queries) GetProductsWithDiscountInfo(ctx context.Context, arg GetProduc
 			&i.Brand,
 			&i.ImageUrls,
 			&i.SpecHighlights,
+			&i.WeightGrams,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
@@ -0,0 +1,163 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: delivery_rates.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const addDeliveryZoneWilayas = `-- name: AddDeliveryZoneWilayas :exec
+INSERT INTO delivery_zone_wilayas (zone_id, delivery_service_id, wilaya_code)
+SELECT $1, $2, UNNEST($3::SMALLINT[])
+`
+
+type AddDeliveryZoneWilayasParams struct {
+	ZoneID            uuid.UUID `json:"zone_id"`
+	DeliveryServiceID uuid.UUID `json:"delivery_service_id"`
+	WilayaCodes       []int16   `json:"wilaya_codes"`
+}
+
+func (q *Queries) AddDeliveryZoneWilayas(ctx context.Context, arg AddDeliveryZoneWilayasParams) error {
+	_, err := q.db.Exec(ctx, addDeliveryZoneWilayas, arg.ZoneID, arg.DeliveryServiceID, arg.WilayaCodes)
+	return err
+}
+
+const createDeliveryRate = `-- name: CreateDeliveryRate :exec
+INSERT INTO delivery_rates (zone_id, delivery_type, max_weight_grams, price_cents)
+VALUES ($1, $2, $3, $4)
+`
+
+type CreateDeliveryRateParams struct {
+	ZoneID         uuid.UUID `json:"zone_id"`
+	DeliveryType   string    `json:"delivery_type"`
+	MaxWeightGrams *int32    `json:"max_weight_grams"`
+	PriceCents     int64     `json:"price_cents"`
+}
+
+func (q *Queries) CreateDeliveryRate(ctx context.Context, arg CreateDeliveryRateParams) error {
+	_, err := q.db.Exec(ctx, createDeliveryRate,
+		arg.ZoneID,
+		arg.DeliveryType,
+		arg.MaxWeightGrams,
+		arg.PriceCents,
+	)
+	return err
+}
+
+const createDeliveryZone = `-- name: CreateDeliveryZone :one
+INSERT INTO delivery_zones (delivery_service_id, name, free_shipping_min_cents)
+VALUES ($1, $2, $3)
+RETURNING id
+`
+
+type CreateDeliveryZoneParams struct {
+	DeliveryServiceID    uuid.UUID `json:"delivery_service_id"`
+	Name                 string    `json:"name"`
+	FreeShippingMinCents *int64    `json:"free_shipping_min_cents"`
+}
+
+func (q *Queries) CreateDeliveryZone(ctx context.Context, arg CreateDeliveryZoneParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, createDeliveryZone, arg.DeliveryServiceID, arg.Name, arg.FreeShippingMinCents)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const deleteDeliveryZones = `-- name: DeleteDeliveryZones :exec
+DELETE FROM delivery_zones WHERE delivery_service_id = $1
+`
+
+// Removes the whole rate table of a delivery service; wilayas and rates go with their zones.
+func (q *Queries) DeleteDeliveryZones(ctx context.Context, deliveryServiceID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteDeliveryZones, deliveryServiceID)
+	return err
+}
+
+const listDeliveryRates = `-- name: ListDeliveryRates :many
+SELECT r.zone_id, r.delivery_type, r.max_weight_grams, r.price_cents
+FROM delivery_rates r
+JOIN delivery_zones z ON z.id = r.zone_id
+WHERE z.delivery_service_id = $1
+ORDER BY r.zone_id, r.delivery_type, r.max_weight_grams ASC NULLS LAST
+`
+
+type ListDeliveryRatesRow struct {
+	ZoneID         uuid.UUID `json:"zone_id"`
+	DeliveryType   string    `json:"delivery_type"`
+	MaxWeightGrams *int32    `json:"max_weight_grams"`
+	PriceCents     int64     `json:"price_cents"`
+}
+
+// Retrieves the rates of every zone of a delivery service, lightest bracket first and the open-ended one last.
+func (q *Queries) ListDeliveryRates(ctx context.Context, deliveryServiceID uuid.UUID) ([]ListDeliveryRatesRow, error) {
+	rows, err := q.db.Query(ctx, listDeliveryRates, deliveryServiceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDeliveryRatesRow
+	for rows.Next() {
+		var i ListDeliveryRatesRow
+		if err := rows.Scan(
+			&i.ZoneID,
+			&i.DeliveryType,
+			&i.MaxWeightGrams,
+			&i.PriceCents,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDeliveryZones = `-- name: ListDeliveryZones :many
+SELECT z.id, z.name, z.free_shipping_min_cents,
+       COALESCE(ARRAY_AGG(zw.wilaya_code ORDER BY zw.wilaya_code) FILTER (WHERE zw.wilaya_code IS NOT NULL), '{}')::SMALLINT[] AS wilaya_codes
+FROM delivery_zones z
+LEFT JOIN delivery_zone_wilayas zw ON zw.zone_id = z.id
+WHERE z.delivery_service_id = $1
+GROUP BY z.id
+ORDER BY z.name
+`
+
+type ListDeliveryZonesRow struct {
+	ID                   uuid.UUID `json:"id"`
+	Name                 string    `json:"name"`
+	FreeShippingMinCents *int64    `json:"free_shipping_min_cents"`
+	WilayaCodes          []int16   `json:"wilaya_codes"`
+}
+
+// Retrieves the zones of a delivery service along with the wilayas each one covers.
+func (q *Queries) ListDeliveryZones(ctx context.Context, deliveryServiceID uuid.UUID) ([]ListDeliveryZonesRow, error) {
+	rows, err := q.db.Query(ctx, listDeliveryZones, deliveryServiceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDeliveryZonesRow
+	for rows.Next() {
+		var i ListDeliveryZonesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.FreeShippingMinCents,
+			&i.WilayaCodes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
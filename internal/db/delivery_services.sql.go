@@ -13,19 +13,21 @@ import (
 
 const createDeliveryService = `-- name: CreateDeliveryService :one
 INSERT INTO delivery_services (
-    name, description, base_cost_cents, estimated_days, is_active
+    name, description, base_cost_cents, estimated_days, is_active, free_shipping_min_cents, courier
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6, $7
 )
-RETURNING id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at
+RETURNING id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at, free_shipping_min_cents, courier
 `
 
 type CreateDeliveryServiceParams struct {
-	Name          string  `json:"name"`
-	Description   *string `json:"description"`
-	BaseCostCents int64   `json:"base_cost_cents"`
-	EstimatedDays *int32  `json:"estimated_days"`
-	IsActive      bool    `json:"is_active"`
+	Name                 string  `json:"name"`
+	Description          *string `json:"description"`
+	BaseCostCents        int64   `json:"base_cost_cents"`
+	EstimatedDays        *int32  `json:"estimated_days"`
+	IsActive             bool    `json:"is_active"`
+	FreeShippingMinCents *int64  `json:"free_shipping_min_cents"`
+	Courier              *string `json:"courier"`
 }
 
 func (q *Queries) CreateDeliveryService(ctx context.Context, arg CreateDeliveryServiceParams) (DeliveryService, error) {
@@ -35,6 +37,8 @@ func (q *Queries) CreateDeliveryService(ctx context.Context, arg CreateDeliveryS
 		arg.BaseCostCents,
 		arg.EstimatedDays,
 		arg.IsActive,
+		arg.FreeShippingMinCents,
+		arg.Courier,
 	)
 	var i DeliveryService
 	err := row.Scan(
@@ -46,6 +50,8 @@ func (q *Queries) CreateDeliveryService(ctx context.Context, arg CreateDeliveryS
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FreeShippingMinCents,
+		&i.Courier,
 	)
 	return i, err
 }
@@ -62,7 +68,7 @@ func (q *Queries) DeleteDeliveryService(ctx context.Context, id uuid.UUID) error
 }
 
 const getActiveDeliveryServices = `-- name: GetActiveDeliveryServices :many
-SELECT id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at
+SELECT id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at, free_shipping_min_cents, courier
 FROM delivery_services
 WHERE is_active = TRUE
 ORDER BY
@@ -98,6 +104,8 @@ func (q *Queries) GetActiveDeliveryServices(ctx context.Context) ([]DeliveryServ
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.FreeShippingMinCents,
+			&i.Courier,
 		); err != nil {
 			return nil, err
 		}
@@ -110,7 +118,7 @@ func (q *Queries) GetActiveDeliveryServices(ctx context.Context) ([]DeliveryServ
 }
 
 const getDeliveryService = `-- name: GetDeliveryService :one
-SELECT id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at
+SELECT id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at, free_shipping_min_cents, courier
 FROM delivery_services
 WHERE id = $1 AND is_active = $2
 `
@@ -132,12 +140,14 @@ func (q *Queries) GetDeliveryService(ctx context.Context, arg GetDeliveryService
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FreeShippingMinCents,
+		&i.Courier,
 	)
 	return i, err
 }
 
 const getDeliveryServiceByID = `-- name: GetDeliveryServiceByID :one
-SELECT id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at
+SELECT id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at, free_shipping_min_cents, courier
 FROM delivery_services
 WHERE id = $1
 `
@@ -156,13 +166,15 @@ func (q *Queries) GetDeliveryServiceByID(ctx context.Context, id uuid.UUID) (Del
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FreeShippingMinCents,
+		&i.Courier,
 	)
 	return i, err
 }
 
 const getDeliveryServiceByName = `-- name: GetDeliveryServiceByName :one
 
-SELECT id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at
+SELECT id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at, free_shipping_min_cents, courier
 FROM delivery_services
 WHERE name = $1 AND is_active = $2
 `
@@ -185,12 +197,14 @@ func (q *Queries) GetDeliveryServiceByName(ctx context.Context, arg GetDeliveryS
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FreeShippingMinCents,
+		&i.Courier,
 	)
 	return i, err
 }
 
 const listAllDeliveryServices = `-- name: ListAllDeliveryServices :many
-SELECT id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at
+SELECT id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at, free_shipping_min_cents, courier
 FROM delivery_services
 WHERE is_active = $1 -- Filter by active status
 ORDER BY
@@ -233,6 +247,8 @@ func (q *Queries) ListAllDeliveryServices(ctx context.Context, arg ListAllDelive
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.FreeShippingMinCents,
+			&i.Courier,
 		); err != nil {
 			return nil, err
 		}
@@ -253,18 +269,23 @@ SET
     base_cost_cents = COALESCE($3, base_cost_cents),
     estimated_days = COALESCE($4, estimated_days),
     is_active = COALESCE($5, is_active),
+    free_shipping_min_cents = CASE WHEN $6::BOOLEAN THEN NULL ELSE COALESCE($7, free_shipping_min_cents) END,
+    courier = CASE WHEN $8::TEXT = '' THEN NULL ELSE COALESCE($8, courier) END, -- '' unlinks the courier
     updated_at = NOW()
-WHERE id = $6
-RETURNING id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at
+WHERE id = $9
+RETURNING id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at, free_shipping_min_cents, courier
 `
 
 type UpdateDeliveryServiceParams struct {
-	Name          *string   `json:"name"`
-	Description   *string   `json:"description"`
-	BaseCostCents *int64    `json:"base_cost_cents"`
-	EstimatedDays *int32    `json:"estimated_days"`
-	IsActive      *bool     `json:"is_active"`
-	ID            uuid.UUID `json:"id"`
+	Name                      *string   `json:"name"`
+	Description               *string   `json:"description"`
+	BaseCostCents             *int64    `json:"base_cost_cents"`
+	EstimatedDays             *int32    `json:"estimated_days"`
+	IsActive                  *bool     `json:"is_active"`
+	ClearFreeShippingMinCents bool      `json:"clear_free_shipping_min_cents"`
+	FreeShippingMinCents      *int64    `json:"free_shipping_min_cents"`
+	Courier                   *string   `json:"courier"`
+	ID                        uuid.UUID `json:"id"`
 }
 
 // Allow filtering by active status
@@ -275,6 +296,9 @@ func (q *Queries) UpdateDeliveryService(ctx context.Context, arg UpdateDeliveryS
 		arg.BaseCostCents,
 		arg.EstimatedDays,
 		arg.IsActive,
+		arg.ClearFreeShippingMinCents,
+		arg.FreeShippingMinCents,
+		arg.Courier,
 		arg.ID,
 	)
 	var i DeliveryService
@@ -287,6 +311,8 @@ func (q *Queries) UpdateDeliveryService(ctx context.Context, arg UpdateDeliveryS
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FreeShippingMinCents,
+		&i.Courier,
 	)
 	return i, err
 }
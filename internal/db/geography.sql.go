@@ -0,0 +1,72 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: geography.sql
+
+package db
+
+import (
+	"context"
+)
+
+const listCommunes = `-- name: ListCommunes :many
+SELECT code, wilaya_code, name, aliases
+FROM communes
+ORDER BY wilaya_code, name
+`
+
+func (q *Queries) ListCommunes(ctx context.Context) ([]Commune, error) {
+	rows, err := q.db.Query(ctx, listCommunes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Commune
+	for rows.Next() {
+		var i Commune
+		if err := rows.Scan(
+			&i.Code,
+			&i.WilayaCode,
+			&i.Name,
+			&i.Aliases,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWilayas = `-- name: ListWilayas :many
+SELECT code, name, name_ar, aliases
+FROM wilayas
+ORDER BY code
+`
+
+func (q *Queries) ListWilayas(ctx context.Context) ([]Wilaya, error) {
+	rows, err := q.db.Query(ctx, listWilayas)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Wilaya
+	for rows.Next() {
+		var i Wilaya
+		if err := rows.Scan(
+			&i.Code,
+			&i.Name,
+			&i.NameAr,
+			&i.Aliases,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
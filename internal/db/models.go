@@ -9,6 +9,36 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type ApiKey struct {
+	ID           uuid.UUID          `json:"id"`
+	Name         string             `json:"name"`
+	KeyPrefix    string             `json:"key_prefix"`
+	KeyHash      string             `json:"key_hash"`
+	Scopes       []string           `json:"scopes"`
+	AllowedIps   []string           `json:"allowed_ips"`
+	ExpiresAt    pgtype.Timestamptz `json:"expires_at"`
+	CreatedBy    uuid.UUID          `json:"created_by"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	LastUsedAt   pgtype.Timestamptz `json:"last_used_at"`
+	RequestCount int64              `json:"request_count"`
+	RevokedAt    pgtype.Timestamptz `json:"revoked_at"`
+}
+
+type AuditLog struct {
+	ID         uuid.UUID          `json:"id"`
+	ActorID    uuid.UUID          `json:"actor_id"`
+	ActorEmail string             `json:"actor_email"`
+	Action     string             `json:"action"`
+	EntityType string             `json:"entity_type"`
+	EntityID   string             `json:"entity_id"`
+	Before     []byte             `json:"before"`
+	After      []byte             `json:"after"`
+	Diff       []byte             `json:"diff"`
+	RequestID  string             `json:"request_id"`
+	IpAddress  string             `json:"ip_address"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
 type Cart struct {
 	ID        uuid.UUID          `json:"id"`
 	UserID    uuid.UUID          `json:"user_id"`
@@ -44,6 +74,21 @@ type CategoryDiscount struct {
 	CreatedAt  pgtype.Timestamptz `json:"created_at"`
 }
 
+type Commune struct {
+	Code       int32    `json:"code"`
+	WilayaCode int16    `json:"wilaya_code"`
+	Name       string   `json:"name"`
+	Aliases    []string `json:"aliases"`
+}
+
+type DeliveryRate struct {
+	ID             uuid.UUID `json:"id"`
+	ZoneID         uuid.UUID `json:"zone_id"`
+	DeliveryType   string    `json:"delivery_type"`
+	MaxWeightGrams *int32    `json:"max_weight_grams"`
+	PriceCents     int64     `json:"price_cents"`
+}
+
 // Stores available delivery service options.
 type DeliveryService struct {
 	ID uuid.UUID `json:"id"`
@@ -56,9 +101,25 @@ type DeliveryService struct {
 	// Estimated number of days for delivery.
 	EstimatedDays *int32 `json:"estimated_days"`
 	// Indicates if the delivery service is currently offered.
-	IsActive  bool               `json:"is_active"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	IsActive             bool               `json:"is_active"`
+	CreatedAt            pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+	FreeShippingMinCents *int64             `json:"free_shipping_min_cents"`
+	Courier              *string            `json:"courier"`
+}
+
+type DeliveryZone struct {
+	ID                   uuid.UUID          `json:"id"`
+	DeliveryServiceID    uuid.UUID          `json:"delivery_service_id"`
+	Name                 string             `json:"name"`
+	FreeShippingMinCents *int64             `json:"free_shipping_min_cents"`
+	CreatedAt            pgtype.Timestamptz `json:"created_at"`
+}
+
+type DeliveryZoneWilaya struct {
+	ZoneID            uuid.UUID `json:"zone_id"`
+	DeliveryServiceID uuid.UUID `json:"delivery_service_id"`
+	WilayaCode        int16     `json:"wilaya_code"`
 }
 
 type Discount struct {
@@ -94,6 +155,10 @@ type Order struct {
 	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
 	CompletedAt       pgtype.Timestamptz `json:"completed_at"`
 	CancelledAt       pgtype.Timestamptz `json:"cancelled_at"`
+	WilayaCode        *int16             `json:"wilaya_code"`
+	CommuneCode       *int32             `json:"commune_code"`
+	DeliveryType      string             `json:"delivery_type"`
+	DeliveryFeeCents  *int64             `json:"delivery_fee_cents"`
 }
 
 type OrderItem struct {
@@ -106,6 +171,17 @@ type OrderItem struct {
 	SubtotalCents *int64             `json:"subtotal_cents"`
 	CreatedAt     pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	UnitCostCents *int64             `json:"unit_cost_cents"`
+}
+
+type OrderItemAllocation struct {
+	ID          uuid.UUID          `json:"id"`
+	OrderID     uuid.UUID          `json:"order_id"`
+	OrderItemID uuid.UUID          `json:"order_item_id"`
+	ProductID   uuid.UUID          `json:"product_id"`
+	LocationID  uuid.UUID          `json:"location_id"`
+	Quantity    int32              `json:"quantity"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 }
 
 type PasswordResetToken struct {
@@ -116,6 +192,11 @@ type PasswordResetToken struct {
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
+type Permission struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
 type Product struct {
 	ID               uuid.UUID          `json:"id"`
 	CategoryID       uuid.UUID          `json:"category_id"`
@@ -134,6 +215,44 @@ type Product struct {
 	CreatedAt        pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
+	WeightGrams      *int32             `json:"weight_grams"`
+}
+
+type ProductAlert struct {
+	ID               uuid.UUID          `json:"id"`
+	UserID           uuid.UUID          `json:"user_id"`
+	ProductID        uuid.UUID          `json:"product_id"`
+	AlertType        string             `json:"alert_type"`
+	TargetPriceCents *int64             `json:"target_price_cents"`
+	NotifiedAt       pgtype.Timestamptz `json:"notified_at"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+}
+
+type ProductAnswer struct {
+	ID               uuid.UUID          `json:"id"`
+	QuestionID       uuid.UUID          `json:"question_id"`
+	UserID           uuid.UUID          `json:"user_id"`
+	Body             string             `json:"body"`
+	IsOfficial       bool               `json:"is_official"`
+	Status           string             `json:"status"`
+	ModerationReason *string            `json:"moderation_reason"`
+	ModeratedBy      uuid.UUID          `json:"moderated_by"`
+	ModeratedAt      pgtype.Timestamptz `json:"moderated_at"`
+	UpvoteCount      int32              `json:"upvote_count"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+}
+
+type ProductAnswerVote struct {
+	AnswerID  uuid.UUID          `json:"answer_id"`
+	UserID    uuid.UUID          `json:"user_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type ProductCost struct {
+	ProductID        uuid.UUID          `json:"product_id"`
+	AverageCostCents int64              `json:"average_cost_cents"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
 }
 
 type ProductDiscount struct {
@@ -143,25 +262,147 @@ type ProductDiscount struct {
 	CreatedAt  pgtype.Timestamptz `json:"created_at"`
 }
 
+type ProductQuestion struct {
+	ID               uuid.UUID          `json:"id"`
+	ProductID        uuid.UUID          `json:"product_id"`
+	UserID           uuid.UUID          `json:"user_id"`
+	Body             string             `json:"body"`
+	Status           string             `json:"status"`
+	ModerationReason *string            `json:"moderation_reason"`
+	ModeratedBy      uuid.UUID          `json:"moderated_by"`
+	ModeratedAt      pgtype.Timestamptz `json:"moderated_at"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+}
+
+type ProductStockLevel struct {
+	ProductID  uuid.UUID          `json:"product_id"`
+	LocationID uuid.UUID          `json:"location_id"`
+	Quantity   int32              `json:"quantity"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
+type PurchaseOrder struct {
+	ID         uuid.UUID          `json:"id"`
+	SupplierID uuid.UUID          `json:"supplier_id"`
+	LocationID uuid.UUID          `json:"location_id"`
+	Status     string             `json:"status"`
+	Reference  *string            `json:"reference"`
+	Notes      *string            `json:"notes"`
+	ExpectedAt pgtype.Timestamptz `json:"expected_at"`
+	CreatedBy  uuid.UUID          `json:"created_by"`
+	OrderedAt  pgtype.Timestamptz `json:"ordered_at"`
+	ReceivedAt pgtype.Timestamptz `json:"received_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
+type PurchaseOrderItem struct {
+	ID               uuid.UUID          `json:"id"`
+	PurchaseOrderID  uuid.UUID          `json:"purchase_order_id"`
+	ProductID        uuid.UUID          `json:"product_id"`
+	QuantityOrdered  int32              `json:"quantity_ordered"`
+	QuantityReceived int32              `json:"quantity_received"`
+	UnitCostCents    int64              `json:"unit_cost_cents"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+}
+
+type PurchaseOrderReceipt struct {
+	ID                  uuid.UUID          `json:"id"`
+	PurchaseOrderID     uuid.UUID          `json:"purchase_order_id"`
+	PurchaseOrderItemID uuid.UUID          `json:"purchase_order_item_id"`
+	ProductID           uuid.UUID          `json:"product_id"`
+	LocationID          uuid.UUID          `json:"location_id"`
+	Quantity            int32              `json:"quantity"`
+	UnitCostCents       int64              `json:"unit_cost_cents"`
+	StockMovementID     int64              `json:"stock_movement_id"`
+	ReceivedBy          uuid.UUID          `json:"received_by"`
+	ReceivedAt          pgtype.Timestamptz `json:"received_at"`
+}
+
 type RefreshToken struct {
-	ID        int32              `json:"id"`
-	Jti       string             `json:"jti"`
-	UserID    uuid.UUID          `json:"user_id"`
-	TokenHash string             `json:"token_hash"`
-	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
-	RevokedAt pgtype.Timestamptz `json:"revoked_at"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	ID            int32              `json:"id"`
+	Jti           string             `json:"jti"`
+	UserID        uuid.UUID          `json:"user_id"`
+	TokenHash     string             `json:"token_hash"`
+	ExpiresAt     pgtype.Timestamptz `json:"expires_at"`
+	RevokedAt     pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	FamilyID      uuid.UUID          `json:"family_id"`
+	RevokedReason *string            `json:"revoked_reason"`
+	UserAgent     string             `json:"user_agent"`
+	IpAddress     string             `json:"ip_address"`
+	LastUsedAt    pgtype.Timestamptz `json:"last_used_at"`
+	Mfa           bool               `json:"mfa"`
 }
 
 type Review struct {
+	ID               uuid.UUID          `json:"id"`
+	UserID           uuid.UUID          `json:"user_id"`
+	ProductID        uuid.UUID          `json:"product_id"`
+	Rating           int32              `json:"rating"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
+	Title            *string            `json:"title"`
+	Body             *string            `json:"body"`
+	Pros             *string            `json:"pros"`
+	Cons             *string            `json:"cons"`
+	Status           string             `json:"status"`
+	ModerationReason *string            `json:"moderation_reason"`
+	ModeratedBy      uuid.UUID          `json:"moderated_by"`
+	ModeratedAt      pgtype.Timestamptz `json:"moderated_at"`
+	VerifiedPurchase bool               `json:"verified_purchase"`
+	HelpfulCount     int32              `json:"helpful_count"`
+	UnhelpfulCount   int32              `json:"unhelpful_count"`
+}
+
+type ReviewImage struct {
 	ID        uuid.UUID          `json:"id"`
+	ReviewID  uuid.UUID          `json:"review_id"`
+	Url       string             `json:"url"`
+	Position  int32              `json:"position"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type ReviewReply struct {
+	ID        uuid.UUID          `json:"id"`
+	ReviewID  uuid.UUID          `json:"review_id"`
+	AuthorID  uuid.UUID          `json:"author_id"`
+	Body      string             `json:"body"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type ReviewReport struct {
+	ID         uuid.UUID          `json:"id"`
+	ReviewID   uuid.UUID          `json:"review_id"`
+	UserID     uuid.UUID          `json:"user_id"`
+	Reason     string             `json:"reason"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	ResolvedAt pgtype.Timestamptz `json:"resolved_at"`
+	ResolvedBy uuid.UUID          `json:"resolved_by"`
+}
+
+type ReviewVote struct {
+	ReviewID  uuid.UUID          `json:"review_id"`
 	UserID    uuid.UUID          `json:"user_id"`
-	ProductID uuid.UUID          `json:"product_id"`
-	Rating    int32              `json:"rating"`
+	IsHelpful bool               `json:"is_helpful"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+type Role struct {
+	ID          uuid.UUID          `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type RolePermission struct {
+	RoleID         uuid.UUID `json:"role_id"`
+	PermissionCode string    `json:"permission_code"`
 }
 
 type SchemaMigration struct {
@@ -170,6 +411,76 @@ type SchemaMigration struct {
 	AppliedAt pgtype.Timestamptz `json:"applied_at"`
 }
 
+type Shipment struct {
+	ID              uuid.UUID          `json:"id"`
+	OrderID         uuid.UUID          `json:"order_id"`
+	Courier         string             `json:"courier"`
+	TrackingNumber  string             `json:"tracking_number"`
+	Status          string             `json:"status"`
+	StatusUpdatedAt pgtype.Timestamptz `json:"status_updated_at"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+}
+
+type ShipmentEvent struct {
+	ID          uuid.UUID          `json:"id"`
+	ShipmentID  uuid.UUID          `json:"shipment_id"`
+	Status      string             `json:"status"`
+	Description *string            `json:"description"`
+	OccurredAt  pgtype.Timestamptz `json:"occurred_at"`
+	Source      string             `json:"source"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type StockLocation struct {
+	ID         uuid.UUID          `json:"id"`
+	Code       string             `json:"code"`
+	Name       string             `json:"name"`
+	IsSellable bool               `json:"is_sellable"`
+	IsActive   bool               `json:"is_active"`
+	IsDefault  bool               `json:"is_default"`
+	Priority   int32              `json:"priority"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
+type StockMovement struct {
+	ID                int64              `json:"id"`
+	ProductID         uuid.UUID          `json:"product_id"`
+	Delta             int32              `json:"delta"`
+	ResultingQuantity int32              `json:"resulting_quantity"`
+	Reason            string             `json:"reason"`
+	OrderID           uuid.UUID          `json:"order_id"`
+	ActorID           uuid.UUID          `json:"actor_id"`
+	Note              *string            `json:"note"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	LocationID        uuid.UUID          `json:"location_id"`
+	TransferID        uuid.UUID          `json:"transfer_id"`
+}
+
+type StockTransfer struct {
+	ID             uuid.UUID          `json:"id"`
+	ProductID      uuid.UUID          `json:"product_id"`
+	FromLocationID uuid.UUID          `json:"from_location_id"`
+	ToLocationID   uuid.UUID          `json:"to_location_id"`
+	Quantity       int32              `json:"quantity"`
+	ActorID        uuid.UUID          `json:"actor_id"`
+	Note           *string            `json:"note"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type Supplier struct {
+	ID          uuid.UUID          `json:"id"`
+	Name        string             `json:"name"`
+	ContactName *string            `json:"contact_name"`
+	Email       *string            `json:"email"`
+	Phone       *string            `json:"phone"`
+	Notes       *string            `json:"notes"`
+	IsActive    bool               `json:"is_active"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
 type User struct {
 	ID           uuid.UUID          `json:"id"`
 	Email        string             `json:"email"`
@@ -179,6 +490,54 @@ type User struct {
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt    pgtype.Timestamptz `json:"deleted_at"`
+	TokenVersion int32              `json:"token_version"`
+}
+
+type UserAddress struct {
+	ID           uuid.UUID          `json:"id"`
+	UserID       uuid.UUID          `json:"user_id"`
+	Label        *string            `json:"label"`
+	FullName     string             `json:"full_name"`
+	PhoneNumber1 string             `json:"phone_number_1"`
+	PhoneNumber2 *string            `json:"phone_number_2"`
+	Province     string             `json:"province"`
+	City         string             `json:"city"`
+	IsDefault    bool               `json:"is_default"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
+
+type UserIdentity struct {
+	ID          uuid.UUID          `json:"id"`
+	UserID      uuid.UUID          `json:"user_id"`
+	Provider    string             `json:"provider"`
+	Subject     string             `json:"subject"`
+	Email       *string            `json:"email"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	LastLoginAt pgtype.Timestamptz `json:"last_login_at"`
+}
+
+type UserRecoveryCode struct {
+	ID        uuid.UUID          `json:"id"`
+	UserID    uuid.UUID          `json:"user_id"`
+	CodeHash  string             `json:"code_hash"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type UserRole struct {
+	UserID     uuid.UUID          `json:"user_id"`
+	RoleID     uuid.UUID          `json:"role_id"`
+	AssignedBy uuid.UUID          `json:"assigned_by"`
+	AssignedAt pgtype.Timestamptz `json:"assigned_at"`
+}
+
+type UserTotp struct {
+	UserID       uuid.UUID          `json:"user_id"`
+	Secret       string             `json:"secret"`
+	ConfirmedAt  pgtype.Timestamptz `json:"confirmed_at"`
+	LastUsedStep int64              `json:"last_used_step"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 }
 
 type VProductsWithCalculatedDiscount struct {
@@ -213,3 +572,18 @@ type VProductsWithCurrentDiscount struct {
 	ActiveDiscountValue     *int64             `json:"active_discount_value"`
 	HasActiveDiscount       bool               `json:"has_active_discount"`
 }
+
+type Wilaya struct {
+	Code    int16    `json:"code"`
+	Name    string   `json:"name"`
+	NameAr  string   `json:"name_ar"`
+	Aliases []string `json:"aliases"`
+}
+
+type WishlistItem struct {
+	ID        uuid.UUID          `json:"id"`
+	UserID    uuid.UUID          `json:"user_id"`
+	SessionID *string            `json:"session_id"`
+	ProductID uuid.UUID          `json:"product_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
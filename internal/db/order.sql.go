@@ -25,7 +25,7 @@ RETURNING
     id, user_id, user_full_name, status, total_amount_cents, payment_method,
     province, city, phone_number_1, phone_number_2,
     notes, delivery_service_id, 
-    created_at, updated_at, completed_at, cancelled_at
+    created_at, updated_at, completed_at, cancelled_at, wilaya_code, commune_code, delivery_type, delivery_fee_cents
 `
 
 // Order items consistently
@@ -51,6 +51,10 @@ func (q *Queries) CancelOrder(ctx context.Context, orderID uuid.UUID) (Order, er
 		&i.UpdatedAt,
 		&i.CompletedAt,
 		&i.CancelledAt,
+		&i.WilayaCode,
+		&i.CommuneCode,
+		&i.DeliveryType,
+		&i.DeliveryFeeCents,
 	)
 	return i, err
 }
@@ -102,15 +106,15 @@ const createOrder = `-- name: CreateOrder :one
 INSERT INTO orders (
     user_id, user_full_name, status, total_amount_cents, payment_method,
     province, city, phone_number_1, phone_number_2,
-    notes, delivery_service_id
+    notes, delivery_service_id, wilaya_code, commune_code, delivery_type, delivery_fee_cents
 ) VALUES (
     $1, $2, $3, $4, $5,
     $6, $7, $8, $9,
-    $10, $11
+    $10, $11, $12, $13, $14, $15
 )
 RETURNING id, user_id, user_full_name, status, total_amount_cents, payment_method,
          province, city, phone_number_1, phone_number_2,
-         notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at
+         notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at, wilaya_code, commune_code, delivery_type, delivery_fee_cents
 `
 
 type CreateOrderParams struct {
@@ -125,6 +129,10 @@ type CreateOrderParams struct {
 	PhoneNumber2      *string   `json:"phone_number_2"`
 	Notes             *string   `json:"notes"`
 	DeliveryServiceID uuid.UUID `json:"delivery_service_id"`
+	WilayaCode        *int16    `json:"wilaya_code"`
+	CommuneCode       *int32    `json:"commune_code"`
+	DeliveryType      string    `json:"delivery_type"`
+	DeliveryFeeCents  *int64    `json:"delivery_fee_cents"`
 }
 
 // Creates a new order with denormalized address fields and returns its details.
@@ -141,6 +149,10 @@ func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order
 		arg.PhoneNumber2,
 		arg.Notes,
 		arg.DeliveryServiceID,
+		arg.WilayaCode,
+		arg.CommuneCode,
+		arg.DeliveryType,
+		arg.DeliveryFeeCents,
 	)
 	var i Order
 	err := row.Scan(
@@ -160,65 +172,10 @@ func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order
 		&i.UpdatedAt,
 		&i.CompletedAt,
 		&i.CancelledAt,
-	)
-	return i, err
-}
-
-const decrementStockIfSufficient = `-- name: DecrementStockIfSufficient :one
-UPDATE products
-SET stock_quantity = stock_quantity - $1
-WHERE id = $2 AND stock_quantity >= $1 -- The crucial condition
-RETURNING id, category_id, name, slug, description, short_description, price_cents, stock_quantity, status, brand, image_urls, spec_highlights, created_at, updated_at, deleted_at
-`
-
-type DecrementStockIfSufficientParams struct {
-	DecrementAmount int32     `json:"decrement_amount"`
-	ProductID       uuid.UUID `json:"product_id"`
-}
-
-type DecrementStockIfSufficientRow struct {
-	ID               uuid.UUID          `json:"id"`
-	CategoryID       uuid.UUID          `json:"category_id"`
-	Name             string             `json:"name"`
-	Slug             string             `json:"slug"`
-	Description      *string            `json:"description"`
-	ShortDescription *string            `json:"short_description"`
-	PriceCents       int64              `json:"price_cents"`
-	StockQuantity    int32              `json:"stock_quantity"`
-	Status           string             `json:"status"`
-	Brand            string             `json:"brand"`
-	ImageUrls        []byte             `json:"image_urls"`
-	SpecHighlights   []byte             `json:"spec_highlights"`
-	CreatedAt        pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
-}
-
-// Attempts to decrement the stock_quantity for a product by a given amount.
-// Succeeds only if the resulting stock_quantity would be >= 0.
-// Returns the updated product row if successful, or an error if insufficient stock.
-// Note: The RETURNING clause might not be strictly necessary if we only care about RowsAffected.
-// If RETURNING is omitted, the querier function will likely return sql.Result.
-// Let's include RETURNING to get the updated stock if needed for debugging/logging.
-func (q *Queries) DecrementStockIfSufficient(ctx context.Context, arg DecrementStockIfSufficientParams) (DecrementStockIfSufficientRow, error) {
-	row := q.db.QueryRow(ctx, decrementStockIfSufficient, arg.DecrementAmount, arg.ProductID)
-	var i DecrementStockIfSufficientRow
-	err := row.Scan(
-		&i.ID,
-		&i.CategoryID,
-		&i.Name,
-		&i.Slug,
-		&i.Description,
-		&i.ShortDescription,
-		&i.PriceCents,
-		&i.StockQuantity,
-		&i.Status,
-		&i.Brand,
-		&i.ImageUrls,
-		&i.SpecHighlights,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.DeletedAt,
+		&i.WilayaCode,
+		&i.CommuneCode,
+		&i.DeliveryType,
+		&i.DeliveryFeeCents,
 	)
 	return i, err
 }
@@ -228,7 +185,7 @@ const getOrder = `-- name: GetOrder :one
 SELECT 
     id, user_id, user_full_name, status, total_amount_cents, payment_method,
     province, city, phone_number_1, phone_number_2,
-    notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at
+    notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at, wilaya_code, commune_code, delivery_type, delivery_fee_cents
 FROM orders
 WHERE id = $1
 `
@@ -255,13 +212,17 @@ func (q *Queries) GetOrder(ctx context.Context, orderID uuid.UUID) (Order, error
 		&i.UpdatedAt,
 		&i.CompletedAt,
 		&i.CancelledAt,
+		&i.WilayaCode,
+		&i.CommuneCode,
+		&i.DeliveryType,
+		&i.DeliveryFeeCents,
 	)
 	return i, err
 }
 
 const getOrderItemsByOrderID = `-- name: GetOrderItemsByOrderID :many
 SELECT 
-    id, order_id, product_id, product_name, price_cents, quantity, subtotal_cents, created_at, updated_at
+    id, order_id, product_id, product_name, price_cents, quantity, subtotal_cents, created_at, updated_at, unit_cost_cents
 FROM order_items
 WHERE order_id = $1
 ORDER BY created_at ASC
@@ -287,6 +248,7 @@ func (q *Queries) GetOrderItemsByOrderID(ctx context.Context, orderID uuid.UUID)
 			&i.SubtotalCents,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.UnitCostCents,
 		); err != nil {
 			return nil, err
 		}
@@ -302,7 +264,7 @@ const getOrderWithItems = `-- name: GetOrderWithItems :many
 SELECT 
     o.id, o.user_id, o.user_full_name, o.status, o.total_amount_cents, o.payment_method,
     o.province, o.city, o.phone_number_1, o.phone_number_2,
-    o.notes, o.delivery_service_id, o.created_at, o.updated_at, o.completed_at, o.cancelled_at,
+    o.notes, o.delivery_service_id, o.created_at, o.updated_at, o.completed_at, o.cancelled_at, o.wilaya_code, o.commune_code, o.delivery_type, o.delivery_fee_cents,
     oi.id AS item_id, oi.order_id AS item_order_id, oi.product_id AS item_product_id,
     oi.product_name AS item_product_name, oi.price_cents AS item_price_cents,
     oi.quantity AS item_quantity, oi.subtotal_cents AS item_subtotal_cents,
@@ -330,6 +292,10 @@ type GetOrderWithItemsRow struct {
 	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
 	CompletedAt       pgtype.Timestamptz `json:"completed_at"`
 	CancelledAt       pgtype.Timestamptz `json:"cancelled_at"`
+	WilayaCode        *int16             `json:"wilaya_code"`
+	CommuneCode       *int32             `json:"commune_code"`
+	DeliveryType      string             `json:"delivery_type"`
+	DeliveryFeeCents  *int64             `json:"delivery_fee_cents"`
 	ItemID            uuid.UUID          `json:"item_id"`
 	ItemOrderID       uuid.UUID          `json:"item_order_id"`
 	ItemProductID     uuid.UUID          `json:"item_product_id"`
@@ -370,6 +336,10 @@ func (q *Queries) GetOrderWithItems(ctx context.Context, orderID uuid.UUID) ([]G
 			&i.UpdatedAt,
 			&i.CompletedAt,
 			&i.CancelledAt,
+			&i.WilayaCode,
+			&i.CommuneCode,
+			&i.DeliveryType,
+			&i.DeliveryFeeCents,
 			&i.ItemID,
 			&i.ItemOrderID,
 			&i.ItemProductID,
@@ -390,61 +360,6 @@ func (q *Queries) GetOrderWithItems(ctx context.Context, orderID uuid.UUID) ([]G
 	return items, nil
 }
 
-const incrementStock = `-- name: IncrementStock :one
-UPDATE products
-SET stock_quantity = stock_quantity + $1
-WHERE id = $2
-RETURNING id, category_id, name, slug, description, short_description, price_cents, stock_quantity, status, brand, image_urls, spec_highlights, created_at, updated_at, deleted_at
-`
-
-type IncrementStockParams struct {
-	IncrementAmount int32     `json:"increment_amount"`
-	ProductID       uuid.UUID `json:"product_id"`
-}
-
-type IncrementStockRow struct {
-	ID               uuid.UUID          `json:"id"`
-	CategoryID       uuid.UUID          `json:"category_id"`
-	Name             string             `json:"name"`
-	Slug             string             `json:"slug"`
-	Description      *string            `json:"description"`
-	ShortDescription *string            `json:"short_description"`
-	PriceCents       int64              `json:"price_cents"`
-	StockQuantity    int32              `json:"stock_quantity"`
-	Status           string             `json:"status"`
-	Brand            string             `json:"brand"`
-	ImageUrls        []byte             `json:"image_urls"`
-	SpecHighlights   []byte             `json:"spec_highlights"`
-	CreatedAt        pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
-}
-
-// Increments the stock_quantity for a product by a given amount.
-// Suitable for releasing stock back when cancelling an order.
-func (q *Queries) IncrementStock(ctx context.Context, arg IncrementStockParams) (IncrementStockRow, error) {
-	row := q.db.QueryRow(ctx, incrementStock, arg.IncrementAmount, arg.ProductID)
-	var i IncrementStockRow
-	err := row.Scan(
-		&i.ID,
-		&i.CategoryID,
-		&i.Name,
-		&i.Slug,
-		&i.Description,
-		&i.ShortDescription,
-		&i.PriceCents,
-		&i.StockQuantity,
-		&i.Status,
-		&i.Brand,
-		&i.ImageUrls,
-		&i.SpecHighlights,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.DeletedAt,
-	)
-	return i, err
-}
-
 const insertOrderItemsBulk = `-- name: InsertOrderItemsBulk :exec
 INSERT INTO order_items (order_id, product_id, product_name, price_cents, quantity)
 SELECT
@@ -514,7 +429,7 @@ const listAllOrders = `-- name: ListAllOrders :many
 SELECT 
     id, user_id, user_full_name, status, total_amount_cents, payment_method,
     province, city, phone_number_1, phone_number_2,
-    notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at
+    notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at, wilaya_code, commune_code, delivery_type, delivery_fee_cents
 FROM orders
 WHERE ($1::UUID = '00000000-0000-0000-0000-000000000000'::UUID OR user_id = $1) -- Filter by user_id if provided
   AND ($2::TEXT = '' OR status = $2) -- Filter by status if provided
@@ -565,6 +480,10 @@ func (q *Queries) ListAllOrders(ctx context.Context, arg ListAllOrdersParams) ([
 			&i.UpdatedAt,
 			&i.CompletedAt,
 			&i.CancelledAt,
+			&i.WilayaCode,
+			&i.CommuneCode,
+			&i.DeliveryType,
+			&i.DeliveryFeeCents,
 		); err != nil {
 			return nil, err
 		}
@@ -581,7 +500,7 @@ const listUserOrders = `-- name: ListUserOrders :many
 SELECT 
     id, user_id, user_full_name, status, total_amount_cents, payment_method,
     province, city, phone_number_1, phone_number_2,
-    notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at
+    notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at, wilaya_code, commune_code, delivery_type, delivery_fee_cents
 FROM orders
 WHERE user_id = $1
   AND ($2::TEXT = '' OR status = $2) -- Filter by status if provided
@@ -632,6 +551,10 @@ func (q *Queries) ListUserOrders(ctx context.Context, arg ListUserOrdersParams)
 			&i.UpdatedAt,
 			&i.CompletedAt,
 			&i.CancelledAt,
+			&i.WilayaCode,
+			&i.CommuneCode,
+			&i.DeliveryType,
+			&i.DeliveryFeeCents,
 		); err != nil {
 			return nil, err
 		}
@@ -652,7 +575,7 @@ SET
 WHERE id = $2
 RETURNING id, user_id, user_full_name, status, total_amount_cents, payment_method,
          province, city, phone_number_1, phone_number_2,
-         notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at
+         notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at, wilaya_code, commune_code, delivery_type, delivery_fee_cents
 `
 
 type UpdateOrderParams struct {
@@ -683,6 +606,10 @@ func (q *Queries) UpdateOrder(ctx context.Context, arg UpdateOrderParams) (Order
 		&i.UpdatedAt,
 		&i.CompletedAt,
 		&i.CancelledAt,
+		&i.WilayaCode,
+		&i.CommuneCode,
+		&i.DeliveryType,
+		&i.DeliveryFeeCents,
 	)
 	return i, err
 }
@@ -702,7 +629,7 @@ SET status = $1::TEXT ,
 WHERE id = $2
 RETURNING id, user_id, user_full_name, status, total_amount_cents, payment_method,
          province, city, phone_number_1, phone_number_2,
-         notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at
+         notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at, wilaya_code, commune_code, delivery_type, delivery_fee_cents
 `
 
 type UpdateOrderStatusParams struct {
@@ -731,6 +658,10 @@ func (q *Queries) UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusPa
 		&i.UpdatedAt,
 		&i.CompletedAt,
 		&i.CancelledAt,
+		&i.WilayaCode,
+		&i.CommuneCode,
+		&i.DeliveryType,
+		&i.DeliveryFeeCents,
 	)
 	return i, err
 }
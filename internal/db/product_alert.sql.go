@@ -0,0 +1,260 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: product_alert.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createProductAlert = `-- name: CreateProductAlert :one
+INSERT INTO product_alerts (user_id, product_id, alert_type, target_price_cents)
+VALUES ($1, $2, $3, $4)
+RETURNING id, user_id, product_id, alert_type, target_price_cents, notified_at, created_at
+`
+
+type CreateProductAlertParams struct {
+	UserID           uuid.UUID `json:"user_id"`
+	ProductID        uuid.UUID `json:"product_id"`
+	AlertType        string    `json:"alert_type"`
+	TargetPriceCents *int64    `json:"target_price_cents"`
+}
+
+// Subscribes a user to a back-in-stock or price-below alert for a product.
+func (q *Queries) CreateProductAlert(ctx context.Context, arg CreateProductAlertParams) (ProductAlert, error) {
+	row := q.db.QueryRow(ctx, createProductAlert,
+		arg.UserID,
+		arg.ProductID,
+		arg.AlertType,
+		arg.TargetPriceCents,
+	)
+	var i ProductAlert
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProductID,
+		&i.AlertType,
+		&i.TargetPriceCents,
+		&i.NotifiedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteProductAlert = `-- name: DeleteProductAlert :execrows
+DELETE FROM product_alerts
+WHERE id = $1 AND user_id = $2
+`
+
+type DeleteProductAlertParams struct {
+	AlertID uuid.UUID `json:"alert_id"`
+	UserID  uuid.UUID `json:"user_id"`
+}
+
+// Deletes an alert, scoped to its owner.
+func (q *Queries) DeleteProductAlert(ctx context.Context, arg DeleteProductAlertParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteProductAlert, arg.AlertID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getTriggeredProductAlerts = `-- name: GetTriggeredProductAlerts :many
+SELECT
+    pa.id,
+    pa.user_id,
+    u.email,
+    u.full_name,
+    pa.product_id,
+    p.name AS product_name,
+    p.slug AS product_slug,
+    pa.alert_type,
+    pa.target_price_cents,
+    p.stock_quantity,
+    COALESCE(vpcd.calculated_discounted_price_cents, p.price_cents)::BIGINT AS current_price_cents
+FROM product_alerts pa
+JOIN users u ON pa.user_id = u.id AND u.deleted_at IS NULL
+JOIN products p ON pa.product_id = p.id AND p.deleted_at IS NULL
+LEFT JOIN v_products_with_calculated_discounts vpcd ON p.id = vpcd.product_id
+WHERE pa.product_id = $1
+  AND pa.notified_at IS NULL
+  AND p.status <> 'discontinued'
+  AND (
+    (pa.alert_type = 'back_in_stock' AND p.stock_quantity > 0)
+    OR (
+        pa.alert_type = 'price_below'
+        AND p.stock_quantity > 0
+        AND COALESCE(vpcd.calculated_discounted_price_cents, p.price_cents) <= pa.target_price_cents
+    )
+  )
+`
+
+type GetTriggeredProductAlertsRow struct {
+	ID                uuid.UUID `json:"id"`
+	UserID            uuid.UUID `json:"user_id"`
+	Email             string    `json:"email"`
+	FullName          *string   `json:"full_name"`
+	ProductID         uuid.UUID `json:"product_id"`
+	ProductName       string    `json:"product_name"`
+	ProductSlug       string    `json:"product_slug"`
+	AlertType         string    `json:"alert_type"`
+	TargetPriceCents  *int64    `json:"target_price_cents"`
+	StockQuantity     int32     `json:"stock_quantity"`
+	CurrentPriceCents int64     `json:"current_price_cents"`
+}
+
+// Fetches the pending alerts of a product whose condition is currently met,
+// using the live discounted price from v_products_with_calculated_discounts.
+// Price alerts only fire while the product can actually be bought.
+func (q *Queries) GetTriggeredProductAlerts(ctx context.Context, productID uuid.UUID) ([]GetTriggeredProductAlertsRow, error) {
+	rows, err := q.db.Query(ctx, getTriggeredProductAlerts, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTriggeredProductAlertsRow
+	for rows.Next() {
+		var i GetTriggeredProductAlertsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Email,
+			&i.FullName,
+			&i.ProductID,
+			&i.ProductName,
+			&i.ProductSlug,
+			&i.AlertType,
+			&i.TargetPriceCents,
+			&i.StockQuantity,
+			&i.CurrentPriceCents,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductAlertsByUserID = `-- name: ListProductAlertsByUserID :many
+SELECT
+    pa.id,
+    pa.user_id,
+    pa.product_id,
+    p.name AS product_name,
+    p.slug AS product_slug,
+    pa.alert_type,
+    pa.target_price_cents,
+    pa.notified_at,
+    pa.created_at
+FROM product_alerts pa
+JOIN products p ON pa.product_id = p.id
+WHERE pa.user_id = $1
+ORDER BY (pa.notified_at IS NULL) DESC, pa.created_at DESC
+`
+
+type ListProductAlertsByUserIDRow struct {
+	ID               uuid.UUID          `json:"id"`
+	UserID           uuid.UUID          `json:"user_id"`
+	ProductID        uuid.UUID          `json:"product_id"`
+	ProductName      string             `json:"product_name"`
+	ProductSlug      string             `json:"product_slug"`
+	AlertType        string             `json:"alert_type"`
+	TargetPriceCents *int64             `json:"target_price_cents"`
+	NotifiedAt       pgtype.Timestamptz `json:"notified_at"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+}
+
+// Lists a user's alerts (pending first) with the product's name and slug.
+func (q *Queries) ListProductAlertsByUserID(ctx context.Context, userID uuid.UUID) ([]ListProductAlertsByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, listProductAlertsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListProductAlertsByUserIDRow
+	for rows.Next() {
+		var i ListProductAlertsByUserIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProductID,
+			&i.ProductName,
+			&i.ProductSlug,
+			&i.AlertType,
+			&i.TargetPriceCents,
+			&i.NotifiedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductIDsWithPendingAlertsByDiscount = `-- name: ListProductIDsWithPendingAlertsByDiscount :many
+SELECT DISTINCT pd.product_id
+FROM product_discounts pd
+JOIN product_alerts pa ON pa.product_id = pd.product_id AND pa.notified_at IS NULL
+WHERE pd.discount_id = $1
+`
+
+// Lists the products linked to a discount that have at least one pending alert.
+func (q *Queries) ListProductIDsWithPendingAlertsByDiscount(ctx context.Context, discountID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listProductIDsWithPendingAlertsByDiscount, discountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var product_id uuid.UUID
+		if err := rows.Scan(&product_id); err != nil {
+			return nil, err
+		}
+		items = append(items, product_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markProductAlertNotified = `-- name: MarkProductAlertNotified :execrows
+UPDATE product_alerts
+SET notified_at = NOW()
+WHERE id = $1 AND notified_at IS NULL
+`
+
+// Claims a pending alert. Returns 0 rows if another process already fired it.
+func (q *Queries) MarkProductAlertNotified(ctx context.Context, alertID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, markProductAlertNotified, alertID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const resetProductAlertNotified = `-- name: ResetProductAlertNotified :exec
+UPDATE product_alerts
+SET notified_at = NULL
+WHERE id = $1
+`
+
+// Releases a claimed alert so it can fire again (used when the notification could not be delivered).
+func (q *Queries) ResetProductAlertNotified(ctx context.Context, alertID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, resetProductAlertNotified, alertID)
+	return err
+}
@@ -0,0 +1,651 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: product_question.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const countApprovedProductQuestions = `-- name: CountApprovedProductQuestions :one
+SELECT COUNT(*)::BIGINT
+FROM product_questions
+WHERE product_id = $1 AND status = 'approved'
+`
+
+// Counts the published questions of a product.
+func (q *Queries) CountApprovedProductQuestions(ctx context.Context, productID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countApprovedProductQuestions, productID)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const countProductAnswersForModeration = `-- name: CountProductAnswersForModeration :one
+SELECT COUNT(*)::BIGINT
+FROM product_answers
+WHERE ($1::TEXT = '' OR status = $1::TEXT)
+`
+
+// Counts answers in the admin moderation queue, optionally filtered by status (empty = all).
+func (q *Queries) CountProductAnswersForModeration(ctx context.Context, status string) (int64, error) {
+	row := q.db.QueryRow(ctx, countProductAnswersForModeration, status)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const countProductQuestionsForModeration = `-- name: CountProductQuestionsForModeration :one
+SELECT COUNT(*)::BIGINT
+FROM product_questions
+WHERE ($1::TEXT = '' OR status = $1::TEXT)
+`
+
+// Counts questions in the admin moderation queue, optionally filtered by status (empty = all).
+func (q *Queries) CountProductQuestionsForModeration(ctx context.Context, status string) (int64, error) {
+	row := q.db.QueryRow(ctx, countProductQuestionsForModeration, status)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const createProductAnswer = `-- name: CreateProductAnswer :one
+INSERT INTO product_answers (question_id, user_id, body, is_official, status)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, question_id, user_id, body, is_official, status, upvote_count, created_at, updated_at
+`
+
+type CreateProductAnswerParams struct {
+	QuestionID uuid.UUID `json:"question_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Body       string    `json:"body"`
+	IsOfficial bool      `json:"is_official"`
+	Status     string    `json:"status"`
+}
+
+type CreateProductAnswerRow struct {
+	ID          uuid.UUID          `json:"id"`
+	QuestionID  uuid.UUID          `json:"question_id"`
+	UserID      uuid.UUID          `json:"user_id"`
+	Body        string             `json:"body"`
+	IsOfficial  bool               `json:"is_official"`
+	Status      string             `json:"status"`
+	UpvoteCount int32              `json:"upvote_count"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Records an answer to a question. Staff answers are created official and already approved.
+func (q *Queries) CreateProductAnswer(ctx context.Context, arg CreateProductAnswerParams) (CreateProductAnswerRow, error) {
+	row := q.db.QueryRow(ctx, createProductAnswer,
+		arg.QuestionID,
+		arg.UserID,
+		arg.Body,
+		arg.IsOfficial,
+		arg.Status,
+	)
+	var i CreateProductAnswerRow
+	err := row.Scan(
+		&i.ID,
+		&i.QuestionID,
+		&i.UserID,
+		&i.Body,
+		&i.IsOfficial,
+		&i.Status,
+		&i.UpvoteCount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createProductAnswerVote = `-- name: CreateProductAnswerVote :exec
+INSERT INTO product_answer_votes (answer_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT (answer_id, user_id) DO NOTHING
+`
+
+type CreateProductAnswerVoteParams struct {
+	AnswerID uuid.UUID `json:"answer_id"`
+	UserID   uuid.UUID `json:"user_id"`
+}
+
+// Records a user's upvote on an answer; voting twice has no effect.
+func (q *Queries) CreateProductAnswerVote(ctx context.Context, arg CreateProductAnswerVoteParams) error {
+	_, err := q.db.Exec(ctx, createProductAnswerVote, arg.AnswerID, arg.UserID)
+	return err
+}
+
+const createProductQuestion = `-- name: CreateProductQuestion :one
+INSERT INTO product_questions (product_id, user_id, body)
+VALUES ($1, $2, $3)
+RETURNING id, product_id, user_id, body, status, created_at, updated_at
+`
+
+type CreateProductQuestionParams struct {
+	ProductID uuid.UUID `json:"product_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Body      string    `json:"body"`
+}
+
+type CreateProductQuestionRow struct {
+	ID        uuid.UUID          `json:"id"`
+	ProductID uuid.UUID          `json:"product_id"`
+	UserID    uuid.UUID          `json:"user_id"`
+	Body      string             `json:"body"`
+	Status    string             `json:"status"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Records a customer's question about a product.
+func (q *Queries) CreateProductQuestion(ctx context.Context, arg CreateProductQuestionParams) (CreateProductQuestionRow, error) {
+	row := q.db.QueryRow(ctx, createProductQuestion, arg.ProductID, arg.UserID, arg.Body)
+	var i CreateProductQuestionRow
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.Body,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteProductAnswerVote = `-- name: DeleteProductAnswerVote :execrows
+DELETE FROM product_answer_votes
+WHERE answer_id = $1 AND user_id = $2
+`
+
+type DeleteProductAnswerVoteParams struct {
+	AnswerID uuid.UUID `json:"answer_id"`
+	UserID   uuid.UUID `json:"user_id"`
+}
+
+// Removes a user's upvote on an answer.
+func (q *Queries) DeleteProductAnswerVote(ctx context.Context, arg DeleteProductAnswerVoteParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteProductAnswerVote, arg.AnswerID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getApprovedProductAnswer = `-- name: GetApprovedProductAnswer :one
+SELECT a.id, a.user_id
+FROM product_answers a
+JOIN product_questions q ON a.question_id = q.id
+WHERE a.id = $1 AND a.status = 'approved' AND q.status = 'approved'
+`
+
+type GetApprovedProductAnswerRow struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// Retrieves a published answer, e.g. before upvoting it.
+func (q *Queries) GetApprovedProductAnswer(ctx context.Context, id uuid.UUID) (GetApprovedProductAnswerRow, error) {
+	row := q.db.QueryRow(ctx, getApprovedProductAnswer, id)
+	var i GetApprovedProductAnswerRow
+	err := row.Scan(&i.ID, &i.UserID)
+	return i, err
+}
+
+const getApprovedProductQuestion = `-- name: GetApprovedProductQuestion :one
+SELECT id, product_id, user_id
+FROM product_questions
+WHERE id = $1 AND status = 'approved'
+`
+
+type GetApprovedProductQuestionRow struct {
+	ID        uuid.UUID `json:"id"`
+	ProductID uuid.UUID `json:"product_id"`
+	UserID    uuid.UUID `json:"user_id"`
+}
+
+// Retrieves a published question, e.g. before answering it.
+func (q *Queries) GetApprovedProductQuestion(ctx context.Context, id uuid.UUID) (GetApprovedProductQuestionRow, error) {
+	row := q.db.QueryRow(ctx, getApprovedProductQuestion, id)
+	var i GetApprovedProductQuestionRow
+	err := row.Scan(&i.ID, &i.ProductID, &i.UserID)
+	return i, err
+}
+
+const getProductQuestionForAnswer = `-- name: GetProductQuestionForAnswer :one
+SELECT
+    q.id,
+    q.user_id,
+    q.body,
+    q.status,
+    u.email AS asker_email,
+    p.name AS product_name,
+    p.slug AS product_slug
+FROM product_questions q
+JOIN users u ON q.user_id = u.id
+JOIN products p ON q.product_id = p.id
+WHERE q.id = $1
+`
+
+type GetProductQuestionForAnswerRow struct {
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Body        string    `json:"body"`
+	Status      string    `json:"status"`
+	AskerEmail  string    `json:"asker_email"`
+	ProductName string    `json:"product_name"`
+	ProductSlug string    `json:"product_slug"`
+}
+
+// Retrieves a question with its asker and product, for staff answers and answer notifications.
+func (q *Queries) GetProductQuestionForAnswer(ctx context.Context, id uuid.UUID) (GetProductQuestionForAnswerRow, error) {
+	row := q.db.QueryRow(ctx, getProductQuestionForAnswer, id)
+	var i GetProductQuestionForAnswerRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Body,
+		&i.Status,
+		&i.AskerEmail,
+		&i.ProductName,
+		&i.ProductSlug,
+	)
+	return i, err
+}
+
+const listApprovedAnswersByQuestionIDs = `-- name: ListApprovedAnswersByQuestionIDs :many
+SELECT
+    a.id,
+    a.question_id,
+    a.body,
+    a.is_official,
+    a.upvote_count,
+    a.created_at,
+    u.full_name AS author_name
+FROM product_answers a
+LEFT JOIN users u ON a.user_id = u.id
+WHERE a.question_id = ANY($1::UUID[]) AND a.status = 'approved'
+ORDER BY a.question_id, a.is_official DESC, a.upvote_count DESC, a.created_at ASC
+`
+
+type ListApprovedAnswersByQuestionIDsRow struct {
+	ID          uuid.UUID          `json:"id"`
+	QuestionID  uuid.UUID          `json:"question_id"`
+	Body        string             `json:"body"`
+	IsOfficial  bool               `json:"is_official"`
+	UpvoteCount int32              `json:"upvote_count"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	AuthorName  *string            `json:"author_name"`
+}
+
+// Retrieves the published answers of several questions: official answers first, then by upvotes.
+func (q *Queries) ListApprovedAnswersByQuestionIDs(ctx context.Context, questionIds []uuid.UUID) ([]ListApprovedAnswersByQuestionIDsRow, error) {
+	rows, err := q.db.Query(ctx, listApprovedAnswersByQuestionIDs, questionIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListApprovedAnswersByQuestionIDsRow
+	for rows.Next() {
+		var i ListApprovedAnswersByQuestionIDsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.QuestionID,
+			&i.Body,
+			&i.IsOfficial,
+			&i.UpvoteCount,
+			&i.CreatedAt,
+			&i.AuthorName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listApprovedProductQuestions = `-- name: ListApprovedProductQuestions :many
+SELECT
+    q.id,
+    q.product_id,
+    q.body,
+    q.created_at,
+    u.full_name AS asker_name,
+    (SELECT COUNT(*) FROM product_answers a WHERE a.question_id = q.id AND a.status = 'approved')::BIGINT AS answer_count
+FROM product_questions q
+JOIN users u ON q.user_id = u.id
+WHERE q.product_id = $1 AND q.status = 'approved'
+ORDER BY q.created_at DESC
+LIMIT $3 OFFSET $2
+`
+
+type ListApprovedProductQuestionsParams struct {
+	ProductID  uuid.UUID `json:"product_id"`
+	PageOffset int32     `json:"page_offset"`
+	PageLimit  int32     `json:"page_limit"`
+}
+
+type ListApprovedProductQuestionsRow struct {
+	ID          uuid.UUID          `json:"id"`
+	ProductID   uuid.UUID          `json:"product_id"`
+	Body        string             `json:"body"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	AskerName   *string            `json:"asker_name"`
+	AnswerCount int64              `json:"answer_count"`
+}
+
+// Retrieves the published questions of a product, newest first, with the number of published answers.
+func (q *Queries) ListApprovedProductQuestions(ctx context.Context, arg ListApprovedProductQuestionsParams) ([]ListApprovedProductQuestionsRow, error) {
+	rows, err := q.db.Query(ctx, listApprovedProductQuestions, arg.ProductID, arg.PageOffset, arg.PageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListApprovedProductQuestionsRow
+	for rows.Next() {
+		var i ListApprovedProductQuestionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.Body,
+			&i.CreatedAt,
+			&i.AskerName,
+			&i.AnswerCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductAnswersForModeration = `-- name: ListProductAnswersForModeration :many
+SELECT
+    a.id,
+    a.question_id,
+    a.user_id,
+    a.body,
+    a.is_official,
+    a.status,
+    a.moderation_reason,
+    a.moderated_by,
+    a.moderated_at,
+    a.upvote_count,
+    a.created_at,
+    u.full_name AS author_name,
+    u.email AS author_email,
+    q.body AS question_body,
+    q.product_id
+FROM product_answers a
+JOIN product_questions q ON a.question_id = q.id
+LEFT JOIN users u ON a.user_id = u.id
+WHERE ($1::TEXT = '' OR a.status = $1::TEXT)
+ORDER BY a.created_at ASC
+LIMIT $3 OFFSET $2
+`
+
+type ListProductAnswersForModerationParams struct {
+	Status     string `json:"status"`
+	PageOffset int32  `json:"page_offset"`
+	PageLimit  int32  `json:"page_limit"`
+}
+
+type ListProductAnswersForModerationRow struct {
+	ID               uuid.UUID          `json:"id"`
+	QuestionID       uuid.UUID          `json:"question_id"`
+	UserID           uuid.UUID          `json:"user_id"`
+	Body             string             `json:"body"`
+	IsOfficial       bool               `json:"is_official"`
+	Status           string             `json:"status"`
+	ModerationReason *string            `json:"moderation_reason"`
+	ModeratedBy      uuid.UUID          `json:"moderated_by"`
+	ModeratedAt      pgtype.Timestamptz `json:"moderated_at"`
+	UpvoteCount      int32              `json:"upvote_count"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	AuthorName       *string            `json:"author_name"`
+	AuthorEmail      *string            `json:"author_email"`
+	QuestionBody     string             `json:"question_body"`
+	ProductID        uuid.UUID          `json:"product_id"`
+}
+
+// Retrieves answers for the admin moderation queue, oldest first, optionally filtered by status (empty = all).
+func (q *Queries) ListProductAnswersForModeration(ctx context.Context, arg ListProductAnswersForModerationParams) ([]ListProductAnswersForModerationRow, error) {
+	rows, err := q.db.Query(ctx, listProductAnswersForModeration, arg.Status, arg.PageOffset, arg.PageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListProductAnswersForModerationRow
+	for rows.Next() {
+		var i ListProductAnswersForModerationRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.QuestionID,
+			&i.UserID,
+			&i.Body,
+			&i.IsOfficial,
+			&i.Status,
+			&i.ModerationReason,
+			&i.ModeratedBy,
+			&i.ModeratedAt,
+			&i.UpvoteCount,
+			&i.CreatedAt,
+			&i.AuthorName,
+			&i.AuthorEmail,
+			&i.QuestionBody,
+			&i.ProductID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductQuestionsForModeration = `-- name: ListProductQuestionsForModeration :many
+SELECT
+    q.id,
+    q.product_id,
+    q.user_id,
+    q.body,
+    q.status,
+    q.moderation_reason,
+    q.moderated_by,
+    q.moderated_at,
+    q.created_at,
+    u.full_name AS asker_name,
+    u.email AS asker_email,
+    p.name AS product_name
+FROM product_questions q
+JOIN users u ON q.user_id = u.id
+JOIN products p ON q.product_id = p.id
+WHERE ($1::TEXT = '' OR q.status = $1::TEXT)
+ORDER BY q.created_at ASC
+LIMIT $3 OFFSET $2
+`
+
+type ListProductQuestionsForModerationParams struct {
+	Status     string `json:"status"`
+	PageOffset int32  `json:"page_offset"`
+	PageLimit  int32  `json:"page_limit"`
+}
+
+type ListProductQuestionsForModerationRow struct {
+	ID               uuid.UUID          `json:"id"`
+	ProductID        uuid.UUID          `json:"product_id"`
+	UserID           uuid.UUID          `json:"user_id"`
+	Body             string             `json:"body"`
+	Status           string             `json:"status"`
+	ModerationReason *string            `json:"moderation_reason"`
+	ModeratedBy      uuid.UUID          `json:"moderated_by"`
+	ModeratedAt      pgtype.Timestamptz `json:"moderated_at"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	AskerName        *string            `json:"asker_name"`
+	AskerEmail       string             `json:"asker_email"`
+	ProductName      string             `json:"product_name"`
+}
+
+// Retrieves questions for the admin moderation queue, oldest first, optionally filtered by status (empty = all).
+func (q *Queries) ListProductQuestionsForModeration(ctx context.Context, arg ListProductQuestionsForModerationParams) ([]ListProductQuestionsForModerationRow, error) {
+	rows, err := q.db.Query(ctx, listProductQuestionsForModeration, arg.Status, arg.PageOffset, arg.PageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListProductQuestionsForModerationRow
+	for rows.Next() {
+		var i ListProductQuestionsForModerationRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.UserID,
+			&i.Body,
+			&i.Status,
+			&i.ModerationReason,
+			&i.ModeratedBy,
+			&i.ModeratedAt,
+			&i.CreatedAt,
+			&i.AskerName,
+			&i.AskerEmail,
+			&i.ProductName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const moderateProductAnswer = `-- name: ModerateProductAnswer :one
+UPDATE product_answers a
+SET
+    status = $1,
+    moderation_reason = $2,
+    moderated_by = NULLIF($3::UUID, '00000000-0000-0000-0000-000000000000'),
+    moderated_at = NOW(),
+    updated_at = NOW()
+FROM product_answers prev
+WHERE a.id = $4 AND prev.id = a.id
+RETURNING a.id, a.question_id, a.user_id, a.body, a.is_official, a.status, a.upvote_count, a.created_at, a.updated_at, prev.status AS previous_status
+`
+
+type ModerateProductAnswerParams struct {
+	Status           string    `json:"status"`
+	ModerationReason *string   `json:"moderation_reason"`
+	ModeratedBy      uuid.UUID `json:"moderated_by"`
+	ID               uuid.UUID `json:"id"`
+}
+
+type ModerateProductAnswerRow struct {
+	ID             uuid.UUID          `json:"id"`
+	QuestionID     uuid.UUID          `json:"question_id"`
+	UserID         uuid.UUID          `json:"user_id"`
+	Body           string             `json:"body"`
+	IsOfficial     bool               `json:"is_official"`
+	Status         string             `json:"status"`
+	UpvoteCount    int32              `json:"upvote_count"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	PreviousStatus string             `json:"previous_status"`
+}
+
+// Sets the moderation status of an answer and returns its previous status,
+// so the asker is only notified the first time the answer is published.
+func (q *Queries) ModerateProductAnswer(ctx context.Context, arg ModerateProductAnswerParams) (ModerateProductAnswerRow, error) {
+	row := q.db.QueryRow(ctx, moderateProductAnswer,
+		arg.Status,
+		arg.ModerationReason,
+		arg.ModeratedBy,
+		arg.ID,
+	)
+	var i ModerateProductAnswerRow
+	err := row.Scan(
+		&i.ID,
+		&i.QuestionID,
+		&i.UserID,
+		&i.Body,
+		&i.IsOfficial,
+		&i.Status,
+		&i.UpvoteCount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PreviousStatus,
+	)
+	return i, err
+}
+
+const moderateProductQuestion = `-- name: ModerateProductQuestion :one
+UPDATE product_questions
+SET
+    status = $1,
+    moderation_reason = $2,
+    moderated_by = NULLIF($3::UUID, '00000000-0000-0000-0000-000000000000'),
+    moderated_at = NOW(),
+    updated_at = NOW()
+WHERE id = $4
+RETURNING id, product_id, user_id, body, status, moderation_reason, moderated_by, moderated_at, created_at, updated_at
+`
+
+type ModerateProductQuestionParams struct {
+	Status           string    `json:"status"`
+	ModerationReason *string   `json:"moderation_reason"`
+	ModeratedBy      uuid.UUID `json:"moderated_by"`
+	ID               uuid.UUID `json:"id"`
+}
+
+// Sets the moderation status of a question.
+func (q *Queries) ModerateProductQuestion(ctx context.Context, arg ModerateProductQuestionParams) (ProductQuestion, error) {
+	row := q.db.QueryRow(ctx, moderateProductQuestion,
+		arg.Status,
+		arg.ModerationReason,
+		arg.ModeratedBy,
+		arg.ID,
+	)
+	var i ProductQuestion
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.Body,
+		&i.Status,
+		&i.ModerationReason,
+		&i.ModeratedBy,
+		&i.ModeratedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const refreshProductAnswerUpvoteCount = `-- name: RefreshProductAnswerUpvoteCount :one
+UPDATE product_answers
+SET upvote_count = (SELECT COUNT(*) FROM product_answer_votes v WHERE v.answer_id = product_answers.id)
+WHERE id = $1
+RETURNING upvote_count
+`
+
+// Recalculates the upvote total of an answer from its votes.
+func (q *Queries) RefreshProductAnswerUpvoteCount(ctx context.Context, id uuid.UUID) (int32, error) {
+	row := q.db.QueryRow(ctx, refreshProductAnswerUpvoteCount, id)
+	var upvote_count int32
+	err := row.Scan(&upvote_count)
+	return upvote_count, err
+}
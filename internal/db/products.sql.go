@@ -152,7 +152,7 @@ func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams)
 
 const createProduct = `-- name: CreateProduct :one
 INSERT INTO products (
-    category_id, name, slug, description, short_description, price_cents, stock_quantity, status, brand, image_urls, spec_highlights, created_at, updated_at
+    category_id, name, slug, description, short_description, price_cents, stock_quantity, status, brand, image_urls, spec_highlights, weight_grams, created_at, updated_at
 ) VALUES (
     $1, 
     $2, 
@@ -165,11 +165,12 @@ INSERT INTO products (
     $9, 
     $10, 
     $11, 
+    $12, -- Shipping weight, used to price delivery
     NOW(), -- created_at
     NOW()  -- updated_at
 ) 
 RETURNING  id, category_id, name, slug, description, short_description, price_cents, stock_quantity, status, brand, 
-    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at
+    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at, weight_grams
 `
 
 type CreateProductParams struct {
@@ -184,6 +185,7 @@ type CreateProductParams struct {
 	Brand            string    `json:"brand"`
 	ImageUrls        []byte    `json:"image_urls"`
 	SpecHighlights   []byte    `json:"spec_highlights"`
+	WeightGrams      *int32    `json:"weight_grams"`
 }
 
 func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error) {
@@ -199,6 +201,7 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		arg.Brand,
 		arg.ImageUrls,
 		arg.SpecHighlights,
+		arg.WeightGrams,
 	)
 	var i Product
 	err := row.Scan(
@@ -219,6 +222,7 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.WeightGrams,
 	)
 	return i, err
 }
@@ -285,7 +289,7 @@ func (q *Queries) GetCategoryBySlug(ctx context.Context, slug string) (Category,
 
 const getProduct = `-- name: GetProduct :one
 SELECT id, category_id, name, slug, description, short_description, price_cents, stock_quantity, status, brand, 
-    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at
+    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at, weight_grams
 FROM products
 WHERE id = $1 AND deleted_at IS NULL
 `
@@ -311,13 +315,14 @@ func (q *Queries) GetProduct(ctx context.Context, productID uuid.UUID) (Product,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.WeightGrams,
 	)
 	return i, err
 }
 
 const getProductBySlug = `-- name: GetProductBySlug :one
 SELECT id, category_id, name, slug, description, short_description, price_cents, stock_quantity, status, brand, 
-    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at
+    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at, weight_grams
 FROM products
 WHERE slug = $1 AND deleted_at IS NULL
 `
@@ -343,10 +348,42 @@ func (q *Queries) GetProductBySlug(ctx context.Context, slug string) (Product, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.WeightGrams,
 	)
 	return i, err
 }
 
+const getProductWeights = `-- name: GetProductWeights :many
+SELECT id, weight_grams FROM products
+WHERE id = ANY($1::UUID[])
+`
+
+type GetProductWeightsRow struct {
+	ID          uuid.UUID `json:"id"`
+	WeightGrams *int32    `json:"weight_grams"`
+}
+
+// Retrieves the shipping weight of each product, for pricing delivery of a cart.
+func (q *Queries) GetProductWeights(ctx context.Context, productIds []uuid.UUID) ([]GetProductWeightsRow, error) {
+	rows, err := q.db.Query(ctx, getProductWeights, productIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetProductWeightsRow
+	for rows.Next() {
+		var i GetProductWeightsRow
+		if err := rows.Scan(&i.ID, &i.WeightGrams); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listCategories = `-- name: ListCategories :many
 SELECT id, name, slug, type, parent_id, created_at 
 FROM categories
@@ -388,7 +425,7 @@ func (q *Queries) ListCategories(ctx context.Context, arg ListCategoriesParams)
 
 const listProducts = `-- name: ListProducts :many
 SELECT id, category_id, name, slug, description, short_description, price_cents, stock_quantity, status, brand, 
-    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at
+    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at, weight_grams
 FROM products
 WHERE deleted_at IS NULL
 ORDER BY created_at DESC
@@ -427,6 +464,7 @@ func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]P
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.WeightGrams,
 		); err != nil {
 			return nil, err
 		}
@@ -440,7 +478,7 @@ func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]P
 
 const listProductsByCategory = `-- name: ListProductsByCategory :many
 SELECT id, category_id, name, slug, description, short_description, price_cents, stock_quantity, status, brand, 
-    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at
+    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at, weight_grams
 FROM products
 WHERE category_id = $1 AND deleted_at IS NULL
 ORDER BY created_at DESC
@@ -480,6 +518,7 @@ func (q *Queries) ListProductsByCategory(ctx context.Context, arg ListProductsBy
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.WeightGrams,
 		); err != nil {
 			return nil, err
 		}
@@ -493,7 +532,7 @@ func (q *Queries) ListProductsByCategory(ctx context.Context, arg ListProductsBy
 
 const listProductsWithCategory = `-- name: ListProductsWithCategory :many
 SELECT 
-    p.id, p.category_id, p.name, p.slug, p.description, p.short_description, p.price_cents, p.stock_quantity, p.status, p.brand, p.avg_rating, p.num_ratings, p.image_urls, p.spec_highlights, p.created_at, p.updated_at, p.deleted_at,
+    p.id, p.category_id, p.name, p.slug, p.description, p.short_description, p.price_cents, p.stock_quantity, p.status, p.brand, p.avg_rating, p.num_ratings, p.image_urls, p.spec_highlights, p.created_at, p.updated_at, p.deleted_at, p.weight_grams,
     c.name as category_name,
     c.slug as category_slug,
     c.type as category_type
@@ -543,6 +582,7 @@ func (q *Queries) ListProductsWithCategory(ctx context.Context, arg ListProducts
 			&i.Product.CreatedAt,
 			&i.Product.UpdatedAt,
 			&i.Product.DeletedAt,
+			&i.Product.WeightGrams,
 			&i.CategoryName,
 			&i.CategorySlug,
 			&i.CategoryType,
@@ -559,7 +599,7 @@ func (q *Queries) ListProductsWithCategory(ctx context.Context, arg ListProducts
 
 const listProductsWithCategoryDetail = `-- name: ListProductsWithCategoryDetail :many
 SELECT 
-    p.id, p.category_id, p.name, p.slug, p.description, p.short_description, p.price_cents, p.stock_quantity, p.status, p.brand, p.avg_rating, p.num_ratings, p.image_urls, p.spec_highlights, p.created_at, p.updated_at, p.deleted_at,
+    p.id, p.category_id, p.name, p.slug, p.description, p.short_description, p.price_cents, p.stock_quantity, p.status, p.brand, p.avg_rating, p.num_ratings, p.image_urls, p.spec_highlights, p.created_at, p.updated_at, p.deleted_at, p.weight_grams,
     c.id, c.name, c.slug, c.type, c.parent_id, c.created_at
 FROM products p
 JOIN categories c ON p.category_id = c.id
@@ -606,6 +646,7 @@ func (q *Queries) ListProductsWithCategoryDetail(ctx context.Context, arg ListPr
 			&i.Product.CreatedAt,
 			&i.Product.UpdatedAt,
 			&i.Product.DeletedAt,
+			&i.Product.WeightGrams,
 			&i.Category.ID,
 			&i.Category.Name,
 			&i.Category.Slug,
@@ -625,7 +666,7 @@ func (q *Queries) ListProductsWithCategoryDetail(ctx context.Context, arg ListPr
 
 const searchProductsWithCategory = `-- name: SearchProductsWithCategory :many
 SELECT 
-    p.id, p.category_id, p.name, p.slug, p.description, p.short_description, p.price_cents, p.stock_quantity, p.status, p.brand, p.avg_rating, p.num_ratings, p.image_urls, p.spec_highlights, p.created_at, p.updated_at, p.deleted_at,
+    p.id, p.category_id, p.name, p.slug, p.description, p.short_description, p.price_cents, p.stock_quantity, p.status, p.brand, p.avg_rating, p.num_ratings, p.image_urls, p.spec_highlights, p.created_at, p.updated_at, p.deleted_at, p.weight_grams,
     c.name as category_name,
     c.slug as category_slug,
     c.type as category_type
@@ -696,6 +737,7 @@ func (q *Queries) SearchProductsWithCategory(ctx context.Context, arg SearchProd
 			&i.Product.CreatedAt,
 			&i.Product.UpdatedAt,
 			&i.Product.DeletedAt,
+			&i.Product.WeightGrams,
 			&i.CategoryName,
 			&i.CategorySlug,
 			&i.CategoryType,
@@ -725,6 +767,7 @@ SELECT
     p.brand,
     p.image_urls,
     p.spec_highlights,
+    p.weight_grams,
     p.created_at,
     p.updated_at,
     p.deleted_at,
@@ -801,6 +844,7 @@ type SearchProductsWithDiscountsRow struct {
 	Brand                        string             `json:"brand"`
 	ImageUrls                    []byte             `json:"image_urls"`
 	SpecHighlights               []byte             `json:"spec_highlights"`
+	WeightGrams                  *int32             `json:"weight_grams"`
 	CreatedAt                    pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt                    pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt                    pgtype.Timestamptz `json:"deleted_at"`
@@ -850,6 +894,7 @@ func (q *Queries) SearchProductsWithDiscounts(ctx context.Context, arg SearchPro
 			&i.Brand,
 			&i.ImageUrls,
 			&i.SpecHighlights,
+			&i.WeightGrams,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
@@ -915,15 +960,16 @@ SET
     description = COALESCE($4, description),
     short_description = COALESCE($5, short_description),
     price_cents = COALESCE($6, price_cents),
-    stock_quantity = COALESCE($7, stock_quantity),
-    status = COALESCE($8, status),
-    brand = COALESCE($9, brand),
-    image_urls = COALESCE($10, image_urls),
-    spec_highlights = COALESCE($11, spec_highlights),
+    -- stock_quantity is changed through SetProductStock/ApplyStockMovement so every change is recorded in stock_movements
+    status = COALESCE($7, status),
+    brand = COALESCE($8, brand),
+    image_urls = COALESCE($9, image_urls),
+    spec_highlights = COALESCE($10, spec_highlights),
+    weight_grams = COALESCE($11, weight_grams),
     updated_at = NOW()
 WHERE id = $12 AND deleted_at IS NULL
 RETURNING  id, category_id, name, slug, description, short_description, price_cents, stock_quantity, status, brand, 
-    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at
+    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at, weight_grams
 `
 
 type UpdateProductParams struct {
@@ -933,11 +979,11 @@ type UpdateProductParams struct {
 	Description      *string   `json:"description"`
 	ShortDescription *string   `json:"short_description"`
 	PriceCents       int64     `json:"price_cents"`
-	StockQuantity    int32     `json:"stock_quantity"`
 	Status           string    `json:"status"`
 	Brand            string    `json:"brand"`
 	ImageUrls        []byte    `json:"image_urls"`
 	SpecHighlights   []byte    `json:"spec_highlights"`
+	WeightGrams      *int32    `json:"weight_grams"`
 	ProductID        uuid.UUID `json:"product_id"`
 }
 
@@ -949,11 +995,11 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (P
 		arg.Description,
 		arg.ShortDescription,
 		arg.PriceCents,
-		arg.StockQuantity,
 		arg.Status,
 		arg.Brand,
 		arg.ImageUrls,
 		arg.SpecHighlights,
+		arg.WeightGrams,
 		arg.ProductID,
 	)
 	var i Product
@@ -975,6 +1021,7 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (P
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.WeightGrams,
 	)
 	return i, err
 }
@@ -0,0 +1,735 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: purchase_order.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const countOutstandingPurchaseOrderItems = `-- name: CountOutstandingPurchaseOrderItems :one
+SELECT COUNT(*) FROM purchase_order_items
+WHERE purchase_order_id = $1 AND quantity_received < quantity_ordered
+`
+
+// Counts the lines of a purchase order that are not fully received.
+func (q *Queries) CountOutstandingPurchaseOrderItems(ctx context.Context, purchaseOrderID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countOutstandingPurchaseOrderItems, purchaseOrderID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countPurchaseOrders = `-- name: CountPurchaseOrders :one
+SELECT COUNT(*) FROM purchase_orders po
+WHERE ($1::TEXT = '' OR po.status = $1::TEXT)
+  AND ($2::UUID = '00000000-0000-0000-0000-000000000000' OR po.supplier_id = $2::UUID)
+`
+
+type CountPurchaseOrdersParams struct {
+	Status     string    `json:"status"`
+	SupplierID uuid.UUID `json:"supplier_id"`
+}
+
+func (q *Queries) CountPurchaseOrders(ctx context.Context, arg CountPurchaseOrdersParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countPurchaseOrders, arg.Status, arg.SupplierID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createPurchaseOrder = `-- name: CreatePurchaseOrder :one
+INSERT INTO purchase_orders (supplier_id, location_id, reference, notes, expected_at, created_by)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    NULLIF($6::UUID, '00000000-0000-0000-0000-000000000000')
+)
+RETURNING id, supplier_id, location_id, status, reference, notes, expected_at, created_by, ordered_at, received_at, created_at, updated_at
+`
+
+type CreatePurchaseOrderParams struct {
+	SupplierID uuid.UUID          `json:"supplier_id"`
+	LocationID uuid.UUID          `json:"location_id"`
+	Reference  *string            `json:"reference"`
+	Notes      *string            `json:"notes"`
+	ExpectedAt pgtype.Timestamptz `json:"expected_at"`
+	CreatedBy  uuid.UUID          `json:"created_by"`
+}
+
+func (q *Queries) CreatePurchaseOrder(ctx context.Context, arg CreatePurchaseOrderParams) (PurchaseOrder, error) {
+	row := q.db.QueryRow(ctx, createPurchaseOrder,
+		arg.SupplierID,
+		arg.LocationID,
+		arg.Reference,
+		arg.Notes,
+		arg.ExpectedAt,
+		arg.CreatedBy,
+	)
+	var i PurchaseOrder
+	err := row.Scan(
+		&i.ID,
+		&i.SupplierID,
+		&i.LocationID,
+		&i.Status,
+		&i.Reference,
+		&i.Notes,
+		&i.ExpectedAt,
+		&i.CreatedBy,
+		&i.OrderedAt,
+		&i.ReceivedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createPurchaseOrderItem = `-- name: CreatePurchaseOrderItem :one
+INSERT INTO purchase_order_items (purchase_order_id, product_id, quantity_ordered, unit_cost_cents)
+VALUES ($1, $2, $3, $4)
+RETURNING id, purchase_order_id, product_id, quantity_ordered, quantity_received, unit_cost_cents, created_at
+`
+
+type CreatePurchaseOrderItemParams struct {
+	PurchaseOrderID uuid.UUID `json:"purchase_order_id"`
+	ProductID       uuid.UUID `json:"product_id"`
+	QuantityOrdered int32     `json:"quantity_ordered"`
+	UnitCostCents   int64     `json:"unit_cost_cents"`
+}
+
+func (q *Queries) CreatePurchaseOrderItem(ctx context.Context, arg CreatePurchaseOrderItemParams) (PurchaseOrderItem, error) {
+	row := q.db.QueryRow(ctx, createPurchaseOrderItem,
+		arg.PurchaseOrderID,
+		arg.ProductID,
+		arg.QuantityOrdered,
+		arg.UnitCostCents,
+	)
+	var i PurchaseOrderItem
+	err := row.Scan(
+		&i.ID,
+		&i.PurchaseOrderID,
+		&i.ProductID,
+		&i.QuantityOrdered,
+		&i.QuantityReceived,
+		&i.UnitCostCents,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createPurchaseOrderReceipt = `-- name: CreatePurchaseOrderReceipt :one
+INSERT INTO purchase_order_receipts (purchase_order_id, purchase_order_item_id, product_id, location_id, quantity, unit_cost_cents, stock_movement_id, received_by)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    NULLIF($8::UUID, '00000000-0000-0000-0000-000000000000')
+)
+RETURNING id, purchase_order_id, purchase_order_item_id, product_id, location_id, quantity, unit_cost_cents, stock_movement_id, received_by, received_at
+`
+
+type CreatePurchaseOrderReceiptParams struct {
+	PurchaseOrderID     uuid.UUID `json:"purchase_order_id"`
+	PurchaseOrderItemID uuid.UUID `json:"purchase_order_item_id"`
+	ProductID           uuid.UUID `json:"product_id"`
+	LocationID          uuid.UUID `json:"location_id"`
+	Quantity            int32     `json:"quantity"`
+	UnitCostCents       int64     `json:"unit_cost_cents"`
+	StockMovementID     int64     `json:"stock_movement_id"`
+	ReceivedBy          uuid.UUID `json:"received_by"`
+}
+
+func (q *Queries) CreatePurchaseOrderReceipt(ctx context.Context, arg CreatePurchaseOrderReceiptParams) (PurchaseOrderReceipt, error) {
+	row := q.db.QueryRow(ctx, createPurchaseOrderReceipt,
+		arg.PurchaseOrderID,
+		arg.PurchaseOrderItemID,
+		arg.ProductID,
+		arg.LocationID,
+		arg.Quantity,
+		arg.UnitCostCents,
+		arg.StockMovementID,
+		arg.ReceivedBy,
+	)
+	var i PurchaseOrderReceipt
+	err := row.Scan(
+		&i.ID,
+		&i.PurchaseOrderID,
+		&i.PurchaseOrderItemID,
+		&i.ProductID,
+		&i.LocationID,
+		&i.Quantity,
+		&i.UnitCostCents,
+		&i.StockMovementID,
+		&i.ReceivedBy,
+		&i.ReceivedAt,
+	)
+	return i, err
+}
+
+const createSupplier = `-- name: CreateSupplier :one
+INSERT INTO suppliers (name, contact_name, email, phone, notes)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, name, contact_name, email, phone, notes, is_active, created_at, updated_at
+`
+
+type CreateSupplierParams struct {
+	Name        string  `json:"name"`
+	ContactName *string `json:"contact_name"`
+	Email       *string `json:"email"`
+	Phone       *string `json:"phone"`
+	Notes       *string `json:"notes"`
+}
+
+func (q *Queries) CreateSupplier(ctx context.Context, arg CreateSupplierParams) (Supplier, error) {
+	row := q.db.QueryRow(ctx, createSupplier,
+		arg.Name,
+		arg.ContactName,
+		arg.Email,
+		arg.Phone,
+		arg.Notes,
+	)
+	var i Supplier
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ContactName,
+		&i.Email,
+		&i.Phone,
+		&i.Notes,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deletePurchaseOrderItems = `-- name: DeletePurchaseOrderItems :exec
+DELETE FROM purchase_order_items WHERE purchase_order_id = $1
+`
+
+func (q *Queries) DeletePurchaseOrderItems(ctx context.Context, purchaseOrderID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deletePurchaseOrderItems, purchaseOrderID)
+	return err
+}
+
+const getPurchaseOrder = `-- name: GetPurchaseOrder :one
+SELECT id, supplier_id, location_id, status, reference, notes, expected_at, created_by, ordered_at, received_at, created_at, updated_at FROM purchase_orders WHERE id = $1
+`
+
+func (q *Queries) GetPurchaseOrder(ctx context.Context, id uuid.UUID) (PurchaseOrder, error) {
+	row := q.db.QueryRow(ctx, getPurchaseOrder, id)
+	var i PurchaseOrder
+	err := row.Scan(
+		&i.ID,
+		&i.SupplierID,
+		&i.LocationID,
+		&i.Status,
+		&i.Reference,
+		&i.Notes,
+		&i.ExpectedAt,
+		&i.CreatedBy,
+		&i.OrderedAt,
+		&i.ReceivedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getPurchaseOrderForUpdate = `-- name: GetPurchaseOrderForUpdate :one
+SELECT id, supplier_id, location_id, status, reference, notes, expected_at, created_by, ordered_at, received_at, created_at, updated_at FROM purchase_orders WHERE id = $1 FOR UPDATE
+`
+
+// Locks the purchase order so that concurrent receipts and status changes are serialized.
+func (q *Queries) GetPurchaseOrderForUpdate(ctx context.Context, id uuid.UUID) (PurchaseOrder, error) {
+	row := q.db.QueryRow(ctx, getPurchaseOrderForUpdate, id)
+	var i PurchaseOrder
+	err := row.Scan(
+		&i.ID,
+		&i.SupplierID,
+		&i.LocationID,
+		&i.Status,
+		&i.Reference,
+		&i.Notes,
+		&i.ExpectedAt,
+		&i.CreatedBy,
+		&i.OrderedAt,
+		&i.ReceivedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getPurchaseOrderItemForUpdate = `-- name: GetPurchaseOrderItemForUpdate :one
+SELECT id, purchase_order_id, product_id, quantity_ordered, quantity_received, unit_cost_cents, created_at FROM purchase_order_items
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetPurchaseOrderItemForUpdate(ctx context.Context, id uuid.UUID) (PurchaseOrderItem, error) {
+	row := q.db.QueryRow(ctx, getPurchaseOrderItemForUpdate, id)
+	var i PurchaseOrderItem
+	err := row.Scan(
+		&i.ID,
+		&i.PurchaseOrderID,
+		&i.ProductID,
+		&i.QuantityOrdered,
+		&i.QuantityReceived,
+		&i.UnitCostCents,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getSupplier = `-- name: GetSupplier :one
+SELECT id, name, contact_name, email, phone, notes, is_active, created_at, updated_at FROM suppliers WHERE id = $1
+`
+
+func (q *Queries) GetSupplier(ctx context.Context, id uuid.UUID) (Supplier, error) {
+	row := q.db.QueryRow(ctx, getSupplier, id)
+	var i Supplier
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ContactName,
+		&i.Email,
+		&i.Phone,
+		&i.Notes,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listPurchaseOrderItems = `-- name: ListPurchaseOrderItems :many
+SELECT
+    poi.id, poi.purchase_order_id, poi.product_id, poi.quantity_ordered, poi.quantity_received, poi.unit_cost_cents, poi.created_at,
+    p.name AS product_name
+FROM purchase_order_items poi
+JOIN products p ON p.id = poi.product_id
+WHERE poi.purchase_order_id = $1
+ORDER BY poi.created_at, poi.id
+`
+
+type ListPurchaseOrderItemsRow struct {
+	ID               uuid.UUID          `json:"id"`
+	PurchaseOrderID  uuid.UUID          `json:"purchase_order_id"`
+	ProductID        uuid.UUID          `json:"product_id"`
+	QuantityOrdered  int32              `json:"quantity_ordered"`
+	QuantityReceived int32              `json:"quantity_received"`
+	UnitCostCents    int64              `json:"unit_cost_cents"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	ProductName      string             `json:"product_name"`
+}
+
+func (q *Queries) ListPurchaseOrderItems(ctx context.Context, purchaseOrderID uuid.UUID) ([]ListPurchaseOrderItemsRow, error) {
+	rows, err := q.db.Query(ctx, listPurchaseOrderItems, purchaseOrderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPurchaseOrderItemsRow
+	for rows.Next() {
+		var i ListPurchaseOrderItemsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PurchaseOrderID,
+			&i.ProductID,
+			&i.QuantityOrdered,
+			&i.QuantityReceived,
+			&i.UnitCostCents,
+			&i.CreatedAt,
+			&i.ProductName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPurchaseOrderReceipts = `-- name: ListPurchaseOrderReceipts :many
+SELECT id, purchase_order_id, purchase_order_item_id, product_id, location_id, quantity, unit_cost_cents, stock_movement_id, received_by, received_at FROM purchase_order_receipts
+WHERE purchase_order_id = $1
+ORDER BY received_at, id
+`
+
+func (q *Queries) ListPurchaseOrderReceipts(ctx context.Context, purchaseOrderID uuid.UUID) ([]PurchaseOrderReceipt, error) {
+	rows, err := q.db.Query(ctx, listPurchaseOrderReceipts, purchaseOrderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PurchaseOrderReceipt
+	for rows.Next() {
+		var i PurchaseOrderReceipt
+		if err := rows.Scan(
+			&i.ID,
+			&i.PurchaseOrderID,
+			&i.PurchaseOrderItemID,
+			&i.ProductID,
+			&i.LocationID,
+			&i.Quantity,
+			&i.UnitCostCents,
+			&i.StockMovementID,
+			&i.ReceivedBy,
+			&i.ReceivedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPurchaseOrders = `-- name: ListPurchaseOrders :many
+SELECT
+    po.id,
+    po.supplier_id,
+    s.name AS supplier_name,
+    po.location_id,
+    po.status,
+    po.reference,
+    po.expected_at,
+    po.ordered_at,
+    po.received_at,
+    po.created_at,
+    COALESCE(SUM(poi.quantity_ordered * poi.unit_cost_cents), 0)::BIGINT AS total_cost_cents,
+    COALESCE(SUM(poi.quantity_ordered), 0)::BIGINT AS total_quantity_ordered,
+    COALESCE(SUM(poi.quantity_received), 0)::BIGINT AS total_quantity_received
+FROM purchase_orders po
+JOIN suppliers s ON s.id = po.supplier_id
+LEFT JOIN purchase_order_items poi ON poi.purchase_order_id = po.id
+WHERE ($1::TEXT = '' OR po.status = $1::TEXT)
+  AND ($2::UUID = '00000000-0000-0000-0000-000000000000' OR po.supplier_id = $2::UUID)
+GROUP BY po.id, s.name
+ORDER BY po.created_at DESC
+LIMIT $4 OFFSET $3
+`
+
+type ListPurchaseOrdersParams struct {
+	Status     string    `json:"status"`
+	SupplierID uuid.UUID `json:"supplier_id"`
+	PageOffset int32     `json:"page_offset"`
+	PageLimit  int32     `json:"page_limit"`
+}
+
+type ListPurchaseOrdersRow struct {
+	ID                    uuid.UUID          `json:"id"`
+	SupplierID            uuid.UUID          `json:"supplier_id"`
+	SupplierName          string             `json:"supplier_name"`
+	LocationID            uuid.UUID          `json:"location_id"`
+	Status                string             `json:"status"`
+	Reference             *string            `json:"reference"`
+	ExpectedAt            pgtype.Timestamptz `json:"expected_at"`
+	OrderedAt             pgtype.Timestamptz `json:"ordered_at"`
+	ReceivedAt            pgtype.Timestamptz `json:"received_at"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	TotalCostCents        int64              `json:"total_cost_cents"`
+	TotalQuantityOrdered  int64              `json:"total_quantity_ordered"`
+	TotalQuantityReceived int64              `json:"total_quantity_received"`
+}
+
+// Lists purchase orders with their supplier and totals, newest first.
+// Pass an empty status and the zero UUID for supplier_id to disable the filters.
+func (q *Queries) ListPurchaseOrders(ctx context.Context, arg ListPurchaseOrdersParams) ([]ListPurchaseOrdersRow, error) {
+	rows, err := q.db.Query(ctx, listPurchaseOrders,
+		arg.Status,
+		arg.SupplierID,
+		arg.PageOffset,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPurchaseOrdersRow
+	for rows.Next() {
+		var i ListPurchaseOrdersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SupplierID,
+			&i.SupplierName,
+			&i.LocationID,
+			&i.Status,
+			&i.Reference,
+			&i.ExpectedAt,
+			&i.OrderedAt,
+			&i.ReceivedAt,
+			&i.CreatedAt,
+			&i.TotalCostCents,
+			&i.TotalQuantityOrdered,
+			&i.TotalQuantityReceived,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSuppliers = `-- name: ListSuppliers :many
+SELECT id, name, contact_name, email, phone, notes, is_active, created_at, updated_at FROM suppliers
+WHERE (NOT $1::BOOLEAN OR is_active)
+ORDER BY name
+`
+
+func (q *Queries) ListSuppliers(ctx context.Context, activeOnly bool) ([]Supplier, error) {
+	rows, err := q.db.Query(ctx, listSuppliers, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Supplier
+	for rows.Next() {
+		var i Supplier
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.ContactName,
+			&i.Email,
+			&i.Phone,
+			&i.Notes,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const receivePurchaseOrderItem = `-- name: ReceivePurchaseOrderItem :one
+UPDATE purchase_order_items
+SET quantity_received = quantity_received + $1::INT
+WHERE id = $2 AND quantity_received + $1::INT <= quantity_ordered
+RETURNING id, purchase_order_id, product_id, quantity_ordered, quantity_received, unit_cost_cents, created_at
+`
+
+type ReceivePurchaseOrderItemParams struct {
+	Quantity int32     `json:"quantity"`
+	ID       uuid.UUID `json:"id"`
+}
+
+// Adds a received quantity to a line; returns no rows if it would exceed the ordered quantity.
+func (q *Queries) ReceivePurchaseOrderItem(ctx context.Context, arg ReceivePurchaseOrderItemParams) (PurchaseOrderItem, error) {
+	row := q.db.QueryRow(ctx, receivePurchaseOrderItem, arg.Quantity, arg.ID)
+	var i PurchaseOrderItem
+	err := row.Scan(
+		&i.ID,
+		&i.PurchaseOrderID,
+		&i.ProductID,
+		&i.QuantityOrdered,
+		&i.QuantityReceived,
+		&i.UnitCostCents,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const snapshotOrderItemCosts = `-- name: SnapshotOrderItemCosts :exec
+UPDATE order_items oi
+SET unit_cost_cents = pc.average_cost_cents
+FROM product_costs pc
+WHERE oi.product_id = pc.product_id AND oi.order_id = $1
+`
+
+// Copies the current average cost of each product onto the order's items, so margins use the cost at the time of sale.
+func (q *Queries) SnapshotOrderItemCosts(ctx context.Context, orderID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, snapshotOrderItemCosts, orderID)
+	return err
+}
+
+const updateProductAverageCost = `-- name: UpdateProductAverageCost :exec
+WITH on_hand AS (
+    SELECT COALESCE(SUM(psl.quantity), 0)::BIGINT AS quantity
+    FROM product_stock_levels psl
+    WHERE psl.product_id = $1
+)
+INSERT INTO product_costs (product_id, average_cost_cents)
+VALUES ($1, $2::BIGINT)
+ON CONFLICT (product_id) DO UPDATE
+SET average_cost_cents = CASE
+        WHEN (SELECT quantity FROM on_hand) <= 0 THEN EXCLUDED.average_cost_cents
+        ELSE ROUND(
+            (product_costs.average_cost_cents * (SELECT quantity FROM on_hand) + EXCLUDED.average_cost_cents * $3::INT)::NUMERIC
+            / ((SELECT quantity FROM on_hand) + $3::INT)
+        )::BIGINT
+    END,
+    updated_at = NOW()
+`
+
+type UpdateProductAverageCostParams struct {
+	ProductID     uuid.UUID `json:"product_id"`
+	UnitCostCents int64     `json:"unit_cost_cents"`
+	Quantity      int32     `json:"quantity"`
+}
+
+// Folds a receipt into the product's weighted average cost, using the stock on hand at all locations before the receipt.
+func (q *Queries) UpdateProductAverageCost(ctx context.Context, arg UpdateProductAverageCostParams) error {
+	_, err := q.db.Exec(ctx, updateProductAverageCost, arg.ProductID, arg.UnitCostCents, arg.Quantity)
+	return err
+}
+
+const updatePurchaseOrderDetails = `-- name: UpdatePurchaseOrderDetails :one
+UPDATE purchase_orders
+SET
+    location_id = COALESCE(NULLIF($1::UUID, '00000000-0000-0000-0000-000000000000'), location_id),
+    reference = COALESCE($2, reference),
+    notes = COALESCE($3, notes),
+    expected_at = COALESCE($4, expected_at),
+    updated_at = NOW()
+WHERE id = $5
+RETURNING id, supplier_id, location_id, status, reference, notes, expected_at, created_by, ordered_at, received_at, created_at, updated_at
+`
+
+type UpdatePurchaseOrderDetailsParams struct {
+	LocationID uuid.UUID          `json:"location_id"`
+	Reference  *string            `json:"reference"`
+	Notes      *string            `json:"notes"`
+	ExpectedAt pgtype.Timestamptz `json:"expected_at"`
+	ID         uuid.UUID          `json:"id"`
+}
+
+// Updates the editable fields of a draft purchase order.
+// Pass the zero UUID for location_id to keep the current location.
+func (q *Queries) UpdatePurchaseOrderDetails(ctx context.Context, arg UpdatePurchaseOrderDetailsParams) (PurchaseOrder, error) {
+	row := q.db.QueryRow(ctx, updatePurchaseOrderDetails,
+		arg.LocationID,
+		arg.Reference,
+		arg.Notes,
+		arg.ExpectedAt,
+		arg.ID,
+	)
+	var i PurchaseOrder
+	err := row.Scan(
+		&i.ID,
+		&i.SupplierID,
+		&i.LocationID,
+		&i.Status,
+		&i.Reference,
+		&i.Notes,
+		&i.ExpectedAt,
+		&i.CreatedBy,
+		&i.OrderedAt,
+		&i.ReceivedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updatePurchaseOrderStatus = `-- name: UpdatePurchaseOrderStatus :one
+UPDATE purchase_orders
+SET
+    status = $1,
+    ordered_at = CASE WHEN $1 = 'ordered' THEN NOW() ELSE ordered_at END,
+    received_at = CASE WHEN $1 = 'received' THEN NOW() ELSE received_at END,
+    updated_at = NOW()
+WHERE id = $2
+RETURNING id, supplier_id, location_id, status, reference, notes, expected_at, created_by, ordered_at, received_at, created_at, updated_at
+`
+
+type UpdatePurchaseOrderStatusParams struct {
+	Status string    `json:"status"`
+	ID     uuid.UUID `json:"id"`
+}
+
+// Sets the status; ordered_at and received_at are stamped on the corresponding transitions.
+func (q *Queries) UpdatePurchaseOrderStatus(ctx context.Context, arg UpdatePurchaseOrderStatusParams) (PurchaseOrder, error) {
+	row := q.db.QueryRow(ctx, updatePurchaseOrderStatus, arg.Status, arg.ID)
+	var i PurchaseOrder
+	err := row.Scan(
+		&i.ID,
+		&i.SupplierID,
+		&i.LocationID,
+		&i.Status,
+		&i.Reference,
+		&i.Notes,
+		&i.ExpectedAt,
+		&i.CreatedBy,
+		&i.OrderedAt,
+		&i.ReceivedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateSupplier = `-- name: UpdateSupplier :one
+UPDATE suppliers
+SET
+    name = COALESCE($1, name),
+    contact_name = COALESCE($2, contact_name),
+    email = COALESCE($3, email),
+    phone = COALESCE($4, phone),
+    notes = COALESCE($5, notes),
+    is_active = COALESCE($6, is_active),
+    updated_at = NOW()
+WHERE id = $7
+RETURNING id, name, contact_name, email, phone, notes, is_active, created_at, updated_at
+`
+
+type UpdateSupplierParams struct {
+	Name        *string   `json:"name"`
+	ContactName *string   `json:"contact_name"`
+	Email       *string   `json:"email"`
+	Phone       *string   `json:"phone"`
+	Notes       *string   `json:"notes"`
+	IsActive    *bool     `json:"is_active"`
+	ID          uuid.UUID `json:"id"`
+}
+
+// Partially updates a supplier; only provided fields change.
+func (q *Queries) UpdateSupplier(ctx context.Context, arg UpdateSupplierParams) (Supplier, error) {
+	row := q.db.QueryRow(ctx, updateSupplier,
+		arg.Name,
+		arg.ContactName,
+		arg.Email,
+		arg.Phone,
+		arg.Notes,
+		arg.IsActive,
+		arg.ID,
+	)
+	var i Supplier
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ContactName,
+		&i.Email,
+		&i.Phone,
+		&i.Notes,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
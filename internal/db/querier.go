@@ -8,6 +8,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
@@ -18,17 +19,33 @@ type Querier interface {
 	// Checks stock availability for each item during the insert/update process.
 	// Join with products table to validate existence, status, deletion, and stock for the INSERT
 	AddCartItemsBulk(ctx context.Context, arg AddCartItemsBulkParams) (int64, error)
+	AddDeliveryZoneWilayas(ctx context.Context, arg AddDeliveryZoneWilayasParams) error
+	// Records a status event, ignoring events already recorded.
+	AddShipmentEvent(ctx context.Context, arg AddShipmentEventParams) (int64, error)
+	// Assigns a role to a user.
+	AddUserRole(ctx context.Context, arg AddUserRoleParams) error
+	// Adds a product to a user's or guest's wishlist. Adding a product twice is a no-op.
+	// Guests pass the zero UUID as user_id, which is stored as NULL.
+	AddWishlistItem(ctx context.Context, arg AddWishlistItemParams) error
 	// Gets a specific user by ID, regardless of soft-delete status.
 	// Useful for admin to see any user, active or inactive.
-	AdminGetUser(ctx context.Context, userID uuid.UUID) (User, error)
+	AdminGetUser(ctx context.Context, userID uuid.UUID) (AdminGetUserRow, error)
 	// Associates a discount with a specific category (simplified version, might need more checks).
 	ApplyDiscountToCategory(ctx context.Context, arg ApplyDiscountToCategoryParams) error
 	// Include usage limit check
 	// Associates a discount with a specific product (simplified version, might need more checks).
 	ApplyDiscountToProduct(ctx context.Context, arg ApplyDiscountToProductParams) error
-	// Calculates the average rating and count of non-deleted reviews for a specific product.
+	// Changes a product's stock at a location by a signed delta and records the movement in the ledger, in one statement.
+	// products.stock_quantity follows through the trg_product_stock_levels_sync trigger.
+	// Returns no rows if the stock level does not exist or the stock would become negative.
+	// Pass the zero UUID for order_id/actor_id/transfer_id when there is no reference; it is stored as NULL.
+	ApplyStockMovement(ctx context.Context, arg ApplyStockMovementParams) (StockMovement, error)
+	// Increments the token version of a user, invalidating their outstanding access tokens.
+	BumpUserTokenVersion(ctx context.Context, id uuid.UUID) (int32, error)
+	// Calculates the average rating and count of approved, non-deleted reviews for a specific product.
+	// Verified-purchase reviews count verified_weight times in the average (1 = no weighting).
 	// Used to update the products table.
-	CalculateReviewStatsForProduct(ctx context.Context, productID uuid.UUID) (CalculateReviewStatsForProductRow, error)
+	CalculateReviewStatsForProduct(ctx context.Context, arg CalculateReviewStatsForProductParams) (CalculateReviewStatsForProductRow, error)
 	// Order items consistently
 	// Updates the status of an order to 'cancelled' and sets the cancelled_at and completed_at timestamps.
 	// This is a soft cancellation.
@@ -38,17 +55,40 @@ type Querier interface {
 	CheckSlugExists(ctx context.Context, slug string) (bool, error)
 	CleanupExpiredRefreshTokens(ctx context.Context) error
 	ClearCart(ctx context.Context, cartID uuid.UUID) error
+	ClearDefaultUserAddress(ctx context.Context, userID uuid.UUID) error
+	// Enables 2FA once the first code has been verified.
+	ConfirmUserTOTP(ctx context.Context, arg ConfirmUserTOTPParams) (int64, error)
+	// Counts active users holding a role, e.g. to keep at least one superuser.
+	CountActiveUsersWithRole(ctx context.Context, roleName string) (int64, error)
 	// Nullable status filter
 	// Counts all orders based on optional user and status filters.
 	CountAllOrders(ctx context.Context, arg CountAllOrdersParams) (int64, error)
 	CountAllProducts(ctx context.Context) (int64, error)
+	// Counts the published questions of a product.
+	CountApprovedProductQuestions(ctx context.Context, productID uuid.UUID) (int64, error)
+	// Counts the audit log entries matching the same filters as ListAuditLog.
+	CountAuditLog(ctx context.Context, arg CountAuditLogParams) (int64, error)
 	CountCategories(ctx context.Context) (int64, error)
 	// Counts discounts based on the same filters as ListDiscounts.
 	CountDiscounts(ctx context.Context, arg CountDiscountsParams) (int64, error)
+	// Counts the lines of a purchase order that are not fully received.
+	CountOutstandingPurchaseOrderItems(ctx context.Context, purchaseOrderID uuid.UUID) (int64, error)
+	// Counts answers in the admin moderation queue, optionally filtered by status (empty = all).
+	CountProductAnswersForModeration(ctx context.Context, status string) (int64, error)
+	// Counts questions in the admin moderation queue, optionally filtered by status (empty = all).
+	CountProductQuestionsForModeration(ctx context.Context, status string) (int64, error)
 	CountProducts(ctx context.Context, arg CountProductsParams) (int64, error)
+	CountPurchaseOrders(ctx context.Context, arg CountPurchaseOrdersParams) (int64, error)
+	// Counts the approved reviews for a specific product; rating = 0 means all star counts.
+	CountReviewsByProductID(ctx context.Context, arg CountReviewsByProductIDParams) (int64, error)
+	// Counts the reviews in the admin moderation queue, optionally filtered by status (empty = all).
+	CountReviewsForModeration(ctx context.Context, arg CountReviewsForModerationParams) (int64, error)
 	// Counts users matching the search term, optionally filtered by active status.
 	// Useful for pagination metadata with search.
 	CountSearchUsers(ctx context.Context, arg CountSearchUsersParams) (int64, error)
+	CountStockMovementsByProduct(ctx context.Context, productID uuid.UUID) (int64, error)
+	CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int64, error)
+	CountUserAddresses(ctx context.Context, userID uuid.UUID) (int64, error)
 	// Only include items not marked as deleted in the cart
 	// Counts orders for a specific user based on optional status filter.
 	// NOTE: UserID is a specific user to count for, FilterStatus is optional.
@@ -56,33 +96,60 @@ type Querier interface {
 	// Counts total users, optionally filtered by active status (soft-deleted).
 	// Useful for pagination metadata.
 	CountUsers(ctx context.Context, activeOnly bool) (int64, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error)
+	// Records a mutating admin action.
+	// Pass the zero UUID for actor_id when the actor is unknown; it is stored as NULL.
+	CreateAuditLogEntry(ctx context.Context, arg CreateAuditLogEntryParams) error
 	// Cart Item Management
 	CreateCartItem(ctx context.Context, arg CreateCartItemParams) (CartItem, error)
 	CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error)
+	CreateDeliveryRate(ctx context.Context, arg CreateDeliveryRateParams) error
 	CreateDeliveryService(ctx context.Context, arg CreateDeliveryServiceParams) (DeliveryService, error)
+	CreateDeliveryZone(ctx context.Context, arg CreateDeliveryZoneParams) (uuid.UUID, error)
 	// Inserts a new discount record.
 	CreateDiscount(ctx context.Context, arg CreateDiscountParams) (Discount, error)
 	CreateGuestCart(ctx context.Context, sessionID *string) (Cart, error)
 	// Creates a new order with denormalized address fields and returns its details.
 	CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error)
+	CreateOrderItemAllocation(ctx context.Context, arg CreateOrderItemAllocationParams) error
 	// --- Password Reset Tokens ---
 	// Inserts a new password reset token record.
 	CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) error
 	CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error)
+	// Subscribes a user to a back-in-stock or price-below alert for a product.
+	CreateProductAlert(ctx context.Context, arg CreateProductAlertParams) (ProductAlert, error)
+	// Records an answer to a question. Staff answers are created official and already approved.
+	CreateProductAnswer(ctx context.Context, arg CreateProductAnswerParams) (CreateProductAnswerRow, error)
+	// Records a user's upvote on an answer; voting twice has no effect.
+	CreateProductAnswerVote(ctx context.Context, arg CreateProductAnswerVoteParams) error
+	// Records a customer's question about a product.
+	CreateProductQuestion(ctx context.Context, arg CreateProductQuestionParams) (CreateProductQuestionRow, error)
+	CreatePurchaseOrder(ctx context.Context, arg CreatePurchaseOrderParams) (PurchaseOrder, error)
+	CreatePurchaseOrderItem(ctx context.Context, arg CreatePurchaseOrderItemParams) (PurchaseOrderItem, error)
+	CreatePurchaseOrderReceipt(ctx context.Context, arg CreatePurchaseOrderReceiptParams) (PurchaseOrderReceipt, error)
+	// last_used_at starts at NOW(): a token is created when its session signs in or refreshes.
 	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) error
 	// Inserts a new review and returns its details.
 	// NOTE: This query alone does not update the product's avg_rating/num_ratings.
 	CreateReview(ctx context.Context, arg CreateReviewParams) (CreateReviewRow, error)
-	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	// Attaches an uploaded image to a review.
+	CreateReviewImage(ctx context.Context, arg CreateReviewImageParams) error
+	// Adds the official reply to a review.
+	CreateReviewReply(ctx context.Context, arg CreateReviewReplyParams) (ReviewReply, error)
+	// Records an abuse report on a review.
+	CreateReviewReport(ctx context.Context, arg CreateReviewReportParams) (CreateReviewReportRow, error)
+	CreateShipment(ctx context.Context, arg CreateShipmentParams) (Shipment, error)
+	CreateStockLocation(ctx context.Context, arg CreateStockLocationParams) (StockLocation, error)
+	CreateStockTransfer(ctx context.Context, arg CreateStockTransferParams) (StockTransfer, error)
+	CreateSupplier(ctx context.Context, arg CreateSupplierParams) (Supplier, error)
+	CreateUser(ctx context.Context, arg CreateUserParams) (CreateUserRow, error)
+	CreateUserAddress(ctx context.Context, arg CreateUserAddressParams) (UserAddress, error)
 	// Cart Management
 	CreateUserCart(ctx context.Context, userID uuid.UUID) (Cart, error)
-	// Attempts to decrement the stock_quantity for a product by a given amount.
-	// Succeeds only if the resulting stock_quantity would be >= 0.
-	// Returns the updated product row if successful, or an error if insufficient stock.
-	// Note: The RETURNING clause might not be strictly necessary if we only care about RowsAffected.
-	// If RETURNING is omitted, the querier function will likely return sql.Result.
-	// Let's include RETURNING to get the updated stock if needed for debugging/logging.
-	DecrementStockIfSufficient(ctx context.Context, arg DecrementStockIfSufficientParams) (DecrementStockIfSufficientRow, error)
+	CreateUserIdentity(ctx context.Context, arg CreateUserIdentityParams) error
+	CreateUserRecoveryCode(ctx context.Context, arg CreateUserRecoveryCodeParams) error
+	// Deletes the audit log entries older than the retention cutoff.
+	DeleteAuditLogBefore(ctx context.Context, cutoff pgtype.Timestamptz) (int64, error)
 	DeleteCart(ctx context.Context, cartID uuid.UUID) error
 	// Cart Cleanup
 	DeleteCartItem(ctx context.Context, itemID uuid.UUID) error
@@ -90,6 +157,8 @@ type Querier interface {
 	// Soft delete could be achieved by updating is_active to FALSE
 	// For hard delete:
 	DeleteDeliveryService(ctx context.Context, id uuid.UUID) error
+	// Removes the whole rate table of a delivery service; wilayas and rates go with their zones.
+	DeleteDeliveryZones(ctx context.Context, deliveryServiceID uuid.UUID) error
 	// Deletes a discount record (and associated links via CASCADE).
 	DeleteDiscount(ctx context.Context, id uuid.UUID) error
 	// $1=token_string
@@ -99,15 +168,45 @@ type Querier interface {
 	// Deletes a specific password reset token record by its token string.
 	DeletePasswordResetToken(ctx context.Context, token string) error
 	DeleteProduct(ctx context.Context, productID uuid.UUID) error
+	// Deletes an alert, scoped to its owner.
+	DeleteProductAlert(ctx context.Context, arg DeleteProductAlertParams) (int64, error)
+	// Removes a user's upvote on an answer.
+	DeleteProductAnswerVote(ctx context.Context, arg DeleteProductAnswerVoteParams) (int64, error)
+	DeletePurchaseOrderItems(ctx context.Context, purchaseOrderID uuid.UUID) error
 	// Soft deletes a review by setting deleted_at.
 	// NOTE: This query alone does not update the product's avg_rating/num_ratings.
 	DeleteReview(ctx context.Context, arg DeleteReviewParams) (DeleteReviewRow, error)
+	// Removes the images of a review and returns their URLs so the files can be deleted.
+	DeleteReviewImages(ctx context.Context, reviewID uuid.UUID) ([]string, error)
+	// Removes the official reply to a review.
+	DeleteReviewReply(ctx context.Context, reviewID uuid.UUID) (int64, error)
+	// Removes a user's vote on a review.
+	DeleteReviewVote(ctx context.Context, arg DeleteReviewVoteParams) (int64, error)
+	DeleteUserAddress(ctx context.Context, arg DeleteUserAddressParams) (bool, error)
+	DeleteUserRecoveryCodes(ctx context.Context, userID uuid.UUID) error
+	// Removes every role assigned to a user.
+	DeleteUserRoles(ctx context.Context, userID uuid.UUID) error
+	DeleteUserTOTP(ctx context.Context, userID uuid.UUID) error
+	// Removes a product from a user's or guest's wishlist.
+	DeleteWishlistItem(ctx context.Context, arg DeleteWishlistItemParams) (int64, error)
+	// Creates the (empty) stock level of a product at a location if it does not exist yet.
+	// Called before ApplyStockMovement/SetProductStock, which only update existing levels.
+	EnsureProductStockLevel(ctx context.Context, arg EnsureProductStockLevelParams) error
+	GetAPIKey(ctx context.Context, id uuid.UUID) (ApiKey, error)
+	// Finds a key that is neither revoked nor expired. Checked on every request, so revocation is immediate.
+	GetActiveAPIKeyByHash(ctx context.Context, keyHash string) (ApiKey, error)
 	// Retrieves all delivery services that are currently active.
 	// Suitable for user-facing contexts like checkout.
 	GetActiveDeliveryServices(ctx context.Context) ([]DeliveryService, error)
 	// Check usage limit
 	// Fetches all currently active discounts (within date range and usage limits).
 	GetActiveDiscounts(ctx context.Context) ([]Discount, error)
+	// Retrieves a published answer, e.g. before upvoting it.
+	GetApprovedProductAnswer(ctx context.Context, id uuid.UUID) (GetApprovedProductAnswerRow, error)
+	// Retrieves a published question, e.g. before answering it.
+	GetApprovedProductQuestion(ctx context.Context, id uuid.UUID) (GetApprovedProductQuestionRow, error)
+	// Retrieves an approved, non-deleted review, for voting and reporting.
+	GetApprovedReview(ctx context.Context, id uuid.UUID) (GetApprovedReviewRow, error)
 	// Calculates the average time between order confirmation and shipment/delivery completion.
 	// Assumes 'confirmed' status is the start and 'shipped' or 'delivered' is the end.
 	GetAverageFulfillmentTime(ctx context.Context, arg GetAverageFulfillmentTimeParams) (float64, error)
@@ -131,6 +230,7 @@ type Querier interface {
 	GetCartWithItemsAndProductsWithDiscounts(ctx context.Context, id uuid.UUID) ([]GetCartWithItemsAndProductsWithDiscountsRow, error)
 	GetCategory(ctx context.Context, id uuid.UUID) (Category, error)
 	GetCategoryBySlug(ctx context.Context, slug string) (Category, error)
+	GetDefaultStockLocation(ctx context.Context) (StockLocation, error)
 	GetDeliveryService(ctx context.Context, arg GetDeliveryServiceParams) (DeliveryService, error)
 	// Retrieves a delivery service by its ID, regardless of its active status.
 	// Suitable for admin operations.
@@ -152,6 +252,12 @@ type Querier interface {
 	// --- Product Performance ---
 	// Retrieves products with stock quantity below a specified threshold.
 	GetLowStockProducts(ctx context.Context, stockQuantity int32) ([]GetLowStockProductsRow, error)
+	// Retrieves the stock of each product at each active location that is below a threshold,
+	// optionally limited to one location (pass the zero UUID for all locations).
+	GetLowStockProductsByLocation(ctx context.Context, arg GetLowStockProductsByLocationParams) ([]GetLowStockProductsByLocationRow, error)
+	// Calculates revenue, cost of goods sold and gross margin for delivered orders within a given time range.
+	// Items sold before their cost was known are reported separately and excluded from the margin.
+	GetMarginSummary(ctx context.Context, arg GetMarginSummaryParams) (GetMarginSummaryRow, error)
 	// --- Customer Insights ---
 	// Counts the number of new customers registered within a given time range.
 	GetNewCustomersCount(ctx context.Context, arg GetNewCustomersCountParams) (int64, error)
@@ -191,10 +297,19 @@ type Querier interface {
 	GetOrdersByStatusWithinTimeRange(ctx context.Context, arg GetOrdersByStatusWithinTimeRangeParams) ([]GetOrdersByStatusWithinTimeRangeRow, error)
 	GetProduct(ctx context.Context, productID uuid.UUID) (Product, error)
 	GetProductBySlug(ctx context.Context, slug string) (Product, error)
+	// Retrieves the N products with the highest gross margin for delivered orders within a given time range.
+	// Only items with a known cost are included.
+	GetProductMargins(ctx context.Context, arg GetProductMarginsParams) ([]GetProductMarginsRow, error)
+	// Retrieves a question with its asker and product, for staff answers and answer notifications.
+	GetProductQuestionForAnswer(ctx context.Context, id uuid.UUID) (GetProductQuestionForAnswerRow, error)
 	// Retrieves average rating and number of ratings for a specific product.
 	// (This might already be covered by the existing product queries selecting avg_rating, num_ratings)
 	// But here's a dedicated query if needed:
 	GetProductReviewStats(ctx context.Context, id uuid.UUID) (GetProductReviewStatsRow, error)
+	// Compares a product's stock at each location with the sum of its ledger movements there.
+	GetProductStockReconciliation(ctx context.Context, productID uuid.UUID) ([]GetProductStockReconciliationRow, error)
+	// Retrieves the shipping weight of each product, for pricing delivery of a cart.
+	GetProductWeights(ctx context.Context, productIds []uuid.UUID) ([]GetProductWeightsRow, error)
 	GetProductWithDiscountInfo(ctx context.Context, id uuid.UUID) (GetProductWithDiscountInfoRow, error)
 	// Query: GetProductWithDiscountInfoBySlug
 	// Retrieves a specific product by slug along with its calculated discount information using the pre-calculated view.
@@ -205,6 +320,12 @@ type Querier interface {
 	GetProductWithMultiDiscountDetails(ctx context.Context, id uuid.UUID) (GetProductWithMultiDiscountDetailsRow, error)
 	GetProductsWithDiscountInfo(ctx context.Context, arg GetProductsWithDiscountInfoParams) ([]GetProductsWithDiscountInfoRow, error)
 	GetProductsWithDiscountInfoView(ctx context.Context) ([]VProductsWithCurrentDiscount, error)
+	GetPurchaseOrder(ctx context.Context, id uuid.UUID) (PurchaseOrder, error)
+	// Locks the purchase order so that concurrent receipts and status changes are serialized.
+	GetPurchaseOrderForUpdate(ctx context.Context, id uuid.UUID) (PurchaseOrder, error)
+	GetPurchaseOrderItemForUpdate(ctx context.Context, id uuid.UUID) (PurchaseOrderItem, error)
+	// Retrieves an unexpired refresh token, including revoked ones so that reuse can be detected.
+	GetRefreshTokenRecord(ctx context.Context, jti string) (GetRefreshTokenRecordRow, error)
 	// $1=user_id, $2=token_string, $3=expiry_time
 	// Fetches a password reset token record by its token string.
 	GetResetToken(ctx context.Context, token string) (PasswordResetToken, error)
@@ -212,13 +333,28 @@ type Querier interface {
 	GetReviewByIDAndUser(ctx context.Context, arg GetReviewByIDAndUserParams) (GetReviewByIDAndUserRow, error)
 	// Retrieves a review by a specific user for a specific product.
 	GetReviewByUserAndProduct(ctx context.Context, arg GetReviewByUserAndProductParams) (GetReviewByUserAndProductRow, error)
-	// Retrieves all reviews for a specific product, including the reviewer's name, potentially paginated.
+	// Retrieves a single review with its author and product, for moderation.
+	GetReviewForModeration(ctx context.Context, id uuid.UUID) (GetReviewForModerationRow, error)
+	// Counts the approved reviews of a product per star rating. Ratings without reviews are omitted.
+	GetReviewRatingDistribution(ctx context.Context, productID uuid.UUID) ([]GetReviewRatingDistributionRow, error)
+	// Retrieves what is needed to tell a reviewer their review was answered.
+	GetReviewReplyRecipient(ctx context.Context, id uuid.UUID) (GetReviewReplyRecipientRow, error)
+	// Retrieves the approved reviews for a specific product, including the reviewer's name, potentially paginated.
+	// sort is one of 'most_helpful', 'highest', 'lowest' or 'newest' (default); rating = 0 means all star counts.
 	GetReviewsByProductID(ctx context.Context, arg GetReviewsByProductIDParams) ([]GetReviewsByProductIDRow, error)
 	// Retrieves all reviews submitted by a specific user, including the product name, potentially paginated.
+	// The author sees their reviews whatever their moderation status.
 	GetReviewsByUserID(ctx context.Context, arg GetReviewsByUserIDParams) ([]GetReviewsByUserIDRow, error)
+	// Retrieves the roles with the given names.
+	GetRolesByNames(ctx context.Context, names []string) ([]GetRolesByNamesRow, error)
 	// $1 = start_date, $2 = end_date
 	// Counts the total number of delivered orders within a given time range.
 	GetSalesVolume(ctx context.Context, arg GetSalesVolumeParams) (int64, error)
+	// Retrieves the latest shipment of an order; earlier ones can only be cancelled shipments.
+	GetShipmentByOrderID(ctx context.Context, orderID uuid.UUID) (Shipment, error)
+	GetShipmentByTrackingNumber(ctx context.Context, arg GetShipmentByTrackingNumberParams) (Shipment, error)
+	GetStockLocation(ctx context.Context, id uuid.UUID) (StockLocation, error)
+	GetSupplier(ctx context.Context, id uuid.UUID) (Supplier, error)
 	// $3 = number of top products to return (N)
 	// Retrieves the top N selling categories (by quantity sold) within a given time range.
 	GetTopSellingCategories(ctx context.Context, arg GetTopSellingCategoriesParams) ([]GetTopSellingCategoriesRow, error)
@@ -228,23 +364,31 @@ type Querier interface {
 	// --- Sales Performance ---
 	// Calculates the total revenue from all delivered orders within a given time range.
 	GetTotalRevenue(ctx context.Context, arg GetTotalRevenueParams) (int64, error)
-	GetUser(ctx context.Context, id uuid.UUID) (User, error)
-	GetUserByEmail(ctx context.Context, email string) (User, error)
+	// Fetches the pending alerts of a product whose condition is currently met,
+	// using the live discounted price from v_products_with_calculated_discounts.
+	// Price alerts only fire while the product can actually be bought.
+	GetTriggeredProductAlerts(ctx context.Context, productID uuid.UUID) ([]GetTriggeredProductAlertsRow, error)
+	GetUser(ctx context.Context, id uuid.UUID) (GetUserRow, error)
+	GetUserAddress(ctx context.Context, arg GetUserAddressParams) (UserAddress, error)
+	GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error)
 	// $1=token_string
 	// Fetches the user associated with a valid, non-expired reset token.
 	GetUserByResetToken(ctx context.Context, token string) (GetUserByResetTokenRow, error)
+	// Finds the user an external identity is linked to, including deactivated users.
+	GetUserIdentity(ctx context.Context, arg GetUserIdentityParams) (GetUserIdentityRow, error)
+	GetUserTOTP(ctx context.Context, userID uuid.UUID) (UserTotp, error)
+	// Retrieves the token version of an active user. Access tokens issued with an older version are rejected.
+	GetUserTokenVersion(ctx context.Context, id uuid.UUID) (int32, error)
 	// Fetches a specific user by ID along with order count and last order date.
 	// Joins with the orders table to get aggregated details.
 	// Includes soft-deleted users as well.
 	GetUserWithDetails(ctx context.Context, userID uuid.UUID) (GetUserWithDetailsRow, error)
-	GetValidRefreshTokenRecord(ctx context.Context, jti string) (RefreshToken, error)
+	// Checks whether the user has a delivered order containing the product.
+	HasUserPurchasedProduct(ctx context.Context, arg HasUserPurchasedProductParams) (bool, error)
 	// Pagination using limit and offset
 	// Increments the current_uses count for a specific discount.
 	// This should ideally be called within a transaction when applying the discount.
 	IncrementDiscountUsage(ctx context.Context, id uuid.UUID) error
-	// Increments the stock_quantity for a product by a given amount.
-	// Suitable for releasing stock back when cancelling an order.
-	IncrementStock(ctx context.Context, arg IncrementStockParams) (IncrementStockRow, error)
 	// Inserts multiple order items efficiently in a single query.
 	// Requires arrays of equal length for product_ids, quantities, names, and prices_cents.
 	InsertOrderItemsBulk(ctx context.Context, arg InsertOrderItemsBulkParams) error
@@ -259,6 +403,11 @@ type Querier interface {
 	// --- Link/Unlink Queries ---
 	// Associates a product with a discount.
 	LinkProductToDiscount(ctx context.Context, arg LinkProductToDiscountParams) error
+	// Lists every key, revoked and expired ones included, newest first.
+	ListAPIKeys(ctx context.Context) ([]ApiKey, error)
+	// Only revoke non-already-revoked tokens
+	// Retrieves the live sessions (token families) of a user, most recently used first.
+	ListActiveSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]ListActiveSessionsByUserIDRow, error)
 	// Retrieves delivery services, optionally filtered by active status.
 	// Suitable for admin operations.
 	ListAllDeliveryServices(ctx context.Context, arg ListAllDeliveryServicesParams) ([]DeliveryService, error)
@@ -268,20 +417,82 @@ type Querier interface {
 	// If filter_user_id is the zero UUID ('00000000-0000-0000-0000-000000000000'), it retrieves orders for all users.
 	// If filter_status is an empty string (''), it retrieves orders of all statuses.
 	ListAllOrders(ctx context.Context, arg ListAllOrdersParams) ([]Order, error)
+	// Lists the stock of a product at active, sellable locations in allocation order, locking the levels.
+	ListAllocatableStockLevelsForUpdate(ctx context.Context, productID uuid.UUID) ([]ListAllocatableStockLevelsForUpdateRow, error)
+	// Retrieves the published answers of several questions: official answers first, then by upvotes.
+	ListApprovedAnswersByQuestionIDs(ctx context.Context, questionIds []uuid.UUID) ([]ListApprovedAnswersByQuestionIDsRow, error)
+	// Retrieves the published questions of a product, newest first, with the number of published answers.
+	ListApprovedProductQuestions(ctx context.Context, arg ListApprovedProductQuestionsParams) ([]ListApprovedProductQuestionsRow, error)
+	// Retrieves audit log entries, newest first.
+	// The zero UUID and empty strings disable their filter; from_time/to_time are optional.
+	ListAuditLog(ctx context.Context, arg ListAuditLogParams) ([]AuditLog, error)
 	ListCategories(ctx context.Context, arg ListCategoriesParams) ([]Category, error)
+	ListCommunes(ctx context.Context) ([]Commune, error)
+	// Retrieves the rates of every zone of a delivery service, lightest bracket first and the open-ended one last.
+	ListDeliveryRates(ctx context.Context, deliveryServiceID uuid.UUID) ([]ListDeliveryRatesRow, error)
+	// Retrieves the zones of a delivery service along with the wilayas each one covers.
+	ListDeliveryZones(ctx context.Context, deliveryServiceID uuid.UUID) ([]ListDeliveryZonesRow, error)
 	// Fetches a list of discounts, potentially with filters and pagination.
 	ListDiscounts(ctx context.Context, arg ListDiscountsParams) ([]Discount, error)
+	// Lists the open abuse reports of a review, oldest first.
+	ListOpenReviewReports(ctx context.Context, reviewID uuid.UUID) ([]ListOpenReviewReportsRow, error)
+	// Retrieves shipments that have not reached a final status, least recently updated first,
+	// along with delivered shipments whose order has not been marked delivered yet.
+	ListOpenShipments(ctx context.Context, pageLimit int32) ([]Shipment, error)
+	ListOrderItemAllocations(ctx context.Context, orderID uuid.UUID) ([]OrderItemAllocation, error)
+	// Retrieves every permission that can be granted to a role.
+	ListPermissions(ctx context.Context) ([]Permission, error)
+	// Lists a user's alerts (pending first) with the product's name and slug.
+	ListProductAlertsByUserID(ctx context.Context, userID uuid.UUID) ([]ListProductAlertsByUserIDRow, error)
+	// Retrieves answers for the admin moderation queue, oldest first, optionally filtered by status (empty = all).
+	ListProductAnswersForModeration(ctx context.Context, arg ListProductAnswersForModerationParams) ([]ListProductAnswersForModerationRow, error)
+	ListProductIDsByStockLocation(ctx context.Context, locationID uuid.UUID) ([]uuid.UUID, error)
+	// Lists the products linked to a discount that have at least one pending alert.
+	ListProductIDsWithPendingAlertsByDiscount(ctx context.Context, discountID uuid.UUID) ([]uuid.UUID, error)
+	// Retrieves questions for the admin moderation queue, oldest first, optionally filtered by status (empty = all).
+	ListProductQuestionsForModeration(ctx context.Context, arg ListProductQuestionsForModerationParams) ([]ListProductQuestionsForModerationRow, error)
+	// Lists a product's stock at every location, including locations without stock.
+	ListProductStockLevels(ctx context.Context, productID uuid.UUID) ([]ListProductStockLevelsRow, error)
 	ListProducts(ctx context.Context, arg ListProductsParams) ([]Product, error)
 	ListProductsByCategory(ctx context.Context, arg ListProductsByCategoryParams) ([]Product, error)
 	ListProductsWithCategory(ctx context.Context, arg ListProductsWithCategoryParams) ([]ListProductsWithCategoryRow, error)
 	ListProductsWithCategoryDetail(ctx context.Context, arg ListProductsWithCategoryDetailParams) ([]ListProductsWithCategoryDetailRow, error)
+	ListPurchaseOrderItems(ctx context.Context, purchaseOrderID uuid.UUID) ([]ListPurchaseOrderItemsRow, error)
+	ListPurchaseOrderReceipts(ctx context.Context, purchaseOrderID uuid.UUID) ([]PurchaseOrderReceipt, error)
+	// Lists purchase orders with their supplier and totals, newest first.
+	// Pass an empty status and the zero UUID for supplier_id to disable the filters.
+	ListPurchaseOrders(ctx context.Context, arg ListPurchaseOrdersParams) ([]ListPurchaseOrdersRow, error)
+	// Retrieves the images of several reviews, in display order.
+	ListReviewImagesByReviewIDs(ctx context.Context, reviewIds []uuid.UUID) ([]ListReviewImagesByReviewIDsRow, error)
+	// Retrieves the official replies of several reviews.
+	ListReviewRepliesByReviewIDs(ctx context.Context, reviewIds []uuid.UUID) ([]ReviewReply, error)
+	// Retrieves reviews for the admin moderation queue, oldest first, optionally filtered by status (empty = all)
+	// and to reviews with open abuse reports.
+	ListReviewsForModeration(ctx context.Context, arg ListReviewsForModerationParams) ([]ListReviewsForModerationRow, error)
+	// Retrieves the permissions granted to every role.
+	ListRolePermissions(ctx context.Context) ([]RolePermission, error)
+	// Retrieves every role.
+	ListRoles(ctx context.Context) ([]Role, error)
+	ListShipmentEvents(ctx context.Context, shipmentID uuid.UUID) ([]ShipmentEvent, error)
+	// Lists every product stock level that does not match the sum of its ledger movements.
+	ListStockDiscrepancies(ctx context.Context) ([]ListStockDiscrepanciesRow, error)
+	ListStockLocations(ctx context.Context) ([]StockLocation, error)
+	// Lists the ledger entries of a product, newest first, with the location and the acting admin's email.
+	ListStockMovementsByProduct(ctx context.Context, arg ListStockMovementsByProductParams) ([]ListStockMovementsByProductRow, error)
+	ListSuppliers(ctx context.Context, activeOnly bool) ([]Supplier, error)
+	// Lists a user's addresses, the default one first.
+	ListUserAddresses(ctx context.Context, userID uuid.UUID) ([]UserAddress, error)
 	// Order items consistently
 	// Retrieves a paginated list of orders for a specific user with denormalized address fields, optionally filtered by status.
 	// Excludes cancelled orders by default. Admins should use ListAllOrders.
 	ListUserOrders(ctx context.Context, arg ListUserOrdersParams) ([]Order, error)
+	// Retrieves the permissions a user holds through their roles.
+	ListUserPermissions(ctx context.Context, userID uuid.UUID) ([]string, error)
+	// Retrieves the names of the roles assigned to a user.
+	ListUserRoleNames(ctx context.Context, userID uuid.UUID) ([]string, error)
 	// Lists users, optionally filtered by active status (soft-deleted).
 	// Paginated using LIMIT and OFFSET.
-	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	ListUsers(ctx context.Context, arg ListUsersParams) ([]ListUsersRow, error)
 	// Lists users with essential details for admin list view (name, email, registration date, last order date, order count, status).
 	// Optionally filter by active status.
 	// Paginated using LIMIT and OFFSET.
@@ -290,22 +501,80 @@ type Querier interface {
 	// Optionally filter by active status.
 	// Paginated using LIMIT and OFFSET.
 	ListUsersWithOrderCounts(ctx context.Context, arg ListUsersWithOrderCountsParams) ([]ListUsersWithOrderCountsRow, error)
+	ListWilayas(ctx context.Context) ([]Wilaya, error)
+	// Lists the wishlist of a user or guest with live prices (from v_products_with_calculated_discounts) and stock.
+	ListWishlistItemsWithDiscounts(ctx context.Context, arg ListWishlistItemsWithDiscountsParams) ([]ListWishlistItemsWithDiscountsRow, error)
+	// Serialises changes to a user's address book, so concurrent requests cannot both pick a default address.
+	LockUserAddresses(ctx context.Context, userID uuid.UUID) error
+	// Locks the assignments of a role until the end of the transaction, in a fixed order, so that concurrent
+	// changes cannot each remove a holder they counted while the other was still there.
+	LockUserRolesByRoleName(ctx context.Context, roleName string) error
+	// Claims a pending alert. Returns 0 rows if another process already fired it.
+	MarkProductAlertNotified(ctx context.Context, alertID uuid.UUID) (int64, error)
+	// Flags the user's existing reviews of the products in a delivered order as verified purchases.
+	// Returns the affected product IDs so their stats can be recalculated.
+	MarkReviewsVerifiedForOrder(ctx context.Context, orderID uuid.UUID) ([]uuid.UUID, error)
+	// Moves every item of a guest wishlist to a user's wishlist in a single statement.
+	// Products already on the user's wishlist are dropped from the guest list without duplicating them.
+	MergeGuestWishlistIntoUserWishlist(ctx context.Context, arg MergeGuestWishlistIntoUserWishlistParams) (int64, error)
+	// Sets the moderation status of an answer and returns its previous status,
+	// so the asker is only notified the first time the answer is published.
+	ModerateProductAnswer(ctx context.Context, arg ModerateProductAnswerParams) (ModerateProductAnswerRow, error)
+	// Sets the moderation status of a question.
+	ModerateProductQuestion(ctx context.Context, arg ModerateProductQuestionParams) (ProductQuestion, error)
+	// Sets the moderation status of a review.
+	// NOTE: This query alone does not update the product's avg_rating/num_ratings.
+	ModerateReview(ctx context.Context, arg ModerateReviewParams) (ModerateReviewRow, error)
+	// Makes the most recently created address the default, used when the default address is deleted.
+	PromoteLatestUserAddress(ctx context.Context, userID uuid.UUID) error
+	// Adds a received quantity to a line; returns no rows if it would exceed the ordered quantity.
+	ReceivePurchaseOrderItem(ctx context.Context, arg ReceivePurchaseOrderItemParams) (PurchaseOrderItem, error)
+	RecordAPIKeyUsage(ctx context.Context, id uuid.UUID) error
+	// Recalculates the upvote total of an answer from its votes.
+	RefreshProductAnswerUpvoteCount(ctx context.Context, id uuid.UUID) (int32, error)
+	// Recalculates the helpful/unhelpful totals of a review from its votes.
+	RefreshReviewVoteCounts(ctx context.Context, id uuid.UUID) (RefreshReviewVoteCountsRow, error)
+	// Releases a claimed alert so it can fire again (used when the notification could not be delivered).
+	ResetProductAlertNotified(ctx context.Context, alertID uuid.UUID) error
+	// Closes the open abuse reports of a review once a moderator has decided on it.
+	ResolveReviewReports(ctx context.Context, arg ResolveReviewReportsParams) error
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) (int64, error)
 	// Revokes all refresh tokens for a specific user.
 	RevokeAllRefreshTokensByUserID(ctx context.Context, userID uuid.UUID) error
+	// Revokes every session of a user except the given one (pass the zero UUID to revoke all of them).
+	// Returns the revoked sessions.
+	RevokeOtherUserSessions(ctx context.Context, arg RevokeOtherUserSessionsParams) ([]uuid.UUID, error)
 	RevokeRefreshTokenByJTI(ctx context.Context, jti string) error
+	// Revokes every live token descending from the same login.
+	RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) (int64, error)
+	// Revokes the live token of one session of a user.
+	RevokeUserSession(ctx context.Context, arg RevokeUserSessionParams) (int64, error)
+	// Revokes a refresh token that is being exchanged for its successor.
+	// Affects no rows if the token was already revoked, e.g. by a concurrent refresh with the same token.
+	RotateRefreshToken(ctx context.Context, jti string) (int64, error)
 	SearchProductsWithCategory(ctx context.Context, arg SearchProductsWithCategoryParams) ([]SearchProductsWithCategoryRow, error)
 	// Searches for products and includes pre-calculated discount information using the view.
 	// Includes a flexible spec highlight filter for partial matching within values.
 	SearchProductsWithDiscounts(ctx context.Context, arg SearchProductsWithDiscountsParams) ([]SearchProductsWithDiscountsRow, error)
 	// Searches users by email or full_name, optionally filtered by active status.
 	// Paginated using LIMIT and OFFSET.
-	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error)
+	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]SearchUsersRow, error)
+	SetDefaultUserAddress(ctx context.Context, arg SetDefaultUserAddressParams) (int64, error)
+	// Sets a product's stock at a location to an absolute quantity and records the difference in the ledger, in one statement.
+	// The level is locked so the delta is computed against the current stock, not a stale read.
+	// Returns no rows if the stock level does not exist or the quantity is unchanged.
+	SetProductStock(ctx context.Context, arg SetProductStockParams) (StockMovement, error)
+	// Marks a user as staff (any role) or customer (no role), which decides access to the admin area.
+	SetUserAdminFlag(ctx context.Context, arg SetUserAdminFlagParams) error
+	// Copies the current average cost of each product onto the order's items, so margins use the cost at the time of sale.
+	SnapshotOrderItemCosts(ctx context.Context, orderID uuid.UUID) error
 	// Marks a user as soft-deleted by setting deleted_at to NOW().
 	SoftDeleteUser(ctx context.Context, userID uuid.UUID) error
 	// Merges items from a guest cart into a user's cart using upsert logic.
 	// Handles quantity updates, stock checks, and soft-delete state transitions (undeletion).
 	// This query performs the core merge operation efficiently in a single statement.
 	SyncGuestCartItemsToUserCart(ctx context.Context, arg SyncGuestCartItemsToUserCartParams) error
+	TouchUserIdentity(ctx context.Context, id uuid.UUID) error
 	// Removes association between a category and a discount.
 	UnlinkCategoryFromDiscount(ctx context.Context, arg UnlinkCategoryFromDiscountParams) error
 	// Removes association between a product and a discount.
@@ -323,11 +592,26 @@ type Querier interface {
 	// Updates the status of an order and manages completion/cancellation timestamps.
 	UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) (Order, error)
 	UpdateProduct(ctx context.Context, arg UpdateProductParams) (Product, error)
+	// Folds a receipt into the product's weighted average cost, using the stock on hand at all locations before the receipt.
+	UpdateProductAverageCost(ctx context.Context, arg UpdateProductAverageCostParams) error
 	// Updates the avg_rating and num_ratings fields in the products table for a specific product.
 	UpdateProductReviewStats(ctx context.Context, arg UpdateProductReviewStatsParams) error
-	// Updates the rating of an existing review.
+	// Updates the editable fields of a draft purchase order.
+	// Pass the zero UUID for location_id to keep the current location.
+	UpdatePurchaseOrderDetails(ctx context.Context, arg UpdatePurchaseOrderDetailsParams) (PurchaseOrder, error)
+	// Sets the status; ordered_at and received_at are stamped on the corresponding transitions.
+	UpdatePurchaseOrderStatus(ctx context.Context, arg UpdatePurchaseOrderStatusParams) (PurchaseOrder, error)
+	// Updates the rating and text of an existing review and sets its moderation status again.
 	// NOTE: This query alone does not update the product's avg_rating/num_ratings.
 	UpdateReview(ctx context.Context, arg UpdateReviewParams) (UpdateReviewRow, error)
+	// Edits the official reply to a review.
+	UpdateReviewReply(ctx context.Context, arg UpdateReviewReplyParams) (ReviewReply, error)
+	UpdateShipmentStatus(ctx context.Context, arg UpdateShipmentStatusParams) (Shipment, error)
+	// Partially updates a location; products.stock_quantity follows sellability changes through trg_stock_locations_sync.
+	UpdateStockLocation(ctx context.Context, arg UpdateStockLocationParams) (StockLocation, error)
+	// Partially updates a supplier; only provided fields change.
+	UpdateSupplier(ctx context.Context, arg UpdateSupplierParams) (Supplier, error)
+	UpdateUserAddress(ctx context.Context, arg UpdateUserAddressParams) (UserAddress, error)
 	// Updates the user's email address.
 	UpdateUserEmail(ctx context.Context, arg UpdateUserEmailParams) (UpdateUserEmailRow, error)
 	// --- Profile & Password Management ---
@@ -335,6 +619,18 @@ type Querier interface {
 	UpdateUserFullName(ctx context.Context, arg UpdateUserFullNameParams) (UpdateUserFullNameRow, error)
 	// Updates the user's hashed password.
 	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (UpdateUserPasswordRow, error)
+	// Starts (or restarts) TOTP enrolment with a new secret.
+	// Affects no rows if the user has already confirmed 2FA.
+	UpsertPendingUserTOTP(ctx context.Context, arg UpsertPendingUserTOTPParams) (int64, error)
+	// Records a user's vote on a review, replacing any previous vote.
+	UpsertReviewVote(ctx context.Context, arg UpsertReviewVoteParams) error
+	// Consumes a recovery code. Affects no rows if the code does not exist or was already used.
+	UseUserRecoveryCode(ctx context.Context, arg UseUserRecoveryCodeParams) (int64, error)
+	// Records the time step of an accepted code. Affects no rows if a code of that step or a later one
+	// was already used, so concurrent requests cannot replay the same code.
+	UseUserTOTPStep(ctx context.Context, arg UseUserTOTPStepParams) (int64, error)
+	// Checks whether a product is on a user's or guest's wishlist.
+	WishlistItemExists(ctx context.Context, arg WishlistItemExistsParams) (bool, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -192,3 +192,66 @@ WHERE
     AND o.created_at BETWEEN @start_date AND @end_date -- $1 = start_date, $2 = end_date
 GROUP BY
     d.code, d.discount_type, d.discount_value;
+
+-- name: GetLowStockProductsByLocation :many
+-- Retrieves the stock of each product at each active location that is below a threshold,
+-- optionally limited to one location (pass the zero UUID for all locations).
+SELECT
+    p.id AS product_id,
+    p.name AS product_name,
+    sl.id AS location_id,
+    sl.code AS location_code,
+    sl.name AS location_name,
+    COALESCE(psl.quantity, 0)::INT AS quantity
+FROM products p
+CROSS JOIN stock_locations sl
+LEFT JOIN product_stock_levels psl ON psl.product_id = p.id AND psl.location_id = sl.id
+WHERE
+    p.deleted_at IS NULL
+    AND sl.is_active
+    AND (sqlc.arg(location_id)::UUID = '00000000-0000-0000-0000-000000000000' OR sl.id = sqlc.arg(location_id)::UUID)
+    AND COALESCE(psl.quantity, 0) < sqlc.arg(threshold)::INT
+ORDER BY
+    sl.priority, sl.code, quantity ASC;
+
+-- name: GetMarginSummary :one
+-- Calculates revenue, cost of goods sold and gross margin for delivered orders within a given time range.
+-- Items sold before their cost was known are reported separately and excluded from the margin.
+SELECT
+    COALESCE(SUM(oi.quantity * oi.price_cents), 0)::BIGINT AS total_revenue_cents,
+    COALESCE(SUM(oi.quantity * oi.price_cents) FILTER (WHERE oi.unit_cost_cents IS NOT NULL), 0)::BIGINT AS costed_revenue_cents,
+    COALESCE(SUM(oi.quantity * oi.unit_cost_cents), 0)::BIGINT AS total_cost_cents,
+    COALESCE(SUM(oi.quantity) FILTER (WHERE oi.unit_cost_cents IS NULL), 0)::BIGINT AS uncosted_units
+FROM
+    orders o
+JOIN
+    order_items oi ON o.id = oi.order_id
+WHERE
+    o.status = 'delivered'
+    AND o.created_at BETWEEN @start_date AND @end_date;
+
+-- name: GetProductMargins :many
+-- Retrieves the N products with the highest gross margin for delivered orders within a given time range.
+-- Only items with a known cost are included.
+SELECT
+    p.id AS product_id,
+    p.name AS product_name,
+    SUM(oi.quantity)::BIGINT AS units_sold,
+    SUM(oi.quantity * oi.price_cents)::BIGINT AS revenue_cents,
+    SUM(oi.quantity * oi.unit_cost_cents)::BIGINT AS cost_cents,
+    (SUM(oi.quantity * oi.price_cents) - SUM(oi.quantity * oi.unit_cost_cents))::BIGINT AS margin_cents
+FROM
+    order_items oi
+JOIN
+    orders o ON oi.order_id = o.id
+JOIN
+    products p ON oi.product_id = p.id
+WHERE
+    o.status = 'delivered'
+    AND oi.unit_cost_cents IS NOT NULL
+    AND o.created_at BETWEEN @start_date AND @end_date
+GROUP BY
+    p.id, p.name
+ORDER BY
+    margin_cents DESC
+LIMIT @limits;
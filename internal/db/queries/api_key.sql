@@ -0,0 +1,37 @@
+-- name: CreateAPIKey :one
+INSERT INTO api_keys (name, key_prefix, key_hash, scopes, allowed_ips, expires_at, created_by)
+VALUES (
+    sqlc.arg(name), sqlc.arg(key_prefix), sqlc.arg(key_hash), sqlc.arg(scopes)::TEXT[], sqlc.arg(allowed_ips)::TEXT[],
+    sqlc.narg(expires_at)::TIMESTAMPTZ,
+    NULLIF(sqlc.arg(created_by)::UUID, '00000000-0000-0000-0000-000000000000')
+)
+RETURNING id, name, key_prefix, key_hash, scopes, allowed_ips, expires_at, created_by, created_at, last_used_at, request_count, revoked_at;
+
+-- name: ListAPIKeys :many
+-- Lists every key, revoked and expired ones included, newest first.
+SELECT id, name, key_prefix, key_hash, scopes, allowed_ips, expires_at, created_by, created_at, last_used_at, request_count, revoked_at
+FROM api_keys
+ORDER BY created_at DESC;
+
+-- name: GetAPIKey :one
+SELECT id, name, key_prefix, key_hash, scopes, allowed_ips, expires_at, created_by, created_at, last_used_at, request_count, revoked_at
+FROM api_keys
+WHERE id = sqlc.arg(id);
+
+-- name: GetActiveAPIKeyByHash :one
+-- Finds a key that is neither revoked nor expired. Checked on every request, so revocation is immediate.
+SELECT id, name, key_prefix, key_hash, scopes, allowed_ips, expires_at, created_by, created_at, last_used_at, request_count, revoked_at
+FROM api_keys
+WHERE key_hash = sqlc.arg(key_hash)
+  AND revoked_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW());
+
+-- name: RecordAPIKeyUsage :exec
+UPDATE api_keys
+SET last_used_at = NOW(), request_count = request_count + 1
+WHERE id = sqlc.arg(id);
+
+-- name: RevokeAPIKey :execrows
+UPDATE api_keys
+SET revoked_at = NOW()
+WHERE id = sqlc.arg(id) AND revoked_at IS NULL;
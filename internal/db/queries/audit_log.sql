@@ -0,0 +1,46 @@
+-- name: CreateAuditLogEntry :exec
+-- Records a mutating admin action.
+-- Pass the zero UUID for actor_id when the actor is unknown; it is stored as NULL.
+INSERT INTO audit_log (actor_id, actor_email, action, entity_type, entity_id, before, after, diff, request_id, ip_address)
+VALUES (
+    NULLIF(sqlc.arg(actor_id)::UUID, '00000000-0000-0000-0000-000000000000'),
+    sqlc.arg(actor_email),
+    sqlc.arg(action),
+    sqlc.arg(entity_type),
+    sqlc.arg(entity_id),
+    sqlc.narg(before),
+    sqlc.narg(after),
+    sqlc.narg(diff),
+    sqlc.arg(request_id),
+    sqlc.arg(ip_address)
+);
+
+-- name: ListAuditLog :many
+-- Retrieves audit log entries, newest first.
+-- The zero UUID and empty strings disable their filter; from_time/to_time are optional.
+SELECT id, actor_id, actor_email, action, entity_type, entity_id, before, after, diff, request_id, ip_address, created_at
+FROM audit_log
+WHERE (sqlc.arg(actor_id)::UUID = '00000000-0000-0000-0000-000000000000' OR actor_id = sqlc.arg(actor_id)::UUID)
+  AND (sqlc.arg(entity_type)::TEXT = '' OR entity_type = sqlc.arg(entity_type)::TEXT)
+  AND (sqlc.arg(entity_id)::TEXT = '' OR entity_id = sqlc.arg(entity_id)::TEXT)
+  AND (sqlc.arg(action)::TEXT = '' OR action = sqlc.arg(action)::TEXT)
+  AND (sqlc.narg(from_time)::TIMESTAMPTZ IS NULL OR created_at >= sqlc.narg(from_time)::TIMESTAMPTZ)
+  AND (sqlc.narg(to_time)::TIMESTAMPTZ IS NULL OR created_at < sqlc.narg(to_time)::TIMESTAMPTZ)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountAuditLog :one
+-- Counts the audit log entries matching the same filters as ListAuditLog.
+SELECT COUNT(*)
+FROM audit_log
+WHERE (sqlc.arg(actor_id)::UUID = '00000000-0000-0000-0000-000000000000' OR actor_id = sqlc.arg(actor_id)::UUID)
+  AND (sqlc.arg(entity_type)::TEXT = '' OR entity_type = sqlc.arg(entity_type)::TEXT)
+  AND (sqlc.arg(entity_id)::TEXT = '' OR entity_id = sqlc.arg(entity_id)::TEXT)
+  AND (sqlc.arg(action)::TEXT = '' OR action = sqlc.arg(action)::TEXT)
+  AND (sqlc.narg(from_time)::TIMESTAMPTZ IS NULL OR created_at >= sqlc.narg(from_time)::TIMESTAMPTZ)
+  AND (sqlc.narg(to_time)::TIMESTAMPTZ IS NULL OR created_at < sqlc.narg(to_time)::TIMESTAMPTZ);
+
+-- name: DeleteAuditLogBefore :execrows
+-- Deletes the audit log entries older than the retention cutoff.
+DELETE FROM audit_log
+WHERE created_at < sqlc.arg(cutoff);
@@ -13,6 +13,7 @@ SELECT
     p.brand,
     p.image_urls,
     p.spec_highlights,
+    p.weight_grams,
     p.created_at,
     p.updated_at,
     p.deleted_at,
@@ -49,6 +50,7 @@ SELECT
     p.brand,
     p.image_urls,
     p.spec_highlights,
+    p.weight_grams,
     p.created_at,
     p.updated_at,
     p.deleted_at,
@@ -82,6 +84,7 @@ SELECT
     p.brand,
     p.image_urls,
     p.spec_highlights,
+    p.weight_grams,
     p.created_at,
     p.updated_at,
     p.deleted_at,
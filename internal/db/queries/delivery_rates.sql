@@ -0,0 +1,34 @@
+-- name: ListDeliveryZones :many
+-- Retrieves the zones of a delivery service along with the wilayas each one covers.
+SELECT z.id, z.name, z.free_shipping_min_cents,
+       COALESCE(ARRAY_AGG(zw.wilaya_code ORDER BY zw.wilaya_code) FILTER (WHERE zw.wilaya_code IS NOT NULL), '{}')::SMALLINT[] AS wilaya_codes
+FROM delivery_zones z
+LEFT JOIN delivery_zone_wilayas zw ON zw.zone_id = z.id
+WHERE z.delivery_service_id = sqlc.arg(delivery_service_id)
+GROUP BY z.id
+ORDER BY z.name;
+
+-- name: ListDeliveryRates :many
+-- Retrieves the rates of every zone of a delivery service, lightest bracket first and the open-ended one last.
+SELECT r.zone_id, r.delivery_type, r.max_weight_grams, r.price_cents
+FROM delivery_rates r
+JOIN delivery_zones z ON z.id = r.zone_id
+WHERE z.delivery_service_id = sqlc.arg(delivery_service_id)
+ORDER BY r.zone_id, r.delivery_type, r.max_weight_grams ASC NULLS LAST;
+
+-- name: DeleteDeliveryZones :exec
+-- Removes the whole rate table of a delivery service; wilayas and rates go with their zones.
+DELETE FROM delivery_zones WHERE delivery_service_id = sqlc.arg(delivery_service_id);
+
+-- name: CreateDeliveryZone :one
+INSERT INTO delivery_zones (delivery_service_id, name, free_shipping_min_cents)
+VALUES (sqlc.arg(delivery_service_id), sqlc.arg(name), sqlc.narg(free_shipping_min_cents))
+RETURNING id;
+
+-- name: AddDeliveryZoneWilayas :exec
+INSERT INTO delivery_zone_wilayas (zone_id, delivery_service_id, wilaya_code)
+SELECT sqlc.arg(zone_id), sqlc.arg(delivery_service_id), UNNEST(sqlc.arg(wilaya_codes)::SMALLINT[]);
+
+-- name: CreateDeliveryRate :exec
+INSERT INTO delivery_rates (zone_id, delivery_type, max_weight_grams, price_cents)
+VALUES (sqlc.arg(zone_id), sqlc.arg(delivery_type), sqlc.narg(max_weight_grams), sqlc.arg(price_cents));
@@ -1,14 +1,14 @@
 -- name: GetDeliveryServiceByID :one
 -- Retrieves a delivery service by its ID, regardless of its active status.
 -- Suitable for admin operations.
-SELECT id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at
+SELECT id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at, free_shipping_min_cents, courier
 FROM delivery_services
 WHERE id = sqlc.arg(id);
 
 -- name: GetActiveDeliveryServices :many
 -- Retrieves all delivery services that are currently active.
 -- Suitable for user-facing contexts like checkout.
-SELECT id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at
+SELECT id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at, free_shipping_min_cents, courier
 FROM delivery_services
 WHERE is_active = TRUE
 ORDER BY
@@ -26,7 +26,7 @@ ORDER BY
 -- name: ListAllDeliveryServices :many
 -- Retrieves delivery services, optionally filtered by active status.
 -- Suitable for admin operations.
-SELECT id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at
+SELECT id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at, free_shipping_min_cents, courier
 FROM delivery_services
 WHERE is_active = sqlc.arg(active_filter) -- Filter by active status
 ORDER BY
@@ -44,19 +44,19 @@ LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
 
 -- name: CreateDeliveryService :one
 INSERT INTO delivery_services (
-    name, description, base_cost_cents, estimated_days, is_active
+    name, description, base_cost_cents, estimated_days, is_active, free_shipping_min_cents, courier
 ) VALUES (
-    sqlc.arg(name), sqlc.arg(description), sqlc.arg(base_cost_cents), sqlc.arg(estimated_days), sqlc.arg(is_active)
+    sqlc.arg(name), sqlc.arg(description), sqlc.arg(base_cost_cents), sqlc.arg(estimated_days), sqlc.arg(is_active), sqlc.narg(free_shipping_min_cents), sqlc.narg(courier)
 )
-RETURNING id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at;
+RETURNING id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at, free_shipping_min_cents, courier;
 
 -- name: GetDeliveryService :one
-SELECT id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at
+SELECT id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at, free_shipping_min_cents, courier
 FROM delivery_services
 WHERE id = sqlc.arg(id) AND is_active = sqlc.arg(active_filter); -- Allow filtering by active status
 
 -- name: GetDeliveryServiceByName :one
-SELECT id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at
+SELECT id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at, free_shipping_min_cents, courier
 FROM delivery_services
 WHERE name = sqlc.arg(name) AND is_active = sqlc.arg(active_filter); -- Allow filtering by active status
 
@@ -68,9 +68,11 @@ SET
     base_cost_cents = COALESCE(sqlc.narg(base_cost_cents), base_cost_cents),
     estimated_days = COALESCE(sqlc.narg(estimated_days), estimated_days),
     is_active = COALESCE(sqlc.narg(is_active), is_active),
+    free_shipping_min_cents = CASE WHEN sqlc.arg(clear_free_shipping_min_cents)::BOOLEAN THEN NULL ELSE COALESCE(sqlc.narg(free_shipping_min_cents), free_shipping_min_cents) END,
+    courier = CASE WHEN sqlc.narg(courier)::TEXT = '' THEN NULL ELSE COALESCE(sqlc.narg(courier), courier) END, -- '' unlinks the courier
     updated_at = NOW()
 WHERE id = sqlc.arg(id)
-RETURNING id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at;
+RETURNING id, name, description, base_cost_cents, estimated_days, is_active, created_at, updated_at, free_shipping_min_cents, courier;
 
 -- name: DeleteDeliveryService :exec
 -- Soft delete could be achieved by updating is_active to FALSE
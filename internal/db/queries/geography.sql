@@ -0,0 +1,9 @@
+-- name: ListWilayas :many
+SELECT code, name, name_ar, aliases
+FROM wilayas
+ORDER BY code;
+
+-- name: ListCommunes :many
+SELECT code, wilaya_code, name, aliases
+FROM communes
+ORDER BY wilaya_code, name;
@@ -3,15 +3,15 @@
 INSERT INTO orders (
     user_id, user_full_name, status, total_amount_cents, payment_method,
     province, city, phone_number_1, phone_number_2,
-    notes, delivery_service_id
+    notes, delivery_service_id, wilaya_code, commune_code, delivery_type, delivery_fee_cents
 ) VALUES (
     sqlc.arg(user_id), sqlc.arg(user_full_name), sqlc.arg(status), sqlc.arg(total_amount_cents), sqlc.arg(payment_method),
     sqlc.arg(province), sqlc.arg(city), sqlc.arg(phone_number_1), sqlc.arg(phone_number_2),
-    sqlc.arg(notes), sqlc.arg(delivery_service_id)
+    sqlc.arg(notes), sqlc.arg(delivery_service_id), sqlc.arg(wilaya_code), sqlc.arg(commune_code), sqlc.arg(delivery_type), sqlc.arg(delivery_fee_cents)
 )
 RETURNING id, user_id, user_full_name, status, total_amount_cents, payment_method,
          province, city, phone_number_1, phone_number_2,
-         notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at;
+         notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at, wilaya_code, commune_code, delivery_type, delivery_fee_cents;
 
 -- name: InsertOrderItemsBulk :exec
 -- Inserts multiple order items efficiently in a single query.
@@ -29,7 +29,7 @@ SELECT
 SELECT 
     id, user_id, user_full_name, status, total_amount_cents, payment_method,
     province, city, phone_number_1, phone_number_2,
-    notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at
+    notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at, wilaya_code, commune_code, delivery_type, delivery_fee_cents
 FROM orders
 WHERE id = sqlc.arg(order_id);
 
@@ -40,7 +40,7 @@ WHERE id = sqlc.arg(order_id);
 SELECT 
     o.id, o.user_id, o.user_full_name, o.status, o.total_amount_cents, o.payment_method,
     o.province, o.city, o.phone_number_1, o.phone_number_2,
-    o.notes, o.delivery_service_id, o.created_at, o.updated_at, o.completed_at, o.cancelled_at,
+    o.notes, o.delivery_service_id, o.created_at, o.updated_at, o.completed_at, o.cancelled_at, o.wilaya_code, o.commune_code, o.delivery_type, o.delivery_fee_cents,
     oi.id AS item_id, oi.order_id AS item_order_id, oi.product_id AS item_product_id,
     oi.product_name AS item_product_name, oi.price_cents AS item_price_cents,
     oi.quantity AS item_quantity, oi.subtotal_cents AS item_subtotal_cents,
@@ -56,7 +56,7 @@ ORDER BY oi.created_at ASC; -- Order items consistently
 SELECT 
     id, user_id, user_full_name, status, total_amount_cents, payment_method,
     province, city, phone_number_1, phone_number_2,
-    notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at
+    notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at, wilaya_code, commune_code, delivery_type, delivery_fee_cents
 FROM orders
 WHERE user_id = sqlc.arg(user_id)
   AND (sqlc.arg(filter_status)::TEXT = '' OR status = sqlc.arg(filter_status)) -- Filter by status if provided
@@ -73,7 +73,7 @@ LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset); -- Page limit and offse
 SELECT 
     id, user_id, user_full_name, status, total_amount_cents, payment_method,
     province, city, phone_number_1, phone_number_2,
-    notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at
+    notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at, wilaya_code, commune_code, delivery_type, delivery_fee_cents
 FROM orders
 WHERE (sqlc.arg(filter_user_id)::UUID = '00000000-0000-0000-0000-000000000000'::UUID OR user_id = sqlc.arg(filter_user_id)) -- Filter by user_id if provided
   AND (sqlc.arg(filter_status)::TEXT = '' OR status = sqlc.arg(filter_status)) -- Filter by status if provided
@@ -90,7 +90,7 @@ SET
 WHERE id = sqlc.arg(order_id)
 RETURNING id, user_id, user_full_name, status, total_amount_cents, payment_method,
          province, city, phone_number_1, phone_number_2,
-         notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at;
+         notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at, wilaya_code, commune_code, delivery_type, delivery_fee_cents;
 
 -- name: UpdateOrderStatus :one
 -- Updates the status of an order and manages completion/cancellation timestamps.
@@ -108,12 +108,12 @@ SET status = sqlc.arg(status)::TEXT ,
 WHERE id = sqlc.arg(order_id)
 RETURNING id, user_id, user_full_name, status, total_amount_cents, payment_method,
          province, city, phone_number_1, phone_number_2,
-         notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at;
+         notes, delivery_service_id, created_at, updated_at, completed_at, cancelled_at, wilaya_code, commune_code, delivery_type, delivery_fee_cents;
 
 -- name: GetOrderItemsByOrderID :many
 -- Retrieves all items for a specific order ID.
 SELECT 
-    id, order_id, product_id, product_name, price_cents, quantity, subtotal_cents, created_at, updated_at
+    id, order_id, product_id, product_name, price_cents, quantity, subtotal_cents, created_at, updated_at, unit_cost_cents
 FROM order_items
 WHERE order_id = sqlc.arg(order_id)
 ORDER BY created_at ASC; -- Order items consistently
@@ -132,27 +132,7 @@ RETURNING
     id, user_id, user_full_name, status, total_amount_cents, payment_method,
     province, city, phone_number_1, phone_number_2,
     notes, delivery_service_id, 
-    created_at, updated_at, completed_at, cancelled_at;
-
--- name: DecrementStockIfSufficient :one
--- Attempts to decrement the stock_quantity for a product by a given amount.
--- Succeeds only if the resulting stock_quantity would be >= 0.
--- Returns the updated product row if successful, or an error if insufficient stock.
--- Note: The RETURNING clause might not be strictly necessary if we only care about RowsAffected.
--- If RETURNING is omitted, the querier function will likely return sql.Result.
--- Let's include RETURNING to get the updated stock if needed for debugging/logging.
-UPDATE products
-SET stock_quantity = stock_quantity - sqlc.arg(decrement_amount)
-WHERE id = sqlc.arg(product_id) AND stock_quantity >= sqlc.arg(decrement_amount) -- The crucial condition
-RETURNING id, category_id, name, slug, description, short_description, price_cents, stock_quantity, status, brand, image_urls, spec_highlights, created_at, updated_at, deleted_at;
-
--- name: IncrementStock :one
--- Increments the stock_quantity for a product by a given amount.
--- Suitable for releasing stock back when cancelling an order.
-UPDATE products
-SET stock_quantity = stock_quantity + sqlc.arg(increment_amount)
-WHERE id = sqlc.arg(product_id)
-RETURNING id, category_id, name, slug, description, short_description, price_cents, stock_quantity, status, brand, image_urls, spec_highlights, created_at, updated_at, deleted_at;
+    created_at, updated_at, completed_at, cancelled_at, wilaya_code, commune_code, delivery_type, delivery_fee_cents;
 
 -- name: InsertOrderItemsFromCart :exec
 -- Inserts order items into the order_items table by copying them from the user's current cart.
@@ -0,0 +1,78 @@
+-- name: CreateProductAlert :one
+-- Subscribes a user to a back-in-stock or price-below alert for a product.
+INSERT INTO product_alerts (user_id, product_id, alert_type, target_price_cents)
+VALUES (sqlc.arg(user_id), sqlc.arg(product_id), sqlc.arg(alert_type), sqlc.narg(target_price_cents))
+RETURNING id, user_id, product_id, alert_type, target_price_cents, notified_at, created_at;
+
+-- name: ListProductAlertsByUserID :many
+-- Lists a user's alerts (pending first) with the product's name and slug.
+SELECT
+    pa.id,
+    pa.user_id,
+    pa.product_id,
+    p.name AS product_name,
+    p.slug AS product_slug,
+    pa.alert_type,
+    pa.target_price_cents,
+    pa.notified_at,
+    pa.created_at
+FROM product_alerts pa
+JOIN products p ON pa.product_id = p.id
+WHERE pa.user_id = sqlc.arg(user_id)
+ORDER BY (pa.notified_at IS NULL) DESC, pa.created_at DESC;
+
+-- name: DeleteProductAlert :execrows
+-- Deletes an alert, scoped to its owner.
+DELETE FROM product_alerts
+WHERE id = sqlc.arg(alert_id) AND user_id = sqlc.arg(user_id);
+
+-- name: GetTriggeredProductAlerts :many
+-- Fetches the pending alerts of a product whose condition is currently met,
+-- using the live discounted price from v_products_with_calculated_discounts.
+-- Price alerts only fire while the product can actually be bought.
+SELECT
+    pa.id,
+    pa.user_id,
+    u.email,
+    u.full_name,
+    pa.product_id,
+    p.name AS product_name,
+    p.slug AS product_slug,
+    pa.alert_type,
+    pa.target_price_cents,
+    p.stock_quantity,
+    COALESCE(vpcd.calculated_discounted_price_cents, p.price_cents)::BIGINT AS current_price_cents
+FROM product_alerts pa
+JOIN users u ON pa.user_id = u.id AND u.deleted_at IS NULL
+JOIN products p ON pa.product_id = p.id AND p.deleted_at IS NULL
+LEFT JOIN v_products_with_calculated_discounts vpcd ON p.id = vpcd.product_id
+WHERE pa.product_id = sqlc.arg(product_id)
+  AND pa.notified_at IS NULL
+  AND p.status <> 'discontinued'
+  AND (
+    (pa.alert_type = 'back_in_stock' AND p.stock_quantity > 0)
+    OR (
+        pa.alert_type = 'price_below'
+        AND p.stock_quantity > 0
+        AND COALESCE(vpcd.calculated_discounted_price_cents, p.price_cents) <= pa.target_price_cents
+    )
+  );
+
+-- name: MarkProductAlertNotified :execrows
+-- Claims a pending alert. Returns 0 rows if another process already fired it.
+UPDATE product_alerts
+SET notified_at = NOW()
+WHERE id = sqlc.arg(alert_id) AND notified_at IS NULL;
+
+-- name: ResetProductAlertNotified :exec
+-- Releases a claimed alert so it can fire again (used when the notification could not be delivered).
+UPDATE product_alerts
+SET notified_at = NULL
+WHERE id = sqlc.arg(alert_id);
+
+-- name: ListProductIDsWithPendingAlertsByDiscount :many
+-- Lists the products linked to a discount that have at least one pending alert.
+SELECT DISTINCT pd.product_id
+FROM product_discounts pd
+JOIN product_alerts pa ON pa.product_id = pd.product_id AND pa.notified_at IS NULL
+WHERE pd.discount_id = sqlc.arg(discount_id);
@@ -0,0 +1,178 @@
+-- name: CreateProductQuestion :one
+-- Records a customer's question about a product.
+INSERT INTO product_questions (product_id, user_id, body)
+VALUES (sqlc.arg(product_id), sqlc.arg(user_id), sqlc.arg(body))
+RETURNING id, product_id, user_id, body, status, created_at, updated_at;
+
+-- name: ListApprovedProductQuestions :many
+-- Retrieves the published questions of a product, newest first, with the number of published answers.
+SELECT
+    q.id,
+    q.product_id,
+    q.body,
+    q.created_at,
+    u.full_name AS asker_name,
+    (SELECT COUNT(*) FROM product_answers a WHERE a.question_id = q.id AND a.status = 'approved')::BIGINT AS answer_count
+FROM product_questions q
+JOIN users u ON q.user_id = u.id
+WHERE q.product_id = sqlc.arg(product_id) AND q.status = 'approved'
+ORDER BY q.created_at DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountApprovedProductQuestions :one
+-- Counts the published questions of a product.
+SELECT COUNT(*)::BIGINT
+FROM product_questions
+WHERE product_id = sqlc.arg(product_id) AND status = 'approved';
+
+-- name: ListApprovedAnswersByQuestionIDs :many
+-- Retrieves the published answers of several questions: official answers first, then by upvotes.
+SELECT
+    a.id,
+    a.question_id,
+    a.body,
+    a.is_official,
+    a.upvote_count,
+    a.created_at,
+    u.full_name AS author_name
+FROM product_answers a
+LEFT JOIN users u ON a.user_id = u.id
+WHERE a.question_id = ANY(sqlc.arg(question_ids)::UUID[]) AND a.status = 'approved'
+ORDER BY a.question_id, a.is_official DESC, a.upvote_count DESC, a.created_at ASC;
+
+-- name: GetApprovedProductQuestion :one
+-- Retrieves a published question, e.g. before answering it.
+SELECT id, product_id, user_id
+FROM product_questions
+WHERE id = sqlc.arg(id) AND status = 'approved';
+
+-- name: GetProductQuestionForAnswer :one
+-- Retrieves a question with its asker and product, for staff answers and answer notifications.
+SELECT
+    q.id,
+    q.user_id,
+    q.body,
+    q.status,
+    u.email AS asker_email,
+    p.name AS product_name,
+    p.slug AS product_slug
+FROM product_questions q
+JOIN users u ON q.user_id = u.id
+JOIN products p ON q.product_id = p.id
+WHERE q.id = sqlc.arg(id);
+
+-- name: CreateProductAnswer :one
+-- Records an answer to a question. Staff answers are created official and already approved.
+INSERT INTO product_answers (question_id, user_id, body, is_official, status)
+VALUES (sqlc.arg(question_id), sqlc.arg(user_id), sqlc.arg(body), sqlc.arg(is_official), sqlc.arg(status))
+RETURNING id, question_id, user_id, body, is_official, status, upvote_count, created_at, updated_at;
+
+-- name: ListProductQuestionsForModeration :many
+-- Retrieves questions for the admin moderation queue, oldest first, optionally filtered by status (empty = all).
+SELECT
+    q.id,
+    q.product_id,
+    q.user_id,
+    q.body,
+    q.status,
+    q.moderation_reason,
+    q.moderated_by,
+    q.moderated_at,
+    q.created_at,
+    u.full_name AS asker_name,
+    u.email AS asker_email,
+    p.name AS product_name
+FROM product_questions q
+JOIN users u ON q.user_id = u.id
+JOIN products p ON q.product_id = p.id
+WHERE (sqlc.arg(status)::TEXT = '' OR q.status = sqlc.arg(status)::TEXT)
+ORDER BY q.created_at ASC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountProductQuestionsForModeration :one
+-- Counts questions in the admin moderation queue, optionally filtered by status (empty = all).
+SELECT COUNT(*)::BIGINT
+FROM product_questions
+WHERE (sqlc.arg(status)::TEXT = '' OR status = sqlc.arg(status)::TEXT);
+
+-- name: ModerateProductQuestion :one
+-- Sets the moderation status of a question.
+UPDATE product_questions
+SET
+    status = sqlc.arg(status),
+    moderation_reason = sqlc.narg(moderation_reason),
+    moderated_by = NULLIF(sqlc.arg(moderated_by)::UUID, '00000000-0000-0000-0000-000000000000'),
+    moderated_at = NOW(),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING id, product_id, user_id, body, status, moderation_reason, moderated_by, moderated_at, created_at, updated_at;
+
+-- name: ListProductAnswersForModeration :many
+-- Retrieves answers for the admin moderation queue, oldest first, optionally filtered by status (empty = all).
+SELECT
+    a.id,
+    a.question_id,
+    a.user_id,
+    a.body,
+    a.is_official,
+    a.status,
+    a.moderation_reason,
+    a.moderated_by,
+    a.moderated_at,
+    a.upvote_count,
+    a.created_at,
+    u.full_name AS author_name,
+    u.email AS author_email,
+    q.body AS question_body,
+    q.product_id
+FROM product_answers a
+JOIN product_questions q ON a.question_id = q.id
+LEFT JOIN users u ON a.user_id = u.id
+WHERE (sqlc.arg(status)::TEXT = '' OR a.status = sqlc.arg(status)::TEXT)
+ORDER BY a.created_at ASC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountProductAnswersForModeration :one
+-- Counts answers in the admin moderation queue, optionally filtered by status (empty = all).
+SELECT COUNT(*)::BIGINT
+FROM product_answers
+WHERE (sqlc.arg(status)::TEXT = '' OR status = sqlc.arg(status)::TEXT);
+
+-- name: ModerateProductAnswer :one
+-- Sets the moderation status of an answer and returns its previous status,
+-- so the asker is only notified the first time the answer is published.
+UPDATE product_answers a
+SET
+    status = sqlc.arg(status),
+    moderation_reason = sqlc.narg(moderation_reason),
+    moderated_by = NULLIF(sqlc.arg(moderated_by)::UUID, '00000000-0000-0000-0000-000000000000'),
+    moderated_at = NOW(),
+    updated_at = NOW()
+FROM product_answers prev
+WHERE a.id = sqlc.arg(id) AND prev.id = a.id
+RETURNING a.id, a.question_id, a.user_id, a.body, a.is_official, a.status, a.upvote_count, a.created_at, a.updated_at, prev.status AS previous_status;
+
+-- name: GetApprovedProductAnswer :one
+-- Retrieves a published answer, e.g. before upvoting it.
+SELECT a.id, a.user_id
+FROM product_answers a
+JOIN product_questions q ON a.question_id = q.id
+WHERE a.id = sqlc.arg(id) AND a.status = 'approved' AND q.status = 'approved';
+
+-- name: CreateProductAnswerVote :exec
+-- Records a user's upvote on an answer; voting twice has no effect.
+INSERT INTO product_answer_votes (answer_id, user_id)
+VALUES (sqlc.arg(answer_id), sqlc.arg(user_id))
+ON CONFLICT (answer_id, user_id) DO NOTHING;
+
+-- name: DeleteProductAnswerVote :execrows
+-- Removes a user's upvote on an answer.
+DELETE FROM product_answer_votes
+WHERE answer_id = sqlc.arg(answer_id) AND user_id = sqlc.arg(user_id);
+
+-- name: RefreshProductAnswerUpvoteCount :one
+-- Recalculates the upvote total of an answer from its votes.
+UPDATE product_answers
+SET upvote_count = (SELECT COUNT(*) FROM product_answer_votes v WHERE v.answer_id = product_answers.id)
+WHERE id = sqlc.arg(id)
+RETURNING upvote_count;
@@ -1,12 +1,12 @@
 -- name: GetProduct :one
 SELECT id, category_id, name, slug, description, short_description, price_cents, stock_quantity, status, brand, 
-    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at
+    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at, weight_grams
 FROM products
 WHERE id = sqlc.arg(product_id) AND deleted_at IS NULL;
 
 -- name: GetProductBySlug :one
 SELECT id, category_id, name, slug, description, short_description, price_cents, stock_quantity, status, brand, 
-    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at
+    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at, weight_grams
 FROM products
 WHERE slug = sqlc.arg(slug) AND deleted_at IS NULL;
 
@@ -16,7 +16,7 @@ SELECT EXISTS(SELECT 1 FROM products WHERE slug = $1 AND deleted_at IS NULL) AS
 
 -- name: ListProducts :many
 SELECT id, category_id, name, slug, description, short_description, price_cents, stock_quantity, status, brand, 
-    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at
+    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at, weight_grams
 FROM products
 WHERE deleted_at IS NULL
 ORDER BY created_at DESC
@@ -36,7 +36,7 @@ LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
 
 -- name: ListProductsByCategory :many
 SELECT id, category_id, name, slug, description, short_description, price_cents, stock_quantity, status, brand, 
-    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at
+    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at, weight_grams
 FROM products
 WHERE category_id = sqlc.arg(category_id) AND deleted_at IS NULL
 ORDER BY created_at DESC
@@ -70,6 +70,7 @@ SELECT
     p.brand,
     p.image_urls,
     p.spec_highlights,
+    p.weight_grams,
     p.created_at,
     p.updated_at,
     p.deleted_at,
@@ -136,7 +137,7 @@ LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
 
 -- name: CreateProduct :one
 INSERT INTO products (
-    category_id, name, slug, description, short_description, price_cents, stock_quantity, status, brand, image_urls, spec_highlights, created_at, updated_at
+    category_id, name, slug, description, short_description, price_cents, stock_quantity, status, brand, image_urls, spec_highlights, weight_grams, created_at, updated_at
 ) VALUES (
     sqlc.arg(category_id), 
     sqlc.arg(name), 
@@ -149,11 +150,12 @@ INSERT INTO products (
     sqlc.arg(brand), 
     sqlc.arg(image_urls), 
     sqlc.arg(spec_highlights), 
+    sqlc.narg(weight_grams), -- Shipping weight, used to price delivery
     NOW(), -- created_at
     NOW()  -- updated_at
 ) 
 RETURNING  id, category_id, name, slug, description, short_description, price_cents, stock_quantity, status, brand, 
-    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at;
+    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at, weight_grams;
 
 -- name: UpdateProduct :one
 UPDATE products
@@ -164,21 +166,27 @@ SET
     description = COALESCE(sqlc.arg(description), description),
     short_description = COALESCE(sqlc.arg(short_description), short_description),
     price_cents = COALESCE(sqlc.arg(price_cents), price_cents),
-    stock_quantity = COALESCE(sqlc.arg(stock_quantity), stock_quantity),
+    -- stock_quantity is changed through SetProductStock/ApplyStockMovement so every change is recorded in stock_movements
     status = COALESCE(sqlc.arg(status), status),
     brand = COALESCE(sqlc.arg(brand), brand),
     image_urls = COALESCE(sqlc.arg(image_urls), image_urls),
     spec_highlights = COALESCE(sqlc.arg(spec_highlights), spec_highlights),
+    weight_grams = COALESCE(sqlc.narg(weight_grams), weight_grams),
     updated_at = NOW()
 WHERE id = sqlc.arg(product_id) AND deleted_at IS NULL
 RETURNING  id, category_id, name, slug, description, short_description, price_cents, stock_quantity, status, brand, 
-    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at;
+    avg_rating, num_ratings,image_urls, spec_highlights, created_at, updated_at, deleted_at, weight_grams;
 
 -- name: DeleteProduct :exec
 UPDATE products
 SET deleted_at = NOW()
 WHERE id = sqlc.arg(product_id);
 
+-- name: GetProductWeights :many
+-- Retrieves the shipping weight of each product, for pricing delivery of a cart.
+SELECT id, weight_grams FROM products
+WHERE id = ANY(sqlc.arg(product_ids)::UUID[]);
+
 
 -- name: CountProducts :one
 SELECT COUNT(*) FROM products p
@@ -0,0 +1,179 @@
+-- name: CreateSupplier :one
+INSERT INTO suppliers (name, contact_name, email, phone, notes)
+VALUES (sqlc.arg(name), sqlc.narg(contact_name), sqlc.narg(email), sqlc.narg(phone), sqlc.narg(notes))
+RETURNING *;
+
+-- name: GetSupplier :one
+SELECT * FROM suppliers WHERE id = sqlc.arg(id);
+
+-- name: ListSuppliers :many
+SELECT * FROM suppliers
+WHERE (NOT sqlc.arg(active_only)::BOOLEAN OR is_active)
+ORDER BY name;
+
+-- name: UpdateSupplier :one
+-- Partially updates a supplier; only provided fields change.
+UPDATE suppliers
+SET
+    name = COALESCE(sqlc.narg(name), name),
+    contact_name = COALESCE(sqlc.narg(contact_name), contact_name),
+    email = COALESCE(sqlc.narg(email), email),
+    phone = COALESCE(sqlc.narg(phone), phone),
+    notes = COALESCE(sqlc.narg(notes), notes),
+    is_active = COALESCE(sqlc.narg(is_active), is_active),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CreatePurchaseOrder :one
+INSERT INTO purchase_orders (supplier_id, location_id, reference, notes, expected_at, created_by)
+VALUES (
+    sqlc.arg(supplier_id),
+    sqlc.arg(location_id),
+    sqlc.narg(reference),
+    sqlc.narg(notes),
+    sqlc.narg(expected_at),
+    NULLIF(sqlc.arg(created_by)::UUID, '00000000-0000-0000-0000-000000000000')
+)
+RETURNING *;
+
+-- name: GetPurchaseOrder :one
+SELECT * FROM purchase_orders WHERE id = sqlc.arg(id);
+
+-- name: GetPurchaseOrderForUpdate :one
+-- Locks the purchase order so that concurrent receipts and status changes are serialized.
+SELECT * FROM purchase_orders WHERE id = sqlc.arg(id) FOR UPDATE;
+
+-- name: ListPurchaseOrders :many
+-- Lists purchase orders with their supplier and totals, newest first.
+-- Pass an empty status and the zero UUID for supplier_id to disable the filters.
+SELECT
+    po.id,
+    po.supplier_id,
+    s.name AS supplier_name,
+    po.location_id,
+    po.status,
+    po.reference,
+    po.expected_at,
+    po.ordered_at,
+    po.received_at,
+    po.created_at,
+    COALESCE(SUM(poi.quantity_ordered * poi.unit_cost_cents), 0)::BIGINT AS total_cost_cents,
+    COALESCE(SUM(poi.quantity_ordered), 0)::BIGINT AS total_quantity_ordered,
+    COALESCE(SUM(poi.quantity_received), 0)::BIGINT AS total_quantity_received
+FROM purchase_orders po
+JOIN suppliers s ON s.id = po.supplier_id
+LEFT JOIN purchase_order_items poi ON poi.purchase_order_id = po.id
+WHERE (sqlc.arg(status)::TEXT = '' OR po.status = sqlc.arg(status)::TEXT)
+  AND (sqlc.arg(supplier_id)::UUID = '00000000-0000-0000-0000-000000000000' OR po.supplier_id = sqlc.arg(supplier_id)::UUID)
+GROUP BY po.id, s.name
+ORDER BY po.created_at DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountPurchaseOrders :one
+SELECT COUNT(*) FROM purchase_orders po
+WHERE (sqlc.arg(status)::TEXT = '' OR po.status = sqlc.arg(status)::TEXT)
+  AND (sqlc.arg(supplier_id)::UUID = '00000000-0000-0000-0000-000000000000' OR po.supplier_id = sqlc.arg(supplier_id)::UUID);
+
+-- name: UpdatePurchaseOrderDetails :one
+-- Updates the editable fields of a draft purchase order.
+-- Pass the zero UUID for location_id to keep the current location.
+UPDATE purchase_orders
+SET
+    location_id = COALESCE(NULLIF(sqlc.arg(location_id)::UUID, '00000000-0000-0000-0000-000000000000'), location_id),
+    reference = COALESCE(sqlc.narg(reference), reference),
+    notes = COALESCE(sqlc.narg(notes), notes),
+    expected_at = COALESCE(sqlc.narg(expected_at), expected_at),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: UpdatePurchaseOrderStatus :one
+-- Sets the status; ordered_at and received_at are stamped on the corresponding transitions.
+UPDATE purchase_orders
+SET
+    status = sqlc.arg(status),
+    ordered_at = CASE WHEN sqlc.arg(status) = 'ordered' THEN NOW() ELSE ordered_at END,
+    received_at = CASE WHEN sqlc.arg(status) = 'received' THEN NOW() ELSE received_at END,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CreatePurchaseOrderItem :one
+INSERT INTO purchase_order_items (purchase_order_id, product_id, quantity_ordered, unit_cost_cents)
+VALUES (sqlc.arg(purchase_order_id), sqlc.arg(product_id), sqlc.arg(quantity_ordered), sqlc.arg(unit_cost_cents))
+RETURNING *;
+
+-- name: DeletePurchaseOrderItems :exec
+DELETE FROM purchase_order_items WHERE purchase_order_id = sqlc.arg(purchase_order_id);
+
+-- name: ListPurchaseOrderItems :many
+SELECT
+    poi.*,
+    p.name AS product_name
+FROM purchase_order_items poi
+JOIN products p ON p.id = poi.product_id
+WHERE poi.purchase_order_id = sqlc.arg(purchase_order_id)
+ORDER BY poi.created_at, poi.id;
+
+-- name: ReceivePurchaseOrderItem :one
+-- Adds a received quantity to a line; returns no rows if it would exceed the ordered quantity.
+UPDATE purchase_order_items
+SET quantity_received = quantity_received + sqlc.arg(quantity)::INT
+WHERE id = sqlc.arg(id) AND quantity_received + sqlc.arg(quantity)::INT <= quantity_ordered
+RETURNING *;
+
+-- name: GetPurchaseOrderItemForUpdate :one
+SELECT * FROM purchase_order_items
+WHERE id = sqlc.arg(id)
+FOR UPDATE;
+
+-- name: CountOutstandingPurchaseOrderItems :one
+-- Counts the lines of a purchase order that are not fully received.
+SELECT COUNT(*) FROM purchase_order_items
+WHERE purchase_order_id = sqlc.arg(purchase_order_id) AND quantity_received < quantity_ordered;
+
+-- name: CreatePurchaseOrderReceipt :one
+INSERT INTO purchase_order_receipts (purchase_order_id, purchase_order_item_id, product_id, location_id, quantity, unit_cost_cents, stock_movement_id, received_by)
+VALUES (
+    sqlc.arg(purchase_order_id),
+    sqlc.arg(purchase_order_item_id),
+    sqlc.arg(product_id),
+    sqlc.arg(location_id),
+    sqlc.arg(quantity),
+    sqlc.arg(unit_cost_cents),
+    sqlc.arg(stock_movement_id),
+    NULLIF(sqlc.arg(received_by)::UUID, '00000000-0000-0000-0000-000000000000')
+)
+RETURNING *;
+
+-- name: ListPurchaseOrderReceipts :many
+SELECT * FROM purchase_order_receipts
+WHERE purchase_order_id = sqlc.arg(purchase_order_id)
+ORDER BY received_at, id;
+
+-- name: UpdateProductAverageCost :exec
+-- Folds a receipt into the product's weighted average cost, using the stock on hand at all locations before the receipt.
+WITH on_hand AS (
+    SELECT COALESCE(SUM(psl.quantity), 0)::BIGINT AS quantity
+    FROM product_stock_levels psl
+    WHERE psl.product_id = sqlc.arg(product_id)
+)
+INSERT INTO product_costs (product_id, average_cost_cents)
+VALUES (sqlc.arg(product_id), sqlc.arg(unit_cost_cents)::BIGINT)
+ON CONFLICT (product_id) DO UPDATE
+SET average_cost_cents = CASE
+        WHEN (SELECT quantity FROM on_hand) <= 0 THEN EXCLUDED.average_cost_cents
+        ELSE ROUND(
+            (product_costs.average_cost_cents * (SELECT quantity FROM on_hand) + EXCLUDED.average_cost_cents * sqlc.arg(quantity)::INT)::NUMERIC
+            / ((SELECT quantity FROM on_hand) + sqlc.arg(quantity)::INT)
+        )::BIGINT
+    END,
+    updated_at = NOW();
+
+-- name: SnapshotOrderItemCosts :exec
+-- Copies the current average cost of each product onto the order's items, so margins use the cost at the time of sale.
+UPDATE order_items oi
+SET unit_cost_cents = pc.average_cost_cents
+FROM product_costs pc
+WHERE oi.product_id = pc.product_id AND oi.order_id = sqlc.arg(order_id);
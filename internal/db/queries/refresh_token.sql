@@ -1,14 +1,29 @@
 -- name: CreateRefreshToken :exec
-INSERT INTO refresh_tokens (jti, user_id, token_hash, expires_at)
-VALUES (@jti::text, @user_id::uuid, @token_hash::char(64), @expires_at::timestamptz);
+-- last_used_at starts at NOW(): a token is created when its session signs in or refreshes.
+INSERT INTO refresh_tokens (jti, user_id, token_hash, expires_at, family_id, user_agent, ip_address, mfa)
+VALUES (@jti::text, @user_id::uuid, @token_hash::char(64), @expires_at::timestamptz, @family_id::uuid, @user_agent::text, @ip_address::text, @mfa::boolean);
 
--- name: GetValidRefreshTokenRecord :one
-SELECT id, jti, user_id, token_hash, expires_at, revoked_at, created_at, updated_at
+-- name: GetRefreshTokenRecord :one
+-- Retrieves an unexpired refresh token, including revoked ones so that reuse can be detected.
+SELECT id, jti, user_id, token_hash, expires_at, revoked_at, created_at, updated_at, family_id, revoked_reason, mfa
 FROM refresh_tokens
-WHERE jti = @jti::text AND expires_at > NOW() AND revoked_at IS NULL;
+WHERE jti = @jti::text AND expires_at > NOW();
+
+-- name: RotateRefreshToken :execrows
+-- Revokes a refresh token that is being exchanged for its successor.
+-- Affects no rows if the token was already revoked, e.g. by a concurrent refresh with the same token.
+UPDATE refresh_tokens
+SET revoked_at = NOW(), revoked_reason = 'rotated', updated_at = NOW()
+WHERE jti = @jti::text AND revoked_at IS NULL;
+
+-- name: RevokeRefreshTokenFamily :execrows
+-- Revokes every live token descending from the same login.
+UPDATE refresh_tokens
+SET revoked_at = NOW(), revoked_reason = 'reuse_detected', updated_at = NOW()
+WHERE family_id = @family_id::uuid AND revoked_at IS NULL;
 
 -- name: RevokeRefreshTokenByJTI :exec
-UPDATE refresh_tokens SET revoked_at = NOW(), updated_at = NOW() WHERE jti = @jti::text;
+UPDATE refresh_tokens SET revoked_at = NOW(), revoked_reason = 'logout', updated_at = NOW() WHERE jti = @jti::text;
 
 -- name: CleanupExpiredRefreshTokens :exec
 DELETE FROM refresh_tokens WHERE expires_at < NOW() AND revoked_at IS NULL;
@@ -16,5 +31,32 @@ DELETE FROM refresh_tokens WHERE expires_at < NOW() AND revoked_at IS NULL;
 -- name: RevokeAllRefreshTokensByUserID :exec
 -- Revokes all refresh tokens for a specific user.
 UPDATE refresh_tokens
-SET revoked_at = NOW(), updated_at = NOW()
+SET revoked_at = NOW(), revoked_reason = 'revoked', updated_at = NOW()
 WHERE user_id = @user_id::uuid AND revoked_at IS NULL; -- Only revoke non-already-revoked tokens
+
+-- name: ListActiveSessionsByUserID :many
+-- Retrieves the live sessions (token families) of a user, most recently used first.
+SELECT
+    rt.family_id,
+    rt.user_agent,
+    rt.ip_address,
+    rt.last_used_at,
+    rt.expires_at,
+    (SELECT MIN(f.created_at) FROM refresh_tokens f WHERE f.family_id = rt.family_id)::TIMESTAMPTZ AS signed_in_at
+FROM refresh_tokens rt
+WHERE rt.user_id = @user_id::uuid AND rt.revoked_at IS NULL AND rt.expires_at > NOW()
+ORDER BY rt.last_used_at DESC;
+
+-- name: RevokeUserSession :execrows
+-- Revokes the live token of one session of a user.
+UPDATE refresh_tokens
+SET revoked_at = NOW(), revoked_reason = 'session_revoked', updated_at = NOW()
+WHERE user_id = @user_id::uuid AND family_id = @family_id::uuid AND revoked_at IS NULL;
+
+-- name: RevokeOtherUserSessions :many
+-- Revokes every session of a user except the given one (pass the zero UUID to revoke all of them).
+-- Returns the revoked sessions.
+UPDATE refresh_tokens
+SET revoked_at = NOW(), revoked_reason = 'session_revoked', updated_at = NOW()
+WHERE user_id = @user_id::uuid AND family_id <> @keep_family_id::uuid AND revoked_at IS NULL
+RETURNING family_id;
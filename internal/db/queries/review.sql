@@ -2,11 +2,34 @@
 -- Inserts a new review and returns its details.
 -- NOTE: This query alone does not update the product's avg_rating/num_ratings.
 INSERT INTO reviews (
-    user_id, product_id, rating
+    user_id, product_id, rating, title, body, pros, cons, status, verified_purchase
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
 )
-RETURNING id, user_id, product_id, rating, created_at, updated_at;
+RETURNING id, user_id, product_id, rating, title, body, pros, cons, status, moderation_reason, verified_purchase, created_at, updated_at;
+
+-- name: HasUserPurchasedProduct :one
+-- Checks whether the user has a delivered order containing the product.
+SELECT EXISTS (
+    SELECT 1
+    FROM orders o
+    JOIN order_items oi ON oi.order_id = o.id
+    WHERE o.user_id = sqlc.arg(user_id) AND oi.product_id = sqlc.arg(product_id) AND o.status = 'delivered'
+)::BOOLEAN AS purchased;
+
+-- name: MarkReviewsVerifiedForOrder :many
+-- Flags the user's existing reviews of the products in a delivered order as verified purchases.
+-- Returns the affected product IDs so their stats can be recalculated.
+UPDATE reviews r
+SET verified_purchase = TRUE
+FROM orders o
+JOIN order_items oi ON oi.order_id = o.id
+WHERE o.id = sqlc.arg(order_id)
+  AND r.user_id = o.user_id
+  AND r.product_id = oi.product_id
+  AND r.deleted_at IS NULL
+  AND NOT r.verified_purchase
+RETURNING r.product_id;
 
 -- name: GetReviewByUserAndProduct :one
 -- Retrieves a review by a specific user for a specific product.
@@ -21,29 +44,64 @@ FROM reviews
 WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL;
 
 -- name: GetReviewsByProductID :many
--- Retrieves all reviews for a specific product, including the reviewer's name, potentially paginated.
+-- Retrieves the approved reviews for a specific product, including the reviewer's name, potentially paginated.
+-- sort is one of 'most_helpful', 'highest', 'lowest' or 'newest' (default); rating = 0 means all star counts.
 SELECT 
     r.id,
     r.user_id,
     r.product_id,
     r.rating,
+    r.title,
+    r.body,
+    r.pros,
+    r.cons,
+    r.verified_purchase,
+    r.helpful_count,
+    r.unhelpful_count,
     r.created_at,
     r.updated_at,
     u.full_name AS reviewer_name 
 FROM reviews r
 JOIN users u ON r.user_id = u.id -- INNER JOIN to link review to user
-WHERE r.product_id = sqlc.arg(product_id) AND r.deleted_at IS NULL
-ORDER BY r.created_at DESC -- Or rating DESC, etc.
+WHERE r.product_id = sqlc.arg(product_id) AND r.deleted_at IS NULL AND r.status = 'approved'
+  AND (sqlc.arg(rating)::INTEGER = 0 OR r.rating = sqlc.arg(rating)::INTEGER)
+ORDER BY
+    CASE WHEN sqlc.arg(sort)::TEXT = 'most_helpful' THEN r.helpful_count - r.unhelpful_count END DESC,
+    CASE WHEN sqlc.arg(sort)::TEXT = 'highest' THEN r.rating END DESC,
+    CASE WHEN sqlc.arg(sort)::TEXT = 'lowest' THEN r.rating END ASC,
+    r.created_at DESC
 LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
 
+-- name: CountReviewsByProductID :one
+-- Counts the approved reviews for a specific product; rating = 0 means all star counts.
+SELECT COUNT(*)::BIGINT AS total
+FROM reviews
+WHERE product_id = sqlc.arg(product_id) AND deleted_at IS NULL AND status = 'approved'
+  AND (sqlc.arg(rating)::INTEGER = 0 OR rating = sqlc.arg(rating)::INTEGER);
+
+-- name: GetReviewRatingDistribution :many
+-- Counts the approved reviews of a product per star rating. Ratings without reviews are omitted.
+SELECT rating, COUNT(*)::BIGINT AS count
+FROM reviews
+WHERE product_id = sqlc.arg(product_id) AND deleted_at IS NULL AND status = 'approved'
+GROUP BY rating
+ORDER BY rating;
 
 -- name: GetReviewsByUserID :many
 -- Retrieves all reviews submitted by a specific user, including the product name, potentially paginated.
+-- The author sees their reviews whatever their moderation status.
 SELECT 
     r.id,
     r.user_id,
     r.product_id,
     r.rating,
+    r.title,
+    r.body,
+    r.pros,
+    r.cons,
+    r.status,
+    r.moderation_reason,
+    r.verified_purchase,
     r.created_at,
     r.updated_at,
     p.name AS product_name -- Join with products table to get the name
@@ -54,12 +112,22 @@ ORDER BY r.created_at DESC
 LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
 
 -- name: UpdateReview :one
--- Updates the rating of an existing review.
+-- Updates the rating and text of an existing review and sets its moderation status again.
 -- NOTE: This query alone does not update the product's avg_rating/num_ratings.
 UPDATE reviews
-SET rating = $1, updated_at = NOW()
-WHERE id = $2 AND user_id = $3 -- Ensure user owns the review
-RETURNING id, user_id, product_id, rating, created_at, updated_at;
+SET
+    rating = sqlc.arg(rating),
+    title = sqlc.arg(title),
+    body = sqlc.arg(body),
+    pros = sqlc.arg(pros),
+    cons = sqlc.arg(cons),
+    status = sqlc.arg(status),
+    moderation_reason = NULL,
+    moderated_by = NULL,
+    moderated_at = NULL,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(user_id) -- Ensure user owns the review
+RETURNING id, user_id, product_id, rating, title, body, pros, cons, status, moderation_reason, verified_purchase, created_at, updated_at;
 
 -- name: DeleteReview :one
 -- Soft deletes a review by setting deleted_at.
@@ -70,13 +138,15 @@ WHERE id = $1 AND user_id = $2 -- Ensure user owns the review
 RETURNING id, user_id, product_id, rating, created_at, updated_at;
 
 -- name: CalculateReviewStatsForProduct :one
--- Calculates the average rating and count of non-deleted reviews for a specific product.
+-- Calculates the average rating and count of approved, non-deleted reviews for a specific product.
+-- Verified-purchase reviews count verified_weight times in the average (1 = no weighting).
 -- Used to update the products table.
 SELECT
-    AVG(r.rating)::NUMERIC(3,2) AS avg_rating,
+    (SUM(r.rating * CASE WHEN r.verified_purchase THEN sqlc.arg(verified_weight)::FLOAT8 ELSE 1 END)
+        / NULLIF(SUM(CASE WHEN r.verified_purchase THEN sqlc.arg(verified_weight)::FLOAT8 ELSE 1 END), 0))::NUMERIC(3,2) AS avg_rating,
     COUNT(r.rating)::INTEGER AS num_ratings
 FROM reviews r
-WHERE r.product_id = sqlc.arg(product_id) AND r.deleted_at IS NULL;
+WHERE r.product_id = sqlc.arg(product_id) AND r.deleted_at IS NULL AND r.status = 'approved';
 
 -- name: UpdateProductReviewStats :exec
 -- Updates the avg_rating and num_ratings fields in the products table for a specific product.
@@ -86,3 +156,192 @@ SET
     num_ratings = sqlc.arg(num_ratings),
     updated_at = NOW() -- Optionally update the product's general updated_at too
 WHERE id = sqlc.arg(product_id);
+
+-- name: ListReviewsForModeration :many
+-- Retrieves reviews for the admin moderation queue, oldest first, optionally filtered by status (empty = all)
+-- and to reviews with open abuse reports.
+SELECT
+    r.id,
+    r.user_id,
+    r.product_id,
+    r.rating,
+    r.title,
+    r.body,
+    r.pros,
+    r.cons,
+    r.status,
+    r.moderation_reason,
+    r.verified_purchase,
+    r.helpful_count,
+    r.unhelpful_count,
+    r.moderated_by,
+    r.moderated_at,
+    r.created_at,
+    r.updated_at,
+    u.full_name AS reviewer_name,
+    u.email AS reviewer_email,
+    p.name AS product_name,
+    (SELECT COUNT(*) FROM review_reports rr WHERE rr.review_id = r.id AND rr.resolved_at IS NULL)::BIGINT AS open_report_count
+FROM reviews r
+JOIN users u ON r.user_id = u.id
+JOIN products p ON r.product_id = p.id
+WHERE r.deleted_at IS NULL
+  AND (sqlc.arg(status)::TEXT = '' OR r.status = sqlc.arg(status)::TEXT)
+  AND (NOT sqlc.arg(reported_only)::BOOLEAN OR EXISTS (
+      SELECT 1 FROM review_reports rr WHERE rr.review_id = r.id AND rr.resolved_at IS NULL
+  ))
+ORDER BY r.created_at ASC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountReviewsForModeration :one
+-- Counts the reviews in the admin moderation queue, optionally filtered by status (empty = all).
+SELECT COUNT(*)::BIGINT AS total
+FROM reviews r
+WHERE r.deleted_at IS NULL
+  AND (sqlc.arg(status)::TEXT = '' OR r.status = sqlc.arg(status)::TEXT)
+  AND (NOT sqlc.arg(reported_only)::BOOLEAN OR EXISTS (
+      SELECT 1 FROM review_reports rr WHERE rr.review_id = r.id AND rr.resolved_at IS NULL
+  ));
+
+-- name: GetReviewForModeration :one
+-- Retrieves a single review with its author and product, for moderation.
+SELECT
+    r.id,
+    r.user_id,
+    r.product_id,
+    r.rating,
+    r.title,
+    r.body,
+    r.pros,
+    r.cons,
+    r.status,
+    r.moderation_reason,
+    r.verified_purchase,
+    r.helpful_count,
+    r.unhelpful_count,
+    r.moderated_by,
+    r.moderated_at,
+    r.created_at,
+    r.updated_at,
+    u.full_name AS reviewer_name,
+    u.email AS reviewer_email,
+    p.name AS product_name,
+    (SELECT COUNT(*) FROM review_reports rr WHERE rr.review_id = r.id AND rr.resolved_at IS NULL)::BIGINT AS open_report_count
+FROM reviews r
+JOIN users u ON r.user_id = u.id
+JOIN products p ON r.product_id = p.id
+WHERE r.id = sqlc.arg(id) AND r.deleted_at IS NULL;
+
+-- name: ModerateReview :one
+-- Sets the moderation status of a review.
+-- NOTE: This query alone does not update the product's avg_rating/num_ratings.
+UPDATE reviews
+SET
+    status = sqlc.arg(status),
+    moderation_reason = sqlc.narg(moderation_reason),
+    moderated_by = NULLIF(sqlc.arg(moderated_by)::UUID, '00000000-0000-0000-0000-000000000000'),
+    moderated_at = NOW()
+WHERE id = sqlc.arg(id) AND deleted_at IS NULL
+RETURNING id, product_id, status;
+
+-- name: GetApprovedReview :one
+-- Retrieves an approved, non-deleted review, for voting and reporting.
+SELECT id, user_id, product_id
+FROM reviews
+WHERE id = sqlc.arg(id) AND deleted_at IS NULL AND status = 'approved';
+
+-- name: UpsertReviewVote :exec
+-- Records a user's vote on a review, replacing any previous vote.
+INSERT INTO review_votes (review_id, user_id, is_helpful)
+VALUES (sqlc.arg(review_id), sqlc.arg(user_id), sqlc.arg(is_helpful))
+ON CONFLICT (review_id, user_id) DO UPDATE
+SET is_helpful = EXCLUDED.is_helpful, updated_at = NOW();
+
+-- name: DeleteReviewVote :execrows
+-- Removes a user's vote on a review.
+DELETE FROM review_votes
+WHERE review_id = sqlc.arg(review_id) AND user_id = sqlc.arg(user_id);
+
+-- name: RefreshReviewVoteCounts :one
+-- Recalculates the helpful/unhelpful totals of a review from its votes.
+UPDATE reviews
+SET
+    helpful_count = (SELECT COUNT(*) FROM review_votes v WHERE v.review_id = reviews.id AND v.is_helpful),
+    unhelpful_count = (SELECT COUNT(*) FROM review_votes v WHERE v.review_id = reviews.id AND NOT v.is_helpful)
+WHERE id = sqlc.arg(id)
+RETURNING helpful_count, unhelpful_count;
+
+-- name: CreateReviewReport :one
+-- Records an abuse report on a review.
+INSERT INTO review_reports (review_id, user_id, reason)
+VALUES (sqlc.arg(review_id), sqlc.arg(user_id), sqlc.arg(reason))
+RETURNING id, review_id, user_id, reason, created_at;
+
+-- name: ResolveReviewReports :exec
+-- Closes the open abuse reports of a review once a moderator has decided on it.
+UPDATE review_reports
+SET resolved_at = NOW(), resolved_by = NULLIF(sqlc.arg(resolved_by)::UUID, '00000000-0000-0000-0000-000000000000')
+WHERE review_id = sqlc.arg(review_id) AND resolved_at IS NULL;
+
+-- name: ListOpenReviewReports :many
+-- Lists the open abuse reports of a review, oldest first.
+SELECT rr.id, rr.user_id, rr.reason, rr.created_at, u.email AS reporter_email
+FROM review_reports rr
+JOIN users u ON rr.user_id = u.id
+WHERE rr.review_id = sqlc.arg(review_id) AND rr.resolved_at IS NULL
+ORDER BY rr.created_at;
+
+-- name: CreateReviewImage :exec
+-- Attaches an uploaded image to a review.
+INSERT INTO review_images (review_id, url, position)
+VALUES (sqlc.arg(review_id), sqlc.arg(url), sqlc.arg(position));
+
+-- name: ListReviewImagesByReviewIDs :many
+-- Retrieves the images of several reviews, in display order.
+SELECT review_id, url
+FROM review_images
+WHERE review_id = ANY(sqlc.arg(review_ids)::UUID[])
+ORDER BY review_id, position;
+
+-- name: DeleteReviewImages :many
+-- Removes the images of a review and returns their URLs so the files can be deleted.
+DELETE FROM review_images
+WHERE review_id = sqlc.arg(review_id)
+RETURNING url;
+
+-- name: CreateReviewReply :one
+-- Adds the official reply to a review.
+INSERT INTO review_replies (review_id, author_id, body)
+VALUES (sqlc.arg(review_id), NULLIF(sqlc.arg(author_id)::UUID, '00000000-0000-0000-0000-000000000000'), sqlc.arg(body))
+RETURNING id, review_id, author_id, body, created_at, updated_at;
+
+-- name: UpdateReviewReply :one
+-- Edits the official reply to a review.
+UPDATE review_replies
+SET body = sqlc.arg(body),
+    author_id = NULLIF(sqlc.arg(author_id)::UUID, '00000000-0000-0000-0000-000000000000'),
+    updated_at = NOW()
+WHERE review_id = sqlc.arg(review_id)
+RETURNING id, review_id, author_id, body, created_at, updated_at;
+
+-- name: DeleteReviewReply :execrows
+-- Removes the official reply to a review.
+DELETE FROM review_replies
+WHERE review_id = sqlc.arg(review_id);
+
+-- name: ListReviewRepliesByReviewIDs :many
+-- Retrieves the official replies of several reviews.
+SELECT id, review_id, author_id, body, created_at, updated_at
+FROM review_replies
+WHERE review_id = ANY(sqlc.arg(review_ids)::UUID[]);
+
+-- name: GetReviewReplyRecipient :one
+-- Retrieves what is needed to tell a reviewer their review was answered.
+SELECT
+    u.email AS reviewer_email,
+    p.name AS product_name,
+    p.slug AS product_slug
+FROM reviews r
+JOIN users u ON r.user_id = u.id
+JOIN products p ON r.product_id = p.id
+WHERE r.id = sqlc.arg(id) AND r.deleted_at IS NULL;
@@ -0,0 +1,74 @@
+-- name: ListPermissions :many
+-- Retrieves every permission that can be granted to a role.
+SELECT code, description
+FROM permissions
+ORDER BY code;
+
+-- name: ListRoles :many
+-- Retrieves every role.
+SELECT id, name, description, created_at
+FROM roles
+ORDER BY name;
+
+-- name: ListRolePermissions :many
+-- Retrieves the permissions granted to every role.
+SELECT role_id, permission_code
+FROM role_permissions
+ORDER BY role_id, permission_code;
+
+-- name: GetRolesByNames :many
+-- Retrieves the roles with the given names.
+SELECT id, name
+FROM roles
+WHERE name = ANY(sqlc.arg(names)::TEXT[]);
+
+-- name: ListUserRoleNames :many
+-- Retrieves the names of the roles assigned to a user.
+SELECT r.name
+FROM user_roles ur
+JOIN roles r ON ur.role_id = r.id
+WHERE ur.user_id = sqlc.arg(user_id)
+ORDER BY r.name;
+
+-- name: ListUserPermissions :many
+-- Retrieves the permissions a user holds through their roles.
+SELECT DISTINCT rp.permission_code
+FROM user_roles ur
+JOIN role_permissions rp ON ur.role_id = rp.role_id
+WHERE ur.user_id = sqlc.arg(user_id)
+ORDER BY rp.permission_code;
+
+-- name: DeleteUserRoles :exec
+-- Removes every role assigned to a user.
+DELETE FROM user_roles
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: AddUserRole :exec
+-- Assigns a role to a user.
+INSERT INTO user_roles (user_id, role_id, assigned_by)
+VALUES (sqlc.arg(user_id), sqlc.arg(role_id), NULLIF(sqlc.arg(assigned_by)::UUID, '00000000-0000-0000-0000-000000000000'))
+ON CONFLICT (user_id, role_id) DO NOTHING;
+
+-- name: SetUserAdminFlag :exec
+-- Marks a user as staff (any role) or customer (no role), which decides access to the admin area.
+UPDATE users
+SET is_admin = sqlc.arg(is_admin), updated_at = NOW()
+WHERE id = sqlc.arg(id);
+
+-- name: CountActiveUsersWithRole :one
+-- Counts active users holding a role, e.g. to keep at least one superuser.
+SELECT COUNT(*)::BIGINT
+FROM user_roles ur
+JOIN roles r ON ur.role_id = r.id
+JOIN users u ON ur.user_id = u.id
+WHERE r.name = sqlc.arg(role_name) AND u.deleted_at IS NULL;
+
+-- name: LockUserRolesByRoleName :exec
+-- Locks the assignments of a role until the end of the transaction, in a fixed order, so that concurrent
+-- changes cannot each remove a holder they counted while the other was still there.
+SELECT ur.user_id
+FROM user_roles ur
+JOIN roles r ON ur.role_id = r.id
+WHERE r.name = sqlc.arg(role_name)
+ORDER BY ur.user_id
+FOR UPDATE OF ur;
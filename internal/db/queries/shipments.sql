@@ -0,0 +1,46 @@
+-- name: CreateShipment :one
+INSERT INTO shipments (order_id, courier, tracking_number, status, status_updated_at)
+VALUES (sqlc.arg(order_id), sqlc.arg(courier), sqlc.arg(tracking_number), sqlc.arg(status), sqlc.arg(status_updated_at))
+RETURNING id, order_id, courier, tracking_number, status, status_updated_at, created_at, updated_at;
+
+-- name: GetShipmentByOrderID :one
+-- Retrieves the latest shipment of an order; earlier ones can only be cancelled shipments.
+SELECT id, order_id, courier, tracking_number, status, status_updated_at, created_at, updated_at
+FROM shipments
+WHERE order_id = sqlc.arg(order_id)
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: GetShipmentByTrackingNumber :one
+SELECT id, order_id, courier, tracking_number, status, status_updated_at, created_at, updated_at
+FROM shipments
+WHERE courier = sqlc.arg(courier) AND tracking_number = sqlc.arg(tracking_number);
+
+-- name: ListOpenShipments :many
+-- Retrieves shipments that have not reached a final status, least recently updated first,
+-- along with delivered shipments whose order has not been marked delivered yet.
+SELECT s.id, s.order_id, s.courier, s.tracking_number, s.status, s.status_updated_at, s.created_at, s.updated_at
+FROM shipments s
+JOIN orders o ON o.id = s.order_id
+WHERE s.status NOT IN ('delivered', 'returned', 'cancelled')
+   OR (s.status = 'delivered' AND o.status = 'shipped')
+ORDER BY s.status_updated_at ASC
+LIMIT sqlc.arg(page_limit);
+
+-- name: UpdateShipmentStatus :one
+UPDATE shipments
+SET status = sqlc.arg(status), status_updated_at = sqlc.arg(status_updated_at), updated_at = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING id, order_id, courier, tracking_number, status, status_updated_at, created_at, updated_at;
+
+-- name: AddShipmentEvent :execrows
+-- Records a status event, ignoring events already recorded.
+INSERT INTO shipment_events (shipment_id, status, description, occurred_at, source)
+VALUES (sqlc.arg(shipment_id), sqlc.arg(status), sqlc.narg(description), sqlc.arg(occurred_at), sqlc.arg(source))
+ON CONFLICT (shipment_id, status, occurred_at) DO NOTHING;
+
+-- name: ListShipmentEvents :many
+SELECT id, shipment_id, status, description, occurred_at, source, created_at
+FROM shipment_events
+WHERE shipment_id = sqlc.arg(shipment_id)
+ORDER BY occurred_at ASC, created_at ASC;
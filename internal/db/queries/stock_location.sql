@@ -0,0 +1,69 @@
+-- name: CreateStockLocation :one
+INSERT INTO stock_locations (code, name, is_sellable, is_active, priority)
+VALUES (sqlc.arg(code), sqlc.arg(name), sqlc.arg(is_sellable), sqlc.arg(is_active), sqlc.arg(priority))
+RETURNING *;
+
+-- name: GetStockLocation :one
+SELECT * FROM stock_locations WHERE id = sqlc.arg(id);
+
+-- name: GetDefaultStockLocation :one
+SELECT * FROM stock_locations WHERE is_default LIMIT 1;
+
+-- name: ListStockLocations :many
+SELECT * FROM stock_locations ORDER BY priority, code;
+
+-- name: UpdateStockLocation :one
+-- Partially updates a location; products.stock_quantity follows sellability changes through trg_stock_locations_sync.
+UPDATE stock_locations
+SET
+    name = COALESCE(sqlc.narg(name), name),
+    is_sellable = COALESCE(sqlc.narg(is_sellable), is_sellable),
+    is_active = COALESCE(sqlc.narg(is_active), is_active),
+    priority = COALESCE(sqlc.narg(priority), priority),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ListProductStockLevels :many
+-- Lists a product's stock at every location, including locations without stock.
+SELECT
+    sl.id AS location_id,
+    sl.code AS location_code,
+    sl.name AS location_name,
+    sl.is_sellable,
+    sl.is_active,
+    COALESCE(psl.quantity, 0)::INT AS quantity
+FROM stock_locations sl
+LEFT JOIN product_stock_levels psl ON psl.location_id = sl.id AND psl.product_id = sqlc.arg(product_id)
+ORDER BY sl.priority, sl.code;
+
+-- name: ListAllocatableStockLevelsForUpdate :many
+-- Lists the stock of a product at active, sellable locations in allocation order, locking the levels.
+SELECT psl.location_id, psl.quantity
+FROM product_stock_levels psl
+JOIN stock_locations sl ON sl.id = psl.location_id
+WHERE psl.product_id = sqlc.arg(product_id) AND sl.is_sellable AND sl.is_active AND psl.quantity > 0
+ORDER BY sl.priority, psl.quantity DESC
+FOR UPDATE OF psl;
+
+-- name: CreateOrderItemAllocation :exec
+INSERT INTO order_item_allocations (order_id, order_item_id, product_id, location_id, quantity)
+VALUES (sqlc.arg(order_id), sqlc.arg(order_item_id), sqlc.arg(product_id), sqlc.arg(location_id), sqlc.arg(quantity));
+
+-- name: ListOrderItemAllocations :many
+SELECT * FROM order_item_allocations WHERE order_id = sqlc.arg(order_id) ORDER BY created_at, id;
+
+-- name: CreateStockTransfer :one
+INSERT INTO stock_transfers (product_id, from_location_id, to_location_id, quantity, actor_id, note)
+VALUES (
+    sqlc.arg(product_id),
+    sqlc.arg(from_location_id),
+    sqlc.arg(to_location_id),
+    sqlc.arg(quantity),
+    NULLIF(sqlc.arg(actor_id)::UUID, '00000000-0000-0000-0000-000000000000'),
+    sqlc.narg(note)
+)
+RETURNING *;
+
+-- name: ListProductIDsByStockLocation :many
+SELECT product_id FROM product_stock_levels WHERE location_id = sqlc.arg(location_id);
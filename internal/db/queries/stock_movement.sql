@@ -0,0 +1,122 @@
+-- name: EnsureProductStockLevel :exec
+-- Creates the (empty) stock level of a product at a location if it does not exist yet.
+-- Called before ApplyStockMovement/SetProductStock, which only update existing levels.
+INSERT INTO product_stock_levels (product_id, location_id, quantity)
+VALUES (sqlc.arg(product_id), sqlc.arg(location_id), 0)
+ON CONFLICT (product_id, location_id) DO NOTHING;
+
+-- name: ApplyStockMovement :one
+-- Changes a product's stock at a location by a signed delta and records the movement in the ledger, in one statement.
+-- products.stock_quantity follows through the trg_product_stock_levels_sync trigger.
+-- Returns no rows if the stock level does not exist or the stock would become negative.
+-- Pass the zero UUID for order_id/actor_id/transfer_id when there is no reference; it is stored as NULL.
+WITH updated AS (
+    UPDATE product_stock_levels
+    SET quantity = product_stock_levels.quantity + sqlc.arg(delta)::INT, updated_at = NOW()
+    WHERE product_stock_levels.product_id = sqlc.arg(product_id)
+      AND product_stock_levels.location_id = sqlc.arg(location_id)
+      AND product_stock_levels.quantity + sqlc.arg(delta)::INT >= 0
+    RETURNING product_stock_levels.product_id, product_stock_levels.location_id, product_stock_levels.quantity
+)
+INSERT INTO stock_movements (product_id, location_id, delta, resulting_quantity, reason, order_id, actor_id, transfer_id, note)
+SELECT
+    updated.product_id,
+    updated.location_id,
+    sqlc.arg(delta)::INT,
+    updated.quantity,
+    sqlc.arg(reason),
+    NULLIF(sqlc.arg(order_id)::UUID, '00000000-0000-0000-0000-000000000000'),
+    NULLIF(sqlc.arg(actor_id)::UUID, '00000000-0000-0000-0000-000000000000'),
+    NULLIF(sqlc.arg(transfer_id)::UUID, '00000000-0000-0000-0000-000000000000'),
+    sqlc.narg(note)
+FROM updated
+RETURNING id, product_id, delta, resulting_quantity, reason, order_id, actor_id, note, created_at, location_id, transfer_id;
+
+-- name: SetProductStock :one
+-- Sets a product's stock at a location to an absolute quantity and records the difference in the ledger, in one statement.
+-- The level is locked so the delta is computed against the current stock, not a stale read.
+-- Returns no rows if the stock level does not exist or the quantity is unchanged.
+WITH current_level AS (
+    SELECT psl.product_id, psl.location_id, psl.quantity
+    FROM product_stock_levels psl
+    WHERE psl.product_id = sqlc.arg(product_id) AND psl.location_id = sqlc.arg(location_id)
+    FOR UPDATE
+),
+updated AS (
+    UPDATE product_stock_levels psl
+    SET quantity = sqlc.arg(new_quantity)::INT, updated_at = NOW()
+    FROM current_level cl
+    WHERE psl.product_id = cl.product_id AND psl.location_id = cl.location_id AND cl.quantity <> sqlc.arg(new_quantity)::INT
+    RETURNING psl.product_id, psl.location_id, psl.quantity, cl.quantity AS previous_quantity
+)
+INSERT INTO stock_movements (product_id, location_id, delta, resulting_quantity, reason, actor_id, note)
+SELECT
+    updated.product_id,
+    updated.location_id,
+    updated.quantity - updated.previous_quantity,
+    updated.quantity,
+    sqlc.arg(reason),
+    NULLIF(sqlc.arg(actor_id)::UUID, '00000000-0000-0000-0000-000000000000'),
+    sqlc.narg(note)
+FROM updated
+RETURNING id, product_id, delta, resulting_quantity, reason, order_id, actor_id, note, created_at, location_id, transfer_id;
+
+-- name: ListStockMovementsByProduct :many
+-- Lists the ledger entries of a product, newest first, with the location and the acting admin's email.
+SELECT
+    sm.id,
+    sm.product_id,
+    sm.location_id,
+    sl.code AS location_code,
+    sm.delta,
+    sm.resulting_quantity,
+    sm.reason,
+    sm.order_id,
+    sm.transfer_id,
+    sm.actor_id,
+    u.email AS actor_email,
+    sm.note,
+    sm.created_at
+FROM stock_movements sm
+JOIN stock_locations sl ON sm.location_id = sl.id
+LEFT JOIN users u ON sm.actor_id = u.id
+WHERE sm.product_id = sqlc.arg(product_id)
+ORDER BY sm.created_at DESC, sm.id DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountStockMovementsByProduct :one
+SELECT COUNT(*) FROM stock_movements WHERE product_id = sqlc.arg(product_id);
+
+-- name: GetProductStockReconciliation :many
+-- Compares a product's stock at each location with the sum of its ledger movements there.
+SELECT
+    p.id AS product_id,
+    p.name AS product_name,
+    sl.id AS location_id,
+    sl.code AS location_code,
+    psl.quantity,
+    COALESCE(SUM(sm.delta), 0)::BIGINT AS ledger_quantity
+FROM product_stock_levels psl
+JOIN products p ON p.id = psl.product_id
+JOIN stock_locations sl ON sl.id = psl.location_id
+LEFT JOIN stock_movements sm ON sm.product_id = psl.product_id AND sm.location_id = psl.location_id
+WHERE psl.product_id = sqlc.arg(product_id)
+GROUP BY p.id, p.name, sl.id, sl.code, sl.priority, psl.quantity
+ORDER BY sl.priority, sl.code;
+
+-- name: ListStockDiscrepancies :many
+-- Lists every product stock level that does not match the sum of its ledger movements.
+SELECT
+    p.id AS product_id,
+    p.name AS product_name,
+    sl.id AS location_id,
+    sl.code AS location_code,
+    psl.quantity,
+    COALESCE(SUM(sm.delta), 0)::BIGINT AS ledger_quantity
+FROM product_stock_levels psl
+JOIN products p ON p.id = psl.product_id
+JOIN stock_locations sl ON sl.id = psl.location_id
+LEFT JOIN stock_movements sm ON sm.product_id = psl.product_id AND sm.location_id = psl.location_id
+GROUP BY p.id, p.name, sl.id, sl.code, psl.quantity
+HAVING psl.quantity <> COALESCE(SUM(sm.delta), 0)
+ORDER BY p.name, sl.code;
@@ -0,0 +1,49 @@
+-- name: UpsertPendingUserTOTP :execrows
+-- Starts (or restarts) TOTP enrolment with a new secret.
+-- Affects no rows if the user has already confirmed 2FA.
+INSERT INTO user_totp (user_id, secret)
+VALUES (sqlc.arg(user_id), sqlc.arg(secret))
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret, last_used_step = 0, created_at = NOW()
+WHERE user_totp.confirmed_at IS NULL;
+
+-- name: GetUserTOTP :one
+SELECT user_id, secret, confirmed_at, last_used_step, created_at
+FROM user_totp
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: ConfirmUserTOTP :execrows
+-- Enables 2FA once the first code has been verified.
+UPDATE user_totp
+SET confirmed_at = NOW(), last_used_step = sqlc.arg(step)
+WHERE user_id = sqlc.arg(user_id) AND confirmed_at IS NULL;
+
+-- name: UseUserTOTPStep :execrows
+-- Records the time step of an accepted code. Affects no rows if a code of that step or a later one
+-- was already used, so concurrent requests cannot replay the same code.
+UPDATE user_totp
+SET last_used_step = sqlc.arg(step)
+WHERE user_id = sqlc.arg(user_id) AND last_used_step < sqlc.arg(step);
+
+-- name: DeleteUserTOTP :exec
+DELETE FROM user_totp
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: CreateUserRecoveryCode :exec
+INSERT INTO user_recovery_codes (user_id, code_hash)
+VALUES (sqlc.arg(user_id), sqlc.arg(code_hash));
+
+-- name: DeleteUserRecoveryCodes :exec
+DELETE FROM user_recovery_codes
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: UseUserRecoveryCode :execrows
+-- Consumes a recovery code. Affects no rows if the code does not exist or was already used.
+UPDATE user_recovery_codes
+SET used_at = NOW()
+WHERE user_id = sqlc.arg(user_id) AND code_hash = sqlc.arg(code_hash) AND used_at IS NULL;
+
+-- name: CountUnusedRecoveryCodes :one
+SELECT COUNT(*)
+FROM user_recovery_codes
+WHERE user_id = sqlc.arg(user_id) AND used_at IS NULL;
@@ -177,3 +177,16 @@ WHERE id = @user_id::uuid;
 UPDATE users
 SET deleted_at = NULL, updated_at = NOW()
 WHERE id = @user_id::uuid;
+
+-- name: GetUserTokenVersion :one
+-- Retrieves the token version of an active user. Access tokens issued with an older version are rejected.
+SELECT token_version
+FROM users
+WHERE id = sqlc.arg(id) AND deleted_at IS NULL;
+
+-- name: BumpUserTokenVersion :one
+-- Increments the token version of a user, invalidating their outstanding access tokens.
+UPDATE users
+SET token_version = token_version + 1, updated_at = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING token_version;
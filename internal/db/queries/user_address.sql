@@ -0,0 +1,64 @@
+-- name: ListUserAddresses :many
+-- Lists a user's addresses, the default one first.
+SELECT id, user_id, label, full_name, phone_number_1, phone_number_2, province, city, is_default, created_at, updated_at
+FROM user_addresses
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY is_default DESC, created_at DESC;
+
+-- name: GetUserAddress :one
+SELECT id, user_id, label, full_name, phone_number_1, phone_number_2, province, city, is_default, created_at, updated_at
+FROM user_addresses
+WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(user_id);
+
+-- name: CountUserAddresses :one
+SELECT COUNT(*) FROM user_addresses WHERE user_id = sqlc.arg(user_id);
+
+-- name: CreateUserAddress :one
+INSERT INTO user_addresses (user_id, label, full_name, phone_number_1, phone_number_2, province, city, is_default)
+VALUES (
+    sqlc.arg(user_id), sqlc.narg(label), sqlc.arg(full_name), sqlc.arg(phone_number_1), sqlc.narg(phone_number_2),
+    sqlc.arg(province), sqlc.arg(city), sqlc.arg(is_default)
+)
+RETURNING id, user_id, label, full_name, phone_number_1, phone_number_2, province, city, is_default, created_at, updated_at;
+
+-- name: UpdateUserAddress :one
+UPDATE user_addresses
+SET label = sqlc.narg(label),
+    full_name = sqlc.arg(full_name),
+    phone_number_1 = sqlc.arg(phone_number_1),
+    phone_number_2 = sqlc.narg(phone_number_2),
+    province = sqlc.arg(province),
+    city = sqlc.arg(city),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(user_id)
+RETURNING id, user_id, label, full_name, phone_number_1, phone_number_2, province, city, is_default, created_at, updated_at;
+
+-- name: DeleteUserAddress :one
+DELETE FROM user_addresses
+WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(user_id)
+RETURNING is_default;
+
+-- name: ClearDefaultUserAddress :exec
+UPDATE user_addresses
+SET is_default = FALSE, updated_at = NOW()
+WHERE user_id = sqlc.arg(user_id) AND is_default;
+
+-- name: SetDefaultUserAddress :execrows
+UPDATE user_addresses
+SET is_default = TRUE, updated_at = NOW()
+WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(user_id);
+
+-- name: PromoteLatestUserAddress :exec
+-- Makes the most recently created address the default, used when the default address is deleted.
+UPDATE user_addresses
+SET is_default = TRUE, updated_at = NOW()
+WHERE id = (
+    SELECT ua.id FROM user_addresses ua
+    WHERE ua.user_id = sqlc.arg(user_id)
+    ORDER BY ua.created_at DESC
+    LIMIT 1
+);
+
+-- name: LockUserAddresses :exec
+-- Serialises changes to a user's address book, so concurrent requests cannot both pick a default address.
+SELECT id FROM users WHERE id = sqlc.arg(user_id) FOR UPDATE;
@@ -0,0 +1,15 @@
+-- name: GetUserIdentity :one
+-- Finds the user an external identity is linked to, including deactivated users.
+SELECT ui.id, ui.user_id, ui.provider, ui.subject, u.deleted_at AS user_deleted_at
+FROM user_identities ui
+JOIN users u ON u.id = ui.user_id
+WHERE ui.provider = sqlc.arg(provider) AND ui.subject = sqlc.arg(subject);
+
+-- name: CreateUserIdentity :exec
+INSERT INTO user_identities (user_id, provider, subject, email, last_login_at)
+VALUES (sqlc.arg(user_id), sqlc.arg(provider), sqlc.arg(subject), sqlc.narg(email), NOW());
+
+-- name: TouchUserIdentity :exec
+UPDATE user_identities
+SET last_login_at = NOW()
+WHERE id = sqlc.arg(id);
@@ -0,0 +1,54 @@
+-- name: AddWishlistItem :exec
+-- Adds a product to a user's or guest's wishlist. Adding a product twice is a no-op.
+-- Guests pass the zero UUID as user_id, which is stored as NULL.
+INSERT INTO wishlist_items (user_id, session_id, product_id)
+VALUES (NULLIF(sqlc.arg(user_id)::UUID, '00000000-0000-0000-0000-000000000000'), sqlc.narg(session_id), sqlc.arg(product_id))
+ON CONFLICT DO NOTHING;
+
+-- name: ListWishlistItemsWithDiscounts :many
+-- Lists the wishlist of a user or guest with live prices (from v_products_with_calculated_discounts) and stock.
+SELECT
+    wi.id,
+    wi.product_id,
+    wi.created_at AS added_at,
+    p.name AS product_name,
+    p.slug AS product_slug,
+    p.brand AS product_brand,
+    p.image_urls AS product_image_urls,
+    p.status AS product_status,
+    p.stock_quantity AS product_stock_quantity,
+    p.price_cents AS original_price_cents,
+    COALESCE(vpcd.calculated_discounted_price_cents, p.price_cents)::BIGINT AS discounted_price_cents,
+    COALESCE(vpcd.has_active_discount, FALSE) AS has_active_discount
+FROM wishlist_items wi
+JOIN products p ON wi.product_id = p.id AND p.deleted_at IS NULL
+LEFT JOIN v_products_with_calculated_discounts vpcd ON p.id = vpcd.product_id
+WHERE wi.user_id = sqlc.narg(user_id) OR wi.session_id = sqlc.narg(session_id)
+ORDER BY wi.created_at DESC;
+
+-- name: WishlistItemExists :one
+-- Checks whether a product is on a user's or guest's wishlist.
+SELECT EXISTS(
+    SELECT 1 FROM wishlist_items
+    WHERE product_id = sqlc.arg(product_id)
+      AND (user_id = sqlc.narg(user_id) OR session_id = sqlc.narg(session_id))
+) AS exists;
+
+-- name: DeleteWishlistItem :execrows
+-- Removes a product from a user's or guest's wishlist.
+DELETE FROM wishlist_items
+WHERE product_id = sqlc.arg(product_id)
+  AND (user_id = sqlc.narg(user_id) OR session_id = sqlc.narg(session_id));
+
+-- name: MergeGuestWishlistIntoUserWishlist :execrows
+-- Moves every item of a guest wishlist to a user's wishlist in a single statement.
+-- Products already on the user's wishlist are dropped from the guest list without duplicating them.
+WITH moved AS (
+    DELETE FROM wishlist_items
+    WHERE wishlist_items.session_id = sqlc.arg(session_id)::TEXT
+    RETURNING wishlist_items.product_id, wishlist_items.created_at
+)
+INSERT INTO wishlist_items (user_id, product_id, created_at)
+SELECT sqlc.arg(user_id)::UUID, moved.product_id, moved.created_at
+FROM moved
+ON CONFLICT DO NOTHING;
@@ -22,8 +22,8 @@ func (q *Queries) CleanupExpiredRefreshTokens(ctx context.Context) error {
 }
 
 const createRefreshToken = `-- name: CreateRefreshToken :exec
-INSERT INTO refresh_tokens (jti, user_id, token_hash, expires_at)
-VALUES ($1::text, $2::uuid, $3::char(64), $4::timestamptz)
+INSERT INTO refresh_tokens (jti, user_id, token_hash, expires_at, family_id, user_agent, ip_address, mfa)
+VALUES ($1::text, $2::uuid, $3::char(64), $4::timestamptz, $5::uuid, $6::text, $7::text, $8::boolean)
 `
 
 type CreateRefreshTokenParams struct {
@@ -31,27 +31,51 @@ type CreateRefreshTokenParams struct {
 	UserID    uuid.UUID          `json:"user_id"`
 	TokenHash string             `json:"token_hash"`
 	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	FamilyID  uuid.UUID          `json:"family_id"`
+	UserAgent string             `json:"user_agent"`
+	IpAddress string             `json:"ip_address"`
+	Mfa       bool               `json:"mfa"`
 }
 
+// last_used_at starts at NOW(): a token is created when its session signs in or refreshes.
 func (q *Queries) CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) error {
 	_, err := q.db.Exec(ctx, createRefreshToken,
 		arg.Jti,
 		arg.UserID,
 		arg.TokenHash,
 		arg.ExpiresAt,
+		arg.FamilyID,
+		arg.UserAgent,
+		arg.IpAddress,
+		arg.Mfa,
 	)
 	return err
 }
 
-const getValidRefreshTokenRecord = `-- name: GetValidRefreshTokenRecord :one
-SELECT id, jti, user_id, token_hash, expires_at, revoked_at, created_at, updated_at
+const getRefreshTokenRecord = `-- name: GetRefreshTokenRecord :one
+SELECT id, jti, user_id, token_hash, expires_at, revoked_at, created_at, updated_at, family_id, revoked_reason, mfa
 FROM refresh_tokens
-WHERE jti = $1::text AND expires_at > NOW() AND revoked_at IS NULL
+WHERE jti = $1::text AND expires_at > NOW()
 `
 
-func (q *Queries) GetValidRefreshTokenRecord(ctx context.Context, jti string) (RefreshToken, error) {
-	row := q.db.QueryRow(ctx, getValidRefreshTokenRecord, jti)
-	var i RefreshToken
+type GetRefreshTokenRecordRow struct {
+	ID            int32              `json:"id"`
+	Jti           string             `json:"jti"`
+	UserID        uuid.UUID          `json:"user_id"`
+	TokenHash     string             `json:"token_hash"`
+	ExpiresAt     pgtype.Timestamptz `json:"expires_at"`
+	RevokedAt     pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	FamilyID      uuid.UUID          `json:"family_id"`
+	RevokedReason *string            `json:"revoked_reason"`
+	Mfa           bool               `json:"mfa"`
+}
+
+// Retrieves an unexpired refresh token, including revoked ones so that reuse can be detected.
+func (q *Queries) GetRefreshTokenRecord(ctx context.Context, jti string) (GetRefreshTokenRecordRow, error) {
+	row := q.db.QueryRow(ctx, getRefreshTokenRecord, jti)
+	var i GetRefreshTokenRecordRow
 	err := row.Scan(
 		&i.ID,
 		&i.Jti,
@@ -61,13 +85,68 @@ func (q *Queries) GetValidRefreshTokenRecord(ctx context.Context, jti string) (R
 		&i.RevokedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FamilyID,
+		&i.RevokedReason,
+		&i.Mfa,
 	)
 	return i, err
 }
 
+const listActiveSessionsByUserID = `-- name: ListActiveSessionsByUserID :many
+
+SELECT
+    rt.family_id,
+    rt.user_agent,
+    rt.ip_address,
+    rt.last_used_at,
+    rt.expires_at,
+    (SELECT MIN(f.created_at) FROM refresh_tokens f WHERE f.family_id = rt.family_id)::TIMESTAMPTZ AS signed_in_at
+FROM refresh_tokens rt
+WHERE rt.user_id = $1::uuid AND rt.revoked_at IS NULL AND rt.expires_at > NOW()
+ORDER BY rt.last_used_at DESC
+`
+
+type ListActiveSessionsByUserIDRow struct {
+	FamilyID   uuid.UUID          `json:"family_id"`
+	UserAgent  string             `json:"user_agent"`
+	IpAddress  string             `json:"ip_address"`
+	LastUsedAt pgtype.Timestamptz `json:"last_used_at"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	SignedInAt pgtype.Timestamptz `json:"signed_in_at"`
+}
+
+// Only revoke non-already-revoked tokens
+// Retrieves the live sessions (token families) of a user, most recently used first.
+func (q *Queries) ListActiveSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]ListActiveSessionsByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, listActiveSessionsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListActiveSessionsByUserIDRow
+	for rows.Next() {
+		var i ListActiveSessionsByUserIDRow
+		if err := rows.Scan(
+			&i.FamilyID,
+			&i.UserAgent,
+			&i.IpAddress,
+			&i.LastUsedAt,
+			&i.ExpiresAt,
+			&i.SignedInAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const revokeAllRefreshTokensByUserID = `-- name: RevokeAllRefreshTokensByUserID :exec
 UPDATE refresh_tokens
-SET revoked_at = NOW(), updated_at = NOW()
+SET revoked_at = NOW(), revoked_reason = 'revoked', updated_at = NOW()
 WHERE user_id = $1::uuid AND revoked_at IS NULL
 `
 
@@ -77,11 +156,96 @@ func (q *Queries) RevokeAllRefreshTokensByUserID(ctx context.Context, userID uui
 	return err
 }
 
+const revokeOtherUserSessions = `-- name: RevokeOtherUserSessions :many
+UPDATE refresh_tokens
+SET revoked_at = NOW(), revoked_reason = 'session_revoked', updated_at = NOW()
+WHERE user_id = $1::uuid AND family_id <> $2::uuid AND revoked_at IS NULL
+RETURNING family_id
+`
+
+type RevokeOtherUserSessionsParams struct {
+	UserID       uuid.UUID `json:"user_id"`
+	KeepFamilyID uuid.UUID `json:"keep_family_id"`
+}
+
+// Revokes every session of a user except the given one (pass the zero UUID to revoke all of them).
+// Returns the revoked sessions.
+func (q *Queries) RevokeOtherUserSessions(ctx context.Context, arg RevokeOtherUserSessionsParams) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, revokeOtherUserSessions, arg.UserID, arg.KeepFamilyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var family_id uuid.UUID
+		if err := rows.Scan(&family_id); err != nil {
+			return nil, err
+		}
+		items = append(items, family_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const revokeRefreshTokenByJTI = `-- name: RevokeRefreshTokenByJTI :exec
-UPDATE refresh_tokens SET revoked_at = NOW(), updated_at = NOW() WHERE jti = $1::text
+UPDATE refresh_tokens SET revoked_at = NOW(), revoked_reason = 'logout', updated_at = NOW() WHERE jti = $1::text
 `
 
 func (q *Queries) RevokeRefreshTokenByJTI(ctx context.Context, jti string) error {
 	_, err := q.db.Exec(ctx, revokeRefreshTokenByJTI, jti)
 	return err
 }
+
+const revokeRefreshTokenFamily = `-- name: RevokeRefreshTokenFamily :execrows
+UPDATE refresh_tokens
+SET revoked_at = NOW(), revoked_reason = 'reuse_detected', updated_at = NOW()
+WHERE family_id = $1::uuid AND revoked_at IS NULL
+`
+
+// Revokes every live token descending from the same login.
+func (q *Queries) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeRefreshTokenFamily, familyID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const revokeUserSession = `-- name: RevokeUserSession :execrows
+UPDATE refresh_tokens
+SET revoked_at = NOW(), revoked_reason = 'session_revoked', updated_at = NOW()
+WHERE user_id = $1::uuid AND family_id = $2::uuid AND revoked_at IS NULL
+`
+
+type RevokeUserSessionParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	FamilyID uuid.UUID `json:"family_id"`
+}
+
+// Revokes the live token of one session of a user.
+func (q *Queries) RevokeUserSession(ctx context.Context, arg RevokeUserSessionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeUserSession, arg.UserID, arg.FamilyID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const rotateRefreshToken = `-- name: RotateRefreshToken :execrows
+UPDATE refresh_tokens
+SET revoked_at = NOW(), revoked_reason = 'rotated', updated_at = NOW()
+WHERE jti = $1::text AND revoked_at IS NULL
+`
+
+// Revokes a refresh token that is being exchanged for its successor.
+// Affects no rows if the token was already revoked, e.g. by a concurrent refresh with the same token.
+func (q *Queries) RotateRefreshToken(ctx context.Context, jti string) (int64, error) {
+	result, err := q.db.Exec(ctx, rotateRefreshToken, jti)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -14,66 +14,224 @@ import (
 
 const calculateReviewStatsForProduct = `-- name: CalculateReviewStatsForProduct :one
 SELECT
-    AVG(r.rating)::NUMERIC(3,2) AS avg_rating,
+    (SUM(r.rating * CASE WHEN r.verified_purchase THEN $1::FLOAT8 ELSE 1 END)
+        / NULLIF(SUM(CASE WHEN r.verified_purchase THEN $1::FLOAT8 ELSE 1 END), 0))::NUMERIC(3,2) AS avg_rating,
     COUNT(r.rating)::INTEGER AS num_ratings
 FROM reviews r
-WHERE r.product_id = $1 AND r.deleted_at IS NULL
+WHERE r.product_id = $2 AND r.deleted_at IS NULL AND r.status = 'approved'
 `
 
+type CalculateReviewStatsForProductParams struct {
+	VerifiedWeight float64   `json:"verified_weight"`
+	ProductID      uuid.UUID `json:"product_id"`
+}
+
 type CalculateReviewStatsForProductRow struct {
 	AvgRating  pgtype.Numeric `json:"avg_rating"`
 	NumRatings int32          `json:"num_ratings"`
 }
 
-// Calculates the average rating and count of non-deleted reviews for a specific product.
+// Calculates the average rating and count of approved, non-deleted reviews for a specific product.
+// Verified-purchase reviews count verified_weight times in the average (1 = no weighting).
 // Used to update the products table.
-func (q *Queries) CalculateReviewStatsForProduct(ctx context.Context, productID uuid.UUID) (CalculateReviewStatsForProductRow, error) {
-	row := q.db.QueryRow(ctx, calculateReviewStatsForProduct, productID)
+func (q *Queries) CalculateReviewStatsForProduct(ctx context.Context, arg CalculateReviewStatsForProductParams) (CalculateReviewStatsForProductRow, error) {
+	row := q.db.QueryRow(ctx, calculateReviewStatsForProduct, arg.VerifiedWeight, arg.ProductID)
 	var i CalculateReviewStatsForProductRow
 	err := row.Scan(&i.AvgRating, &i.NumRatings)
 	return i, err
 }
 
+const countReviewsByProductID = `-- name: CountReviewsByProductID :one
+SELECT COUNT(*)::BIGINT AS total
+FROM reviews
+WHERE product_id = $1 AND deleted_at IS NULL AND status = 'approved'
+  AND ($2::INTEGER = 0 OR rating = $2::INTEGER)
+`
+
+type CountReviewsByProductIDParams struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Rating    int32     `json:"rating"`
+}
+
+// Counts the approved reviews for a specific product; rating = 0 means all star counts.
+func (q *Queries) CountReviewsByProductID(ctx context.Context, arg CountReviewsByProductIDParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countReviewsByProductID, arg.ProductID, arg.Rating)
+	var total int64
+	err := row.Scan(&total)
+	return total, err
+}
+
+const countReviewsForModeration = `-- name: CountReviewsForModeration :one
+SELECT COUNT(*)::BIGINT AS total
+FROM reviews r
+WHERE r.deleted_at IS NULL
+  AND ($1::TEXT = '' OR r.status = $1::TEXT)
+  AND (NOT $2::BOOLEAN OR EXISTS (
+      SELECT 1 FROM review_reports rr WHERE rr.review_id = r.id AND rr.resolved_at IS NULL
+  ))
+`
+
+type CountReviewsForModerationParams struct {
+	Status       string `json:"status"`
+	ReportedOnly bool   `json:"reported_only"`
+}
+
+// Counts the reviews in the admin moderation queue, optionally filtered by status (empty = all).
+func (q *Queries) CountReviewsForModeration(ctx context.Context, arg CountReviewsForModerationParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countReviewsForModeration, arg.Status, arg.ReportedOnly)
+	var total int64
+	err := row.Scan(&total)
+	return total, err
+}
+
 const createReview = `-- name: CreateReview :one
 INSERT INTO reviews (
-    user_id, product_id, rating
+    user_id, product_id, rating, title, body, pros, cons, status, verified_purchase
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
 )
-RETURNING id, user_id, product_id, rating, created_at, updated_at
+RETURNING id, user_id, product_id, rating, title, body, pros, cons, status, moderation_reason, verified_purchase, created_at, updated_at
 `
 
 type CreateReviewParams struct {
-	UserID    uuid.UUID `json:"user_id"`
-	ProductID uuid.UUID `json:"product_id"`
-	Rating    int32     `json:"rating"`
+	UserID           uuid.UUID `json:"user_id"`
+	ProductID        uuid.UUID `json:"product_id"`
+	Rating           int32     `json:"rating"`
+	Title            *string   `json:"title"`
+	Body             *string   `json:"body"`
+	Pros             *string   `json:"pros"`
+	Cons             *string   `json:"cons"`
+	Status           string    `json:"status"`
+	VerifiedPurchase bool      `json:"verified_purchase"`
 }
 
 type CreateReviewRow struct {
-	ID        uuid.UUID          `json:"id"`
-	UserID    uuid.UUID          `json:"user_id"`
-	ProductID uuid.UUID          `json:"product_id"`
-	Rating    int32              `json:"rating"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	ID               uuid.UUID          `json:"id"`
+	UserID           uuid.UUID          `json:"user_id"`
+	ProductID        uuid.UUID          `json:"product_id"`
+	Rating           int32              `json:"rating"`
+	Title            *string            `json:"title"`
+	Body             *string            `json:"body"`
+	Pros             *string            `json:"pros"`
+	Cons             *string            `json:"cons"`
+	Status           string             `json:"status"`
+	ModerationReason *string            `json:"moderation_reason"`
+	VerifiedPurchase bool               `json:"verified_purchase"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
 }
 
 // Inserts a new review and returns its details.
 // NOTE: This query alone does not update the product's avg_rating/num_ratings.
 func (q *Queries) CreateReview(ctx context.Context, arg CreateReviewParams) (CreateReviewRow, error) {
-	row := q.db.QueryRow(ctx, createReview, arg.UserID, arg.ProductID, arg.Rating)
+	row := q.db.QueryRow(ctx, createReview,
+		arg.UserID,
+		arg.ProductID,
+		arg.Rating,
+		arg.Title,
+		arg.Body,
+		arg.Pros,
+		arg.Cons,
+		arg.Status,
+		arg.VerifiedPurchase,
+	)
 	var i CreateReviewRow
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
 		&i.ProductID,
 		&i.Rating,
+		&i.Title,
+		&i.Body,
+		&i.Pros,
+		&i.Cons,
+		&i.Status,
+		&i.ModerationReason,
+		&i.VerifiedPurchase,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
+const createReviewImage = `-- name: CreateReviewImage :exec
+INSERT INTO review_images (review_id, url, position)
+VALUES ($1, $2, $3)
+`
+
+type CreateReviewImageParams struct {
+	ReviewID uuid.UUID `json:"review_id"`
+	Url      string    `json:"url"`
+	Position int32     `json:"position"`
+}
+
+// Attaches an uploaded image to a review.
+func (q *Queries) CreateReviewImage(ctx context.Context, arg CreateReviewImageParams) error {
+	_, err := q.db.Exec(ctx, createReviewImage, arg.ReviewID, arg.Url, arg.Position)
+	return err
+}
+
+const createReviewReply = `-- name: CreateReviewReply :one
+INSERT INTO review_replies (review_id, author_id, body)
+VALUES ($1, NULLIF($2::UUID, '00000000-0000-0000-0000-000000000000'), $3)
+RETURNING id, review_id, author_id, body, created_at, updated_at
+`
+
+type CreateReviewReplyParams struct {
+	ReviewID uuid.UUID `json:"review_id"`
+	AuthorID uuid.UUID `json:"author_id"`
+	Body     string    `json:"body"`
+}
+
+// Adds the official reply to a review.
+func (q *Queries) CreateReviewReply(ctx context.Context, arg CreateReviewReplyParams) (ReviewReply, error) {
+	row := q.db.QueryRow(ctx, createReviewReply, arg.ReviewID, arg.AuthorID, arg.Body)
+	var i ReviewReply
+	err := row.Scan(
+		&i.ID,
+		&i.ReviewID,
+		&i.AuthorID,
+		&i.Body,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createReviewReport = `-- name: CreateReviewReport :one
+INSERT INTO review_reports (review_id, user_id, reason)
+VALUES ($1, $2, $3)
+RETURNING id, review_id, user_id, reason, created_at
+`
+
+type CreateReviewReportParams struct {
+	ReviewID uuid.UUID `json:"review_id"`
+	UserID   uuid.UUID `json:"user_id"`
+	Reason   string    `json:"reason"`
+}
+
+type CreateReviewReportRow struct {
+	ID        uuid.UUID          `json:"id"`
+	ReviewID  uuid.UUID          `json:"review_id"`
+	UserID    uuid.UUID          `json:"user_id"`
+	Reason    string             `json:"reason"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+// Records an abuse report on a review.
+func (q *Queries) CreateReviewReport(ctx context.Context, arg CreateReviewReportParams) (CreateReviewReportRow, error) {
+	row := q.db.QueryRow(ctx, createReviewReport, arg.ReviewID, arg.UserID, arg.Reason)
+	var i CreateReviewReportRow
+	err := row.Scan(
+		&i.ID,
+		&i.ReviewID,
+		&i.UserID,
+		&i.Reason,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const deleteReview = `-- name: DeleteReview :one
 UPDATE reviews
 SET deleted_at = NOW(), updated_at = NOW()
@@ -111,6 +269,86 @@ func (q *Queries) DeleteReview(ctx context.Context, arg DeleteReviewParams) (Del
 	return i, err
 }
 
+const deleteReviewImages = `-- name: DeleteReviewImages :many
+DELETE FROM review_images
+WHERE review_id = $1
+RETURNING url
+`
+
+// Removes the images of a review and returns their URLs so the files can be deleted.
+func (q *Queries) DeleteReviewImages(ctx context.Context, reviewID uuid.UUID) ([]string, error) {
+	rows, err := q.db.Query(ctx, deleteReviewImages, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var url string
+		if err := rows.Scan(&url); err != nil {
+			return nil, err
+		}
+		items = append(items, url)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteReviewReply = `-- name: DeleteReviewReply :execrows
+DELETE FROM review_replies
+WHERE review_id = $1
+`
+
+// Removes the official reply to a review.
+func (q *Queries) DeleteReviewReply(ctx context.Context, reviewID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteReviewReply, reviewID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteReviewVote = `-- name: DeleteReviewVote :execrows
+DELETE FROM review_votes
+WHERE review_id = $1 AND user_id = $2
+`
+
+type DeleteReviewVoteParams struct {
+	ReviewID uuid.UUID `json:"review_id"`
+	UserID   uuid.UUID `json:"user_id"`
+}
+
+// Removes a user's vote on a review.
+func (q *Queries) DeleteReviewVote(ctx context.Context, arg DeleteReviewVoteParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteReviewVote, arg.ReviewID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getApprovedReview = `-- name: GetApprovedReview :one
+SELECT id, user_id, product_id
+FROM reviews
+WHERE id = $1 AND deleted_at IS NULL AND status = 'approved'
+`
+
+type GetApprovedReviewRow struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	ProductID uuid.UUID `json:"product_id"`
+}
+
+// Retrieves an approved, non-deleted review, for voting and reporting.
+func (q *Queries) GetApprovedReview(ctx context.Context, id uuid.UUID) (GetApprovedReviewRow, error) {
+	row := q.db.QueryRow(ctx, getApprovedReview, id)
+	var i GetApprovedReviewRow
+	err := row.Scan(&i.ID, &i.UserID, &i.ProductID)
+	return i, err
+}
+
 const getReviewByIDAndUser = `-- name: GetReviewByIDAndUser :one
 SELECT id, user_id, product_id, rating, created_at, updated_at
 FROM reviews
@@ -181,41 +419,211 @@ func (q *Queries) GetReviewByUserAndProduct(ctx context.Context, arg GetReviewBy
 	return i, err
 }
 
+const getReviewForModeration = `-- name: GetReviewForModeration :one
+SELECT
+    r.id,
+    r.user_id,
+    r.product_id,
+    r.rating,
+    r.title,
+    r.body,
+    r.pros,
+    r.cons,
+    r.status,
+    r.moderation_reason,
+    r.verified_purchase,
+    r.helpful_count,
+    r.unhelpful_count,
+    r.moderated_by,
+    r.moderated_at,
+    r.created_at,
+    r.updated_at,
+    u.full_name AS reviewer_name,
+    u.email AS reviewer_email,
+    p.name AS product_name,
+    (SELECT COUNT(*) FROM review_reports rr WHERE rr.review_id = r.id AND rr.resolved_at IS NULL)::BIGINT AS open_report_count
+FROM reviews r
+JOIN users u ON r.user_id = u.id
+JOIN products p ON r.product_id = p.id
+WHERE r.id = $1 AND r.deleted_at IS NULL
+`
+
+type GetReviewForModerationRow struct {
+	ID               uuid.UUID          `json:"id"`
+	UserID           uuid.UUID          `json:"user_id"`
+	ProductID        uuid.UUID          `json:"product_id"`
+	Rating           int32              `json:"rating"`
+	Title            *string            `json:"title"`
+	Body             *string            `json:"body"`
+	Pros             *string            `json:"pros"`
+	Cons             *string            `json:"cons"`
+	Status           string             `json:"status"`
+	ModerationReason *string            `json:"moderation_reason"`
+	VerifiedPurchase bool               `json:"verified_purchase"`
+	HelpfulCount     int32              `json:"helpful_count"`
+	UnhelpfulCount   int32              `json:"unhelpful_count"`
+	ModeratedBy      uuid.UUID          `json:"moderated_by"`
+	ModeratedAt      pgtype.Timestamptz `json:"moderated_at"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	ReviewerName     *string            `json:"reviewer_name"`
+	ReviewerEmail    string             `json:"reviewer_email"`
+	ProductName      string             `json:"product_name"`
+	OpenReportCount  int64              `json:"open_report_count"`
+}
+
+// Retrieves a single review with its author and product, for moderation.
+func (q *Queries) GetReviewForModeration(ctx context.Context, id uuid.UUID) (GetReviewForModerationRow, error) {
+	row := q.db.QueryRow(ctx, getReviewForModeration, id)
+	var i GetReviewForModerationRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProductID,
+		&i.Rating,
+		&i.Title,
+		&i.Body,
+		&i.Pros,
+		&i.Cons,
+		&i.Status,
+		&i.ModerationReason,
+		&i.VerifiedPurchase,
+		&i.HelpfulCount,
+		&i.UnhelpfulCount,
+		&i.ModeratedBy,
+		&i.ModeratedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ReviewerName,
+		&i.ReviewerEmail,
+		&i.ProductName,
+		&i.OpenReportCount,
+	)
+	return i, err
+}
+
+const getReviewRatingDistribution = `-- name: GetReviewRatingDistribution :many
+SELECT rating, COUNT(*)::BIGINT AS count
+FROM reviews
+WHERE product_id = $1 AND deleted_at IS NULL AND status = 'approved'
+GROUP BY rating
+ORDER BY rating
+`
+
+type GetReviewRatingDistributionRow struct {
+	Rating int32 `json:"rating"`
+	Count  int64 `json:"count"`
+}
+
+// Counts the approved reviews of a product per star rating. Ratings without reviews are omitted.
+func (q *Queries) GetReviewRatingDistribution(ctx context.Context, productID uuid.UUID) ([]GetReviewRatingDistributionRow, error) {
+	rows, err := q.db.Query(ctx, getReviewRatingDistribution, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReviewRatingDistributionRow
+	for rows.Next() {
+		var i GetReviewRatingDistributionRow
+		if err := rows.Scan(&i.Rating, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReviewReplyRecipient = `-- name: GetReviewReplyRecipient :one
+SELECT
+    u.email AS reviewer_email,
+    p.name AS product_name,
+    p.slug AS product_slug
+FROM reviews r
+JOIN users u ON r.user_id = u.id
+JOIN products p ON r.product_id = p.id
+WHERE r.id = $1 AND r.deleted_at IS NULL
+`
+
+type GetReviewReplyRecipientRow struct {
+	ReviewerEmail string `json:"reviewer_email"`
+	ProductName   string `json:"product_name"`
+	ProductSlug   string `json:"product_slug"`
+}
+
+// Retrieves what is needed to tell a reviewer their review was answered.
+func (q *Queries) GetReviewReplyRecipient(ctx context.Context, id uuid.UUID) (GetReviewReplyRecipientRow, error) {
+	row := q.db.QueryRow(ctx, getReviewReplyRecipient, id)
+	var i GetReviewReplyRecipientRow
+	err := row.Scan(&i.ReviewerEmail, &i.ProductName, &i.ProductSlug)
+	return i, err
+}
+
 const getReviewsByProductID = `-- name: GetReviewsByProductID :many
 SELECT 
     r.id,
     r.user_id,
     r.product_id,
     r.rating,
+    r.title,
+    r.body,
+    r.pros,
+    r.cons,
+    r.verified_purchase,
+    r.helpful_count,
+    r.unhelpful_count,
     r.created_at,
     r.updated_at,
     u.full_name AS reviewer_name 
 FROM reviews r
 JOIN users u ON r.user_id = u.id -- INNER JOIN to link review to user
-WHERE r.product_id = $1 AND r.deleted_at IS NULL
-ORDER BY r.created_at DESC -- Or rating DESC, etc.
-LIMIT $3 OFFSET $2
+WHERE r.product_id = $1 AND r.deleted_at IS NULL AND r.status = 'approved'
+  AND ($2::INTEGER = 0 OR r.rating = $2::INTEGER)
+ORDER BY
+    CASE WHEN $3::TEXT = 'most_helpful' THEN r.helpful_count - r.unhelpful_count END DESC,
+    CASE WHEN $3::TEXT = 'highest' THEN r.rating END DESC,
+    CASE WHEN $3::TEXT = 'lowest' THEN r.rating END ASC,
+    r.created_at DESC
+LIMIT $5 OFFSET $4
 `
 
 type GetReviewsByProductIDParams struct {
 	ProductID  uuid.UUID `json:"product_id"`
+	Rating     int32     `json:"rating"`
+	Sort       string    `json:"sort"`
 	PageOffset int32     `json:"page_offset"`
 	PageLimit  int32     `json:"page_limit"`
 }
 
 type GetReviewsByProductIDRow struct {
-	ID           uuid.UUID          `json:"id"`
-	UserID       uuid.UUID          `json:"user_id"`
-	ProductID    uuid.UUID          `json:"product_id"`
-	Rating       int32              `json:"rating"`
-	CreatedAt    pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
-	ReviewerName *string            `json:"reviewer_name"`
+	ID               uuid.UUID          `json:"id"`
+	UserID           uuid.UUID          `json:"user_id"`
+	ProductID        uuid.UUID          `json:"product_id"`
+	Rating           int32              `json:"rating"`
+	Title            *string            `json:"title"`
+	Body             *string            `json:"body"`
+	Pros             *string            `json:"pros"`
+	Cons             *string            `json:"cons"`
+	VerifiedPurchase bool               `json:"verified_purchase"`
+	HelpfulCount     int32              `json:"helpful_count"`
+	UnhelpfulCount   int32              `json:"unhelpful_count"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	ReviewerName     *string            `json:"reviewer_name"`
 }
 
-// Retrieves all reviews for a specific product, including the reviewer's name, potentially paginated.
+// Retrieves the approved reviews for a specific product, including the reviewer's name, potentially paginated.
+// sort is one of 'most_helpful', 'highest', 'lowest' or 'newest' (default); rating = 0 means all star counts.
 func (q *Queries) GetReviewsByProductID(ctx context.Context, arg GetReviewsByProductIDParams) ([]GetReviewsByProductIDRow, error) {
-	rows, err := q.db.Query(ctx, getReviewsByProductID, arg.ProductID, arg.PageOffset, arg.PageLimit)
+	rows, err := q.db.Query(ctx, getReviewsByProductID,
+		arg.ProductID,
+		arg.Rating,
+		arg.Sort,
+		arg.PageOffset,
+		arg.PageLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -228,6 +636,13 @@ func (q *Queries) GetReviewsByProductID(ctx context.Context, arg GetReviewsByPro
 			&i.UserID,
 			&i.ProductID,
 			&i.Rating,
+			&i.Title,
+			&i.Body,
+			&i.Pros,
+			&i.Cons,
+			&i.VerifiedPurchase,
+			&i.HelpfulCount,
+			&i.UnhelpfulCount,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ReviewerName,
@@ -248,6 +663,13 @@ SELECT
     r.user_id,
     r.product_id,
     r.rating,
+    r.title,
+    r.body,
+    r.pros,
+    r.cons,
+    r.status,
+    r.moderation_reason,
+    r.verified_purchase,
     r.created_at,
     r.updated_at,
     p.name AS product_name -- Join with products table to get the name
@@ -265,16 +687,24 @@ type GetReviewsByUserIDParams struct {
 }
 
 type GetReviewsByUserIDRow struct {
-	ID          uuid.UUID          `json:"id"`
-	UserID      uuid.UUID          `json:"user_id"`
-	ProductID   uuid.UUID          `json:"product_id"`
-	Rating      int32              `json:"rating"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
-	ProductName string             `json:"product_name"`
+	ID               uuid.UUID          `json:"id"`
+	UserID           uuid.UUID          `json:"user_id"`
+	ProductID        uuid.UUID          `json:"product_id"`
+	Rating           int32              `json:"rating"`
+	Title            *string            `json:"title"`
+	Body             *string            `json:"body"`
+	Pros             *string            `json:"pros"`
+	Cons             *string            `json:"cons"`
+	Status           string             `json:"status"`
+	ModerationReason *string            `json:"moderation_reason"`
+	VerifiedPurchase bool               `json:"verified_purchase"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	ProductName      string             `json:"product_name"`
 }
 
 // Retrieves all reviews submitted by a specific user, including the product name, potentially paginated.
+// The author sees their reviews whatever their moderation status.
 func (q *Queries) GetReviewsByUserID(ctx context.Context, arg GetReviewsByUserIDParams) ([]GetReviewsByUserIDRow, error) {
 	rows, err := q.db.Query(ctx, getReviewsByUserID, arg.UserID, arg.PageOffset, arg.PageLimit)
 	if err != nil {
@@ -289,6 +719,13 @@ func (q *Queries) GetReviewsByUserID(ctx context.Context, arg GetReviewsByUserID
 			&i.UserID,
 			&i.ProductID,
 			&i.Rating,
+			&i.Title,
+			&i.Body,
+			&i.Pros,
+			&i.Cons,
+			&i.Status,
+			&i.ModerationReason,
+			&i.VerifiedPurchase,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ProductName,
@@ -303,6 +740,365 @@ func (q *Queries) GetReviewsByUserID(ctx context.Context, arg GetReviewsByUserID
 	return items, nil
 }
 
+const hasUserPurchasedProduct = `-- name: HasUserPurchasedProduct :one
+SELECT EXISTS (
+    SELECT 1
+    FROM orders o
+    JOIN order_items oi ON oi.order_id = o.id
+    WHERE o.user_id = $1 AND oi.product_id = $2 AND o.status = 'delivered'
+)::BOOLEAN AS purchased
+`
+
+type HasUserPurchasedProductParams struct {
+	UserID    uuid.UUID `json:"user_id"`
+	ProductID uuid.UUID `json:"product_id"`
+}
+
+// Checks whether the user has a delivered order containing the product.
+func (q *Queries) HasUserPurchasedProduct(ctx context.Context, arg HasUserPurchasedProductParams) (bool, error) {
+	row := q.db.QueryRow(ctx, hasUserPurchasedProduct, arg.UserID, arg.ProductID)
+	var purchased bool
+	err := row.Scan(&purchased)
+	return purchased, err
+}
+
+const listOpenReviewReports = `-- name: ListOpenReviewReports :many
+SELECT rr.id, rr.user_id, rr.reason, rr.created_at, u.email AS reporter_email
+FROM review_reports rr
+JOIN users u ON rr.user_id = u.id
+WHERE rr.review_id = $1 AND rr.resolved_at IS NULL
+ORDER BY rr.created_at
+`
+
+type ListOpenReviewReportsRow struct {
+	ID            uuid.UUID          `json:"id"`
+	UserID        uuid.UUID          `json:"user_id"`
+	Reason        string             `json:"reason"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	ReporterEmail string             `json:"reporter_email"`
+}
+
+// Lists the open abuse reports of a review, oldest first.
+func (q *Queries) ListOpenReviewReports(ctx context.Context, reviewID uuid.UUID) ([]ListOpenReviewReportsRow, error) {
+	rows, err := q.db.Query(ctx, listOpenReviewReports, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOpenReviewReportsRow
+	for rows.Next() {
+		var i ListOpenReviewReportsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Reason,
+			&i.CreatedAt,
+			&i.ReporterEmail,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReviewImagesByReviewIDs = `-- name: ListReviewImagesByReviewIDs :many
+SELECT review_id, url
+FROM review_images
+WHERE review_id = ANY($1::UUID[])
+ORDER BY review_id, position
+`
+
+type ListReviewImagesByReviewIDsRow struct {
+	ReviewID uuid.UUID `json:"review_id"`
+	Url      string    `json:"url"`
+}
+
+// Retrieves the images of several reviews, in display order.
+func (q *Queries) ListReviewImagesByReviewIDs(ctx context.Context, reviewIds []uuid.UUID) ([]ListReviewImagesByReviewIDsRow, error) {
+	rows, err := q.db.Query(ctx, listReviewImagesByReviewIDs, reviewIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListReviewImagesByReviewIDsRow
+	for rows.Next() {
+		var i ListReviewImagesByReviewIDsRow
+		if err := rows.Scan(&i.ReviewID, &i.Url); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReviewRepliesByReviewIDs = `-- name: ListReviewRepliesByReviewIDs :many
+SELECT id, review_id, author_id, body, created_at, updated_at
+FROM review_replies
+WHERE review_id = ANY($1::UUID[])
+`
+
+// Retrieves the official replies of several reviews.
+func (q *Queries) ListReviewRepliesByReviewIDs(ctx context.Context, reviewIds []uuid.UUID) ([]ReviewReply, error) {
+	rows, err := q.db.Query(ctx, listReviewRepliesByReviewIDs, reviewIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ReviewReply
+	for rows.Next() {
+		var i ReviewReply
+		if err := rows.Scan(
+			&i.ID,
+			&i.ReviewID,
+			&i.AuthorID,
+			&i.Body,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReviewsForModeration = `-- name: ListReviewsForModeration :many
+SELECT
+    r.id,
+    r.user_id,
+    r.product_id,
+    r.rating,
+    r.title,
+    r.body,
+    r.pros,
+    r.cons,
+    r.status,
+    r.moderation_reason,
+    r.verified_purchase,
+    r.helpful_count,
+    r.unhelpful_count,
+    r.moderated_by,
+    r.moderated_at,
+    r.created_at,
+    r.updated_at,
+    u.full_name AS reviewer_name,
+    u.email AS reviewer_email,
+    p.name AS product_name,
+    (SELECT COUNT(*) FROM review_reports rr WHERE rr.review_id = r.id AND rr.resolved_at IS NULL)::BIGINT AS open_report_count
+FROM reviews r
+JOIN users u ON r.user_id = u.id
+JOIN products p ON r.product_id = p.id
+WHERE r.deleted_at IS NULL
+  AND ($1::TEXT = '' OR r.status = $1::TEXT)
+  AND (NOT $2::BOOLEAN OR EXISTS (
+      SELECT 1 FROM review_reports rr WHERE rr.review_id = r.id AND rr.resolved_at IS NULL
+  ))
+ORDER BY r.created_at ASC
+LIMIT $4 OFFSET $3
+`
+
+type ListReviewsForModerationParams struct {
+	Status       string `json:"status"`
+	ReportedOnly bool   `json:"reported_only"`
+	PageOffset   int32  `json:"page_offset"`
+	PageLimit    int32  `json:"page_limit"`
+}
+
+type ListReviewsForModerationRow struct {
+	ID               uuid.UUID          `json:"id"`
+	UserID           uuid.UUID          `json:"user_id"`
+	ProductID        uuid.UUID          `json:"product_id"`
+	Rating           int32              `json:"rating"`
+	Title            *string            `json:"title"`
+	Body             *string            `json:"body"`
+	Pros             *string            `json:"pros"`
+	Cons             *string            `json:"cons"`
+	Status           string             `json:"status"`
+	ModerationReason *string            `json:"moderation_reason"`
+	VerifiedPurchase bool               `json:"verified_purchase"`
+	HelpfulCount     int32              `json:"helpful_count"`
+	UnhelpfulCount   int32              `json:"unhelpful_count"`
+	ModeratedBy      uuid.UUID          `json:"moderated_by"`
+	ModeratedAt      pgtype.Timestamptz `json:"moderated_at"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	ReviewerName     *string            `json:"reviewer_name"`
+	ReviewerEmail    string             `json:"reviewer_email"`
+	ProductName      string             `json:"product_name"`
+	OpenReportCount  int64              `json:"open_report_count"`
+}
+
+// Retrieves reviews for the admin moderation queue, oldest first, optionally filtered by status (empty = all)
+// and to reviews with open abuse reports.
+func (q *Queries) ListReviewsForModeration(ctx context.Context, arg ListReviewsForModerationParams) ([]ListReviewsForModerationRow, error) {
+	rows, err := q.db.Query(ctx, listReviewsForModeration,
+		arg.Status,
+		arg.ReportedOnly,
+		arg.PageOffset,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListReviewsForModerationRow
+	for rows.Next() {
+		var i ListReviewsForModerationRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProductID,
+			&i.Rating,
+			&i.Title,
+			&i.Body,
+			&i.Pros,
+			&i.Cons,
+			&i.Status,
+			&i.ModerationReason,
+			&i.VerifiedPurchase,
+			&i.HelpfulCount,
+			&i.UnhelpfulCount,
+			&i.ModeratedBy,
+			&i.ModeratedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ReviewerName,
+			&i.ReviewerEmail,
+			&i.ProductName,
+			&i.OpenReportCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markReviewsVerifiedForOrder = `-- name: MarkReviewsVerifiedForOrder :many
+UPDATE reviews r
+SET verified_purchase = TRUE
+FROM orders o
+JOIN order_items oi ON oi.order_id = o.id
+WHERE o.id = $1
+  AND r.user_id = o.user_id
+  AND r.product_id = oi.product_id
+  AND r.deleted_at IS NULL
+  AND NOT r.verified_purchase
+RETURNING r.product_id
+`
+
+// Flags the user's existing reviews of the products in a delivered order as verified purchases.
+// Returns the affected product IDs so their stats can be recalculated.
+func (q *Queries) MarkReviewsVerifiedForOrder(ctx context.Context, orderID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, markReviewsVerifiedForOrder, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var product_id uuid.UUID
+		if err := rows.Scan(&product_id); err != nil {
+			return nil, err
+		}
+		items = append(items, product_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const moderateReview = `-- name: ModerateReview :one
+UPDATE reviews
+SET
+    status = $1,
+    moderation_reason = $2,
+    moderated_by = NULLIF($3::UUID, '00000000-0000-0000-0000-000000000000'),
+    moderated_at = NOW()
+WHERE id = $4 AND deleted_at IS NULL
+RETURNING id, product_id, status
+`
+
+type ModerateReviewParams struct {
+	Status           string    `json:"status"`
+	ModerationReason *string   `json:"moderation_reason"`
+	ModeratedBy      uuid.UUID `json:"moderated_by"`
+	ID               uuid.UUID `json:"id"`
+}
+
+type ModerateReviewRow struct {
+	ID        uuid.UUID `json:"id"`
+	ProductID uuid.UUID `json:"product_id"`
+	Status    string    `json:"status"`
+}
+
+// Sets the moderation status of a review.
+// NOTE: This query alone does not update the product's avg_rating/num_ratings.
+func (q *Queries) ModerateReview(ctx context.Context, arg ModerateReviewParams) (ModerateReviewRow, error) {
+	row := q.db.QueryRow(ctx, moderateReview,
+		arg.Status,
+		arg.ModerationReason,
+		arg.ModeratedBy,
+		arg.ID,
+	)
+	var i ModerateReviewRow
+	err := row.Scan(&i.ID, &i.ProductID, &i.Status)
+	return i, err
+}
+
+const refreshReviewVoteCounts = `-- name: RefreshReviewVoteCounts :one
+UPDATE reviews
+SET
+    helpful_count = (SELECT COUNT(*) FROM review_votes v WHERE v.review_id = reviews.id AND v.is_helpful),
+    unhelpful_count = (SELECT COUNT(*) FROM review_votes v WHERE v.review_id = reviews.id AND NOT v.is_helpful)
+WHERE id = $1
+RETURNING helpful_count, unhelpful_count
+`
+
+type RefreshReviewVoteCountsRow struct {
+	HelpfulCount   int32 `json:"helpful_count"`
+	UnhelpfulCount int32 `json:"unhelpful_count"`
+}
+
+// Recalculates the helpful/unhelpful totals of a review from its votes.
+func (q *Queries) RefreshReviewVoteCounts(ctx context.Context, id uuid.UUID) (RefreshReviewVoteCountsRow, error) {
+	row := q.db.QueryRow(ctx, refreshReviewVoteCounts, id)
+	var i RefreshReviewVoteCountsRow
+	err := row.Scan(&i.HelpfulCount, &i.UnhelpfulCount)
+	return i, err
+}
+
+const resolveReviewReports = `-- name: ResolveReviewReports :exec
+UPDATE review_reports
+SET resolved_at = NOW(), resolved_by = NULLIF($1::UUID, '00000000-0000-0000-0000-000000000000')
+WHERE review_id = $2 AND resolved_at IS NULL
+`
+
+type ResolveReviewReportsParams struct {
+	ResolvedBy uuid.UUID `json:"resolved_by"`
+	ReviewID   uuid.UUID `json:"review_id"`
+}
+
+// Closes the open abuse reports of a review once a moderator has decided on it.
+func (q *Queries) ResolveReviewReports(ctx context.Context, arg ResolveReviewReportsParams) error {
+	_, err := q.db.Exec(ctx, resolveReviewReports, arg.ResolvedBy, arg.ReviewID)
+	return err
+}
+
 const updateProductReviewStats = `-- name: UpdateProductReviewStats :exec
 UPDATE products
 SET
@@ -326,38 +1122,125 @@ func (q *Queries) UpdateProductReviewStats(ctx context.Context, arg UpdateProduc
 
 const updateReview = `-- name: UpdateReview :one
 UPDATE reviews
-SET rating = $1, updated_at = NOW()
-WHERE id = $2 AND user_id = $3 -- Ensure user owns the review
-RETURNING id, user_id, product_id, rating, created_at, updated_at
+SET
+    rating = $1,
+    title = $2,
+    body = $3,
+    pros = $4,
+    cons = $5,
+    status = $6,
+    moderation_reason = NULL,
+    moderated_by = NULL,
+    moderated_at = NULL,
+    updated_at = NOW()
+WHERE id = $7 AND user_id = $8 -- Ensure user owns the review
+RETURNING id, user_id, product_id, rating, title, body, pros, cons, status, moderation_reason, verified_purchase, created_at, updated_at
 `
 
 type UpdateReviewParams struct {
 	Rating int32     `json:"rating"`
+	Title  *string   `json:"title"`
+	Body   *string   `json:"body"`
+	Pros   *string   `json:"pros"`
+	Cons   *string   `json:"cons"`
+	Status string    `json:"status"`
 	ID     uuid.UUID `json:"id"`
 	UserID uuid.UUID `json:"user_id"`
 }
 
 type UpdateReviewRow struct {
-	ID        uuid.UUID          `json:"id"`
-	UserID    uuid.UUID          `json:"user_id"`
-	ProductID uuid.UUID          `json:"product_id"`
-	Rating    int32              `json:"rating"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	ID               uuid.UUID          `json:"id"`
+	UserID           uuid.UUID          `json:"user_id"`
+	ProductID        uuid.UUID          `json:"product_id"`
+	Rating           int32              `json:"rating"`
+	Title            *string            `json:"title"`
+	Body             *string            `json:"body"`
+	Pros             *string            `json:"pros"`
+	Cons             *string            `json:"cons"`
+	Status           string             `json:"status"`
+	ModerationReason *string            `json:"moderation_reason"`
+	VerifiedPurchase bool               `json:"verified_purchase"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
 }
 
-// Updates the rating of an existing review.
+// Updates the rating and text of an existing review and sets its moderation status again.
 // NOTE: This query alone does not update the product's avg_rating/num_ratings.
 func (q *Queries) UpdateReview(ctx context.Context, arg UpdateReviewParams) (UpdateReviewRow, error) {
-	row := q.db.QueryRow(ctx, updateReview, arg.Rating, arg.ID, arg.UserID)
+	row := q.db.QueryRow(ctx, updateReview,
+		arg.Rating,
+		arg.Title,
+		arg.Body,
+		arg.Pros,
+		arg.Cons,
+		arg.Status,
+		arg.ID,
+		arg.UserID,
+	)
 	var i UpdateReviewRow
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
 		&i.ProductID,
 		&i.Rating,
+		&i.Title,
+		&i.Body,
+		&i.Pros,
+		&i.Cons,
+		&i.Status,
+		&i.ModerationReason,
+		&i.VerifiedPurchase,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
+
+const updateReviewReply = `-- name: UpdateReviewReply :one
+UPDATE review_replies
+SET body = $1,
+    author_id = NULLIF($2::UUID, '00000000-0000-0000-0000-000000000000'),
+    updated_at = NOW()
+WHERE review_id = $3
+RETURNING id, review_id, author_id, body, created_at, updated_at
+`
+
+type UpdateReviewReplyParams struct {
+	Body     string    `json:"body"`
+	AuthorID uuid.UUID `json:"author_id"`
+	ReviewID uuid.UUID `json:"review_id"`
+}
+
+// Edits the official reply to a review.
+func (q *Queries) UpdateReviewReply(ctx context.Context, arg UpdateReviewReplyParams) (ReviewReply, error) {
+	row := q.db.QueryRow(ctx, updateReviewReply, arg.Body, arg.AuthorID, arg.ReviewID)
+	var i ReviewReply
+	err := row.Scan(
+		&i.ID,
+		&i.ReviewID,
+		&i.AuthorID,
+		&i.Body,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertReviewVote = `-- name: UpsertReviewVote :exec
+INSERT INTO review_votes (review_id, user_id, is_helpful)
+VALUES ($1, $2, $3)
+ON CONFLICT (review_id, user_id) DO UPDATE
+SET is_helpful = EXCLUDED.is_helpful, updated_at = NOW()
+`
+
+type UpsertReviewVoteParams struct {
+	ReviewID  uuid.UUID `json:"review_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	IsHelpful bool      `json:"is_helpful"`
+}
+
+// Records a user's vote on a review, replacing any previous vote.
+func (q *Queries) UpsertReviewVote(ctx context.Context, arg UpsertReviewVoteParams) error {
+	_, err := q.db.Exec(ctx, upsertReviewVote, arg.ReviewID, arg.UserID, arg.IsHelpful)
+	return err
+}
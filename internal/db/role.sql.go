@@ -0,0 +1,266 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: role.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const addUserRole = `-- name: AddUserRole :exec
+INSERT INTO user_roles (user_id, role_id, assigned_by)
+VALUES ($1, $2, NULLIF($3::UUID, '00000000-0000-0000-0000-000000000000'))
+ON CONFLICT (user_id, role_id) DO NOTHING
+`
+
+type AddUserRoleParams struct {
+	UserID     uuid.UUID `json:"user_id"`
+	RoleID     uuid.UUID `json:"role_id"`
+	AssignedBy uuid.UUID `json:"assigned_by"`
+}
+
+// Assigns a role to a user.
+func (q *Queries) AddUserRole(ctx context.Context, arg AddUserRoleParams) error {
+	_, err := q.db.Exec(ctx, addUserRole, arg.UserID, arg.RoleID, arg.AssignedBy)
+	return err
+}
+
+const countActiveUsersWithRole = `-- name: CountActiveUsersWithRole :one
+SELECT COUNT(*)::BIGINT
+FROM user_roles ur
+JOIN roles r ON ur.role_id = r.id
+JOIN users u ON ur.user_id = u.id
+WHERE r.name = $1 AND u.deleted_at IS NULL
+`
+
+// Counts active users holding a role, e.g. to keep at least one superuser.
+func (q *Queries) CountActiveUsersWithRole(ctx context.Context, roleName string) (int64, error) {
+	row := q.db.QueryRow(ctx, countActiveUsersWithRole, roleName)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const deleteUserRoles = `-- name: DeleteUserRoles :exec
+DELETE FROM user_roles
+WHERE user_id = $1
+`
+
+// Removes every role assigned to a user.
+func (q *Queries) DeleteUserRoles(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteUserRoles, userID)
+	return err
+}
+
+const getRolesByNames = `-- name: GetRolesByNames :many
+SELECT id, name
+FROM roles
+WHERE name = ANY($1::TEXT[])
+`
+
+type GetRolesByNamesRow struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+// Retrieves the roles with the given names.
+func (q *Queries) GetRolesByNames(ctx context.Context, names []string) ([]GetRolesByNamesRow, error) {
+	rows, err := q.db.Query(ctx, getRolesByNames, names)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetRolesByNamesRow
+	for rows.Next() {
+		var i GetRolesByNamesRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPermissions = `-- name: ListPermissions :many
+SELECT code, description
+FROM permissions
+ORDER BY code
+`
+
+// Retrieves every permission that can be granted to a role.
+func (q *Queries) ListPermissions(ctx context.Context) ([]Permission, error) {
+	rows, err := q.db.Query(ctx, listPermissions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Permission
+	for rows.Next() {
+		var i Permission
+		if err := rows.Scan(&i.Code, &i.Description); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRolePermissions = `-- name: ListRolePermissions :many
+SELECT role_id, permission_code
+FROM role_permissions
+ORDER BY role_id, permission_code
+`
+
+// Retrieves the permissions granted to every role.
+func (q *Queries) ListRolePermissions(ctx context.Context) ([]RolePermission, error) {
+	rows, err := q.db.Query(ctx, listRolePermissions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RolePermission
+	for rows.Next() {
+		var i RolePermission
+		if err := rows.Scan(&i.RoleID, &i.PermissionCode); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRoles = `-- name: ListRoles :many
+SELECT id, name, description, created_at
+FROM roles
+ORDER BY name
+`
+
+// Retrieves every role.
+func (q *Queries) ListRoles(ctx context.Context) ([]Role, error) {
+	rows, err := q.db.Query(ctx, listRoles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Role
+	for rows.Next() {
+		var i Role
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserPermissions = `-- name: ListUserPermissions :many
+SELECT DISTINCT rp.permission_code
+FROM user_roles ur
+JOIN role_permissions rp ON ur.role_id = rp.role_id
+WHERE ur.user_id = $1
+ORDER BY rp.permission_code
+`
+
+// Retrieves the permissions a user holds through their roles.
+func (q *Queries) ListUserPermissions(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	rows, err := q.db.Query(ctx, listUserPermissions, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var permission_code string
+		if err := rows.Scan(&permission_code); err != nil {
+			return nil, err
+		}
+		items = append(items, permission_code)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserRoleNames = `-- name: ListUserRoleNames :many
+SELECT r.name
+FROM user_roles ur
+JOIN roles r ON ur.role_id = r.id
+WHERE ur.user_id = $1
+ORDER BY r.name
+`
+
+// Retrieves the names of the roles assigned to a user.
+func (q *Queries) ListUserRoleNames(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	rows, err := q.db.Query(ctx, listUserRoleNames, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		items = append(items, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockUserRolesByRoleName = `-- name: LockUserRolesByRoleName :exec
+SELECT ur.user_id
+FROM user_roles ur
+JOIN roles r ON ur.role_id = r.id
+WHERE r.name = $1
+ORDER BY ur.user_id
+FOR UPDATE OF ur
+`
+
+// Locks the assignments of a role until the end of the transaction, in a fixed order, so that concurrent
+// changes cannot each remove a holder they counted while the other was still there.
+func (q *Queries) LockUserRolesByRoleName(ctx context.Context, roleName string) error {
+	_, err := q.db.Exec(ctx, lockUserRolesByRoleName, roleName)
+	return err
+}
+
+const setUserAdminFlag = `-- name: SetUserAdminFlag :exec
+UPDATE users
+SET is_admin = $1, updated_at = NOW()
+WHERE id = $2
+`
+
+type SetUserAdminFlagParams struct {
+	IsAdmin bool      `json:"is_admin"`
+	ID      uuid.UUID `json:"id"`
+}
+
+// Marks a user as staff (any role) or customer (no role), which decides access to the admin area.
+func (q *Queries) SetUserAdminFlag(ctx context.Context, arg SetUserAdminFlagParams) error {
+	_, err := q.db.Exec(ctx, setUserAdminFlag, arg.IsAdmin, arg.ID)
+	return err
+}
@@ -0,0 +1,235 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: shipments.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const addShipmentEvent = `-- name: AddShipmentEvent :execrows
+INSERT INTO shipment_events (shipment_id, status, description, occurred_at, source)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (shipment_id, status, occurred_at) DO NOTHING
+`
+
+type AddShipmentEventParams struct {
+	ShipmentID  uuid.UUID          `json:"shipment_id"`
+	Status      string             `json:"status"`
+	Description *string            `json:"description"`
+	OccurredAt  pgtype.Timestamptz `json:"occurred_at"`
+	Source      string             `json:"source"`
+}
+
+// Records a status event, ignoring events already recorded.
+func (q *Queries) AddShipmentEvent(ctx context.Context, arg AddShipmentEventParams) (int64, error) {
+	result, err := q.db.Exec(ctx, addShipmentEvent,
+		arg.ShipmentID,
+		arg.Status,
+		arg.Description,
+		arg.OccurredAt,
+		arg.Source,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createShipment = `-- name: CreateShipment :one
+INSERT INTO shipments (order_id, courier, tracking_number, status, status_updated_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, order_id, courier, tracking_number, status, status_updated_at, created_at, updated_at
+`
+
+type CreateShipmentParams struct {
+	OrderID         uuid.UUID          `json:"order_id"`
+	Courier         string             `json:"courier"`
+	TrackingNumber  string             `json:"tracking_number"`
+	Status          string             `json:"status"`
+	StatusUpdatedAt pgtype.Timestamptz `json:"status_updated_at"`
+}
+
+func (q *Queries) CreateShipment(ctx context.Context, arg CreateShipmentParams) (Shipment, error) {
+	row := q.db.QueryRow(ctx, createShipment,
+		arg.OrderID,
+		arg.Courier,
+		arg.TrackingNumber,
+		arg.Status,
+		arg.StatusUpdatedAt,
+	)
+	var i Shipment
+	err := row.Scan(
+		&i.ID,
+		&i.OrderID,
+		&i.Courier,
+		&i.TrackingNumber,
+		&i.Status,
+		&i.StatusUpdatedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getShipmentByOrderID = `-- name: GetShipmentByOrderID :one
+SELECT id, order_id, courier, tracking_number, status, status_updated_at, created_at, updated_at
+FROM shipments
+WHERE order_id = $1
+ORDER BY created_at DESC
+LIMIT 1
+`
+
+// Retrieves the latest shipment of an order; earlier ones can only be cancelled shipments.
+func (q *Queries) GetShipmentByOrderID(ctx context.Context, orderID uuid.UUID) (Shipment, error) {
+	row := q.db.QueryRow(ctx, getShipmentByOrderID, orderID)
+	var i Shipment
+	err := row.Scan(
+		&i.ID,
+		&i.OrderID,
+		&i.Courier,
+		&i.TrackingNumber,
+		&i.Status,
+		&i.StatusUpdatedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getShipmentByTrackingNumber = `-- name: GetShipmentByTrackingNumber :one
+SELECT id, order_id, courier, tracking_number, status, status_updated_at, created_at, updated_at
+FROM shipments
+WHERE courier = $1 AND tracking_number = $2
+`
+
+type GetShipmentByTrackingNumberParams struct {
+	Courier        string `json:"courier"`
+	TrackingNumber string `json:"tracking_number"`
+}
+
+func (q *Queries) GetShipmentByTrackingNumber(ctx context.Context, arg GetShipmentByTrackingNumberParams) (Shipment, error) {
+	row := q.db.QueryRow(ctx, getShipmentByTrackingNumber, arg.Courier, arg.TrackingNumber)
+	var i Shipment
+	err := row.Scan(
+		&i.ID,
+		&i.OrderID,
+		&i.Courier,
+		&i.TrackingNumber,
+		&i.Status,
+		&i.StatusUpdatedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listOpenShipments = `-- name: ListOpenShipments :many
+SELECT s.id, s.order_id, s.courier, s.tracking_number, s.status, s.status_updated_at, s.created_at, s.updated_at
+FROM shipments s
+JOIN orders o ON o.id = s.order_id
+WHERE s.status NOT IN ('delivered', 'returned', 'cancelled')
+   OR (s.status = 'delivered' AND o.status = 'shipped')
+ORDER BY s.status_updated_at ASC
+LIMIT $1
+`
+
+// Retrieves shipments that have not reached a final status, least recently updated first,
+// along with delivered shipments whose order has not been marked delivered yet.
+func (q *Queries) ListOpenShipments(ctx context.Context, pageLimit int32) ([]Shipment, error) {
+	rows, err := q.db.Query(ctx, listOpenShipments, pageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Shipment
+	for rows.Next() {
+		var i Shipment
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrderID,
+			&i.Courier,
+			&i.TrackingNumber,
+			&i.Status,
+			&i.StatusUpdatedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listShipmentEvents = `-- name: ListShipmentEvents :many
+SELECT id, shipment_id, status, description, occurred_at, source, created_at
+FROM shipment_events
+WHERE shipment_id = $1
+ORDER BY occurred_at ASC, created_at ASC
+`
+
+func (q *Queries) ListShipmentEvents(ctx context.Context, shipmentID uuid.UUID) ([]ShipmentEvent, error) {
+	rows, err := q.db.Query(ctx, listShipmentEvents, shipmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ShipmentEvent
+	for rows.Next() {
+		var i ShipmentEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.ShipmentID,
+			&i.Status,
+			&i.Description,
+			&i.OccurredAt,
+			&i.Source,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateShipmentStatus = `-- name: UpdateShipmentStatus :one
+UPDATE shipments
+SET status = $1, status_updated_at = $2, updated_at = NOW()
+WHERE id = $3
+RETURNING id, order_id, courier, tracking_number, status, status_updated_at, created_at, updated_at
+`
+
+type UpdateShipmentStatusParams struct {
+	Status          string             `json:"status"`
+	StatusUpdatedAt pgtype.Timestamptz `json:"status_updated_at"`
+	ID              uuid.UUID          `json:"id"`
+}
+
+func (q *Queries) UpdateShipmentStatus(ctx context.Context, arg UpdateShipmentStatusParams) (Shipment, error) {
+	row := q.db.QueryRow(ctx, updateShipmentStatus, arg.Status, arg.StatusUpdatedAt, arg.ID)
+	var i Shipment
+	err := row.Scan(
+		&i.ID,
+		&i.OrderID,
+		&i.Courier,
+		&i.TrackingNumber,
+		&i.Status,
+		&i.StatusUpdatedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
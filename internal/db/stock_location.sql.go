@@ -0,0 +1,379 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: stock_location.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const createOrderItemAllocation = `-- name: CreateOrderItemAllocation :exec
+INSERT INTO order_item_allocations (order_id, order_item_id, product_id, location_id, quantity)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateOrderItemAllocationParams struct {
+	OrderID     uuid.UUID `json:"order_id"`
+	OrderItemID uuid.UUID `json:"order_item_id"`
+	ProductID   uuid.UUID `json:"product_id"`
+	LocationID  uuid.UUID `json:"location_id"`
+	Quantity    int32     `json:"quantity"`
+}
+
+func (q *Queries) CreateOrderItemAllocation(ctx context.Context, arg CreateOrderItemAllocationParams) error {
+	_, err := q.db.Exec(ctx, createOrderItemAllocation,
+		arg.OrderID,
+		arg.OrderItemID,
+		arg.ProductID,
+		arg.LocationID,
+		arg.Quantity,
+	)
+	return err
+}
+
+const createStockLocation = `-- name: CreateStockLocation :one
+INSERT INTO stock_locations (code, name, is_sellable, is_active, priority)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, code, name, is_sellable, is_active, is_default, priority, created_at, updated_at
+`
+
+type CreateStockLocationParams struct {
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	IsSellable bool   `json:"is_sellable"`
+	IsActive   bool   `json:"is_active"`
+	Priority   int32  `json:"priority"`
+}
+
+func (q *Queries) CreateStockLocation(ctx context.Context, arg CreateStockLocationParams) (StockLocation, error) {
+	row := q.db.QueryRow(ctx, createStockLocation,
+		arg.Code,
+		arg.Name,
+		arg.IsSellable,
+		arg.IsActive,
+		arg.Priority,
+	)
+	var i StockLocation
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Name,
+		&i.IsSellable,
+		&i.IsActive,
+		&i.IsDefault,
+		&i.Priority,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createStockTransfer = `-- name: CreateStockTransfer :one
+INSERT INTO stock_transfers (product_id, from_location_id, to_location_id, quantity, actor_id, note)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    NULLIF($5::UUID, '00000000-0000-0000-0000-000000000000'),
+    $6
+)
+RETURNING id, product_id, from_location_id, to_location_id, quantity, actor_id, note, created_at
+`
+
+type CreateStockTransferParams struct {
+	ProductID      uuid.UUID `json:"product_id"`
+	FromLocationID uuid.UUID `json:"from_location_id"`
+	ToLocationID   uuid.UUID `json:"to_location_id"`
+	Quantity       int32     `json:"quantity"`
+	ActorID        uuid.UUID `json:"actor_id"`
+	Note           *string   `json:"note"`
+}
+
+func (q *Queries) CreateStockTransfer(ctx context.Context, arg CreateStockTransferParams) (StockTransfer, error) {
+	row := q.db.QueryRow(ctx, createStockTransfer,
+		arg.ProductID,
+		arg.FromLocationID,
+		arg.ToLocationID,
+		arg.Quantity,
+		arg.ActorID,
+		arg.Note,
+	)
+	var i StockTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.FromLocationID,
+		&i.ToLocationID,
+		&i.Quantity,
+		&i.ActorID,
+		&i.Note,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getDefaultStockLocation = `-- name: GetDefaultStockLocation :one
+SELECT id, code, name, is_sellable, is_active, is_default, priority, created_at, updated_at FROM stock_locations WHERE is_default LIMIT 1
+`
+
+func (q *Queries) GetDefaultStockLocation(ctx context.Context) (StockLocation, error) {
+	row := q.db.QueryRow(ctx, getDefaultStockLocation)
+	var i StockLocation
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Name,
+		&i.IsSellable,
+		&i.IsActive,
+		&i.IsDefault,
+		&i.Priority,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getStockLocation = `-- name: GetStockLocation :one
+SELECT id, code, name, is_sellable, is_active, is_default, priority, created_at, updated_at FROM stock_locations WHERE id = $1
+`
+
+func (q *Queries) GetStockLocation(ctx context.Context, id uuid.UUID) (StockLocation, error) {
+	row := q.db.QueryRow(ctx, getStockLocation, id)
+	var i StockLocation
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Name,
+		&i.IsSellable,
+		&i.IsActive,
+		&i.IsDefault,
+		&i.Priority,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listAllocatableStockLevelsForUpdate = `-- name: ListAllocatableStockLevelsForUpdate :many
+SELECT psl.location_id, psl.quantity
+FROM product_stock_levels psl
+JOIN stock_locations sl ON sl.id = psl.location_id
+WHERE psl.product_id = $1 AND sl.is_sellable AND sl.is_active AND psl.quantity > 0
+ORDER BY sl.priority, psl.quantity DESC
+FOR UPDATE OF psl
+`
+
+type ListAllocatableStockLevelsForUpdateRow struct {
+	LocationID uuid.UUID `json:"location_id"`
+	Quantity   int32     `json:"quantity"`
+}
+
+// Lists the stock of a product at active, sellable locations in allocation order, locking the levels.
+func (q *Queries) ListAllocatableStockLevelsForUpdate(ctx context.Context, productID uuid.UUID) ([]ListAllocatableStockLevelsForUpdateRow, error) {
+	rows, err := q.db.Query(ctx, listAllocatableStockLevelsForUpdate, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAllocatableStockLevelsForUpdateRow
+	for rows.Next() {
+		var i ListAllocatableStockLevelsForUpdateRow
+		if err := rows.Scan(&i.LocationID, &i.Quantity); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrderItemAllocations = `-- name: ListOrderItemAllocations :many
+SELECT id, order_id, order_item_id, product_id, location_id, quantity, created_at FROM order_item_allocations WHERE order_id = $1 ORDER BY created_at, id
+`
+
+func (q *Queries) ListOrderItemAllocations(ctx context.Context, orderID uuid.UUID) ([]OrderItemAllocation, error) {
+	rows, err := q.db.Query(ctx, listOrderItemAllocations, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OrderItemAllocation
+	for rows.Next() {
+		var i OrderItemAllocation
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrderID,
+			&i.OrderItemID,
+			&i.ProductID,
+			&i.LocationID,
+			&i.Quantity,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductIDsByStockLocation = `-- name: ListProductIDsByStockLocation :many
+SELECT product_id FROM product_stock_levels WHERE location_id = $1
+`
+
+func (q *Queries) ListProductIDsByStockLocation(ctx context.Context, locationID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listProductIDsByStockLocation, locationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var product_id uuid.UUID
+		if err := rows.Scan(&product_id); err != nil {
+			return nil, err
+		}
+		items = append(items, product_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductStockLevels = `-- name: ListProductStockLevels :many
+SELECT
+    sl.id AS location_id,
+    sl.code AS location_code,
+    sl.name AS location_name,
+    sl.is_sellable,
+    sl.is_active,
+    COALESCE(psl.quantity, 0)::INT AS quantity
+FROM stock_locations sl
+LEFT JOIN product_stock_levels psl ON psl.location_id = sl.id AND psl.product_id = $1
+ORDER BY sl.priority, sl.code
+`
+
+type ListProductStockLevelsRow struct {
+	LocationID   uuid.UUID `json:"location_id"`
+	LocationCode string    `json:"location_code"`
+	LocationName string    `json:"location_name"`
+	IsSellable   bool      `json:"is_sellable"`
+	IsActive     bool      `json:"is_active"`
+	Quantity     int32     `json:"quantity"`
+}
+
+// Lists a product's stock at every location, including locations without stock.
+func (q *Queries) ListProductStockLevels(ctx context.Context, productID uuid.UUID) ([]ListProductStockLevelsRow, error) {
+	rows, err := q.db.Query(ctx, listProductStockLevels, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListProductStockLevelsRow
+	for rows.Next() {
+		var i ListProductStockLevelsRow
+		if err := rows.Scan(
+			&i.LocationID,
+			&i.LocationCode,
+			&i.LocationName,
+			&i.IsSellable,
+			&i.IsActive,
+			&i.Quantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStockLocations = `-- name: ListStockLocations :many
+SELECT id, code, name, is_sellable, is_active, is_default, priority, created_at, updated_at FROM stock_locations ORDER BY priority, code
+`
+
+func (q *Queries) ListStockLocations(ctx context.Context) ([]StockLocation, error) {
+	rows, err := q.db.Query(ctx, listStockLocations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StockLocation
+	for rows.Next() {
+		var i StockLocation
+		if err := rows.Scan(
+			&i.ID,
+			&i.Code,
+			&i.Name,
+			&i.IsSellable,
+			&i.IsActive,
+			&i.IsDefault,
+			&i.Priority,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateStockLocation = `-- name: UpdateStockLocation :one
+UPDATE stock_locations
+SET
+    name = COALESCE($1, name),
+    is_sellable = COALESCE($2, is_sellable),
+    is_active = COALESCE($3, is_active),
+    priority = COALESCE($4, priority),
+    updated_at = NOW()
+WHERE id = $5
+RETURNING id, code, name, is_sellable, is_active, is_default, priority, created_at, updated_at
+`
+
+type UpdateStockLocationParams struct {
+	Name       *string   `json:"name"`
+	IsSellable *bool     `json:"is_sellable"`
+	IsActive   *bool     `json:"is_active"`
+	Priority   *int32    `json:"priority"`
+	ID         uuid.UUID `json:"id"`
+}
+
+// Partially updates a location; products.stock_quantity follows sellability changes through trg_stock_locations_sync.
+func (q *Queries) UpdateStockLocation(ctx context.Context, arg UpdateStockLocationParams) (StockLocation, error) {
+	row := q.db.QueryRow(ctx, updateStockLocation,
+		arg.Name,
+		arg.IsSellable,
+		arg.IsActive,
+		arg.Priority,
+		arg.ID,
+	)
+	var i StockLocation
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Name,
+		&i.IsSellable,
+		&i.IsActive,
+		&i.IsDefault,
+		&i.Priority,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,362 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: stock_movement.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const applyStockMovement = `-- name: ApplyStockMovement :one
+WITH updated AS (
+    UPDATE product_stock_levels
+    SET quantity = product_stock_levels.quantity + $1::INT, updated_at = NOW()
+    WHERE product_stock_levels.product_id = $7
+      AND product_stock_levels.location_id = $8
+      AND product_stock_levels.quantity + $1::INT >= 0
+    RETURNING product_stock_levels.product_id, product_stock_levels.location_id, product_stock_levels.quantity
+)
+INSERT INTO stock_movements (product_id, location_id, delta, resulting_quantity, reason, order_id, actor_id, transfer_id, note)
+SELECT
+    updated.product_id,
+    updated.location_id,
+    $1::INT,
+    updated.quantity,
+    $2,
+    NULLIF($3::UUID, '00000000-0000-0000-0000-000000000000'),
+    NULLIF($4::UUID, '00000000-0000-0000-0000-000000000000'),
+    NULLIF($5::UUID, '00000000-0000-0000-0000-000000000000'),
+    $6
+FROM updated
+RETURNING id, product_id, delta, resulting_quantity, reason, order_id, actor_id, note, created_at, location_id, transfer_id
+`
+
+type ApplyStockMovementParams struct {
+	Delta      int32     `json:"delta"`
+	Reason     string    `json:"reason"`
+	OrderID    uuid.UUID `json:"order_id"`
+	ActorID    uuid.UUID `json:"actor_id"`
+	TransferID uuid.UUID `json:"transfer_id"`
+	Note       *string   `json:"note"`
+	ProductID  uuid.UUID `json:"product_id"`
+	LocationID uuid.UUID `json:"location_id"`
+}
+
+// Changes a product's stock at a location by a signed delta and records the movement in the ledger, in one statement.
+// products.stock_quantity follows through the trg_product_stock_levels_sync trigger.
+// Returns no rows if the stock level does not exist or the stock would become negative.
+// Pass the zero UUID for order_id/actor_id/transfer_id when there is no reference; it is stored as NULL.
+func (q *Queries) ApplyStockMovement(ctx context.Context, arg ApplyStockMovementParams) (StockMovement, error) {
+	row := q.db.QueryRow(ctx, applyStockMovement,
+		arg.Delta,
+		arg.Reason,
+		arg.OrderID,
+		arg.ActorID,
+		arg.TransferID,
+		arg.Note,
+		arg.ProductID,
+		arg.LocationID,
+	)
+	var i StockMovement
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.Delta,
+		&i.ResultingQuantity,
+		&i.Reason,
+		&i.OrderID,
+		&i.ActorID,
+		&i.Note,
+		&i.CreatedAt,
+		&i.LocationID,
+		&i.TransferID,
+	)
+	return i, err
+}
+
+const countStockMovementsByProduct = `-- name: CountStockMovementsByProduct :one
+SELECT COUNT(*) FROM stock_movements WHERE product_id = $1
+`
+
+func (q *Queries) CountStockMovementsByProduct(ctx context.Context, productID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countStockMovementsByProduct, productID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const ensureProductStockLevel = `-- name: EnsureProductStockLevel :exec
+INSERT INTO product_stock_levels (product_id, location_id, quantity)
+VALUES ($1, $2, 0)
+ON CONFLICT (product_id, location_id) DO NOTHING
+`
+
+type EnsureProductStockLevelParams struct {
+	ProductID  uuid.UUID `json:"product_id"`
+	LocationID uuid.UUID `json:"location_id"`
+}
+
+// Creates the (empty) stock level of a product at a location if it does not exist yet.
+// Called before ApplyStockMovement/SetProductStock, which only update existing levels.
+func (q *Queries) EnsureProductStockLevel(ctx context.Context, arg EnsureProductStockLevelParams) error {
+	_, err := q.db.Exec(ctx, ensureProductStockLevel, arg.ProductID, arg.LocationID)
+	return err
+}
+
+const getProductStockReconciliation = `-- name: GetProductStockReconciliation :many
+SELECT
+    p.id AS product_id,
+    p.name AS product_name,
+    sl.id AS location_id,
+    sl.code AS location_code,
+    psl.quantity,
+    COALESCE(SUM(sm.delta), 0)::BIGINT AS ledger_quantity
+FROM product_stock_levels psl
+JOIN products p ON p.id = psl.product_id
+JOIN stock_locations sl ON sl.id = psl.location_id
+LEFT JOIN stock_movements sm ON sm.product_id = psl.product_id AND sm.location_id = psl.location_id
+WHERE psl.product_id = $1
+GROUP BY p.id, p.name, sl.id, sl.code, sl.priority, psl.quantity
+ORDER BY sl.priority, sl.code
+`
+
+type GetProductStockReconciliationRow struct {
+	ProductID      uuid.UUID `json:"product_id"`
+	ProductName    string    `json:"product_name"`
+	LocationID     uuid.UUID `json:"location_id"`
+	LocationCode   string    `json:"location_code"`
+	Quantity       int32     `json:"quantity"`
+	LedgerQuantity int64     `json:"ledger_quantity"`
+}
+
+// Compares a product's stock at each location with the sum of its ledger movements there.
+func (q *Queries) GetProductStockReconciliation(ctx context.Context, productID uuid.UUID) ([]GetProductStockReconciliationRow, error) {
+	rows, err := q.db.Query(ctx, getProductStockReconciliation, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetProductStockReconciliationRow
+	for rows.Next() {
+		var i GetProductStockReconciliationRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.ProductName,
+			&i.LocationID,
+			&i.LocationCode,
+			&i.Quantity,
+			&i.LedgerQuantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStockDiscrepancies = `-- name: ListStockDiscrepancies :many
+SELECT
+    p.id AS product_id,
+    p.name AS product_name,
+    sl.id AS location_id,
+    sl.code AS location_code,
+    psl.quantity,
+    COALESCE(SUM(sm.delta), 0)::BIGINT AS ledger_quantity
+FROM product_stock_levels psl
+JOIN products p ON p.id = psl.product_id
+JOIN stock_locations sl ON sl.id = psl.location_id
+LEFT JOIN stock_movements sm ON sm.product_id = psl.product_id AND sm.location_id = psl.location_id
+GROUP BY p.id, p.name, sl.id, sl.code, psl.quantity
+HAVING psl.quantity <> COALESCE(SUM(sm.delta), 0)
+ORDER BY p.name, sl.code
+`
+
+type ListStockDiscrepanciesRow struct {
+	ProductID      uuid.UUID `json:"product_id"`
+	ProductName    string    `json:"product_name"`
+	LocationID     uuid.UUID `json:"location_id"`
+	LocationCode   string    `json:"location_code"`
+	Quantity       int32     `json:"quantity"`
+	LedgerQuantity int64     `json:"ledger_quantity"`
+}
+
+// Lists every product stock level that does not match the sum of its ledger movements.
+func (q *Queries) ListStockDiscrepancies(ctx context.Context) ([]ListStockDiscrepanciesRow, error) {
+	rows, err := q.db.Query(ctx, listStockDiscrepancies)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListStockDiscrepanciesRow
+	for rows.Next() {
+		var i ListStockDiscrepanciesRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.ProductName,
+			&i.LocationID,
+			&i.LocationCode,
+			&i.Quantity,
+			&i.LedgerQuantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStockMovementsByProduct = `-- name: ListStockMovementsByProduct :many
+SELECT
+    sm.id,
+    sm.product_id,
+    sm.location_id,
+    sl.code AS location_code,
+    sm.delta,
+    sm.resulting_quantity,
+    sm.reason,
+    sm.order_id,
+    sm.transfer_id,
+    sm.actor_id,
+    u.email AS actor_email,
+    sm.note,
+    sm.created_at
+FROM stock_movements sm
+JOIN stock_locations sl ON sm.location_id = sl.id
+LEFT JOIN users u ON sm.actor_id = u.id
+WHERE sm.product_id = $1
+ORDER BY sm.created_at DESC, sm.id DESC
+LIMIT $3 OFFSET $2
+`
+
+type ListStockMovementsByProductParams struct {
+	ProductID  uuid.UUID `json:"product_id"`
+	PageOffset int32     `json:"page_offset"`
+	PageLimit  int32     `json:"page_limit"`
+}
+
+type ListStockMovementsByProductRow struct {
+	ID                int64              `json:"id"`
+	ProductID         uuid.UUID          `json:"product_id"`
+	LocationID        uuid.UUID          `json:"location_id"`
+	LocationCode      string             `json:"location_code"`
+	Delta             int32              `json:"delta"`
+	ResultingQuantity int32              `json:"resulting_quantity"`
+	Reason            string             `json:"reason"`
+	OrderID           uuid.UUID          `json:"order_id"`
+	TransferID        uuid.UUID          `json:"transfer_id"`
+	ActorID           uuid.UUID          `json:"actor_id"`
+	ActorEmail        *string            `json:"actor_email"`
+	Note              *string            `json:"note"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+}
+
+// Lists the ledger entries of a product, newest first, with the location and the acting admin's email.
+func (q *Queries) ListStockMovementsByProduct(ctx context.Context, arg ListStockMovementsByProductParams) ([]ListStockMovementsByProductRow, error) {
+	rows, err := q.db.Query(ctx, listStockMovementsByProduct, arg.ProductID, arg.PageOffset, arg.PageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListStockMovementsByProductRow
+	for rows.Next() {
+		var i ListStockMovementsByProductRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.LocationID,
+			&i.LocationCode,
+			&i.Delta,
+			&i.ResultingQuantity,
+			&i.Reason,
+			&i.OrderID,
+			&i.TransferID,
+			&i.ActorID,
+			&i.ActorEmail,
+			&i.Note,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setProductStock = `-- name: SetProductStock :one
+WITH current_level AS (
+    SELECT psl.product_id, psl.location_id, psl.quantity
+    FROM product_stock_levels psl
+    WHERE psl.product_id = $4 AND psl.location_id = $5
+    FOR UPDATE
+),
+updated AS (
+    UPDATE product_stock_levels psl
+    SET quantity = $6::INT, updated_at = NOW()
+    FROM current_level cl
+    WHERE psl.product_id = cl.product_id AND psl.location_id = cl.location_id AND cl.quantity <> $6::INT
+    RETURNING psl.product_id, psl.location_id, psl.quantity, cl.quantity AS previous_quantity
+)
+INSERT INTO stock_movements (product_id, location_id, delta, resulting_quantity, reason, actor_id, note)
+SELECT
+    updated.product_id,
+    updated.location_id,
+    updated.quantity - updated.previous_quantity,
+    updated.quantity,
+    $1,
+    NULLIF($2::UUID, '00000000-0000-0000-0000-000000000000'),
+    $3
+FROM updated
+RETURNING id, product_id, delta, resulting_quantity, reason, order_id, actor_id, note, created_at, location_id, transfer_id
+`
+
+type SetProductStockParams struct {
+	Reason      string    `json:"reason"`
+	ActorID     uuid.UUID `json:"actor_id"`
+	Note        *string   `json:"note"`
+	ProductID   uuid.UUID `json:"product_id"`
+	LocationID  uuid.UUID `json:"location_id"`
+	NewQuantity int32     `json:"new_quantity"`
+}
+
+// Sets a product's stock at a location to an absolute quantity and records the difference in the ledger, in one statement.
+// The level is locked so the delta is computed against the current stock, not a stale read.
+// Returns no rows if the stock level does not exist or the quantity is unchanged.
+func (q *Queries) SetProductStock(ctx context.Context, arg SetProductStockParams) (StockMovement, error) {
+	row := q.db.QueryRow(ctx, setProductStock,
+		arg.Reason,
+		arg.ActorID,
+		arg.Note,
+		arg.ProductID,
+		arg.LocationID,
+		arg.NewQuantity,
+	)
+	var i StockMovement
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.Delta,
+		&i.ResultingQuantity,
+		&i.Reason,
+		&i.OrderID,
+		&i.ActorID,
+		&i.Note,
+		&i.CreatedAt,
+		&i.LocationID,
+		&i.TransferID,
+	)
+	return i, err
+}
@@ -0,0 +1,268 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: user_address.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const clearDefaultUserAddress = `-- name: ClearDefaultUserAddress :exec
+UPDATE user_addresses
+SET is_default = FALSE, updated_at = NOW()
+WHERE user_id = $1 AND is_default
+`
+
+func (q *Queries) ClearDefaultUserAddress(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, clearDefaultUserAddress, userID)
+	return err
+}
+
+const countUserAddresses = `-- name: CountUserAddresses :one
+SELECT COUNT(*) FROM user_addresses WHERE user_id = $1
+`
+
+func (q *Queries) CountUserAddresses(ctx context.Context, userID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countUserAddresses, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createUserAddress = `-- name: CreateUserAddress :one
+INSERT INTO user_addresses (user_id, label, full_name, phone_number_1, phone_number_2, province, city, is_default)
+VALUES (
+    $1, $2, $3, $4, $5,
+    $6, $7, $8
+)
+RETURNING id, user_id, label, full_name, phone_number_1, phone_number_2, province, city, is_default, created_at, updated_at
+`
+
+type CreateUserAddressParams struct {
+	UserID       uuid.UUID `json:"user_id"`
+	Label        *string   `json:"label"`
+	FullName     string    `json:"full_name"`
+	PhoneNumber1 string    `json:"phone_number_1"`
+	PhoneNumber2 *string   `json:"phone_number_2"`
+	Province     string    `json:"province"`
+	City         string    `json:"city"`
+	IsDefault    bool      `json:"is_default"`
+}
+
+func (q *Queries) CreateUserAddress(ctx context.Context, arg CreateUserAddressParams) (UserAddress, error) {
+	row := q.db.QueryRow(ctx, createUserAddress,
+		arg.UserID,
+		arg.Label,
+		arg.FullName,
+		arg.PhoneNumber1,
+		arg.PhoneNumber2,
+		arg.Province,
+		arg.City,
+		arg.IsDefault,
+	)
+	var i UserAddress
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Label,
+		&i.FullName,
+		&i.PhoneNumber1,
+		&i.PhoneNumber2,
+		&i.Province,
+		&i.City,
+		&i.IsDefault,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteUserAddress = `-- name: DeleteUserAddress :one
+DELETE FROM user_addresses
+WHERE id = $1 AND user_id = $2
+RETURNING is_default
+`
+
+type DeleteUserAddressParams struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) DeleteUserAddress(ctx context.Context, arg DeleteUserAddressParams) (bool, error) {
+	row := q.db.QueryRow(ctx, deleteUserAddress, arg.ID, arg.UserID)
+	var is_default bool
+	err := row.Scan(&is_default)
+	return is_default, err
+}
+
+const getUserAddress = `-- name: GetUserAddress :one
+SELECT id, user_id, label, full_name, phone_number_1, phone_number_2, province, city, is_default, created_at, updated_at
+FROM user_addresses
+WHERE id = $1 AND user_id = $2
+`
+
+type GetUserAddressParams struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetUserAddress(ctx context.Context, arg GetUserAddressParams) (UserAddress, error) {
+	row := q.db.QueryRow(ctx, getUserAddress, arg.ID, arg.UserID)
+	var i UserAddress
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Label,
+		&i.FullName,
+		&i.PhoneNumber1,
+		&i.PhoneNumber2,
+		&i.Province,
+		&i.City,
+		&i.IsDefault,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listUserAddresses = `-- name: ListUserAddresses :many
+SELECT id, user_id, label, full_name, phone_number_1, phone_number_2, province, city, is_default, created_at, updated_at
+FROM user_addresses
+WHERE user_id = $1
+ORDER BY is_default DESC, created_at DESC
+`
+
+// Lists a user's addresses, the default one first.
+func (q *Queries) ListUserAddresses(ctx context.Context, userID uuid.UUID) ([]UserAddress, error) {
+	rows, err := q.db.Query(ctx, listUserAddresses, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UserAddress
+	for rows.Next() {
+		var i UserAddress
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Label,
+			&i.FullName,
+			&i.PhoneNumber1,
+			&i.PhoneNumber2,
+			&i.Province,
+			&i.City,
+			&i.IsDefault,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockUserAddresses = `-- name: LockUserAddresses :exec
+SELECT id FROM users WHERE id = $1 FOR UPDATE
+`
+
+// Serialises changes to a user's address book, so concurrent requests cannot both pick a default address.
+func (q *Queries) LockUserAddresses(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, lockUserAddresses, userID)
+	return err
+}
+
+const promoteLatestUserAddress = `-- name: PromoteLatestUserAddress :exec
+UPDATE user_addresses
+SET is_default = TRUE, updated_at = NOW()
+WHERE id = (
+    SELECT ua.id FROM user_addresses ua
+    WHERE ua.user_id = $1
+    ORDER BY ua.created_at DESC
+    LIMIT 1
+)
+`
+
+// Makes the most recently created address the default, used when the default address is deleted.
+func (q *Queries) PromoteLatestUserAddress(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, promoteLatestUserAddress, userID)
+	return err
+}
+
+const setDefaultUserAddress = `-- name: SetDefaultUserAddress :execrows
+UPDATE user_addresses
+SET is_default = TRUE, updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+`
+
+type SetDefaultUserAddressParams struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) SetDefaultUserAddress(ctx context.Context, arg SetDefaultUserAddressParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setDefaultUserAddress, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateUserAddress = `-- name: UpdateUserAddress :one
+UPDATE user_addresses
+SET label = $1,
+    full_name = $2,
+    phone_number_1 = $3,
+    phone_number_2 = $4,
+    province = $5,
+    city = $6,
+    updated_at = NOW()
+WHERE id = $7 AND user_id = $8
+RETURNING id, user_id, label, full_name, phone_number_1, phone_number_2, province, city, is_default, created_at, updated_at
+`
+
+type UpdateUserAddressParams struct {
+	Label        *string   `json:"label"`
+	FullName     string    `json:"full_name"`
+	PhoneNumber1 string    `json:"phone_number_1"`
+	PhoneNumber2 *string   `json:"phone_number_2"`
+	Province     string    `json:"province"`
+	City         string    `json:"city"`
+	ID           uuid.UUID `json:"id"`
+	UserID       uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) UpdateUserAddress(ctx context.Context, arg UpdateUserAddressParams) (UserAddress, error) {
+	row := q.db.QueryRow(ctx, updateUserAddress,
+		arg.Label,
+		arg.FullName,
+		arg.PhoneNumber1,
+		arg.PhoneNumber2,
+		arg.Province,
+		arg.City,
+		arg.ID,
+		arg.UserID,
+	)
+	var i UserAddress
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Label,
+		&i.FullName,
+		&i.PhoneNumber1,
+		&i.PhoneNumber2,
+		&i.Province,
+		&i.City,
+		&i.IsDefault,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,80 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: user_identity.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createUserIdentity = `-- name: CreateUserIdentity :exec
+INSERT INTO user_identities (user_id, provider, subject, email, last_login_at)
+VALUES ($1, $2, $3, $4, NOW())
+`
+
+type CreateUserIdentityParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Provider string    `json:"provider"`
+	Subject  string    `json:"subject"`
+	Email    *string   `json:"email"`
+}
+
+func (q *Queries) CreateUserIdentity(ctx context.Context, arg CreateUserIdentityParams) error {
+	_, err := q.db.Exec(ctx, createUserIdentity,
+		arg.UserID,
+		arg.Provider,
+		arg.Subject,
+		arg.Email,
+	)
+	return err
+}
+
+const getUserIdentity = `-- name: GetUserIdentity :one
+SELECT ui.id, ui.user_id, ui.provider, ui.subject, u.deleted_at AS user_deleted_at
+FROM user_identities ui
+JOIN users u ON u.id = ui.user_id
+WHERE ui.provider = $1 AND ui.subject = $2
+`
+
+type GetUserIdentityParams struct {
+	Provider string `json:"provider"`
+	Subject  string `json:"subject"`
+}
+
+type GetUserIdentityRow struct {
+	ID            uuid.UUID          `json:"id"`
+	UserID        uuid.UUID          `json:"user_id"`
+	Provider      string             `json:"provider"`
+	Subject       string             `json:"subject"`
+	UserDeletedAt pgtype.Timestamptz `json:"user_deleted_at"`
+}
+
+// Finds the user an external identity is linked to, including deactivated users.
+func (q *Queries) GetUserIdentity(ctx context.Context, arg GetUserIdentityParams) (GetUserIdentityRow, error) {
+	row := q.db.QueryRow(ctx, getUserIdentity, arg.Provider, arg.Subject)
+	var i GetUserIdentityRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Provider,
+		&i.Subject,
+		&i.UserDeletedAt,
+	)
+	return i, err
+}
+
+const touchUserIdentity = `-- name: TouchUserIdentity :exec
+UPDATE user_identities
+SET last_login_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) TouchUserIdentity(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, touchUserIdentity, id)
+	return err
+}
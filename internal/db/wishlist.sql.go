@@ -0,0 +1,177 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: wishlist.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const addWishlistItem = `-- name: AddWishlistItem :exec
+INSERT INTO wishlist_items (user_id, session_id, product_id)
+VALUES (NULLIF($1::UUID, '00000000-0000-0000-0000-000000000000'), $2, $3)
+ON CONFLICT DO NOTHING
+`
+
+type AddWishlistItemParams struct {
+	UserID    uuid.UUID `json:"user_id"`
+	SessionID *string   `json:"session_id"`
+	ProductID uuid.UUID `json:"product_id"`
+}
+
+// Adds a product to a user's or guest's wishlist. Adding a product twice is a no-op.
+// Guests pass the zero UUID as user_id, which is stored as NULL.
+func (q *Queries) AddWishlistItem(ctx context.Context, arg AddWishlistItemParams) error {
+	_, err := q.db.Exec(ctx, addWishlistItem, arg.UserID, arg.SessionID, arg.ProductID)
+	return err
+}
+
+const deleteWishlistItem = `-- name: DeleteWishlistItem :execrows
+DELETE FROM wishlist_items
+WHERE product_id = $1
+  AND (user_id = $2 OR session_id = $3)
+`
+
+type DeleteWishlistItemParams struct {
+	ProductID uuid.UUID `json:"product_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	SessionID *string   `json:"session_id"`
+}
+
+// Removes a product from a user's or guest's wishlist.
+func (q *Queries) DeleteWishlistItem(ctx context.Context, arg DeleteWishlistItemParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteWishlistItem, arg.ProductID, arg.UserID, arg.SessionID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listWishlistItemsWithDiscounts = `-- name: ListWishlistItemsWithDiscounts :many
+SELECT
+    wi.id,
+    wi.product_id,
+    wi.created_at AS added_at,
+    p.name AS product_name,
+    p.slug AS product_slug,
+    p.brand AS product_brand,
+    p.image_urls AS product_image_urls,
+    p.status AS product_status,
+    p.stock_quantity AS product_stock_quantity,
+    p.price_cents AS original_price_cents,
+    COALESCE(vpcd.calculated_discounted_price_cents, p.price_cents)::BIGINT AS discounted_price_cents,
+    COALESCE(vpcd.has_active_discount, FALSE) AS has_active_discount
+FROM wishlist_items wi
+JOIN products p ON wi.product_id = p.id AND p.deleted_at IS NULL
+LEFT JOIN v_products_with_calculated_discounts vpcd ON p.id = vpcd.product_id
+WHERE wi.user_id = $1 OR wi.session_id = $2
+ORDER BY wi.created_at DESC
+`
+
+type ListWishlistItemsWithDiscountsParams struct {
+	UserID    uuid.UUID `json:"user_id"`
+	SessionID *string   `json:"session_id"`
+}
+
+type ListWishlistItemsWithDiscountsRow struct {
+	ID                   uuid.UUID          `json:"id"`
+	ProductID            uuid.UUID          `json:"product_id"`
+	AddedAt              pgtype.Timestamptz `json:"added_at"`
+	ProductName          string             `json:"product_name"`
+	ProductSlug          string             `json:"product_slug"`
+	ProductBrand         string             `json:"product_brand"`
+	ProductImageUrls     []byte             `json:"product_image_urls"`
+	ProductStatus        string             `json:"product_status"`
+	ProductStockQuantity int32              `json:"product_stock_quantity"`
+	OriginalPriceCents   int64              `json:"original_price_cents"`
+	DiscountedPriceCents int64              `json:"discounted_price_cents"`
+	HasActiveDiscount    bool               `json:"has_active_discount"`
+}
+
+// Lists the wishlist of a user or guest with live prices (from v_products_with_calculated_discounts) and stock.
+func (q *Queries) ListWishlistItemsWithDiscounts(ctx context.Context, arg ListWishlistItemsWithDiscountsParams) ([]ListWishlistItemsWithDiscountsRow, error) {
+	rows, err := q.db.Query(ctx, listWishlistItemsWithDiscounts, arg.UserID, arg.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListWishlistItemsWithDiscountsRow
+	for rows.Next() {
+		var i ListWishlistItemsWithDiscountsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.AddedAt,
+			&i.ProductName,
+			&i.ProductSlug,
+			&i.ProductBrand,
+			&i.ProductImageUrls,
+			&i.ProductStatus,
+			&i.ProductStockQuantity,
+			&i.OriginalPriceCents,
+			&i.DiscountedPriceCents,
+			&i.HasActiveDiscount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const mergeGuestWishlistIntoUserWishlist = `-- name: MergeGuestWishlistIntoUserWishlist :execrows
+WITH moved AS (
+    DELETE FROM wishlist_items
+    WHERE wishlist_items.session_id = $2::TEXT
+    RETURNING wishlist_items.product_id, wishlist_items.created_at
+)
+INSERT INTO wishlist_items (user_id, product_id, created_at)
+SELECT $1::UUID, moved.product_id, moved.created_at
+FROM moved
+ON CONFLICT DO NOTHING
+`
+
+type MergeGuestWishlistIntoUserWishlistParams struct {
+	UserID    uuid.UUID `json:"user_id"`
+	SessionID string    `json:"session_id"`
+}
+
+// Moves every item of a guest wishlist to a user's wishlist in a single statement.
+// Products already on the user's wishlist are dropped from the guest list without duplicating them.
+func (q *Queries) MergeGuestWishlistIntoUserWishlist(ctx context.Context, arg MergeGuestWishlistIntoUserWishlistParams) (int64, error) {
+	result, err := q.db.Exec(ctx, mergeGuestWishlistIntoUserWishlist, arg.UserID, arg.SessionID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const wishlistItemExists = `-- name: WishlistItemExists :one
+SELECT EXISTS(
+    SELECT 1 FROM wishlist_items
+    WHERE product_id = $1
+      AND (user_id = $2 OR session_id = $3)
+) AS exists
+`
+
+type WishlistItemExistsParams struct {
+	ProductID uuid.UUID `json:"product_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	SessionID *string   `json:"session_id"`
+}
+
+// Checks whether a product is on a user's or guest's wishlist.
+func (q *Queries) WishlistItemExists(ctx context.Context, arg WishlistItemExistsParams) (bool, error) {
+	row := q.db.QueryRow(ctx, wishlistItemExists, arg.ProductID, arg.UserID, arg.SessionID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/MihoZaki/DzTech/internal/services"
+	"github.com/MihoZaki/DzTech/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// AddressHandler handles HTTP requests for the customer's address book.
+type AddressHandler struct {
+	service *services.AddressService
+	logger  *slog.Logger
+}
+
+// NewAddressHandler creates a new instance of AddressHandler.
+func NewAddressHandler(service *services.AddressService, logger *slog.Logger) *AddressHandler {
+	return &AddressHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes registers the address book routes.
+// This should be mounted under the authenticated user routes (e.g., /api/v1/user/addresses).
+func (h *AddressHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.ListAddresses)                          // GET /api/v1/user/addresses
+	r.Post("/", h.CreateAddress)                         // POST /api/v1/user/addresses
+	r.Get("/{address_id}", h.GetAddress)                 // GET /api/v1/user/addresses/{address_id}
+	r.Put("/{address_id}", h.UpdateAddress)              // PUT /api/v1/user/addresses/{address_id}
+	r.Delete("/{address_id}", h.DeleteAddress)           // DELETE /api/v1/user/addresses/{address_id}
+	r.Post("/{address_id}/default", h.SetDefaultAddress) // POST /api/v1/user/addresses/{address_id}/default
+}
+
+// ListAddresses lists the current user's saved addresses.
+func (h *AddressHandler) ListAddresses(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Authentication required.")
+		return
+	}
+
+	addresses, err := h.service.ListAddresses(r.Context(), user.ID)
+	if err != nil {
+		SendServiceError(w, h.logger, "list addresses", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(addresses); err != nil {
+		h.logger.Error("Failed to encode ListAddresses response", "error", err)
+	}
+}
+
+// CreateAddress saves a new address for the current user.
+func (h *AddressHandler) CreateAddress(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Authentication required.")
+		return
+	}
+
+	var req models.SaveUserAddressRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid CreateAddress request", "error", err)
+		return
+	}
+
+	address, err := h.service.CreateAddress(r.Context(), user.ID, req)
+	if err != nil {
+		SendServiceError(w, h.logger, "create address", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(address); err != nil {
+		h.logger.Error("Failed to encode CreateAddress response", "error", err)
+	}
+}
+
+// GetAddress retrieves one of the current user's saved addresses.
+func (h *AddressHandler) GetAddress(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Authentication required.")
+		return
+	}
+
+	addressID, err := ParseUUIDPathParam(w, r, "address_id")
+	if err != nil {
+		return
+	}
+
+	address, err := h.service.GetAddress(r.Context(), user.ID, addressID)
+	if err != nil {
+		h.sendAddressError(w, "get address", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(address); err != nil {
+		h.logger.Error("Failed to encode GetAddress response", "error", err)
+	}
+}
+
+// UpdateAddress replaces the details of one of the current user's saved addresses.
+func (h *AddressHandler) UpdateAddress(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Authentication required.")
+		return
+	}
+
+	addressID, err := ParseUUIDPathParam(w, r, "address_id")
+	if err != nil {
+		return
+	}
+
+	var req models.SaveUserAddressRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid UpdateAddress request", "error", err)
+		return
+	}
+
+	address, err := h.service.UpdateAddress(r.Context(), user.ID, addressID, req)
+	if err != nil {
+		h.sendAddressError(w, "update address", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(address); err != nil {
+		h.logger.Error("Failed to encode UpdateAddress response", "error", err)
+	}
+}
+
+// DeleteAddress removes one of the current user's saved addresses.
+func (h *AddressHandler) DeleteAddress(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Authentication required.")
+		return
+	}
+
+	addressID, err := ParseUUIDPathParam(w, r, "address_id")
+	if err != nil {
+		return
+	}
+
+	if err := h.service.DeleteAddress(r.Context(), user.ID, addressID); err != nil {
+		h.sendAddressError(w, "delete address", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetDefaultAddress makes one of the current user's saved addresses the default one.
+func (h *AddressHandler) SetDefaultAddress(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Authentication required.")
+		return
+	}
+
+	addressID, err := ParseUUIDPathParam(w, r, "address_id")
+	if err != nil {
+		return
+	}
+
+	if err := h.service.SetDefaultAddress(r.Context(), user.ID, addressID); err != nil {
+		h.sendAddressError(w, "set default address", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AddressHandler) sendAddressError(w http.ResponseWriter, op string, err error) {
+	if errors.Is(err, services.ErrAddressNotFound) {
+		utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Address not found.")
+		return
+	}
+	SendServiceError(w, h.logger, op, err)
+}
@@ -34,6 +34,7 @@ func (h *AdminUserHandler) RegisterRoutes(r chi.Router) {
 	r.Get("/{id}", h.GetUser)                    // GET /api/v1/admin/users/{id}
 	r.Post("/{id}/activate", h.ActivateUser)     // POST /api/v1/admin/users/{id}/activate
 	r.Post("/{id}/deactivate", h.DeactivateUser) // POST /api/v1/admin/users/{id}/deactivate
+	r.Post("/{id}/unlock", h.UnlockUser)         // POST /api/v1/admin/users/{id}/unlock
 }
 
 // ListUsers handles the request to list users with optional filtering and pagination.
@@ -133,6 +134,10 @@ func (h *AdminUserHandler) DeactivateUser(w http.ResponseWriter, r *http.Request
 
 	err = h.service.DeactivateUser(r.Context(), id)
 	if err != nil {
+		if errors.Is(err, services.ErrLastSuperuser) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
 		h.logger.Error("Failed to deactivate user", "error", err, "user_id", id)
 		http.Error(w, "Failed to deactivate user", http.StatusInternalServerError)
 		return
@@ -141,3 +146,27 @@ func (h *AdminUserHandler) DeactivateUser(w http.ResponseWriter, r *http.Request
 	// Return 204 No Content on successful deactivation
 	w.WriteHeader(http.StatusNoContent) // 204 No Content
 }
+
+// UnlockUser handles lifting a login lockout of a user's account.
+func (h *AdminUserHandler) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.UnlockUser(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, services.ErrUserNotFound) {
+			http.Error(w, "User not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("Failed to unlock user", "error", err, "user_id", id)
+		http.Error(w, "Failed to unlock user", http.StatusInternalServerError)
+		return
+	}
+
+	// Return 204 No Content on successful unlock
+	w.WriteHeader(http.StatusNoContent) // 204 No Content
+}
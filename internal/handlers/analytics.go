@@ -11,6 +11,7 @@ import (
 	"github.com/MihoZaki/DzTech/internal/services"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 )
 
 // AnalyticsHandler handles HTTP requests for analytics endpoints.
@@ -35,9 +36,11 @@ func (h *AnalyticsHandler) RegisterRoutes(r chi.Router) {
 	r.Get("/average-order-value", h.GetAverageOrderValue) // GET /api/v1/admin/analytics/average-order-value?start_date=&end_date=
 	r.Get("/top-products", h.GetTopSellingProducts)       // GET /api/v1/admin/analytics/top-products?start_date=&end_date=&limit=
 	r.Get("/top-categories", h.GetTopSellingCategories)   // GET /api/v1/admin/analytics/top-categories?start_date=&end_date=&limit=
+	r.Get("/margins", h.GetMarginReport)                  // GET /api/v1/admin/analytics/margins?start_date=&end_date=&limit=
 
 	// Product Performance
-	r.Get("/low-stock", h.GetLowStockProducts) // GET /api/v1/admin/analytics/low-stock?threshold=
+	r.Get("/low-stock", h.GetLowStockProducts)                       // GET /api/v1/admin/analytics/low-stock?threshold=
+	r.Get("/low-stock/by-location", h.GetLowStockProductsByLocation) // GET /api/v1/admin/analytics/low-stock/by-location?threshold=&location_id=
 	// Note: GetProductReviewStats might be better under product endpoints, not analytics
 
 	// Customer Insights
@@ -254,6 +257,51 @@ func (h *AnalyticsHandler) GetTopSellingProducts(w http.ResponseWriter, r *http.
 	}
 }
 
+// GetMarginReport handles the request to get the gross margin report.
+func (h *AnalyticsHandler) GetMarginReport(w http.ResponseWriter, r *http.Request) {
+	// Parse query parameters
+	startDate, err := parseTimeParam(r, "start_date", time.Now().AddDate(0, -1, 0)) // Default to 1 month ago
+	if err != nil {
+		h.logger.Error("Invalid start_date parameter", "error", err)
+		http.Error(w, fmt.Sprintf(`{"error": "Invalid Parameter", "message": "%v"}`, err.Error()), http.StatusBadRequest)
+		return
+	}
+	endDate, err := parseTimeParam(r, "end_date", time.Now()) // Default to now
+	if err != nil {
+		h.logger.Error("Invalid end_date parameter", "error", err)
+		http.Error(w, fmt.Sprintf(`{"error": "Invalid Parameter", "message": "%v"}`, err.Error()), http.StatusBadRequest)
+		return
+	}
+	limit, err := parseLimitParam(r, 10) // Default to top 10
+	if err != nil {
+		h.logger.Error("Invalid limit parameter", "error", err)
+		http.Error(w, fmt.Sprintf(`{"error": "Invalid Parameter", "message": "%v"}`, err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	// Validate date range
+	if !endDate.After(*startDate) {
+		h.logger.Error("End date must be after start date", "start_date", startDate, "end_date", endDate)
+		http.Error(w, `{"error": "Invalid Parameter", "message": "End date must be after start date"}`, http.StatusBadRequest)
+		return
+	}
+
+	// Call the service
+	response, err := h.service.GetMarginReport(r.Context(), *startDate, *endDate, limit)
+	if err != nil {
+		h.logger.Error("Failed to get margin report", "error", err, "start_date", startDate, "end_date", endDate, "limit", limit)
+		http.Error(w, `{"error": "Internal Server Error", "message": "Failed to retrieve margin report"}`, http.StatusInternalServerError)
+		return
+	}
+
+	// Send success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("Failed to encode GetMarginReport response", "error", err)
+	}
+}
+
 // GetTopSellingCategories handles the request to get top selling categories.
 func (h *AnalyticsHandler) GetTopSellingCategories(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameters
@@ -325,6 +373,42 @@ func (h *AnalyticsHandler) GetLowStockProducts(w http.ResponseWriter, r *http.Re
 	}
 }
 
+// GetLowStockProductsByLocation handles the request to get low stock products per stock location.
+func (h *AnalyticsHandler) GetLowStockProductsByLocation(w http.ResponseWriter, r *http.Request) {
+	// Parse query parameters
+	threshold, err := parseThresholdParam(r)
+	if err != nil {
+		h.logger.Error("Invalid threshold parameter", "error", err)
+		http.Error(w, fmt.Sprintf(`{"error": "Invalid Parameter", "message": "%v"}`, err.Error()), http.StatusBadRequest)
+		return
+	}
+	var locationID *uuid.UUID
+	if locationIDStr := r.URL.Query().Get("location_id"); locationIDStr != "" {
+		parsed, err := uuid.Parse(locationIDStr)
+		if err != nil {
+			h.logger.Error("Invalid location_id parameter", "error", err)
+			http.Error(w, `{"error": "Invalid Parameter", "message": "invalid location_id parameter"}`, http.StatusBadRequest)
+			return
+		}
+		locationID = &parsed
+	}
+
+	// Call the service
+	response, err := h.service.GetLowStockProductsByLocation(r.Context(), threshold, locationID)
+	if err != nil {
+		h.logger.Error("Failed to get low stock products by location", "error", err, "threshold", threshold, "location_id", locationID)
+		http.Error(w, `{"error": "Internal Server Error", "message": "Failed to retrieve low stock products by location"}`, http.StatusInternalServerError)
+		return
+	}
+
+	// Send success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("Failed to encode GetLowStockProductsByLocation response", "error", err)
+	}
+}
+
 // GetNewCustomersCount handles the request to get new customer count.
 func (h *AnalyticsHandler) GetNewCustomersCount(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameters
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/MihoZaki/DzTech/internal/services"
+	"github.com/MihoZaki/DzTech/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+// APIKeyHandler handles admin HTTP requests for the API keys used by integrations.
+type APIKeyHandler struct {
+	service *services.APIKeyService
+	logger  *slog.Logger
+}
+
+// NewAPIKeyHandler creates a new instance of APIKeyHandler.
+func NewAPIKeyHandler(service *services.APIKeyService, logger *slog.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes registers the API key routes.
+// This should be mounted under the admin routes (e.g., /api/v1/admin/api-keys).
+func (h *APIKeyHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.ListAPIKeys)             // GET /api/v1/admin/api-keys
+	r.Post("/", h.CreateAPIKey)           // POST /api/v1/admin/api-keys
+	r.Get("/{key_id}", h.GetAPIKey)       // GET /api/v1/admin/api-keys/{key_id}
+	r.Delete("/{key_id}", h.RevokeAPIKey) // DELETE /api/v1/admin/api-keys/{key_id}
+}
+
+// ListAPIKeys lists every API key, without the keys themselves.
+func (h *APIKeyHandler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.service.ListAPIKeys(r.Context())
+	if err != nil {
+		SendServiceError(w, h.logger, "list API keys", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(keys); err != nil {
+		h.logger.Error("Failed to encode ListAPIKeys response", "error", err)
+	}
+}
+
+// CreateAPIKey creates an API key. The response is the only time the key is shown.
+func (h *APIKeyHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateAPIKeyRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid CreateAPIKey request", "error", err)
+		return
+	}
+
+	created, err := h.service.CreateAPIKey(r.Context(), req)
+	if err != nil {
+		h.sendAPIKeyError(w, "create API key", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(created); err != nil {
+		h.logger.Error("Failed to encode CreateAPIKey response", "error", err)
+	}
+}
+
+// GetAPIKey returns an API key's details and usage.
+func (h *APIKeyHandler) GetAPIKey(w http.ResponseWriter, r *http.Request) {
+	keyID, err := ParseUUIDPathParam(w, r, "key_id")
+	if err != nil {
+		return
+	}
+
+	key, err := h.service.GetAPIKey(r.Context(), keyID)
+	if err != nil {
+		h.sendAPIKeyError(w, "get API key", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(key); err != nil {
+		h.logger.Error("Failed to encode GetAPIKey response", "error", err)
+	}
+}
+
+// RevokeAPIKey revokes an API key; requests using it are rejected from then on.
+func (h *APIKeyHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	keyID, err := ParseUUIDPathParam(w, r, "key_id")
+	if err != nil {
+		return
+	}
+
+	if err := h.service.RevokeAPIKey(r.Context(), keyID); err != nil {
+		h.sendAPIKeyError(w, "revoke API key", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *APIKeyHandler) sendAPIKeyError(w http.ResponseWriter, operation string, err error) {
+	switch {
+	case errors.Is(err, services.ErrAPIKeyNotFound):
+		utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "API key not found.")
+	case errors.Is(err, services.ErrInvalidAPIKeyScope), errors.Is(err, services.ErrInvalidAPIKeyExpiry):
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Bad Request", err.Error())
+	default:
+		SendServiceError(w, h.logger, operation, err)
+	}
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/MihoZaki/DzTech/internal/services"
+	"github.com/MihoZaki/DzTech/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// AuditHandler handles admin HTTP requests for the audit log.
+type AuditHandler struct {
+	service *services.AuditService
+	logger  *slog.Logger
+}
+
+// NewAuditHandler creates a new instance of AuditHandler.
+func NewAuditHandler(service *services.AuditService, logger *slog.Logger) *AuditHandler {
+	return &AuditHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes registers the audit log routes.
+// This should be mounted under the admin routes (e.g., /api/v1/admin/audit-log).
+func (h *AuditHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.ListAuditLog) // GET /api/v1/admin/audit-log?actor_id=&entity_type=&entity_id=&action=&from=&to=&page=&limit=
+}
+
+// ListAuditLog lists recorded admin actions, newest first.
+// from and to are RFC 3339 timestamps; to is exclusive.
+func (h *AuditHandler) ListAuditLog(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := models.AuditLogFilter{
+		EntityType: query.Get("entity_type"),
+		EntityID:   query.Get("entity_id"),
+		Action:     query.Get("action"),
+	}
+	if actorID := query.Get("actor_id"); actorID != "" {
+		id, err := uuid.Parse(actorID)
+		if err != nil {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Bad Request", "Invalid actor_id")
+			return
+		}
+		filter.ActorID = id
+	}
+	for param, target := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		value := query.Get(param)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Bad Request", "Invalid "+param+", expected an RFC 3339 timestamp")
+			return
+		}
+		*target = &t
+	}
+	page, limit := parsePageParams(r)
+
+	result, err := h.service.ListAuditLog(r.Context(), filter, page, limit)
+	if err != nil {
+		SendServiceError(w, h.logger, "list audit log", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		h.logger.Error("Failed to encode ListAuditLog response", "error", err)
+	}
+}
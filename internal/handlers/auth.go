@@ -2,8 +2,12 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/MihoZaki/DzTech/internal/models"
@@ -15,6 +19,10 @@ import (
 
 const RefreshTokenCookieName = "refresh_token" // Define a constant for the cookie name
 
+// oidcStateCookieName binds a social login to the browser that started it, so a callback
+// with someone else's code and state cannot sign the victim in to the attacker's account.
+const oidcStateCookieName = "oidc_state"
+
 type AuthHandler struct {
 	authService *services.AuthService // Use AuthService instead of UserService directly for auth logic
 }
@@ -56,6 +64,27 @@ func setRefreshTokenCookie(w http.ResponseWriter, token string) {
 	http.SetCookie(w, cookie)
 }
 
+// deviceInfoFromRequest describes the client for the session a refresh token is issued to.
+// RemoteAddr already holds the client address resolved by the RealIP middleware.
+func deviceInfoFromRequest(r *http.Request) models.DeviceInfo {
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		ip = host
+	}
+	return models.DeviceInfo{
+		UserAgent: r.UserAgent(),
+		IPAddress: ip,
+	}
+}
+
+// sendLoginThrottled responds to a login refused after repeated failures. The response is the same
+// whether or not the email belongs to an account.
+func sendLoginThrottled(w http.ResponseWriter, throttled *services.LoginThrottledError) {
+	retryAfter := int(math.Ceil(throttled.RetryAfter.Seconds()))
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	utils.SendErrorResponse(w, http.StatusTooManyRequests, "Too Many Requests", "Too many failed login attempts, please try again later")
+}
+
 // Helper function to clear the refresh token cookie
 func clearRefreshTokenCookie(w http.ResponseWriter) {
 	cookie := &http.Cookie{
@@ -96,7 +125,7 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	} else {
 		slog.Debug("No guest session ID cookie found during registration", "error", err) // Usually means no guest cart
 	}
-	loginResp, refreshTokenStr, err := h.authService.Register(r.Context(), req.Email, req.Password, req.FullName, guestSessionID)
+	loginResp, refreshTokenStr, err := h.authService.Register(r.Context(), req.Email, req.Password, req.FullName, guestSessionID, deviceInfoFromRequest(r))
 	if err != nil {
 		if err.Error() == "user already exists" {
 			utils.SendErrorResponse(w, http.StatusConflict, "User Already Exists", "A user with this email already exists")
@@ -151,18 +180,32 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Use AuthService to handle login - now expects (LoginResponse, refreshTokenString, error)
-	loginResp, refreshTokenStr, err := h.authService.Login(r.Context(), req.Email, req.Password, guestSessionID)
+	loginResp, refreshTokenStr, err := h.authService.Login(r.Context(), req.Email, req.Password, guestSessionID, deviceInfoFromRequest(r))
 	if err != nil {
-		if err.Error() == "invalid credentials" {
+		if errors.Is(err, services.ErrInvalidCredentials) {
 			slog.Info("Login failed: invalid credentials", "email", req.Email)
 			utils.SendErrorResponse(w, http.StatusUnauthorized, "Invalid Credentials", "Invalid email or password")
 			return
 		}
+		var throttled *services.LoginThrottledError
+		if errors.As(err, &throttled) {
+			slog.Info("Login throttled", "email", req.Email, "retry_after", throttled.RetryAfter)
+			sendLoginThrottled(w, throttled)
+			return
+		}
 		slog.Error("Failed to authenticate user", "error", err, "email", req.Email)
 		utils.SendErrorResponse(w, http.StatusInternalServerError, "Internal Server Error", "Failed to authenticate user")
 		return
 	}
 
+	if loginResp.TwoFactorRequired {
+		// No tokens yet: the client exchanges the challenge token and a code at /login/2fa.
+		// The guest session cookie is kept so the cart is merged then.
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(loginResp)
+		return
+	}
+
 	slog.Info("User logged in successfully", "user_id", loginResp.User.ID, "email", loginResp.User.Email)
 
 	// Set the refresh token as a secure HTTP-only cookie
@@ -179,6 +222,141 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(loginResp) // Encode LoginResponse (without refresh token)
 }
 
+// LoginTwoFactor completes a login for a user with 2FA enabled, using the challenge token returned by Login.
+func (h *AuthHandler) LoginTwoFactor(w http.ResponseWriter, r *http.Request) {
+	var req models.TwoFactorLoginRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		slog.Debug("Invalid LoginTwoFactor request", "error", err)
+		return
+	}
+	var guestSessionID string
+	if sessionCookie, err := r.Cookie("session_id"); err == nil {
+		guestSessionID = sessionCookie.Value
+	}
+
+	var throttled *services.LoginThrottledError
+	loginResp, refreshTokenStr, err := h.authService.VerifyTwoFactorLogin(r.Context(), req.ChallengeToken, req.Code, guestSessionID, deviceInfoFromRequest(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidTwoFactorChallenge):
+			utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Login challenge is invalid or has expired, please sign in again")
+		case errors.Is(err, services.ErrInvalidTwoFactorCode):
+			utils.SendErrorResponse(w, http.StatusUnauthorized, "Invalid Code", "Invalid two-factor code")
+		case errors.Is(err, services.ErrTooManyTwoFactorAttempts):
+			utils.SendErrorResponse(w, http.StatusTooManyRequests, "Too Many Requests", err.Error())
+		case errors.As(err, &throttled):
+			sendLoginThrottled(w, throttled)
+		default:
+			slog.Error("Failed to complete two-factor login", "error", err)
+			utils.SendErrorResponse(w, http.StatusInternalServerError, "Internal Server Error", "Failed to authenticate user")
+		}
+		return
+	}
+
+	slog.Info("User logged in successfully with two-factor authentication", "user_id", loginResp.User.ID, "email", loginResp.User.Email)
+
+	setRefreshTokenCookie(w, refreshTokenStr)
+	if guestSessionID != "" {
+		deleteGuestSessionCookie(w)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(loginResp)
+}
+
+// OIDCAuthorize starts a social login and returns the identity provider URL the client should navigate to.
+func (h *AuthHandler) OIDCAuthorize(w http.ResponseWriter, r *http.Request) {
+	authURL, state, err := h.authService.StartOIDCLogin(r.Context())
+	if err != nil {
+		sendOIDCError(w, err)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookieName,
+		Value:    state,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+		MaxAge:   int((10 * time.Minute).Seconds()), // Matches the lifetime of the login state in the service
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.OIDCAuthorizationResponse{AuthorizationURL: authURL})
+}
+
+// OIDCCallback completes a social login with the code and state the identity provider redirected back with.
+// It responds like Login: tokens for the user, or a challenge token if the user has 2FA enabled.
+func (h *AuthHandler) OIDCCallback(w http.ResponseWriter, r *http.Request) {
+	var req models.OIDCCallbackRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		slog.Debug("Invalid OIDCCallback request", "error", err)
+		return
+	}
+	stateCookie, err := r.Cookie(oidcStateCookieName)
+	if err != nil || stateCookie.Value != req.State {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Bad Request", "Login session is invalid or has expired, please try again")
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+	})
+
+	var guestSessionID string
+	if sessionCookie, err := r.Cookie("session_id"); err == nil {
+		guestSessionID = sessionCookie.Value
+	}
+
+	loginResp, refreshTokenStr, err := h.authService.LoginWithOIDC(r.Context(), req.Code, req.State, guestSessionID, deviceInfoFromRequest(r))
+	if err != nil {
+		sendOIDCError(w, err)
+		return
+	}
+
+	if loginResp.TwoFactorRequired {
+		// As for password logins, the guest session cookie is kept until the second factor is verified
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(loginResp)
+		return
+	}
+
+	slog.Info("User logged in successfully with social login", "user_id", loginResp.User.ID, "email", loginResp.User.Email)
+
+	setRefreshTokenCookie(w, refreshTokenStr)
+	if guestSessionID != "" {
+		deleteGuestSessionCookie(w)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(loginResp)
+}
+
+func sendOIDCError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrOIDCNotConfigured):
+		utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Social login is not available")
+	case errors.Is(err, services.ErrInvalidOIDCState):
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Bad Request", "Login session is invalid or has expired, please try again")
+	case errors.Is(err, services.ErrOIDCEmailNotVerified):
+		utils.SendErrorResponse(w, http.StatusForbidden, "Forbidden", "Your account at the identity provider has no verified email")
+	case errors.Is(err, services.ErrAccountDeactivated):
+		utils.SendErrorResponse(w, http.StatusForbidden, "Forbidden", "This account has been deactivated")
+	case errors.Is(err, services.ErrOIDCProvider):
+		slog.Error("Social login failed at the identity provider", "error", err)
+		utils.SendErrorResponse(w, http.StatusBadGateway, "Bad Gateway", "Could not complete sign-in with the identity provider")
+	default:
+		slog.Error("Failed to complete social login", "error", err)
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Internal Server Error", "Failed to authenticate user")
+	}
+}
+
 func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
 	// Read the refresh token from the cookie
 	refreshTokenCookie, err := r.Cookie(RefreshTokenCookieName)
@@ -191,7 +369,7 @@ func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
 	refreshTokenStr := refreshTokenCookie.Value
 
 	// Call AuthService to perform the refresh logic (returns new access token and new refresh token string)
-	newAccessToken, newRefreshTokenStr, err := h.authService.Refresh(r.Context(), refreshTokenStr)
+	newAccessToken, newRefreshTokenStr, err := h.authService.Refresh(r.Context(), refreshTokenStr, deviceInfoFromRequest(r))
 	if err != nil {
 		slog.Error("Failed to refresh token", "error", err)
 		// Clear the invalid cookie if the token was rejected
@@ -259,6 +437,9 @@ func formatValidationError(err validator.FieldError) string {
 func (h *AuthHandler) RegisterRoutes(r chi.Router) {
 	r.Post("/register", h.Register)
 	r.Post("/login", h.Login)
+	r.Post("/login/2fa", h.LoginTwoFactor)
 	r.Post("/refresh", h.Refresh)
 	r.Post("/logout", h.Logout) // Add logout route
+	r.Get("/oidc/authorize", h.OIDCAuthorize)
+	r.Post("/oidc/callback", h.OIDCCallback)
 }
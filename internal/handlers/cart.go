@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -15,22 +16,25 @@ import (
 )
 
 type CartHandler struct {
-	cartService    *services.CartService
-	productService *services.ProductService // Might be needed for future operations
-	logger         *slog.Logger
+	cartService     *services.CartService
+	productService  *services.ProductService         // Might be needed for future operations
+	deliveryService *services.DeliveryServiceService // Prices delivery of the cart
+	logger          *slog.Logger
 }
 
-func NewCartHandler(cartService *services.CartService, productService *services.ProductService, logger *slog.Logger) *CartHandler {
+func NewCartHandler(cartService *services.CartService, productService *services.ProductService, deliveryService *services.DeliveryServiceService, logger *slog.Logger) *CartHandler {
 	return &CartHandler{
-		cartService:    cartService,
-		productService: productService,
-		logger:         logger,
+		cartService:     cartService,
+		productService:  productService,
+		deliveryService: deliveryService,
+		logger:          logger,
 	}
 }
 func (h *CartHandler) RegisterRoutes(r chi.Router) {
 	r.Get("/", h.GetCart)                            // GET /cart
 	r.Post("/items", h.AddItem)                      // POST /cart/items <- Add this line
 	r.Patch("/items/{itemID}", h.UpdateItemQuantity) // PATCH /cart/items/{id}
+	r.Get("/delivery-quotes", h.GetDeliveryQuotes)   // GET /cart/delivery-quotes?province=
 	r.Post("/add-bulk", h.AddBulkItemsToCart)
 	r.Delete("/items/{itemID}", h.RemoveItem) // DELETE /cart/items/{id} - Add this line
 	r.Delete("/", h.ClearCart)                // DELETE /cart - Add this line
@@ -133,6 +137,57 @@ func (h *CartHandler) GetCart(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(cartSummary)
 }
 
+// GetDeliveryQuotes prices delivery of the current user's or guest's cart to a province with each
+// active delivery service and delivery type.
+// Query: province, a wilaya code or name (required).
+// Response: 200 OK with a list of DeliveryQuote; services that do not deliver there are left out.
+//
+//	400 Bad Request if the province is missing or unknown.
+func (h *CartHandler) GetDeliveryQuotes(w http.ResponseWriter, r *http.Request) {
+	province := r.URL.Query().Get("province")
+	if strings.TrimSpace(province) == "" {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Bad Request", "province query parameter is required.")
+		return
+	}
+
+	var cartSummary *models.CartSummary
+	if user, ok := models.GetUserFromContext(r.Context()); ok {
+		var err error
+		cartSummary, err = h.cartService.GetCartForContext(r.Context(), &user.ID, "")
+		if err != nil {
+			h.logger.Error("Failed to get user cart", "user_id", user.ID, "error", err)
+			utils.SendErrorResponse(w, http.StatusInternalServerError, "Internal Server Error", "Failed to retrieve cart.")
+			return
+		}
+	} else if sessionID, ok := h.getSessionIDFromCookie(r); ok {
+		var err error
+		cartSummary, err = h.cartService.GetCartForContext(r.Context(), nil, sessionID)
+		if err != nil {
+			h.logger.Error("Failed to get guest cart", "session_id", sessionID, "error", err)
+			utils.SendErrorResponse(w, http.StatusInternalServerError, "Internal Server Error", "Failed to retrieve cart.")
+			return
+		}
+	} else {
+		// A guest without a session has not added anything yet
+		cartSummary = &models.CartSummary{}
+	}
+
+	quotes, err := h.deliveryService.QuoteCart(r.Context(), cartSummary, province)
+	if err != nil {
+		if errors.Is(err, services.ErrUnknownPlace) {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Bad Request", "Unknown province.")
+			return
+		}
+		SendServiceError(w, h.logger, "quote delivery", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(quotes); err != nil {
+		h.logger.Error("Failed to encode GetDeliveryQuotes response", "error", err)
+	}
+}
+
 // AddItem adds an item to the current user's or guest's cart.
 // Expected Headers: Authorization (Bearer token) for authenticated users.
 //
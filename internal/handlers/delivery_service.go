@@ -37,6 +37,8 @@ func (h *DeliveryServiceHandler) RegisterRoutes(r chi.Router) {
 	r.Get("/", h.ListAllDeliveryServices)      // GET /api/v1/admin/delivery-services?page=&limit=&active_only= (admin sees all)
 	r.Patch("/{id}", h.UpdateDeliveryService)  // PATCH /api/v1/admin/delivery-services/{id}
 	r.Delete("/{id}", h.DeleteDeliveryService) // DELETE /api/v1/admin/delivery-services/{id}
+	r.Get("/{id}/rates", h.GetRateTable)       // GET /api/v1/admin/delivery-services/{id}/rates
+	r.Put("/{id}/rates", h.ReplaceRateTable)   // PUT /api/v1/admin/delivery-services/{id}/rates
 }
 
 // CreateDeliveryService handles creating a new delivery service.
@@ -54,6 +56,10 @@ func (h *DeliveryServiceHandler) CreateDeliveryService(w http.ResponseWriter, r
 
 	deliveryService, err := h.service.CreateDeliveryService(r.Context(), req)
 	if err != nil {
+		if errors.Is(err, services.ErrUnknownCourier) {
+			http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+			return
+		}
 		// Log the error server-side
 		h.logger.Error("Failed to create delivery service", "error", err, "name", req.Name)
 		// Check for specific DB errors like unique_violation
@@ -169,6 +175,10 @@ func (h *DeliveryServiceHandler) UpdateDeliveryService(w http.ResponseWriter, r
 
 	updatedDeliveryService, err := h.service.UpdateDeliveryService(r.Context(), id, req)
 	if err != nil {
+		if errors.Is(err, services.ErrUnknownCourier) {
+			http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+			return
+		}
 		if errors.Is(err, services.ErrDeliveryServiceNotFound) {
 			http.Error(w, "Delivery service not found", http.StatusNotFound)
 			return
@@ -213,3 +223,68 @@ func (h *DeliveryServiceHandler) DeleteDeliveryService(w http.ResponseWriter, r
 
 	w.WriteHeader(http.StatusNoContent) // 204 No Content on successful delete
 }
+
+// GetRateTable handles retrieving the zones and rates of a delivery service.
+func (h *DeliveryServiceHandler) GetRateTable(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		http.Error(w, "Invalid delivery service ID format", http.StatusBadRequest)
+		return
+	}
+
+	table, err := h.service.GetRateTable(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, services.ErrDeliveryServiceNotFound) {
+			http.Error(w, "Delivery service not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("Failed to get delivery rate table", "error", err, "id", id)
+		http.Error(w, "Failed to retrieve delivery rate table", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK) // 200 OK
+	if err := json.NewEncoder(w).Encode(table); err != nil {
+		h.logger.Error("Failed to encode GetRateTable response", "error", err)
+	}
+}
+
+// ReplaceRateTable handles replacing the zones and rates of a delivery service.
+func (h *DeliveryServiceHandler) ReplaceRateTable(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		http.Error(w, "Invalid delivery service ID format", http.StatusBadRequest)
+		return
+	}
+
+	var req models.ReplaceDeliveryRatesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON in request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	table, err := h.service.ReplaceRateTable(r.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, services.ErrDeliveryServiceNotFound) {
+			http.Error(w, "Delivery service not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("Failed to replace delivery rate table", "error", err, "id", id)
+		http.Error(w, "Failed to save delivery rate table", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK) // 200 OK
+	if err := json.NewEncoder(w).Encode(table); err != nil {
+		h.logger.Error("Failed to encode ReplaceRateTable response", "error", err)
+	}
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/MihoZaki/DzTech/internal/services"
+	"github.com/MihoZaki/DzTech/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+// geographyCacheControl lets clients cache the reference data, which only changes with a release.
+const geographyCacheControl = "public, max-age=86400"
+
+// GeographyHandler serves the wilaya and commune lists used by address forms.
+type GeographyHandler struct {
+	service *services.GeographyService
+	logger  *slog.Logger
+}
+
+// NewGeographyHandler creates a new instance of GeographyHandler.
+func NewGeographyHandler(service *services.GeographyService, logger *slog.Logger) *GeographyHandler {
+	return &GeographyHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes registers the public geography routes (e.g., under /api/v1/geography).
+func (h *GeographyHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/wilayas", h.ListWilayas)                         // GET /api/v1/geography/wilayas
+	r.Get("/wilayas/{wilaya_code}/communes", h.ListCommunes) // GET /api/v1/geography/wilayas/{wilaya_code}/communes
+}
+
+// ListWilayas lists the 58 wilayas, ordered by code.
+func (h *GeographyHandler) ListWilayas(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", geographyCacheControl)
+	if err := json.NewEncoder(w).Encode(h.service.ListWilayas()); err != nil {
+		h.logger.Error("Failed to encode ListWilayas response", "error", err)
+	}
+}
+
+// ListCommunes lists the communes of a wilaya, ordered by name.
+func (h *GeographyHandler) ListCommunes(w http.ResponseWriter, r *http.Request) {
+	code, err := strconv.ParseInt(chi.URLParam(r, "wilaya_code"), 10, 16)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Bad Request", "Invalid wilaya code.")
+		return
+	}
+
+	communes, err := h.service.ListCommunes(int16(code))
+	if err != nil {
+		if errors.Is(err, services.ErrWilayaNotFound) {
+			utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Wilaya not found.")
+			return
+		}
+		SendServiceError(w, h.logger, "list communes", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", geographyCacheControl)
+	if err := json.NewEncoder(w).Encode(communes); err != nil {
+		h.logger.Error("Failed to encode ListCommunes response", "error", err)
+	}
+}
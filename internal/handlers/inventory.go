@@ -0,0 +1,277 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/MihoZaki/DzTech/internal/services"
+	"github.com/MihoZaki/DzTech/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+// InventoryHandler handles admin HTTP requests for the stock movement ledger.
+type InventoryHandler struct {
+	service *services.InventoryService
+	logger  *slog.Logger
+}
+
+// NewInventoryHandler creates a new instance of InventoryHandler.
+func NewInventoryHandler(service *services.InventoryService, logger *slog.Logger) *InventoryHandler {
+	return &InventoryHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes registers the inventory routes.
+// This should be mounted under the admin routes (e.g., /api/v1/admin/inventory).
+func (h *InventoryHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/locations", h.ListStockLocations)                                  // GET /api/v1/admin/inventory/locations
+	r.Post("/locations", h.CreateStockLocation)                                // POST /api/v1/admin/inventory/locations
+	r.Patch("/locations/{location_id}", h.UpdateStockLocation)                 // PATCH /api/v1/admin/inventory/locations/{location_id}
+	r.Get("/discrepancies", h.ListStockDiscrepancies)                          // GET /api/v1/admin/inventory/discrepancies
+	r.Get("/products/{product_id}/levels", h.ListProductStockLevels)           // GET /api/v1/admin/inventory/products/{product_id}/levels
+	r.Post("/products/{product_id}/transfers", h.TransferStock)                // POST /api/v1/admin/inventory/products/{product_id}/transfers
+	r.Get("/products/{product_id}/movements", h.ListStockMovements)            // GET /api/v1/admin/inventory/products/{product_id}/movements (with ?page=&limit=)
+	r.Post("/products/{product_id}/movements", h.RecordStockMovement)          // POST /api/v1/admin/inventory/products/{product_id}/movements
+	r.Get("/products/{product_id}/reconciliation", h.GetProductReconciliation) // GET /api/v1/admin/inventory/products/{product_id}/reconciliation
+}
+
+// ListStockMovements lists the ledger entries of a product.
+func (h *InventoryHandler) ListStockMovements(w http.ResponseWriter, r *http.Request) {
+	productID, err := ParseUUIDPathParam(w, r, "product_id")
+	if err != nil {
+		return
+	}
+
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	result, err := h.service.ListProductStockMovements(r.Context(), productID, page, limit)
+	if err != nil {
+		if errors.Is(err, services.ErrProductNotFound) {
+			utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Product not found.")
+			return
+		}
+		SendServiceError(w, h.logger, "list stock movements", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		h.logger.Error("Failed to encode ListStockMovements response", "error", err)
+	}
+}
+
+// RecordStockMovement records a manual adjustment, return or restock for a product.
+func (h *InventoryHandler) RecordStockMovement(w http.ResponseWriter, r *http.Request) {
+	productID, err := ParseUUIDPathParam(w, r, "product_id")
+	if err != nil {
+		return
+	}
+
+	var req models.CreateStockMovementRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid CreateStockMovement request", "error", err)
+		return
+	}
+
+	movement, err := h.service.RecordStockMovement(r.Context(), productID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrProductNotFound):
+			utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Product not found.")
+		case errors.Is(err, services.ErrStockLocationNotFound):
+			utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Stock location not found.")
+		case errors.Is(err, services.ErrOrderNotFound):
+			utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Order not found.")
+		case errors.Is(err, services.ErrInsufficientStock):
+			utils.SendErrorResponse(w, http.StatusConflict, "Conflict", "The movement would make the stock at the location negative.")
+		default:
+			SendServiceError(w, h.logger, "record stock movement", err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(movement); err != nil {
+		h.logger.Error("Failed to encode RecordStockMovement response", "error", err)
+	}
+}
+
+// TransferStock moves stock of a product between two locations.
+func (h *InventoryHandler) TransferStock(w http.ResponseWriter, r *http.Request) {
+	productID, err := ParseUUIDPathParam(w, r, "product_id")
+	if err != nil {
+		return
+	}
+
+	var req models.CreateStockTransferRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid CreateStockTransfer request", "error", err)
+		return
+	}
+
+	transfer, err := h.service.TransferStock(r.Context(), productID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrProductNotFound):
+			utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Product not found.")
+		case errors.Is(err, services.ErrStockLocationNotFound):
+			utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Stock location not found.")
+		case errors.Is(err, services.ErrInsufficientStock):
+			utils.SendErrorResponse(w, http.StatusConflict, "Conflict", "The source location does not hold enough stock.")
+		default:
+			SendServiceError(w, h.logger, "transfer stock", err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(transfer); err != nil {
+		h.logger.Error("Failed to encode TransferStock response", "error", err)
+	}
+}
+
+// ListProductStockLevels lists the stock of a product at every location.
+func (h *InventoryHandler) ListProductStockLevels(w http.ResponseWriter, r *http.Request) {
+	productID, err := ParseUUIDPathParam(w, r, "product_id")
+	if err != nil {
+		return
+	}
+
+	levels, err := h.service.ListProductStockLevels(r.Context(), productID)
+	if err != nil {
+		if errors.Is(err, services.ErrProductNotFound) {
+			utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Product not found.")
+			return
+		}
+		SendServiceError(w, h.logger, "list product stock levels", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(levels); err != nil {
+		h.logger.Error("Failed to encode ListProductStockLevels response", "error", err)
+	}
+}
+
+// ListStockLocations lists all stock locations.
+func (h *InventoryHandler) ListStockLocations(w http.ResponseWriter, r *http.Request) {
+	locations, err := h.service.ListStockLocations(r.Context())
+	if err != nil {
+		SendServiceError(w, h.logger, "list stock locations", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(locations); err != nil {
+		h.logger.Error("Failed to encode ListStockLocations response", "error", err)
+	}
+}
+
+// CreateStockLocation creates a new stock location.
+func (h *InventoryHandler) CreateStockLocation(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateStockLocationRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid CreateStockLocation request", "error", err)
+		return
+	}
+
+	location, err := h.service.CreateStockLocation(r.Context(), req)
+	if err != nil {
+		if errors.Is(err, services.ErrStockLocationExists) {
+			utils.SendErrorResponse(w, http.StatusConflict, "Conflict", err.Error())
+			return
+		}
+		SendServiceError(w, h.logger, "create stock location", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(location); err != nil {
+		h.logger.Error("Failed to encode CreateStockLocation response", "error", err)
+	}
+}
+
+// UpdateStockLocation updates a stock location (name, sellability, activation, priority).
+func (h *InventoryHandler) UpdateStockLocation(w http.ResponseWriter, r *http.Request) {
+	locationID, err := ParseUUIDPathParam(w, r, "location_id")
+	if err != nil {
+		return
+	}
+
+	var req models.UpdateStockLocationRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid UpdateStockLocation request", "error", err)
+		return
+	}
+
+	location, err := h.service.UpdateStockLocation(r.Context(), locationID, req)
+	if err != nil {
+		if errors.Is(err, services.ErrStockLocationNotFound) {
+			utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Stock location not found.")
+			return
+		}
+		SendServiceError(w, h.logger, "update stock location", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(location); err != nil {
+		h.logger.Error("Failed to encode UpdateStockLocation response", "error", err)
+	}
+}
+
+// GetProductReconciliation compares a product's stock at each location with the sum of its ledger movements there.
+func (h *InventoryHandler) GetProductReconciliation(w http.ResponseWriter, r *http.Request) {
+	productID, err := ParseUUIDPathParam(w, r, "product_id")
+	if err != nil {
+		return
+	}
+
+	reconciliation, err := h.service.GetProductStockReconciliation(r.Context(), productID)
+	if err != nil {
+		if errors.Is(err, services.ErrProductNotFound) {
+			utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Product not found.")
+			return
+		}
+		SendServiceError(w, h.logger, "reconcile product stock", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(reconciliation); err != nil {
+		h.logger.Error("Failed to encode GetProductReconciliation response", "error", err)
+	}
+}
+
+// ListStockDiscrepancies lists every product stock level that does not match its ledger.
+func (h *InventoryHandler) ListStockDiscrepancies(w http.ResponseWriter, r *http.Request) {
+	discrepancies, err := h.service.ListStockDiscrepancies(r.Context())
+	if err != nil {
+		SendServiceError(w, h.logger, "list stock discrepancies", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(discrepancies); err != nil {
+		h.logger.Error("Failed to encode ListStockDiscrepancies response", "error", err)
+	}
+}
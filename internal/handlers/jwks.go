@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/MihoZaki/DzTech/internal/utils"
+)
+
+// JWKSHandler publishes the public keys that verify the tokens issued by the API.
+type JWKSHandler struct {
+	keys   *utils.JWTKeySet
+	logger *slog.Logger
+}
+
+// NewJWKSHandler creates a new instance of JWKSHandler.
+func NewJWKSHandler(keys *utils.JWTKeySet, logger *slog.Logger) *JWKSHandler {
+	return &JWKSHandler{
+		keys:   keys,
+		logger: logger,
+	}
+}
+
+// GetJWKS serves the JSON Web Key Set at /.well-known/jwks.json.
+// Verifiers may cache it briefly; after a key rotation they refetch it on meeting an unknown kid.
+func (h *JWKSHandler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	if err := json.NewEncoder(w).Encode(h.keys.JWKS()); err != nil {
+		h.logger.Error("Failed to encode JWKS response", "error", err)
+	}
+}
@@ -80,6 +80,18 @@ func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 	// 4. Call the Service Method
 	orderSummary, err := h.service.CreateOrder(r.Context(), req, &userID, sessionID) // Pass the NEW req and userID
 	if err != nil {
+		if errors.Is(err, services.ErrAddressNotFound) {
+			http.Error(w, "Saved address not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, services.ErrUnknownPlace) {
+			http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, services.ErrDeliveryServiceNotFound) || errors.Is(err, services.ErrDeliveryUnavailable) {
+			http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+			return
+		}
 		// Log the error server-side
 		h.logger.Error("Failed to create order", "error", err, "user_id", userID)
 		// Return a generic error message to the client
@@ -129,10 +141,22 @@ func (h *OrderHandler) CreateGuestOrder(w http.ResponseWriter, r *http.Request)
 		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
 		return
 	}
+	if req.AddressID != nil {
+		http.Error(w, "Validation error: guests must provide a shipping_address", http.StatusBadRequest)
+		return
+	}
 
 	// 4. Call the Service Method (pass nil for userID, sessionID)
 	orderSummary, err := h.service.CreateOrder(r.Context(), req, userID, sessionID) // Pass req, nil userID, and sessionID
 	if err != nil {
+		if errors.Is(err, services.ErrUnknownPlace) {
+			http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, services.ErrDeliveryServiceNotFound) || errors.Is(err, services.ErrDeliveryUnavailable) {
+			http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+			return
+		}
 		// Log the error server-side
 		h.logger.Error("Failed to create order for guest user", "error", err, "session_id", sessionIDStr)
 		// Return a generic error message to the client
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -15,6 +16,8 @@ import (
 	"github.com/google/uuid"
 )
 
+var errInvalidWeightGrams = errors.New("weight_grams must be a positive integer")
+
 type ProductHandler struct {
 	productService *services.ProductService
 }
@@ -45,6 +48,10 @@ func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err != nil {
+		if errors.Is(err, errInvalidWeightGrams) {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Bad Request", err.Error())
+			return
+		}
 		slog.Error("Failed to create product", "error", err)
 		utils.SendErrorResponse(w, http.StatusInternalServerError, "Internal Server Error", "Failed to create product")
 		return
@@ -99,6 +106,10 @@ func (h *ProductHandler) createProductFromMultipart(r *http.Request) (*models.Pr
 	} else {
 		specHighlights = make(map[string]any) // Initialize as empty map if not provided
 	}
+	weightGrams, err := parseWeightGramsFormValue(r)
+	if err != nil {
+		return nil, err
+	}
 	imageFileHeaders := r.MultipartForm.File["images"] // Get []*multipart.FileHeader
 	slog.Debug("backend received the image files headers", "image_headers", imageFileHeaders)
 
@@ -113,6 +124,7 @@ func (h *ProductHandler) createProductFromMultipart(r *http.Request) (*models.Pr
 		Brand:            brand,
 		ImageUrls:        []string{}, // Initialize as empty, will be filled by service
 		SpecHighlights:   specHighlights,
+		WeightGrams:      weightGrams,
 	}
 
 	err = req.Validate()
@@ -312,6 +324,14 @@ func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 			utils.SendErrorResponse(w, http.StatusBadRequest, "Bad Request", "Category not found")
 			return
 		}
+		if errors.Is(err, errInvalidWeightGrams) {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Bad Request", err.Error())
+			return
+		}
+		if errors.Is(err, services.ErrStockHeldAtSeveralLocations) {
+			utils.SendErrorResponse(w, http.StatusConflict, "Conflict", err.Error())
+			return
+		}
 		slog.Error("Failed to update product", "error", err, "product_id", productID)
 		utils.SendErrorResponse(w, http.StatusInternalServerError, "Internal Server Error", "Failed to update product")
 		return
@@ -393,6 +413,9 @@ func (h *ProductHandler) updateProductFromMultipart(r *http.Request, productID u
 			return nil, fmt.Errorf("invalid spec_highlights JSON: %w", err)
 		}
 	}
+	if req.WeightGrams, err = parseWeightGramsFormValue(r); err != nil {
+		return nil, err
+	}
 	imageFiles := r.MultipartForm.File["images"]
 	slog.Debug("Update handler received the update image headers", "headers", imageFiles)
 
@@ -513,3 +536,17 @@ func (h *ProductHandler) RegisterRoutes(r chi.Router) {
 
 	r.Get("/search", h.SearchProducts)
 }
+
+// parseWeightGramsFormValue reads the optional weight_grams field of a multipart product form.
+func parseWeightGramsFormValue(r *http.Request) (*int32, error) {
+	val := r.FormValue("weight_grams")
+	if val == "" {
+		return nil, nil
+	}
+	parsedVal, err := strconv.ParseInt(val, 10, 32)
+	if err != nil || parsedVal <= 0 {
+		return nil, errInvalidWeightGrams
+	}
+	weight := int32(parsedVal)
+	return &weight, nil
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/MihoZaki/DzTech/internal/services"
+	"github.com/MihoZaki/DzTech/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// ProductAlertHandler handles HTTP requests for back-in-stock and price-drop alerts.
+type ProductAlertHandler struct {
+	service *services.ProductAlertService
+	logger  *slog.Logger
+}
+
+// NewProductAlertHandler creates a new instance of ProductAlertHandler.
+func NewProductAlertHandler(service *services.ProductAlertService, logger *slog.Logger) *ProductAlertHandler {
+	return &ProductAlertHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes registers the product alert routes.
+// This should be mounted under the authenticated user routes (e.g., /api/v1/user/product-alerts).
+func (h *ProductAlertHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.ListAlerts)               // GET /api/v1/user/product-alerts
+	r.Post("/", h.CreateAlert)             // POST /api/v1/user/product-alerts
+	r.Delete("/{alert_id}", h.DeleteAlert) // DELETE /api/v1/user/product-alerts/{alert_id}
+}
+
+// CreateAlert subscribes the current user to an alert for a product.
+func (h *ProductAlertHandler) CreateAlert(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Authentication required.")
+		return
+	}
+
+	var req models.CreateProductAlertRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid CreateProductAlert request", "error", err)
+		return
+	}
+
+	alert, err := h.service.CreateAlert(r.Context(), user.ID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrProductNotFound):
+			utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Product not found.")
+		case errors.Is(err, services.ErrProductAlertExists), errors.Is(err, services.ErrProductInStock):
+			utils.SendErrorResponse(w, http.StatusConflict, "Conflict", err.Error())
+		default:
+			SendServiceError(w, h.logger, "create product alert", err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(alert); err != nil {
+		h.logger.Error("Failed to encode CreateProductAlert response", "error", err)
+	}
+}
+
+// ListAlerts lists the current user's alerts.
+func (h *ProductAlertHandler) ListAlerts(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Authentication required.")
+		return
+	}
+
+	alerts, err := h.service.ListUserAlerts(r.Context(), user.ID)
+	if err != nil {
+		SendServiceError(w, h.logger, "list product alerts", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(alerts); err != nil {
+		h.logger.Error("Failed to encode ListProductAlerts response", "error", err)
+	}
+}
+
+// DeleteAlert removes one of the current user's alerts.
+func (h *ProductAlertHandler) DeleteAlert(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Authentication required.")
+		return
+	}
+
+	alertID, err := ParseUUIDPathParam(w, r, "alert_id")
+	if err != nil {
+		return
+	}
+
+	if err := h.service.DeleteAlert(r.Context(), user.ID, alertID); err != nil {
+		if errors.Is(err, services.ErrProductAlertNotFound) {
+			utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Product alert not found.")
+			return
+		}
+		SendServiceError(w, h.logger, "delete product alert", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
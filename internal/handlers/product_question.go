@@ -0,0 +1,385 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/MihoZaki/DzTech/internal/services"
+	"github.com/MihoZaki/DzTech/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// ProductQuestionHandler handles HTTP requests for product questions and answers.
+type ProductQuestionHandler struct {
+	service *services.ProductQuestionService
+	logger  *slog.Logger
+}
+
+// NewProductQuestionHandler creates a new instance of ProductQuestionHandler.
+func NewProductQuestionHandler(service *services.ProductQuestionService, logger *slog.Logger) *ProductQuestionHandler {
+	return &ProductQuestionHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes registers the routes for asking, answering and upvoting.
+// This should be mounted behind the JWT middleware (e.g., /api/v1/questions).
+func (h *ProductQuestionHandler) RegisterRoutes(r chi.Router) {
+	r.Post("/", h.AskQuestion)                                  // POST /api/v1/questions
+	r.Post("/{question_id}/answers", h.AnswerQuestion)          // POST /api/v1/questions/{question_id}/answers
+	r.Put("/answers/{answer_id}/upvote", h.UpvoteAnswer)        // PUT /api/v1/questions/answers/{answer_id}/upvote
+	r.Delete("/answers/{answer_id}/upvote", h.RemoveAnswerVote) // DELETE /api/v1/questions/answers/{answer_id}/upvote
+}
+
+// RegisterAdminRoutes registers the question and answer moderation routes.
+// This should be mounted under the admin routes (e.g., /api/v1/admin/questions).
+func (h *ProductQuestionHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Get("/", h.ListQuestionsForModeration)                // GET /api/v1/admin/questions?status=pending|approved|rejected|all&page=&limit=
+	r.Post("/{question_id}/approve", h.ApproveQuestion)     // POST /api/v1/admin/questions/{question_id}/approve
+	r.Post("/{question_id}/reject", h.RejectQuestion)       // POST /api/v1/admin/questions/{question_id}/reject
+	r.Post("/{question_id}/answers", h.PostOfficialAnswer)  // POST /api/v1/admin/questions/{question_id}/answers
+	r.Get("/answers", h.ListAnswersForModeration)           // GET /api/v1/admin/questions/answers?status=pending|approved|rejected|all&page=&limit=
+	r.Post("/answers/{answer_id}/approve", h.ApproveAnswer) // POST /api/v1/admin/questions/answers/{answer_id}/approve
+	r.Post("/answers/{answer_id}/reject", h.RejectAnswer)   // POST /api/v1/admin/questions/answers/{answer_id}/reject
+}
+
+// ListProductQuestions lists the published questions of a product with their answers.
+// It is public and mounted on the product routes (GET /api/v1/products/{id}/questions?page=&limit=).
+func (h *ProductQuestionHandler) ListProductQuestions(w http.ResponseWriter, r *http.Request) {
+	productID, err := ParseUUIDPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+	page, limit := parsePageParams(r)
+
+	result, err := h.service.ListProductQuestions(r.Context(), productID, page, limit)
+	if err != nil {
+		SendServiceError(w, h.logger, "list product questions", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		h.logger.Error("Failed to encode ListProductQuestions response", "error", err)
+	}
+}
+
+// AskQuestion records a question about a product.
+func (h *ProductQuestionHandler) AskQuestion(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Authentication required.")
+		return
+	}
+
+	var req models.CreateProductQuestionRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid AskQuestion request", "error", err)
+		return
+	}
+
+	question, err := h.service.AskQuestion(r.Context(), user.ID, req)
+	if err != nil {
+		if errors.Is(err, services.ErrProductNotFound) {
+			utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Product not found.")
+			return
+		}
+		SendServiceError(w, h.logger, "ask product question", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(question); err != nil {
+		h.logger.Error("Failed to encode AskQuestion response", "error", err)
+	}
+}
+
+// AnswerQuestion records a customer's answer to a published question.
+func (h *ProductQuestionHandler) AnswerQuestion(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Authentication required.")
+		return
+	}
+
+	questionID, err := ParseUUIDPathParam(w, r, "question_id")
+	if err != nil {
+		return
+	}
+
+	var req models.CreateProductAnswerRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid AnswerQuestion request", "error", err)
+		return
+	}
+
+	answer, err := h.service.AnswerQuestion(r.Context(), questionID, user.ID, req.Body)
+	if err != nil {
+		h.sendQAError(w, "answer product question", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(answer); err != nil {
+		h.logger.Error("Failed to encode AnswerQuestion response", "error", err)
+	}
+}
+
+// UpvoteAnswer records the user's upvote on an answer.
+func (h *ProductQuestionHandler) UpvoteAnswer(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Authentication required.")
+		return
+	}
+
+	answerID, err := ParseUUIDPathParam(w, r, "answer_id")
+	if err != nil {
+		return
+	}
+
+	summary, err := h.service.UpvoteAnswer(r.Context(), answerID, user.ID)
+	if err != nil {
+		h.sendQAError(w, "upvote product answer", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(summary); err != nil {
+		h.logger.Error("Failed to encode UpvoteAnswer response", "error", err)
+	}
+}
+
+// RemoveAnswerVote withdraws the user's upvote on an answer.
+func (h *ProductQuestionHandler) RemoveAnswerVote(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Authentication required.")
+		return
+	}
+
+	answerID, err := ParseUUIDPathParam(w, r, "answer_id")
+	if err != nil {
+		return
+	}
+
+	summary, err := h.service.RemoveAnswerUpvote(r.Context(), answerID, user.ID)
+	if err != nil {
+		h.sendQAError(w, "remove product answer upvote", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(summary); err != nil {
+		h.logger.Error("Failed to encode RemoveAnswerVote response", "error", err)
+	}
+}
+
+// ListQuestionsForModeration lists questions for the moderation queue (pending by default).
+func (h *ProductQuestionHandler) ListQuestionsForModeration(w http.ResponseWriter, r *http.Request) {
+	status, ok := parseQAStatusParam(w, r)
+	if !ok {
+		return
+	}
+	page, limit := parsePageParams(r)
+
+	result, err := h.service.ListQuestionsForModeration(r.Context(), status, page, limit)
+	if err != nil {
+		SendServiceError(w, h.logger, "list product questions for moderation", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		h.logger.Error("Failed to encode ListQuestionsForModeration response", "error", err)
+	}
+}
+
+// ApproveQuestion publishes a question.
+func (h *ProductQuestionHandler) ApproveQuestion(w http.ResponseWriter, r *http.Request) {
+	questionID, err := ParseUUIDPathParam(w, r, "question_id")
+	if err != nil {
+		return
+	}
+
+	question, err := h.service.ApproveQuestion(r.Context(), questionID)
+	if err != nil {
+		h.sendQAError(w, "approve product question", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(question); err != nil {
+		h.logger.Error("Failed to encode ApproveQuestion response", "error", err)
+	}
+}
+
+// RejectQuestion hides a question with a reason.
+func (h *ProductQuestionHandler) RejectQuestion(w http.ResponseWriter, r *http.Request) {
+	questionID, err := ParseUUIDPathParam(w, r, "question_id")
+	if err != nil {
+		return
+	}
+
+	var req models.RejectProductQARequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid RejectQuestion request", "error", err)
+		return
+	}
+
+	question, err := h.service.RejectQuestion(r.Context(), questionID, req.Reason)
+	if err != nil {
+		h.sendQAError(w, "reject product question", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(question); err != nil {
+		h.logger.Error("Failed to encode RejectQuestion response", "error", err)
+	}
+}
+
+// PostOfficialAnswer records a staff answer, published immediately and marked official.
+func (h *ProductQuestionHandler) PostOfficialAnswer(w http.ResponseWriter, r *http.Request) {
+	questionID, err := ParseUUIDPathParam(w, r, "question_id")
+	if err != nil {
+		return
+	}
+
+	var req models.CreateProductAnswerRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid PostOfficialAnswer request", "error", err)
+		return
+	}
+
+	answer, err := h.service.PostOfficialAnswer(r.Context(), questionID, req.Body)
+	if err != nil {
+		h.sendQAError(w, "post official product answer", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(answer); err != nil {
+		h.logger.Error("Failed to encode PostOfficialAnswer response", "error", err)
+	}
+}
+
+// ListAnswersForModeration lists answers for the moderation queue (pending by default).
+func (h *ProductQuestionHandler) ListAnswersForModeration(w http.ResponseWriter, r *http.Request) {
+	status, ok := parseQAStatusParam(w, r)
+	if !ok {
+		return
+	}
+	page, limit := parsePageParams(r)
+
+	result, err := h.service.ListAnswersForModeration(r.Context(), status, page, limit)
+	if err != nil {
+		SendServiceError(w, h.logger, "list product answers for moderation", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		h.logger.Error("Failed to encode ListAnswersForModeration response", "error", err)
+	}
+}
+
+// ApproveAnswer publishes an answer.
+func (h *ProductQuestionHandler) ApproveAnswer(w http.ResponseWriter, r *http.Request) {
+	answerID, err := ParseUUIDPathParam(w, r, "answer_id")
+	if err != nil {
+		return
+	}
+
+	answer, err := h.service.ApproveAnswer(r.Context(), answerID)
+	if err != nil {
+		h.sendQAError(w, "approve product answer", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(answer); err != nil {
+		h.logger.Error("Failed to encode ApproveAnswer response", "error", err)
+	}
+}
+
+// RejectAnswer hides an answer with a reason.
+func (h *ProductQuestionHandler) RejectAnswer(w http.ResponseWriter, r *http.Request) {
+	answerID, err := ParseUUIDPathParam(w, r, "answer_id")
+	if err != nil {
+		return
+	}
+
+	var req models.RejectProductQARequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid RejectAnswer request", "error", err)
+		return
+	}
+
+	answer, err := h.service.RejectAnswer(r.Context(), answerID, req.Reason)
+	if err != nil {
+		h.sendQAError(w, "reject product answer", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(answer); err != nil {
+		h.logger.Error("Failed to encode RejectAnswer response", "error", err)
+	}
+}
+
+func (h *ProductQuestionHandler) sendQAError(w http.ResponseWriter, operation string, err error) {
+	switch {
+	case errors.Is(err, services.ErrProductQuestionNotFound):
+		utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Question not found.")
+	case errors.Is(err, services.ErrProductAnswerNotFound):
+		utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Answer not found.")
+	case errors.Is(err, services.ErrProductAnswerVoteNotFound):
+		utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", err.Error())
+	case errors.Is(err, services.ErrProductAnswerOwnVote):
+		utils.SendErrorResponse(w, http.StatusForbidden, "Forbidden", err.Error())
+	default:
+		SendServiceError(w, h.logger, operation, err)
+	}
+}
+
+// parseQAStatusParam reads the moderation status filter, defaulting to pending; "all" disables it.
+func parseQAStatusParam(w http.ResponseWriter, r *http.Request) (string, bool) {
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		return models.ProductQAStatusPending, true
+	case "all":
+		return "", true
+	case models.ProductQAStatusPending, models.ProductQAStatusApproved, models.ProductQAStatusRejected:
+		return status, true
+	default:
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Bad Request", "Invalid status. Use pending, approved, rejected or all.")
+		return "", false
+	}
+}
+
+// parsePageParams reads the page and limit query parameters (defaults 1 and 20, limit capped at 100).
+func parsePageParams(r *http.Request) (int, int) {
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	return page, limit
+}
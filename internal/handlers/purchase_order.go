@@ -0,0 +1,335 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/MihoZaki/DzTech/internal/services"
+	"github.com/MihoZaki/DzTech/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// PurchaseOrderHandler handles admin HTTP requests for suppliers and purchase orders.
+type PurchaseOrderHandler struct {
+	service *services.PurchaseOrderService
+	logger  *slog.Logger
+}
+
+// NewPurchaseOrderHandler creates a new instance of PurchaseOrderHandler.
+func NewPurchaseOrderHandler(service *services.PurchaseOrderService, logger *slog.Logger) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterSupplierRoutes registers the supplier routes.
+// This should be mounted under the admin routes (e.g., /api/v1/admin/suppliers).
+func (h *PurchaseOrderHandler) RegisterSupplierRoutes(r chi.Router) {
+	r.Get("/", h.ListSuppliers)        // GET /api/v1/admin/suppliers (with ?active_only=true)
+	r.Post("/", h.CreateSupplier)      // POST /api/v1/admin/suppliers
+	r.Get("/{id}", h.GetSupplier)      // GET /api/v1/admin/suppliers/{id}
+	r.Patch("/{id}", h.UpdateSupplier) // PATCH /api/v1/admin/suppliers/{id}
+}
+
+// RegisterRoutes registers the purchase order routes.
+// This should be mounted under the admin routes (e.g., /api/v1/admin/purchase-orders).
+func (h *PurchaseOrderHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.ListPurchaseOrders)                // GET /api/v1/admin/purchase-orders (with ?status=&supplier_id=&page=&limit=)
+	r.Post("/", h.CreatePurchaseOrder)              // POST /api/v1/admin/purchase-orders
+	r.Get("/{id}", h.GetPurchaseOrder)              // GET /api/v1/admin/purchase-orders/{id}
+	r.Patch("/{id}", h.UpdatePurchaseOrder)         // PATCH /api/v1/admin/purchase-orders/{id} (drafts only)
+	r.Post("/{id}/order", h.PlacePurchaseOrder)     // POST /api/v1/admin/purchase-orders/{id}/order
+	r.Post("/{id}/receive", h.ReceivePurchaseOrder) // POST /api/v1/admin/purchase-orders/{id}/receive
+	r.Post("/{id}/cancel", h.CancelPurchaseOrder)   // POST /api/v1/admin/purchase-orders/{id}/cancel
+}
+
+// --- Suppliers ---
+
+// ListSuppliers lists suppliers.
+func (h *PurchaseOrderHandler) ListSuppliers(w http.ResponseWriter, r *http.Request) {
+	activeOnly := r.URL.Query().Get("active_only") == "true"
+
+	suppliers, err := h.service.ListSuppliers(r.Context(), activeOnly)
+	if err != nil {
+		SendServiceError(w, h.logger, "list suppliers", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(suppliers); err != nil {
+		h.logger.Error("Failed to encode ListSuppliers response", "error", err)
+	}
+}
+
+// CreateSupplier creates a new supplier.
+func (h *PurchaseOrderHandler) CreateSupplier(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateSupplierRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid CreateSupplier request", "error", err)
+		return
+	}
+
+	supplier, err := h.service.CreateSupplier(r.Context(), req)
+	if err != nil {
+		if errors.Is(err, services.ErrSupplierExists) {
+			utils.SendErrorResponse(w, http.StatusConflict, "Conflict", err.Error())
+			return
+		}
+		SendServiceError(w, h.logger, "create supplier", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(supplier); err != nil {
+		h.logger.Error("Failed to encode CreateSupplier response", "error", err)
+	}
+}
+
+// GetSupplier retrieves a supplier.
+func (h *PurchaseOrderHandler) GetSupplier(w http.ResponseWriter, r *http.Request) {
+	supplierID, err := ParseUUIDPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+
+	supplier, err := h.service.GetSupplier(r.Context(), supplierID)
+	if err != nil {
+		if errors.Is(err, services.ErrSupplierNotFound) {
+			utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Supplier not found.")
+			return
+		}
+		SendServiceError(w, h.logger, "get supplier", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(supplier); err != nil {
+		h.logger.Error("Failed to encode GetSupplier response", "error", err)
+	}
+}
+
+// UpdateSupplier updates a supplier.
+func (h *PurchaseOrderHandler) UpdateSupplier(w http.ResponseWriter, r *http.Request) {
+	supplierID, err := ParseUUIDPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+
+	var req models.UpdateSupplierRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid UpdateSupplier request", "error", err)
+		return
+	}
+
+	supplier, err := h.service.UpdateSupplier(r.Context(), supplierID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrSupplierNotFound):
+			utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Supplier not found.")
+		case errors.Is(err, services.ErrSupplierExists):
+			utils.SendErrorResponse(w, http.StatusConflict, "Conflict", err.Error())
+		default:
+			SendServiceError(w, h.logger, "update supplier", err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(supplier); err != nil {
+		h.logger.Error("Failed to encode UpdateSupplier response", "error", err)
+	}
+}
+
+// --- Purchase orders ---
+
+// ListPurchaseOrders lists purchase orders.
+func (h *PurchaseOrderHandler) ListPurchaseOrders(w http.ResponseWriter, r *http.Request) {
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	var supplierID *uuid.UUID
+	if supplierIDStr := r.URL.Query().Get("supplier_id"); supplierIDStr != "" {
+		id, err := uuid.Parse(supplierIDStr)
+		if err != nil {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Bad Request", "Invalid supplier_id format.")
+			return
+		}
+		supplierID = &id
+	}
+
+	result, err := h.service.ListPurchaseOrders(r.Context(), r.URL.Query().Get("status"), supplierID, page, limit)
+	if err != nil {
+		SendServiceError(w, h.logger, "list purchase orders", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		h.logger.Error("Failed to encode ListPurchaseOrders response", "error", err)
+	}
+}
+
+// CreatePurchaseOrder creates a draft purchase order.
+func (h *PurchaseOrderHandler) CreatePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	var req models.CreatePurchaseOrderRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid CreatePurchaseOrder request", "error", err)
+		return
+	}
+
+	purchaseOrder, err := h.service.CreatePurchaseOrder(r.Context(), req)
+	if err != nil {
+		h.sendPurchaseOrderError(w, "create purchase order", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(purchaseOrder); err != nil {
+		h.logger.Error("Failed to encode CreatePurchaseOrder response", "error", err)
+	}
+}
+
+// GetPurchaseOrder retrieves a purchase order with its lines and receipts.
+func (h *PurchaseOrderHandler) GetPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	purchaseOrderID, err := ParseUUIDPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+
+	purchaseOrder, err := h.service.GetPurchaseOrder(r.Context(), purchaseOrderID)
+	if err != nil {
+		h.sendPurchaseOrderError(w, "get purchase order", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(purchaseOrder); err != nil {
+		h.logger.Error("Failed to encode GetPurchaseOrder response", "error", err)
+	}
+}
+
+// UpdatePurchaseOrder updates a draft purchase order.
+func (h *PurchaseOrderHandler) UpdatePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	purchaseOrderID, err := ParseUUIDPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+
+	var req models.UpdatePurchaseOrderRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid UpdatePurchaseOrder request", "error", err)
+		return
+	}
+
+	purchaseOrder, err := h.service.UpdatePurchaseOrder(r.Context(), purchaseOrderID, req)
+	if err != nil {
+		h.sendPurchaseOrderError(w, "update purchase order", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(purchaseOrder); err != nil {
+		h.logger.Error("Failed to encode UpdatePurchaseOrder response", "error", err)
+	}
+}
+
+// PlacePurchaseOrder marks a draft purchase order as ordered.
+func (h *PurchaseOrderHandler) PlacePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	purchaseOrderID, err := ParseUUIDPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+
+	purchaseOrder, err := h.service.PlacePurchaseOrder(r.Context(), purchaseOrderID)
+	if err != nil {
+		h.sendPurchaseOrderError(w, "place purchase order", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(purchaseOrder); err != nil {
+		h.logger.Error("Failed to encode PlacePurchaseOrder response", "error", err)
+	}
+}
+
+// ReceivePurchaseOrder receives (part of) a purchase order into stock.
+func (h *PurchaseOrderHandler) ReceivePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	purchaseOrderID, err := ParseUUIDPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+
+	var req models.ReceivePurchaseOrderRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid ReceivePurchaseOrder request", "error", err)
+		return
+	}
+
+	purchaseOrder, err := h.service.ReceivePurchaseOrder(r.Context(), purchaseOrderID, req)
+	if err != nil {
+		h.sendPurchaseOrderError(w, "receive purchase order", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(purchaseOrder); err != nil {
+		h.logger.Error("Failed to encode ReceivePurchaseOrder response", "error", err)
+	}
+}
+
+// CancelPurchaseOrder cancels a purchase order that has not received anything yet.
+func (h *PurchaseOrderHandler) CancelPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	purchaseOrderID, err := ParseUUIDPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+
+	purchaseOrder, err := h.service.CancelPurchaseOrder(r.Context(), purchaseOrderID)
+	if err != nil {
+		h.sendPurchaseOrderError(w, "cancel purchase order", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(purchaseOrder); err != nil {
+		h.logger.Error("Failed to encode CancelPurchaseOrder response", "error", err)
+	}
+}
+
+// sendPurchaseOrderError maps purchase order service errors to HTTP responses.
+func (h *PurchaseOrderHandler) sendPurchaseOrderError(w http.ResponseWriter, operation string, err error) {
+	switch {
+	case errors.Is(err, services.ErrPurchaseOrderNotFound):
+		utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Purchase order not found.")
+	case errors.Is(err, services.ErrSupplierNotFound):
+		utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Supplier not found.")
+	case errors.Is(err, services.ErrStockLocationNotFound):
+		utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Stock location not found.")
+	case errors.Is(err, services.ErrPurchaseOrderProductMissing),
+		errors.Is(err, services.ErrPurchaseOrderItemNotFound),
+		errors.Is(err, services.ErrSupplierInactive):
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Bad Request", err.Error())
+	case errors.Is(err, services.ErrPurchaseOrderStatus),
+		errors.Is(err, services.ErrPurchaseOrderOverReceipt):
+		utils.SendErrorResponse(w, http.StatusConflict, "Conflict", err.Error())
+	default:
+		SendServiceError(w, h.logger, operation, err)
+	}
+}
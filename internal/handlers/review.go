@@ -2,12 +2,17 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
+	"mime/multipart"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/MihoZaki/DzTech/internal/models"
 	"github.com/MihoZaki/DzTech/internal/services"
+	"github.com/MihoZaki/DzTech/internal/utils"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 )
@@ -26,19 +31,36 @@ func NewReviewHandler(service *services.ReviewService, logger *slog.Logger) *Rev
 
 // RegisterRoutes registers the review-related routes.
 func (h *ReviewHandler) RegisterRoutes(r chi.Router) {
-	r.Get("/product/{product_id}", h.GetReviewsByProductID) // GET /api/v1/reviews/product/{product_id}?page=&limit=
+	r.Get("/product/{product_id}", h.GetReviewsByProductID) // GET /api/v1/reviews/product/{product_id}?sort=&rating=&page=&limit=
 
 	r.Group(func(r chi.Router) {
-		r.Post("/", h.CreateReview)              // POST /api/v1/reviews
-		r.Put("/{review_id}", h.UpdateReview)    // PUT /api/v1/reviews/{review_id}
-		r.Delete("/{review_id}", h.DeleteReview) // DELETE /api/v1/reviews/{review_id}
+		r.Post("/", h.CreateReview)                       // POST /api/v1/reviews
+		r.Put("/{review_id}", h.UpdateReview)             // PUT /api/v1/reviews/{review_id}
+		r.Delete("/{review_id}", h.DeleteReview)          // DELETE /api/v1/reviews/{review_id}
+		r.Put("/{review_id}/vote", h.VoteReview)          // PUT /api/v1/reviews/{review_id}/vote
+		r.Delete("/{review_id}/vote", h.RemoveReviewVote) // DELETE /api/v1/reviews/{review_id}/vote
+		r.Post("/{review_id}/report", h.ReportReview)     // POST /api/v1/reviews/{review_id}/report
 		// r.Get("/user", h.GetReviewsByCurrentUser) // GET /api/v1/reviews/user?page=&limit=
 	})
 
 	// r.Get("/user/{user_id}", h.GetReviewsByUserID) // GET /api/v1/reviews/user/{user_id}?page=&limit=
 }
 
+// RegisterAdminRoutes registers the review moderation routes.
+// This should be mounted under the admin routes (e.g., /api/v1/admin/reviews).
+func (h *ReviewHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Get("/", h.ListReviewsForModeration)              // GET /api/v1/admin/reviews?status=pending|approved|rejected|all&page=&limit=
+	r.Get("/{review_id}", h.GetReviewForModeration)     // GET /api/v1/admin/reviews/{review_id}
+	r.Post("/{review_id}/approve", h.ApproveReview)     // POST /api/v1/admin/reviews/{review_id}/approve
+	r.Post("/{review_id}/reject", h.RejectReview)       // POST /api/v1/admin/reviews/{review_id}/reject
+	r.Post("/{review_id}/reply", h.CreateReviewReply)   // POST /api/v1/admin/reviews/{review_id}/reply
+	r.Put("/{review_id}/reply", h.UpdateReviewReply)    // PUT /api/v1/admin/reviews/{review_id}/reply
+	r.Delete("/{review_id}/reply", h.DeleteReviewReply) // DELETE /api/v1/admin/reviews/{review_id}/reply
+}
+
 // CreateReview handles creating a new review.
+// It accepts either a JSON body or a multipart form carrying the same fields plus up to
+// models.MaxReviewImages photos in the "images" field.
 func (h *ReviewHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
 	user, ok := models.GetUserFromContext(r.Context())
 	if !ok || user.ID == uuid.Nil {
@@ -47,10 +69,22 @@ func (h *ReviewHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req models.CreateReviewRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.logger.Error("Failed to decode CreateReview request", "error", err)
-		http.Error(w, "Invalid JSON in request body: "+err.Error(), http.StatusBadRequest)
-		return
+	var imageFileHeaders []*multipart.FileHeader
+	contentType := r.Header.Get("Content-Type")
+	if strings.HasPrefix(contentType, "multipart/form-data") {
+		var err error
+		req, imageFileHeaders, err = parseCreateReviewMultipart(r)
+		if err != nil {
+			h.logger.Warn("Failed to parse multipart CreateReview request", "error", err)
+			http.Error(w, "Invalid multipart request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	} else {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.logger.Error("Failed to decode CreateReview request", "error", err)
+			http.Error(w, "Invalid JSON in request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
 	}
 
 	if err := req.Validate(); err != nil {
@@ -59,7 +93,7 @@ func (h *ReviewHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	review, err := h.service.CreateReview(r.Context(), user.ID, req)
+	review, err := h.service.CreateReview(r.Context(), user.ID, req, imageFileHeaders)
 	if err != nil {
 		h.logger.Error("Failed to create review", "error", err, "user_id", user.ID, "product_id", req.ProductID)
 
@@ -67,6 +101,14 @@ func (h *ReviewHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, err.Error(), http.StatusConflict)
 			return
 		}
+		if errors.Is(err, services.ErrReviewPurchaseRequired) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, services.ErrTooManyReviewImages) || errors.Is(err, services.ErrInvalidReviewImage) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, "Failed to create review", http.StatusInternalServerError)
 		return
 	}
@@ -78,6 +120,40 @@ func (h *ReviewHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// parseCreateReviewMultipart reads a review and its photos from a multipart form.
+func parseCreateReviewMultipart(r *http.Request) (models.CreateReviewRequest, []*multipart.FileHeader, error) {
+	var req models.CreateReviewRequest
+	if err := r.ParseMultipartForm(32 << 20); err != nil { // 32 MB
+		return req, nil, fmt.Errorf("error parsing multipart form: %w", err)
+	}
+
+	productID, err := uuid.Parse(r.FormValue("product_id"))
+	if err != nil {
+		return req, nil, fmt.Errorf("invalid product_id format: %v", err)
+	}
+	rating, err := strconv.Atoi(r.FormValue("rating"))
+	if err != nil {
+		return req, nil, fmt.Errorf("invalid rating: %v", err)
+	}
+
+	optional := func(key string) *string {
+		if v := r.FormValue(key); v != "" {
+			return &v
+		}
+		return nil
+	}
+
+	req = models.CreateReviewRequest{
+		ProductID: productID,
+		Rating:    rating,
+		Title:     optional("title"),
+		Body:      optional("body"),
+		Pros:      optional("pros"),
+		Cons:      optional("cons"),
+	}
+	return req, r.MultipartForm.File["images"], nil
+}
+
 // UpdateReview handles updating an existing review.
 func (h *ReviewHandler) UpdateReview(w http.ResponseWriter, r *http.Request) {
 	user, ok := models.GetUserFromContext(r.Context())
@@ -189,7 +265,25 @@ func (h *ReviewHandler) GetReviewsByProductID(w http.ResponseWriter, r *http.Req
 
 	}
 
-	resp, err := h.service.GetReviewsByProductID(r.Context(), productID, page, limit)
+	sort := r.URL.Query().Get("sort")
+	switch sort {
+	case "", models.ReviewSortNewest, models.ReviewSortMostHelpful, models.ReviewSortHighest, models.ReviewSortLowest:
+	default:
+		http.Error(w, "Invalid sort. Use newest, most_helpful, highest or lowest", http.StatusBadRequest)
+		return
+	}
+
+	rating := 0
+	if ratingStr := r.URL.Query().Get("rating"); ratingStr != "" {
+		rt, err := strconv.Atoi(ratingStr)
+		if err != nil || rt < 1 || rt > 5 {
+			http.Error(w, "Invalid rating filter. Use a star count from 1 to 5", http.StatusBadRequest)
+			return
+		}
+		rating = rt
+	}
+
+	resp, err := h.service.GetReviewsByProductID(r.Context(), productID, sort, rating, page, limit)
 	if err != nil {
 		h.logger.Error("Failed to get reviews for product", "error", err, "product_id", productID)
 		http.Error(w, "Failed to retrieve reviews", http.StatusInternalServerError)
@@ -203,6 +297,107 @@ func (h *ReviewHandler) GetReviewsByProductID(w http.ResponseWriter, r *http.Req
 	}
 }
 
+// VoteReview handles voting a review helpful or unhelpful.
+func (h *ReviewHandler) VoteReview(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	reviewID, err := ParseUUIDPathParam(w, r, "review_id")
+	if err != nil {
+		return
+	}
+
+	var req models.VoteReviewRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid VoteReview request", "error", err)
+		return
+	}
+
+	summary, err := h.service.VoteReview(r.Context(), reviewID, user.ID, *req.Helpful)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrReviewNotFound):
+			utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Review not found.")
+		case errors.Is(err, services.ErrReviewOwnVote):
+			utils.SendErrorResponse(w, http.StatusForbidden, "Forbidden", err.Error())
+		default:
+			SendServiceError(w, h.logger, "vote on review", err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(summary); err != nil {
+		h.logger.Error("Failed to encode VoteReview response", "error", err)
+	}
+}
+
+// RemoveReviewVote handles removing the user's vote on a review.
+func (h *ReviewHandler) RemoveReviewVote(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	reviewID, err := ParseUUIDPathParam(w, r, "review_id")
+	if err != nil {
+		return
+	}
+
+	summary, err := h.service.RemoveReviewVote(r.Context(), reviewID, user.ID)
+	if err != nil {
+		if errors.Is(err, services.ErrReviewVoteNotFound) {
+			utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", err.Error())
+			return
+		}
+		SendServiceError(w, h.logger, "remove review vote", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(summary); err != nil {
+		h.logger.Error("Failed to encode RemoveReviewVote response", "error", err)
+	}
+}
+
+// ReportReview handles reporting an abusive review to the moderators.
+func (h *ReviewHandler) ReportReview(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	reviewID, err := ParseUUIDPathParam(w, r, "review_id")
+	if err != nil {
+		return
+	}
+
+	var req models.ReportReviewRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid ReportReview request", "error", err)
+		return
+	}
+
+	if err := h.service.ReportReview(r.Context(), reviewID, user.ID, req.Reason); err != nil {
+		switch {
+		case errors.Is(err, services.ErrReviewNotFound):
+			utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Review not found.")
+		case errors.Is(err, services.ErrReviewAlreadyReported):
+			utils.SendErrorResponse(w, http.StatusConflict, "Conflict", err.Error())
+		default:
+			SendServiceError(w, h.logger, "report review", err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
 // GetReviewsByCurrentUser handles fetching reviews submitted by the currently authenticated user.
 func (h *ReviewHandler) GetReviewsByCurrentUser(w http.ResponseWriter, r *http.Request) {
 	user, ok := models.GetUserFromContext(r.Context())
@@ -253,3 +448,188 @@ func (h *ReviewHandler) GetReviewsByUserID(w http.ResponseWriter, r *http.Reques
 	}
 	h.logger.Info("the user id", "id", userID)
 }
+
+// ListReviewsForModeration handles listing the review moderation queue (pending reviews by default).
+// status=reported lists the reviews with open abuse reports, whatever their status.
+func (h *ReviewHandler) ListReviewsForModeration(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	reportedOnly := false
+	switch status {
+	case "":
+		status = models.ReviewStatusPending
+	case "all":
+		status = ""
+	case "reported":
+		status = ""
+		reportedOnly = true
+	case models.ReviewStatusPending, models.ReviewStatusApproved, models.ReviewStatusRejected:
+	default:
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Bad Request", "Invalid status. Use pending, approved, rejected, reported or all.")
+		return
+	}
+
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	result, err := h.service.ListReviewsForModeration(r.Context(), status, reportedOnly, page, limit)
+	if err != nil {
+		SendServiceError(w, h.logger, "list reviews for moderation", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		h.logger.Error("Failed to encode ListReviewsForModeration response", "error", err)
+	}
+}
+
+// GetReviewForModeration handles fetching a single review for moderation.
+func (h *ReviewHandler) GetReviewForModeration(w http.ResponseWriter, r *http.Request) {
+	reviewID, err := ParseUUIDPathParam(w, r, "review_id")
+	if err != nil {
+		return
+	}
+
+	review, err := h.service.GetReviewForModeration(r.Context(), reviewID)
+	if err != nil {
+		h.sendModerationError(w, "get review for moderation", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		h.logger.Error("Failed to encode GetReviewForModeration response", "error", err)
+	}
+}
+
+// ApproveReview handles approving a review.
+func (h *ReviewHandler) ApproveReview(w http.ResponseWriter, r *http.Request) {
+	reviewID, err := ParseUUIDPathParam(w, r, "review_id")
+	if err != nil {
+		return
+	}
+
+	review, err := h.service.ApproveReview(r.Context(), reviewID)
+	if err != nil {
+		h.sendModerationError(w, "approve review", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		h.logger.Error("Failed to encode ApproveReview response", "error", err)
+	}
+}
+
+// RejectReview handles rejecting a review with a reason.
+func (h *ReviewHandler) RejectReview(w http.ResponseWriter, r *http.Request) {
+	reviewID, err := ParseUUIDPathParam(w, r, "review_id")
+	if err != nil {
+		return
+	}
+
+	var req models.RejectReviewRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid RejectReview request", "error", err)
+		return
+	}
+
+	review, err := h.service.RejectReview(r.Context(), reviewID, req.Reason)
+	if err != nil {
+		h.sendModerationError(w, "reject review", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		h.logger.Error("Failed to encode RejectReview response", "error", err)
+	}
+}
+
+// CreateReviewReply posts the store's official reply to a review.
+func (h *ReviewHandler) CreateReviewReply(w http.ResponseWriter, r *http.Request) {
+	reviewID, err := ParseUUIDPathParam(w, r, "review_id")
+	if err != nil {
+		return
+	}
+
+	var req models.ReviewReplyRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid CreateReviewReply request", "error", err)
+		return
+	}
+
+	reply, err := h.service.CreateReviewReply(r.Context(), reviewID, req.Body)
+	if err != nil {
+		h.sendModerationError(w, "create review reply", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(reply); err != nil {
+		h.logger.Error("Failed to encode CreateReviewReply response", "error", err)
+	}
+}
+
+// UpdateReviewReply edits the official reply to a review.
+func (h *ReviewHandler) UpdateReviewReply(w http.ResponseWriter, r *http.Request) {
+	reviewID, err := ParseUUIDPathParam(w, r, "review_id")
+	if err != nil {
+		return
+	}
+
+	var req models.ReviewReplyRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid UpdateReviewReply request", "error", err)
+		return
+	}
+
+	reply, err := h.service.UpdateReviewReply(r.Context(), reviewID, req.Body)
+	if err != nil {
+		h.sendModerationError(w, "update review reply", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(reply); err != nil {
+		h.logger.Error("Failed to encode UpdateReviewReply response", "error", err)
+	}
+}
+
+// DeleteReviewReply removes the official reply to a review.
+func (h *ReviewHandler) DeleteReviewReply(w http.ResponseWriter, r *http.Request) {
+	reviewID, err := ParseUUIDPathParam(w, r, "review_id")
+	if err != nil {
+		return
+	}
+
+	if err := h.service.DeleteReviewReply(r.Context(), reviewID); err != nil {
+		h.sendModerationError(w, "delete review reply", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ReviewHandler) sendModerationError(w http.ResponseWriter, operation string, err error) {
+	switch {
+	case errors.Is(err, services.ErrReviewNotFound):
+		utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Review not found.")
+	case errors.Is(err, services.ErrReviewReplyNotFound):
+		utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Review reply not found.")
+	case errors.Is(err, services.ErrReviewReplyExists):
+		utils.SendErrorResponse(w, http.StatusConflict, "Conflict", "The review already has a reply; edit it instead.")
+	default:
+		SendServiceError(w, h.logger, operation, err)
+	}
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/MihoZaki/DzTech/internal/services"
+	"github.com/MihoZaki/DzTech/internal/utils"
+	"github.com/go-chi/chi/v5"
+)
+
+// RoleHandler handles admin HTTP requests for roles and their assignment to staff.
+type RoleHandler struct {
+	service *services.RoleService
+	logger  *slog.Logger
+}
+
+// NewRoleHandler creates a new instance of RoleHandler.
+func NewRoleHandler(service *services.RoleService, logger *slog.Logger) *RoleHandler {
+	return &RoleHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes registers the role routes.
+// This should be mounted under the admin routes (e.g., /api/v1/admin/roles).
+func (h *RoleHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.ListRoles)                   // GET /api/v1/admin/roles
+	r.Get("/permissions", h.ListPermissions)  // GET /api/v1/admin/roles/permissions
+	r.Get("/users/{user_id}", h.GetUserRoles) // GET /api/v1/admin/roles/users/{user_id}
+	r.Put("/users/{user_id}", h.SetUserRoles) // PUT /api/v1/admin/roles/users/{user_id}
+}
+
+// ListRoles lists every role with its permissions.
+func (h *RoleHandler) ListRoles(w http.ResponseWriter, r *http.Request) {
+	roles, err := h.service.ListRoles(r.Context())
+	if err != nil {
+		SendServiceError(w, h.logger, "list roles", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(roles); err != nil {
+		h.logger.Error("Failed to encode ListRoles response", "error", err)
+	}
+}
+
+// ListPermissions lists every permission that can be granted.
+func (h *RoleHandler) ListPermissions(w http.ResponseWriter, r *http.Request) {
+	permissions, err := h.service.ListPermissions(r.Context())
+	if err != nil {
+		SendServiceError(w, h.logger, "list permissions", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(permissions); err != nil {
+		h.logger.Error("Failed to encode ListPermissions response", "error", err)
+	}
+}
+
+// GetUserRoles returns the roles and permissions of a user.
+func (h *RoleHandler) GetUserRoles(w http.ResponseWriter, r *http.Request) {
+	userID, err := ParseUUIDPathParam(w, r, "user_id")
+	if err != nil {
+		return
+	}
+
+	userRoles, err := h.service.GetUserRoles(r.Context(), userID)
+	if err != nil {
+		h.sendRoleError(w, "get user roles", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(userRoles); err != nil {
+		h.logger.Error("Failed to encode GetUserRoles response", "error", err)
+	}
+}
+
+// SetUserRoles replaces the roles of a user.
+func (h *RoleHandler) SetUserRoles(w http.ResponseWriter, r *http.Request) {
+	userID, err := ParseUUIDPathParam(w, r, "user_id")
+	if err != nil {
+		return
+	}
+
+	var req models.SetUserRolesRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid SetUserRoles request", "error", err)
+		return
+	}
+
+	userRoles, err := h.service.SetUserRoles(r.Context(), userID, req.Roles)
+	if err != nil {
+		h.sendRoleError(w, "set user roles", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(userRoles); err != nil {
+		h.logger.Error("Failed to encode SetUserRoles response", "error", err)
+	}
+}
+
+func (h *RoleHandler) sendRoleError(w http.ResponseWriter, operation string, err error) {
+	switch {
+	case errors.Is(err, services.ErrUserNotFound):
+		utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "User not found.")
+	case errors.Is(err, services.ErrRoleNotFound):
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Bad Request", "One or more roles do not exist.")
+	case errors.Is(err, services.ErrLastSuperuser):
+		utils.SendErrorResponse(w, http.StatusConflict, "Conflict", err.Error())
+	case errors.Is(err, services.ErrRoleNotGrantable):
+		utils.SendErrorResponse(w, http.StatusForbidden, "Forbidden", err.Error())
+	default:
+		SendServiceError(w, h.logger, operation, err)
+	}
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/MihoZaki/DzTech/internal/services"
+	"github.com/MihoZaki/DzTech/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// SessionHandler handles HTTP requests for listing and revoking signed-in sessions.
+type SessionHandler struct {
+	service *services.SessionService
+	logger  *slog.Logger
+}
+
+// NewSessionHandler creates a new instance of SessionHandler.
+func NewSessionHandler(service *services.SessionService, logger *slog.Logger) *SessionHandler {
+	return &SessionHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes registers the routes for the authenticated user's own sessions.
+// This should be mounted under the user routes (e.g., /api/v1/user).
+func (h *SessionHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/sessions", h.ListMySessions)                  // GET /api/v1/user/sessions
+	r.Delete("/sessions/others", h.RevokeMyOtherSessions) // DELETE /api/v1/user/sessions/others
+	r.Delete("/sessions/{session_id}", h.RevokeMySession) // DELETE /api/v1/user/sessions/{session_id}
+}
+
+// RegisterAdminRoutes registers the routes for managing any user's sessions.
+// This should be mounted under the admin user routes (e.g., /api/v1/admin/users).
+func (h *SessionHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Get("/{id}/sessions", h.ListUserSessions)                  // GET /api/v1/admin/users/{id}/sessions
+	r.Delete("/{id}/sessions", h.RevokeAllUserSessions)          // DELETE /api/v1/admin/users/{id}/sessions
+	r.Delete("/{id}/sessions/{session_id}", h.RevokeUserSession) // DELETE /api/v1/admin/users/{id}/sessions/{session_id}
+}
+
+// ListMySessions lists the authenticated user's sessions, marking the current one.
+func (h *SessionHandler) ListMySessions(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Authentication required.")
+		return
+	}
+
+	sessions, err := h.service.ListSessions(r.Context(), user.ID, user.SessionID)
+	if err != nil {
+		SendServiceError(w, h.logger, "list sessions", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(sessions); err != nil {
+		h.logger.Error("Failed to encode ListMySessions response", "error", err)
+	}
+}
+
+// RevokeMySession signs the authenticated user out of one of their sessions.
+func (h *SessionHandler) RevokeMySession(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Authentication required.")
+		return
+	}
+	sessionID, err := ParseUUIDPathParam(w, r, "session_id")
+	if err != nil {
+		return
+	}
+
+	if err := h.service.RevokeSession(r.Context(), user.ID, sessionID); err != nil {
+		h.sendSessionError(w, "revoke session", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeMyOtherSessions signs the authenticated user out everywhere except the current session.
+func (h *SessionHandler) RevokeMyOtherSessions(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Authentication required.")
+		return
+	}
+	if user.SessionID == uuid.Nil {
+		// Tokens issued before sessions were tracked cannot tell which session to keep
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Bad Request", "Current session is unknown, please sign in again.")
+		return
+	}
+
+	if _, err := h.service.RevokeOtherSessions(r.Context(), user.ID, user.SessionID); err != nil {
+		SendServiceError(w, h.logger, "revoke other sessions", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListUserSessions lists the sessions of any user.
+func (h *SessionHandler) ListUserSessions(w http.ResponseWriter, r *http.Request) {
+	userID, err := ParseUUIDPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+
+	sessions, err := h.service.ListSessions(r.Context(), userID, uuid.Nil)
+	if err != nil {
+		SendServiceError(w, h.logger, "list user sessions", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(sessions); err != nil {
+		h.logger.Error("Failed to encode ListUserSessions response", "error", err)
+	}
+}
+
+// RevokeUserSession signs any user out of one of their sessions.
+func (h *SessionHandler) RevokeUserSession(w http.ResponseWriter, r *http.Request) {
+	userID, err := ParseUUIDPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+	sessionID, err := ParseUUIDPathParam(w, r, "session_id")
+	if err != nil {
+		return
+	}
+
+	if err := h.service.RevokeSession(r.Context(), userID, sessionID); err != nil {
+		h.sendSessionError(w, "revoke user session", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeAllUserSessions signs any user out of all of their sessions.
+func (h *SessionHandler) RevokeAllUserSessions(w http.ResponseWriter, r *http.Request) {
+	userID, err := ParseUUIDPathParam(w, r, "id")
+	if err != nil {
+		return
+	}
+
+	if _, err := h.service.RevokeOtherSessions(r.Context(), userID, uuid.Nil); err != nil {
+		SendServiceError(w, h.logger, "revoke all user sessions", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *SessionHandler) sendSessionError(w http.ResponseWriter, operation string, err error) {
+	if errors.Is(err, services.ErrSessionNotFound) {
+		utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Session not found.")
+		return
+	}
+	SendServiceError(w, h.logger, operation, err)
+}
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/MihoZaki/DzTech/internal/courier"
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/MihoZaki/DzTech/internal/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// ShipmentHandler manages HTTP requests for order shipments and courier notifications.
+type ShipmentHandler struct {
+	service *services.ShipmentService
+	logger  *slog.Logger
+}
+
+// NewShipmentHandler creates a new instance of ShipmentHandler.
+func NewShipmentHandler(service *services.ShipmentService, logger *slog.Logger) *ShipmentHandler {
+	return &ShipmentHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterUserRoutes registers the shipment routes accessible to the customer who placed the order.
+func (h *ShipmentHandler) RegisterUserRoutes(r chi.Router) {
+	r.Get("/{id}/shipment", h.GetMyShipment) // GET /api/v1/orders/{id}/shipment
+}
+
+// RegisterAdminRoutes registers the shipment routes accessible only to admins.
+// Assumes the router 'r' is the admin orders router.
+func (h *ShipmentHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Post("/{id}/shipment", h.ShipOrder)        // POST /api/v1/admin/orders/{id}/shipment
+	r.Get("/{id}/shipment", h.GetShipment)       // GET /api/v1/admin/orders/{id}/shipment
+	r.Get("/{id}/shipment/label", h.GetLabel)    // GET /api/v1/admin/orders/{id}/shipment/label
+	r.Delete("/{id}/shipment", h.CancelShipment) // DELETE /api/v1/admin/orders/{id}/shipment
+}
+
+// RegisterWebhookRoutes registers the public endpoint couriers push status changes to.
+// Couriers authenticate their notifications themselves, e.g. by signing them.
+func (h *ShipmentHandler) RegisterWebhookRoutes(r chi.Router) {
+	r.Post("/{courier}", h.HandleWebhook) // POST /api/v1/webhooks/couriers/{courier}
+}
+
+// ShipOrder hands a confirmed order over to the courier of its delivery service and marks it as shipped.
+func (h *ShipmentHandler) ShipOrder(w http.ResponseWriter, r *http.Request) {
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+
+	shipment, err := h.service.ShipOrder(r.Context(), orderID)
+	if err != nil {
+		var statusErr *services.StatusTransitionError
+		switch {
+		case errors.Is(err, services.ErrOrderNotFound):
+			http.Error(w, "Order not found", http.StatusNotFound)
+		case errors.As(err, &statusErr):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case errors.Is(err, services.ErrNoCourier), errors.Is(err, services.ErrUnknownCourier):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		default:
+			h.logger.Error("Failed to ship order", "error", err, "order_id", orderID)
+			http.Error(w, "Failed to ship order", http.StatusBadGateway) // Most failures come from the courier
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated) // 201 Created
+	if err := json.NewEncoder(w).Encode(shipment); err != nil {
+		h.logger.Error("Failed to encode ShipOrder response", "error", err)
+	}
+}
+
+// GetShipment handles retrieving the shipment of any order (admin only).
+func (h *ShipmentHandler) GetShipment(w http.ResponseWriter, r *http.Request) {
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+
+	shipment, err := h.service.GetShipment(r.Context(), orderID)
+	if err != nil {
+		if errors.Is(err, services.ErrShipmentNotFound) {
+			http.Error(w, "Shipment not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("Failed to get shipment", "error", err, "order_id", orderID)
+		http.Error(w, "Failed to retrieve shipment", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK) // 200 OK
+	if err := json.NewEncoder(w).Encode(shipment); err != nil {
+		h.logger.Error("Failed to encode GetShipment response", "error", err)
+	}
+}
+
+// GetMyShipment handles a customer tracking the shipment of one of their orders.
+func (h *ShipmentHandler) GetMyShipment(w http.ResponseWriter, r *http.Request) {
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized: missing user context", http.StatusUnauthorized)
+		return
+	}
+
+	shipment, err := h.service.GetShipmentForUser(r.Context(), orderID, user.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrOrderNotFound):
+			http.Error(w, "Order not found", http.StatusNotFound)
+		case errors.Is(err, services.ErrShipmentNotFound):
+			http.Error(w, "Order has not been shipped yet", http.StatusNotFound)
+		default:
+			h.logger.Error("Failed to get shipment", "error", err, "order_id", orderID, "user_id", user.ID)
+			http.Error(w, "Failed to retrieve shipment", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK) // 200 OK
+	if err := json.NewEncoder(w).Encode(shipment); err != nil {
+		h.logger.Error("Failed to encode GetMyShipment response", "error", err)
+	}
+}
+
+// GetLabel handles downloading the shipping label of an order's shipment from its courier.
+func (h *ShipmentHandler) GetLabel(w http.ResponseWriter, r *http.Request) {
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+
+	label, err := h.service.GetLabel(r.Context(), orderID)
+	if err != nil {
+		if errors.Is(err, services.ErrShipmentNotFound) {
+			http.Error(w, "Shipment not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("Failed to get shipping label", "error", err, "order_id", orderID)
+		http.Error(w, "Failed to retrieve shipping label", http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", label.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(label.Data)))
+	w.WriteHeader(http.StatusOK) // 200 OK
+	if _, err := w.Write(label.Data); err != nil {
+		h.logger.Error("Failed to write shipping label", "error", err)
+	}
+}
+
+// CancelShipment handles withdrawing an order's shipment from its courier before pickup.
+// The order returns to confirmed so that it can be shipped again.
+func (h *ShipmentHandler) CancelShipment(w http.ResponseWriter, r *http.Request) {
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid order ID format", http.StatusBadRequest)
+		return
+	}
+
+	shipment, err := h.service.CancelShipment(r.Context(), orderID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrShipmentNotFound):
+			http.Error(w, "Shipment not found", http.StatusNotFound)
+		case errors.Is(err, courier.ErrNotCancellable):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			h.logger.Error("Failed to cancel shipment", "error", err, "order_id", orderID)
+			http.Error(w, "Failed to cancel shipment", http.StatusBadGateway)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK) // 200 OK
+	if err := json.NewEncoder(w).Encode(shipment); err != nil {
+		h.logger.Error("Failed to encode CancelShipment response", "error", err)
+	}
+}
+
+// HandleWebhook receives a shipment status notification from a courier.
+func (h *ShipmentHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
+	courierName := chi.URLParam(r, "courier")
+
+	if err := h.service.HandleWebhook(r.Context(), courierName, r); err != nil {
+		switch {
+		case errors.Is(err, services.ErrUnknownCourier):
+			http.Error(w, "Unknown courier", http.StatusNotFound)
+		case errors.Is(err, courier.ErrInvalidWebhook):
+			h.logger.Warn("Rejected courier webhook", "courier", courierName, "error", err, "remote_addr", r.RemoteAddr)
+			http.Error(w, "Invalid webhook", http.StatusUnauthorized)
+		default:
+			// A server error makes the courier retry the notification later
+			h.logger.Error("Failed to handle courier webhook", "error", err, "courier", courierName)
+			http.Error(w, "Failed to handle webhook", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent) // 204 No Content
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/MihoZaki/DzTech/internal/services"
+	"github.com/MihoZaki/DzTech/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// TwoFactorHandler handles HTTP requests for managing the authenticated user's two-factor authentication.
+type TwoFactorHandler struct {
+	service *services.TwoFactorService
+	logger  *slog.Logger
+}
+
+// NewTwoFactorHandler creates a new instance of TwoFactorHandler.
+func NewTwoFactorHandler(service *services.TwoFactorService, logger *slog.Logger) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes registers the two-factor routes.
+// This should be mounted under the user routes (e.g., /api/v1/user).
+func (h *TwoFactorHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/2fa", h.GetStatus)                               // GET /api/v1/user/2fa
+	r.Post("/2fa/enroll", h.Enroll)                          // POST /api/v1/user/2fa/enroll
+	r.Post("/2fa/confirm", h.Confirm)                        // POST /api/v1/user/2fa/confirm
+	r.Post("/2fa/disable", h.Disable)                        // POST /api/v1/user/2fa/disable
+	r.Post("/2fa/recovery-codes", h.RegenerateRecoveryCodes) // POST /api/v1/user/2fa/recovery-codes
+}
+
+// GetStatus reports whether the authenticated user has 2FA enabled.
+func (h *TwoFactorHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Authentication required.")
+		return
+	}
+
+	status, err := h.service.Status(r.Context(), user.ID)
+	if err != nil {
+		SendServiceError(w, h.logger, "get two-factor status", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		h.logger.Error("Failed to encode GetStatus response", "error", err)
+	}
+}
+
+// Enroll starts enrolment and returns the secret and provisioning URI for the authenticator app.
+func (h *TwoFactorHandler) Enroll(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Authentication required.")
+		return
+	}
+
+	enrollment, err := h.service.Enroll(r.Context(), user.ID, user.Email)
+	if err != nil {
+		h.sendTwoFactorError(w, "enroll two-factor", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(enrollment); err != nil {
+		h.logger.Error("Failed to encode Enroll response", "error", err)
+	}
+}
+
+// Confirm enables 2FA with a first code from the authenticator app and returns the recovery codes.
+func (h *TwoFactorHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Authentication required.")
+		return
+	}
+	var req models.TwoFactorCodeRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid Confirm request", "error", err)
+		return
+	}
+
+	codes, err := h.service.Confirm(r.Context(), user.ID, user.SessionID, req.Code)
+	if err != nil {
+		h.sendTwoFactorError(w, "confirm two-factor", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(models.RecoveryCodesResponse{RecoveryCodes: codes}); err != nil {
+		h.logger.Error("Failed to encode Confirm response", "error", err)
+	}
+}
+
+// Disable turns 2FA off after verifying a code.
+func (h *TwoFactorHandler) Disable(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Authentication required.")
+		return
+	}
+	var req models.TwoFactorCodeRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid Disable request", "error", err)
+		return
+	}
+
+	if err := h.service.Disable(r.Context(), user.ID, req.Code); err != nil {
+		h.sendTwoFactorError(w, "disable two-factor", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes after verifying a code.
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	user, ok := models.GetUserFromContext(r.Context())
+	if !ok || user.ID == uuid.Nil {
+		utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Authentication required.")
+		return
+	}
+	var req models.TwoFactorCodeRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid RegenerateRecoveryCodes request", "error", err)
+		return
+	}
+
+	codes, err := h.service.RegenerateRecoveryCodes(r.Context(), user.ID, req.Code)
+	if err != nil {
+		h.sendTwoFactorError(w, "regenerate recovery codes", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(models.RecoveryCodesResponse{RecoveryCodes: codes}); err != nil {
+		h.logger.Error("Failed to encode RegenerateRecoveryCodes response", "error", err)
+	}
+}
+
+func (h *TwoFactorHandler) sendTwoFactorError(w http.ResponseWriter, operation string, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidTwoFactorCode):
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Bad Request", "Invalid two-factor code.")
+	case errors.Is(err, services.ErrTwoFactorAlreadyEnabled),
+		errors.Is(err, services.ErrTwoFactorNotEnabled),
+		errors.Is(err, services.ErrTwoFactorNotPending):
+		utils.SendErrorResponse(w, http.StatusConflict, "Conflict", err.Error())
+	case errors.Is(err, services.ErrTwoFactorRequired):
+		utils.SendErrorResponse(w, http.StatusForbidden, "Forbidden", err.Error())
+	default:
+		SendServiceError(w, h.logger, operation, err)
+	}
+}
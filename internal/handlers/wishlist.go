@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/MihoZaki/DzTech/internal/services"
+	"github.com/MihoZaki/DzTech/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// WishlistHandler handles HTTP requests for user and guest wishlists.
+type WishlistHandler struct {
+	service *services.WishlistService
+	logger  *slog.Logger
+}
+
+// NewWishlistHandler creates a new instance of WishlistHandler.
+func NewWishlistHandler(service *services.WishlistService, logger *slog.Logger) *WishlistHandler {
+	return &WishlistHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes registers the wishlist routes.
+// Authenticated users are identified by their JWT, guests by the "session_id" cookie
+// (the guest wishlist is merged into the user's wishlist on login).
+func (h *WishlistHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.GetWishlist)                                    // GET /api/v1/user/wishlist
+	r.Post("/items", h.AddItem)                                  // POST /api/v1/user/wishlist/items
+	r.Delete("/items/{product_id}", h.RemoveItem)                // DELETE /api/v1/user/wishlist/items/{product_id}
+	r.Post("/items/{product_id}/move-to-cart", h.MoveItemToCart) // POST /api/v1/user/wishlist/items/{product_id}/move-to-cart
+}
+
+// resolveOwner returns the authenticated user ID, or the guest session ID from the cookie.
+// A new session ID is generated for guests without a cookie; isNewSession reports this
+// so the caller can set the cookie on success.
+func (h *WishlistHandler) resolveOwner(r *http.Request) (userID *uuid.UUID, sessionID string, isNewSession bool) {
+	if user, ok := models.GetUserFromContext(r.Context()); ok {
+		return &user.ID, "", false
+	}
+	if sessionID, ok := GetSessionIDFromCookie(r); ok {
+		return nil, sessionID, false
+	}
+	sessionID = uuid.New().String()
+	h.logger.Debug("No session cookie found, generated new session ID for guest wishlist request", "session_id", sessionID)
+	return nil, sessionID, true
+}
+
+// GetWishlist returns the current user's or guest's wishlist.
+func (h *WishlistHandler) GetWishlist(w http.ResponseWriter, r *http.Request) {
+	userID, sessionID, isNewSession := h.resolveOwner(r)
+
+	wishlist, err := h.service.GetWishlist(r.Context(), userID, sessionID)
+	if err != nil {
+		SendServiceError(w, h.logger, "get wishlist", err)
+		return
+	}
+
+	if isNewSession {
+		SetSessionIDCookie(w, sessionID)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(wishlist); err != nil {
+		h.logger.Error("Failed to encode GetWishlist response", "error", err)
+	}
+}
+
+// AddItem adds a product to the wishlist and returns the updated wishlist.
+func (h *WishlistHandler) AddItem(w http.ResponseWriter, r *http.Request) {
+	userID, sessionID, isNewSession := h.resolveOwner(r)
+
+	var req models.AddWishlistItemRequest
+	if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+		h.logger.Debug("Invalid AddWishlistItem request", "error", err)
+		return
+	}
+
+	wishlist, err := h.service.AddItem(r.Context(), userID, sessionID, req.ProductID)
+	if err != nil {
+		if errors.Is(err, services.ErrProductNotFound) {
+			utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Product not found.")
+			return
+		}
+		SendServiceError(w, h.logger, "add item to wishlist", err)
+		return
+	}
+
+	if isNewSession {
+		SetSessionIDCookie(w, sessionID)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(wishlist); err != nil {
+		h.logger.Error("Failed to encode AddWishlistItem response", "error", err)
+	}
+}
+
+// RemoveItem removes a product from the wishlist.
+func (h *WishlistHandler) RemoveItem(w http.ResponseWriter, r *http.Request) {
+	userID, sessionID, _ := h.resolveOwner(r)
+
+	productID, err := ParseUUIDPathParam(w, r, "product_id")
+	if err != nil {
+		return
+	}
+
+	if err := h.service.RemoveItem(r.Context(), userID, sessionID, productID); err != nil {
+		if errors.Is(err, services.ErrWishlistItemNotFound) {
+			utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Product is not on the wishlist.")
+			return
+		}
+		SendServiceError(w, h.logger, "remove item from wishlist", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MoveItemToCart moves a wishlist product into the cart.
+// Expected Body (optional): JSON { "quantity": number }, defaults to 1.
+func (h *WishlistHandler) MoveItemToCart(w http.ResponseWriter, r *http.Request) {
+	userID, sessionID, isNewSession := h.resolveOwner(r)
+
+	productID, err := ParseUUIDPathParam(w, r, "product_id")
+	if err != nil {
+		return
+	}
+
+	req := models.MoveWishlistItemToCartRequest{Quantity: 1}
+	if r.ContentLength != 0 {
+		if err := DecodeAndValidateJSON(w, r, &req); err != nil {
+			h.logger.Debug("Invalid MoveWishlistItemToCart request", "error", err)
+			return
+		}
+		if req.Quantity == 0 {
+			req.Quantity = 1
+		}
+	}
+
+	cartItem, err := h.service.MoveItemToCart(r.Context(), userID, sessionID, productID, req.Quantity)
+	if err != nil {
+		if errors.Is(err, services.ErrWishlistItemNotFound) {
+			utils.SendErrorResponse(w, http.StatusNotFound, "Not Found", "Product is not on the wishlist.")
+			return
+		}
+		SendServiceError(w, h.logger, "move wishlist item to cart", err)
+		return
+	}
+
+	if isNewSession {
+		SetSessionIDCookie(w, sessionID)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(cartItem); err != nil {
+		h.logger.Error("Failed to encode MoveWishlistItemToCart response", "error", err)
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/MihoZaki/DzTech/internal/utils"
+)
+
+// APIKeyAuthenticator resolves an API key presented by a client to the principal it acts as.
+// It returns a nil user if the key is unknown, expired, revoked or not allowed from the client's IP address.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key, ip string) (*models.User, error)
+}
+
+// APIKeyMiddleware authenticates requests carrying an X-API-Key header, for integrations calling the admin API.
+// Requests without the header are left to JWTMiddleware; a request must not carry both.
+func APIKeyMiddleware(authenticator APIKeyAuthenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(models.APIKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if r.Header.Get("Authorization") != "" {
+				utils.SendErrorResponse(w, http.StatusBadRequest, "Bad Request", "Use either an API key or a bearer token, not both")
+				return
+			}
+
+			user, err := authenticator.AuthenticateAPIKey(r.Context(), key, clientIP(r))
+			if err != nil {
+				slog.Error("Failed to check API key", "error", err)
+				utils.SendErrorResponse(w, http.StatusServiceUnavailable, "Service Unavailable", "Could not validate API key, please retry")
+				return
+			}
+			if user == nil {
+				slog.Warn("Invalid API key used", "ip_address", clientIP(r), "path", r.URL.Path)
+				utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Invalid, expired or revoked API key")
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), models.ContextKeyUser, user)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// adminRoutePrefix is stripped from route patterns to build audit actions.
+const adminRoutePrefix = "/api/v1/admin"
+
+// maxAuditBodySize caps how much of a response is kept as the "after" state of an audit entry.
+const maxAuditBodySize = 64 << 10
+
+// AuditRecorder stores audit log entries.
+type AuditRecorder interface {
+	RecordAdminAction(ctx context.Context, entry models.AuditEntry)
+}
+
+// Audit records every successful mutating request (POST, PUT, PATCH, DELETE) in the audit log.
+// It must run after JWTMiddleware so the actor is known. Services can add the entity's previous
+// state with models.SetAuditBefore; the JSON response body is kept as its new state unless the
+// service sets one with models.SetAuditAfter.
+func Audit(recorder AuditRecorder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+			default:
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, trail := models.WithAuditTrail(r.Context())
+			body := &limitedBuffer{limit: maxAuditBodySize}
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ww.Tee(body)
+
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			if status < 200 || status >= 300 {
+				return
+			}
+
+			pattern := strings.TrimPrefix(chi.RouteContext(r.Context()).RoutePattern(), adminRoutePrefix)
+			pattern = strings.TrimSuffix(pattern, "/")
+			if pattern == "" {
+				pattern = "/"
+			}
+			entry := models.AuditEntry{
+				Action:     r.Method + " " + pattern,
+				EntityType: auditEntityType(pattern),
+				EntityID:   auditEntityID(r, body),
+				Before:     trail.Before,
+				RequestID:  middleware.GetReqID(r.Context()),
+				IPAddress:  clientIP(r),
+			}
+			if trail.After != nil {
+				if after, err := json.Marshal(trail.After); err == nil {
+					entry.After = after
+				}
+			} else if !body.truncated && strings.HasPrefix(ww.Header().Get("Content-Type"), "application/json") {
+				entry.After = bytes.TrimSpace(body.Bytes())
+			}
+			if user, ok := models.GetUserFromContext(r.Context()); ok && user != nil {
+				entry.ActorID = user.ID
+				entry.ActorEmail = user.Email
+			}
+
+			// The client may already have gone away; the entry must be written regardless
+			recorder.RecordAdminAction(context.WithoutCancel(r.Context()), entry)
+		})
+	}
+}
+
+// auditEntityType returns the first segment of an admin route pattern, e.g. "products" for "/products/{id}".
+func auditEntityType(pattern string) string {
+	segment, _, _ := strings.Cut(strings.TrimPrefix(pattern, "/"), "/")
+	return segment
+}
+
+// auditEntityID returns the last URL parameter of the route, or the "id" of the created
+// entity in the response body when the route has none.
+func auditEntityID(r *http.Request, body *limitedBuffer) string {
+	values := chi.RouteContext(r.Context()).URLParams.Values
+	for i := len(values) - 1; i >= 0; i-- {
+		if values[i] != "" {
+			return values[i]
+		}
+	}
+	var created struct {
+		ID any `json:"id"`
+	}
+	if body.truncated || json.Unmarshal(body.Bytes(), &created) != nil || created.ID == nil {
+		return ""
+	}
+	if id, ok := created.ID.(string); ok {
+		return id
+	}
+	encoded, _ := json.Marshal(created.ID)
+	return string(encoded)
+}
+
+// clientIP returns the address of the client without its port. RealIP has already applied any forwarding headers.
+func clientIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// limitedBuffer keeps up to limit bytes and records whether anything was dropped.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if remaining := b.limit - b.Len(); len(p) > remaining {
+		b.truncated = true
+		p = p[:max(remaining, 0)]
+	}
+	b.Buffer.Write(p)
+	// Report the full length so the tee never fails the real response
+	return n, nil
+}
@@ -5,10 +5,10 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/netip"
 	"strings"
 	"time"
 
-	"github.com/MihoZaki/DzTech/internal/config"
 	"github.com/MihoZaki/DzTech/internal/models"
 	"github.com/MihoZaki/DzTech/internal/utils"
 	"github.com/go-chi/chi/v5"
@@ -19,7 +19,13 @@ import (
 	"github.com/google/uuid"
 )
 
-func JWTMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
+// TokenVersionChecker reports whether an access token issued for a session with a given token version is still valid.
+// Versions are bumped when a user is deactivated or their roles change; sessions can be revoked individually.
+type TokenVersionChecker interface {
+	IsAccessTokenCurrent(ctx context.Context, userID, sessionID uuid.UUID, tokenVersion int) (bool, error)
+}
+
+func JWTMiddleware(jwtKeys *utils.JWTKeySet, tokenVersions TokenVersionChecker) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
@@ -31,12 +37,7 @@ func JWTMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
 
 			// Token is provided, attempt to validate it
 			tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-			token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
-				if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-					return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-				}
-				return []byte(cfg.JWTSecret), nil
-			})
+			token, err := jwt.Parse(tokenString, jwtKeys.Keyfunc)
 
 			if err != nil || !token.Valid {
 				slog.Warn("Invalid JWT token", "error", err)
@@ -67,14 +68,45 @@ func JWTMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
 				return
 			}
 
-			// Extract other claims if needed (email, isAdmin)
+			// Reject tokens of revoked sessions and of users who were deactivated or had their roles changed
+			// since the token was issued. Older tokens carry no "sid" or "ver" claim and count as version 0.
+			tokenVersion, _ := claims["ver"].(float64)
+			var sessionID uuid.UUID
+			if sid, ok := claims["sid"].(string); ok {
+				sessionID, _ = uuid.Parse(sid)
+			}
+			current, err := tokenVersions.IsAccessTokenCurrent(r.Context(), userID, sessionID, int(tokenVersion))
+			if err != nil {
+				slog.Error("Failed to check access token version", "user_id", userID, "error", err)
+				utils.SendErrorResponse(w, http.StatusServiceUnavailable, "Service Unavailable", "Could not validate token, please retry")
+				return
+			}
+			if !current {
+				slog.Warn("Revoked access token used", "user_id", userID)
+				utils.SendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Token has been revoked")
+				return
+			}
+
+			// Extract other claims if needed (email, isAdmin, permissions)
 			email, _ := claims["email"].(string) // Use _ to ignore the boolean return value
 			isAdmin, _ := claims["is_admin"].(bool)
+			twoFactor, _ := claims["mfa"].(bool)
+			var permissions []string
+			if rawPermissions, ok := claims["permissions"].([]any); ok {
+				for _, p := range rawPermissions {
+					if permission, ok := p.(string); ok {
+						permissions = append(permissions, permission)
+					}
+				}
+			}
 
 			user := &models.User{
-				ID:      userID,
-				Email:   email,
-				IsAdmin: isAdmin,
+				ID:          userID,
+				Email:       email,
+				IsAdmin:     isAdmin,
+				Permissions: permissions,
+				SessionID:   sessionID,
+				TwoFactor:   twoFactor,
 			}
 
 			// Add user to the request context
@@ -96,11 +128,58 @@ func RequireAdmin(next http.Handler) http.Handler {
 	})
 }
 
+// RequireTwoFactor allows the request only if the session signed in with a second factor.
+// Staff without 2FA can still use the user routes to enrol.
+func RequireTwoFactor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := models.GetUserFromContext(r.Context())
+		// API keys are not tied to a person who could complete a second factor
+		if !ok || user == nil || (!user.TwoFactor && user.APIKeyID == uuid.Nil) {
+			slog.Warn("Access denied: two-factor authentication required", "path", r.URL.Path)
+			utils.SendErrorResponse(w, http.StatusForbidden, "Forbidden", "Two-factor authentication must be enabled to use the admin area. Enable it at /api/v1/user/2fa and sign in again.")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequirePermission allows the request only if the user's token grants the permission.
+// It is meant to be used after JWTMiddleware and RequireAdmin on a group of admin routes.
+func RequirePermission(permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, ok := models.GetUserFromContext(r.Context())
+			if !ok || user == nil || !user.HasPermission(permission) {
+				slog.Warn("Access denied: missing permission", "permission", permission, "path", r.URL.Path)
+				utils.SendErrorResponse(w, http.StatusForbidden, "Forbidden", fmt.Sprintf("Permission %q required", permission))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireReadWritePermission checks readPermission for GET and HEAD requests and writePermission for all others.
+func RequireReadWritePermission(readPermission, writePermission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		read := RequirePermission(readPermission)(next)
+		write := RequirePermission(writePermission)(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				read.ServeHTTP(w, r)
+				return
+			}
+			write.ServeHTTP(w, r)
+		})
+	}
+}
+
 // ApplyMiddleware applies essential middleware for the application.
-func ApplyMiddleware(r *chi.Mux) {
+// Forwarding headers are only honoured for requests from trustedProxies.
+func ApplyMiddleware(r *chi.Mux, trustedProxies []netip.Prefix) {
 	// Essential middleware for production
-	r.Use(middleware.RequestID) // Important for rate limiting
-	r.Use(middleware.RealIP)    // Important for rate limiting, analytics and tracing
+	r.Use(middleware.RequestID)   // Important for rate limiting
+	r.Use(RealIP(trustedProxies)) // Important for rate limiting, analytics and tracing
 	r.Use(middleware.Timeout(60 * time.Second))
 
 	r.Use(cors.Handler(cors.Options{
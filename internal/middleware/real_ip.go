@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"sync"
+)
+
+// RealIP replaces r.RemoteAddr with the client address from X-Forwarded-For or X-Real-IP, but only for
+// requests coming from one of the trusted proxies. Any other client could set those headers to whatever
+// it likes, e.g. to get a fresh address for every login attempt. Without trusted proxies the headers are ignored.
+// The first request carrying the headers from an untrusted peer is logged, as it usually means that
+// TRUSTED_PROXIES is missing the proxy in front of the server.
+func RealIP(trustedProxies []netip.Prefix) func(http.Handler) http.Handler {
+	var warnOnce sync.Once
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ip := forwardedClientIP(r, trustedProxies); ip != "" {
+				r.RemoteAddr = ip
+			} else if forwardedByUntrustedPeer(r, trustedProxies) {
+				warnOnce.Do(func() {
+					slog.Warn("Ignoring X-Forwarded-For/X-Real-IP from a peer that is not a trusted proxy; if the server runs behind a reverse proxy, add it to TRUSTED_PROXIES",
+						"peer", r.RemoteAddr,
+						"trusted_proxies", trustedProxies,
+					)
+				})
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// forwardedByUntrustedPeer reports whether a peer outside the trusted proxies sent forwarding headers.
+func forwardedByUntrustedPeer(r *http.Request, trustedProxies []netip.Prefix) bool {
+	if r.Header.Get("X-Forwarded-For") == "" && r.Header.Get("X-Real-IP") == "" {
+		return false
+	}
+	peer, ok := parseIP(r.RemoteAddr)
+	return ok && !isTrustedProxy(peer, trustedProxies)
+}
+
+// forwardedClientIP returns the client address reported by the proxies in front of the server, or "" to
+// keep the connection's address. X-Forwarded-For is read from the right, as each trusted proxy appends
+// the address it received the request from; the first untrusted address is the client.
+func forwardedClientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	peer, ok := parseIP(r.RemoteAddr)
+	if !ok || !isTrustedProxy(peer, trustedProxies) {
+		return ""
+	}
+
+	if xff := r.Header.Values("X-Forwarded-For"); len(xff) > 0 {
+		hops := strings.Split(strings.Join(xff, ","), ",")
+		client := ""
+		for i := len(hops) - 1; i >= 0; i-- {
+			ip, ok := parseIP(strings.TrimSpace(hops[i]))
+			if !ok {
+				break // Anything left of a malformed entry cannot be attributed to a trusted proxy
+			}
+			client = ip.String()
+			if !isTrustedProxy(ip, trustedProxies) {
+				break
+			}
+		}
+		return client
+	}
+	if ip, ok := parseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); ok {
+		return ip.String()
+	}
+	return ""
+}
+
+// parseIP reads an address with or without a port.
+func parseIP(s string) (netip.Addr, bool) {
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+	ip, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return ip.Unmap(), true
+}
+
+func isTrustedProxy(ip netip.Addr, trustedProxies []netip.Prefix) bool {
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserAddress represents a shipping address saved in a customer's address book.
+type UserAddress struct {
+	ID    uuid.UUID `json:"id"`
+	Label *string   `json:"label,omitempty"` // e.g. "Home" or "Work"
+	Address
+	IsDefault bool      `json:"is_default"` // Used when the customer does not pick an address
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SaveUserAddressRequest represents the request body for adding or replacing a saved address.
+// The address fields are the ones of Address, at the top level of the body.
+type SaveUserAddressRequest struct {
+	Label *string `json:"label,omitempty" validate:"omitempty,max=50"`
+	Address
+	IsDefault bool `json:"is_default"` // Makes the address the default one; the first address always is
+}
+
+// Validate validates the SaveUserAddressRequest struct.
+func (r *SaveUserAddressRequest) Validate() error {
+	return Validate.Struct(r)
+}
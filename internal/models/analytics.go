@@ -67,6 +67,31 @@ type TopSellingCategoriesResponse struct {
 	Limit     int              `json:"limit"`      // Number of items requested
 }
 
+// ProductMargin represents the gross margin made on a product.
+type ProductMargin struct {
+	ID           uuid.UUID `json:"id"`            // Product ID
+	Name         string    `json:"name"`          // Product Name
+	UnitsSold    int64     `json:"units_sold"`    // Units sold with a known cost
+	RevenueCents int64     `json:"revenue_cents"` // Revenue of those units (in cents)
+	CostCents    int64     `json:"cost_cents"`    // Cost of those units (in cents)
+	MarginCents  int64     `json:"margin_cents"`  // Revenue minus cost (in cents)
+}
+
+// MarginReportResponse holds the gross margin for a period.
+// Cost and margin only cover items whose cost was known when the order was confirmed.
+type MarginReportResponse struct {
+	TotalRevenueCents  int64           `json:"total_revenue_cents"`  // Revenue of all delivered items (in cents)
+	CostedRevenueCents int64           `json:"costed_revenue_cents"` // Revenue of the items with a known cost (in cents)
+	TotalCostCents     int64           `json:"total_cost_cents"`     // Cost of the items with a known cost (in cents)
+	GrossMarginCents   int64           `json:"gross_margin_cents"`   // Costed revenue minus cost (in cents)
+	GrossMarginPercent float64         `json:"gross_margin_percent"` // Gross margin as a percentage of costed revenue
+	UncostedUnits      int64           `json:"uncosted_units"`       // Units sold without a known cost
+	Products           []ProductMargin `json:"products"`             // Products with the highest margin
+	StartDate          time.Time       `json:"start_date"`           // Period start
+	EndDate            time.Time       `json:"end_date"`             // Period end
+	Limit              int             `json:"limit"`                // Number of products requested
+}
+
 // LowStockProduct represents a product with low stock.
 type LowStockProduct struct {
 	ID            uuid.UUID `json:"id"`             // Product ID
@@ -80,6 +105,23 @@ type LowStockProductsResponse struct {
 	Threshold int               `json:"threshold"` // Threshold used for the query
 }
 
+// LowStockLocationProduct represents a product with low stock at one location.
+type LowStockLocationProduct struct {
+	ID           uuid.UUID `json:"id"`            // Product ID
+	Name         string    `json:"name"`          // Product Name
+	LocationID   uuid.UUID `json:"location_id"`   // Stock location ID
+	LocationCode string    `json:"location_code"` // Stock location code
+	LocationName string    `json:"location_name"` // Stock location name
+	Quantity     int       `json:"quantity"`      // Current stock level at the location
+}
+
+// LowStockByLocationResponse holds the list of low-stock products per location.
+type LowStockByLocationResponse struct {
+	Data       []LowStockLocationProduct `json:"data"`                  // List of low-stock products per location
+	Threshold  int                       `json:"threshold"`             // Threshold used for the query
+	LocationID *uuid.UUID                `json:"location_id,omitempty"` // Location filter, if any
+}
+
 // CustomerInsightsResponse holds new customer count.
 type CustomerInsightsResponse struct {
 	NewCustomersCount int       `json:"new_customers_count"` // Number of new registrations
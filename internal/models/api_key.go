@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyHeader is the request header carrying an API key.
+const APIKeyHeader = "X-API-Key"
+
+// APIKey represents a key an integration uses to call the admin API. The key itself is never returned
+// after creation; KeyPrefix identifies it.
+type APIKey struct {
+	ID           uuid.UUID  `json:"id"`
+	Name         string     `json:"name"`
+	KeyPrefix    string     `json:"key_prefix"`
+	Scopes       []string   `json:"scopes"`
+	AllowedIPs   []string   `json:"allowed_ips"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	CreatedBy    *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	LastUsedAt   *time.Time `json:"last_used_at,omitempty"`
+	RequestCount int64      `json:"request_count"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+}
+
+// CreatedAPIKey is returned once, when a key is created: it is the only time the key is shown.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// CreateAPIKeyRequest represents the request body for creating an API key.
+// Scopes are permission codes; AllowedIPs holds IP addresses or CIDR ranges and is empty to allow any client.
+type CreateAPIKeyRequest struct {
+	Name       string     `json:"name" validate:"required,max=100"`
+	Scopes     []string   `json:"scopes" validate:"required,min=1,dive,required"`
+	AllowedIPs []string   `json:"allowed_ips" validate:"omitempty,dive,ip|cidr"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+}
+
+func (r *CreateAPIKeyRequest) Validate() error {
+	return Validate.Struct(r)
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEntry is a mutating admin action captured by the audit middleware.
+type AuditEntry struct {
+	ActorID    uuid.UUID
+	ActorEmail string
+	Action     string // HTTP method and route pattern, e.g. "PUT /products/{id}"
+	EntityType string // First segment of the admin route, e.g. "products"
+	EntityID   string
+	Before     any             // State recorded by the service before the change, if any
+	After      json.RawMessage // JSON response body, if any
+	RequestID  string
+	IPAddress  string
+}
+
+// AuditLogEntry represents a recorded admin action in API responses.
+type AuditLogEntry struct {
+	ID         uuid.UUID       `json:"id"`
+	ActorID    *uuid.UUID      `json:"actor_id,omitempty"`
+	ActorEmail string          `json:"actor_email"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	Diff       json.RawMessage `json:"diff,omitempty"`
+	RequestID  string          `json:"request_id"`
+	IPAddress  string          `json:"ip_address"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// AuditLogFilter narrows the audit log listing. Zero values disable a filter.
+type AuditLogFilter struct {
+	ActorID    uuid.UUID
+	EntityType string
+	EntityID   string
+	Action     string
+	From       *time.Time
+	To         *time.Time
+}
+
+type contextAuditKey struct{}
+
+// AuditTrail carries the state of the entity being changed from the service back to the audit middleware.
+type AuditTrail struct {
+	Before any
+	After  any // Replaces the response body as the new state, e.g. when the response holds a secret
+}
+
+// WithAuditTrail returns a context in which services can record the state of an entity before changing it.
+func WithAuditTrail(ctx context.Context) (context.Context, *AuditTrail) {
+	trail := &AuditTrail{}
+	return context.WithValue(ctx, contextAuditKey{}, trail), trail
+}
+
+// SetAuditBefore records the state of an entity before an admin request changes it.
+// It does nothing outside an audited request.
+func SetAuditBefore(ctx context.Context, before any) {
+	if trail, ok := ctx.Value(contextAuditKey{}).(*AuditTrail); ok {
+		trail.Before = before
+	}
+}
+
+// SetAuditAfter records the new state of an entity in place of the response body.
+// It does nothing outside an audited request.
+func SetAuditAfter(ctx context.Context, after any) {
+	if trail, ok := ctx.Value(contextAuditKey{}).(*AuditTrail); ok {
+		trail.After = after
+	}
+}
@@ -1,8 +1,36 @@
 package models
 
+import "time"
+
 type LoginResponse struct {
-	Token string `json:"access_token"` // Rename for clarity
-	User  User   `json:"user"`
+	Token string `json:"access_token,omitempty"` // Rename for clarity
+	User  *User  `json:"user,omitempty"`
+	// Set instead of the token and user when the account has 2FA enabled:
+	// the challenge token and a code are then exchanged at /api/v1/auth/login/2fa
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty"`
+	ChallengeToken    string `json:"challenge_token,omitempty"`
+}
+
+// AccountLockoutNotification carries the details needed to warn a user that their account was locked
+// after too many failed logins.
+type AccountLockoutNotification struct {
+	LockedUntil time.Time
+	IPAddress   string // Client the last failed attempt came from
+}
+
+// OIDCAuthorizationResponse points the client to the identity provider to start a social login.
+type OIDCAuthorizationResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+// OIDCCallbackRequest completes a social login with the parameters the provider redirected back with.
+type OIDCCallbackRequest struct {
+	Code  string `json:"code" validate:"required,max=2048"`
+	State string `json:"state" validate:"required,max=128"`
+}
+
+func (r *OIDCCallbackRequest) Validate() error {
+	return Validate.Struct(r)
 }
 
 type RefreshResponse struct {
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Delivery types offered by couriers.
+const (
+	DeliveryTypeHome     = "home"      // Delivered to the customer's address
+	DeliveryTypeStopDesk = "stop_desk" // Collected by the customer at the courier's office
+)
+
+// DeliveryRateTable is the price list of a delivery service. A service without zones charges its
+// base cost for home delivery anywhere; a service with zones only delivers to the wilayas they cover.
+type DeliveryRateTable struct {
+	DeliveryServiceID uuid.UUID      `json:"delivery_service_id"`
+	Zones             []DeliveryZone `json:"zones"`
+}
+
+// DeliveryZone groups the wilayas a delivery service charges the same rates for.
+type DeliveryZone struct {
+	ID                   uuid.UUID      `json:"id"`
+	Name                 string         `json:"name"`
+	WilayaCodes          []int16        `json:"wilaya_codes"`
+	FreeShippingMinCents *int64         `json:"free_shipping_min_cents,omitempty"` // Overrides the service's threshold
+	Rates                []DeliveryRate `json:"rates"`
+}
+
+// DeliveryRate is the price of one weight bracket of one delivery type.
+// A parcel is charged the bracket with the smallest max weight it fits under, or the open-ended one.
+type DeliveryRate struct {
+	DeliveryType   string `json:"delivery_type" validate:"required,oneof=home stop_desk"`
+	MaxWeightGrams *int32 `json:"max_weight_grams,omitempty" validate:"omitempty,gt=0"` // Unset for the open-ended bracket
+	PriceCents     int64  `json:"price_cents" validate:"min=0"`
+}
+
+// DeliveryZoneRequest represents one zone of a rate table being saved.
+type DeliveryZoneRequest struct {
+	Name                 string         `json:"name" validate:"required,max=100"`
+	WilayaCodes          []int16        `json:"wilaya_codes" validate:"required,min=1,dive,min=1,max=58"`
+	FreeShippingMinCents *int64         `json:"free_shipping_min_cents,omitempty" validate:"omitempty,min=0"`
+	Rates                []DeliveryRate `json:"rates" validate:"required,min=1,dive"`
+}
+
+// ReplaceDeliveryRatesRequest replaces the whole rate table of a delivery service.
+// An empty list of zones reverts the service to its flat base cost.
+type ReplaceDeliveryRatesRequest struct {
+	Zones []DeliveryZoneRequest `json:"zones" validate:"dive"`
+}
+
+func (r *ReplaceDeliveryRatesRequest) Validate() error {
+	if err := Validate.Struct(r); err != nil {
+		return err
+	}
+	zoneNames := make(map[string]bool, len(r.Zones))
+	zoneOfWilaya := make(map[int16]string)
+	for _, zone := range r.Zones {
+		if zoneNames[zone.Name] {
+			return fmt.Errorf("zone %q is listed more than once", zone.Name)
+		}
+		zoneNames[zone.Name] = true
+		for _, code := range zone.WilayaCodes {
+			if other, ok := zoneOfWilaya[code]; ok {
+				return fmt.Errorf("wilaya %d is in both zone %q and zone %q", code, other, zone.Name)
+			}
+			zoneOfWilaya[code] = zone.Name
+		}
+		brackets := make(map[string]bool, len(zone.Rates))
+		for _, rate := range zone.Rates {
+			bracket := rate.DeliveryType + "/open"
+			if rate.MaxWeightGrams != nil {
+				bracket = fmt.Sprintf("%s/%d", rate.DeliveryType, *rate.MaxWeightGrams)
+			}
+			if brackets[bracket] {
+				return fmt.Errorf("zone %q has two %s rates for the same weight bracket", zone.Name, rate.DeliveryType)
+			}
+			brackets[bracket] = true
+		}
+	}
+	return nil
+}
+
+// DeliveryQuote is the price of delivering a cart with one delivery service and delivery type.
+type DeliveryQuote struct {
+	DeliveryServiceID    uuid.UUID `json:"delivery_service_id"`
+	DeliveryServiceName  string    `json:"delivery_service_name"`
+	EstimatedDays        *int32    `json:"estimated_days,omitempty"`
+	DeliveryType         string    `json:"delivery_type"`
+	Zone                 *string   `json:"zone,omitempty"` // Unset for services charging a flat base cost
+	WeightGrams          int64     `json:"weight_grams"`
+	FeeCents             int64     `json:"fee_cents"`
+	FreeShipping         bool      `json:"free_shipping"`
+	FreeShippingMinCents *int64    `json:"free_shipping_min_cents,omitempty"` // Threshold that applies, if any
+}
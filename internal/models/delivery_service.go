@@ -13,8 +13,11 @@ type DeliveryService struct {
 	BaseCostCents int64     `json:"base_cost_cents"`          // Base cost for this service
 	EstimatedDays *int32    `json:"estimated_days,omitempty"` // Estimated delivery time
 	IsActive      bool      `json:"is_active"`                // Whether the service is currently offered
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	// Orders worth at least this much ship free, unless their zone sets its own threshold
+	FreeShippingMinCents *int64    `json:"free_shipping_min_cents,omitempty"`
+	Courier              *string   `json:"courier,omitempty"` // Courier integration that ships its parcels; unset if shipped by hand
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
 }
 
 // CreateDeliveryServiceRequest represents data to create a new delivery service.
@@ -24,6 +27,10 @@ type CreateDeliveryServiceRequest struct {
 	BaseCostCents int64   `json:"base_cost_cents" validate:"min=0"` // Cost in cents
 	EstimatedDays *int32  `json:"estimated_days,omitempty" validate:"omitempty,min=1"`
 	IsActive      bool    `json:"is_active"`
+	// Optional free-shipping threshold in cents
+	FreeShippingMinCents *int64 `json:"free_shipping_min_cents,omitempty" validate:"omitempty,min=0"`
+	// Optional name of a configured courier integration
+	Courier *string `json:"courier,omitempty" validate:"omitempty,max=50"`
 }
 
 // UpdateDeliveryServiceRequest represents data to update an existing delivery service.
@@ -33,6 +40,12 @@ type UpdateDeliveryServiceRequest struct {
 	BaseCostCents *int64  `json:"base_cost_cents,omitempty" validate:"omitempty,min=0"`
 	EstimatedDays *int32  `json:"estimated_days,omitempty" validate:"omitempty,min=1"`
 	IsActive      *bool   `json:"is_active,omitempty"`
+	// Optional free-shipping threshold in cents
+	FreeShippingMinCents *int64 `json:"free_shipping_min_cents,omitempty" validate:"omitempty,min=0"`
+	// Removes the free-shipping threshold; cannot be combined with a new threshold
+	ClearFreeShippingMinCents bool `json:"clear_free_shipping_min_cents,omitempty" validate:"excluded_with=FreeShippingMinCents"`
+	// Name of a configured courier integration; an empty string unlinks the courier
+	Courier *string `json:"courier,omitempty" validate:"omitempty,max=50"`
 }
 
 // Validate methods for request structs
@@ -0,0 +1,42 @@
+package models
+
+import "github.com/go-playground/validator/v10"
+
+// Wilaya is an Algerian province.
+type Wilaya struct {
+	Code   int16  `json:"code"` // Official number, 1 to 58
+	Name   string `json:"name"`
+	NameAr string `json:"name_ar"`
+}
+
+// Commune is a municipality within a wilaya.
+type Commune struct {
+	Code       int32  `json:"code"` // Wilaya code followed by the commune's two-digit number, e.g. 1601
+	WilayaCode int16  `json:"wilaya_code"`
+	Name       string `json:"name"`
+}
+
+// PlaceResolver matches the free-text province and city of an address to the reference geography.
+type PlaceResolver interface {
+	// ResolvePlace returns the wilaya and commune named by province and city.
+	// The wilaya is nil if the province is unknown; the commune is nil if the city is not a commune of it.
+	ResolvePlace(province, city string) (*Wilaya, *Commune)
+}
+
+// UsePlaceResolver makes address validation check the province and city against resolver.
+// Without one, any non-empty province and city are accepted.
+// It must be called before requests are served, as the validator is not safe to reconfigure concurrently.
+func UsePlaceResolver(resolver PlaceResolver) {
+	Validate.RegisterStructValidation(func(sl validator.StructLevel) {
+		address := sl.Current().Interface().(Address)
+		if address.Province == "" || address.City == "" {
+			return // Reported by the required tags
+		}
+		wilaya, commune := resolver.ResolvePlace(address.Province, address.City)
+		if wilaya == nil {
+			sl.ReportError(address.Province, "province", "Province", "wilaya", "")
+		} else if commune == nil {
+			sl.ReportError(address.City, "city", "City", "commune", "")
+		}
+	}, Address{})
+}
@@ -0,0 +1,138 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stock movement reasons recorded in the stock_movements ledger.
+const (
+	StockReasonSale             = "sale"
+	StockReasonCancellation     = "cancellation"
+	StockReasonManualAdjustment = "manual_adjustment"
+	StockReasonReturn           = "return"
+	StockReasonRestock          = "restock"
+	StockReasonTransferIn       = "transfer_in"
+	StockReasonTransferOut      = "transfer_out"
+)
+
+// StockLocation represents a place where stock is held (e.g., the warehouse or a shop).
+type StockLocation struct {
+	ID         uuid.UUID `json:"id"`
+	Code       string    `json:"code"`
+	Name       string    `json:"name"`
+	IsSellable bool      `json:"is_sellable"` // Whether the stock here counts towards the public stock_quantity
+	IsActive   bool      `json:"is_active"`
+	IsDefault  bool      `json:"is_default"`
+	Priority   int       `json:"priority"` // Lower values are allocated to orders first
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// CreateStockLocationRequest represents the request body for creating a stock location.
+type CreateStockLocationRequest struct {
+	Code       string `json:"code" validate:"required,max=50"`
+	Name       string `json:"name" validate:"required,max=255"`
+	IsSellable *bool  `json:"is_sellable,omitempty"` // Defaults to true
+	IsActive   *bool  `json:"is_active,omitempty"`   // Defaults to true
+	Priority   int    `json:"priority" validate:"min=0"`
+}
+
+// Validate validates the CreateStockLocationRequest struct.
+func (r *CreateStockLocationRequest) Validate() error {
+	return Validate.Struct(r)
+}
+
+// UpdateStockLocationRequest represents the request body for updating a stock location.
+// Fields are optional; only provided fields are updated.
+type UpdateStockLocationRequest struct {
+	Name       *string `json:"name,omitempty" validate:"omitempty,max=255"`
+	IsSellable *bool   `json:"is_sellable,omitempty"`
+	IsActive   *bool   `json:"is_active,omitempty"`
+	Priority   *int    `json:"priority,omitempty" validate:"omitempty,min=0"`
+}
+
+// Validate validates the UpdateStockLocationRequest struct.
+func (r *UpdateStockLocationRequest) Validate() error {
+	return Validate.Struct(r)
+}
+
+// ProductStockLevel represents the stock of a product at one location.
+type ProductStockLevel struct {
+	LocationID   uuid.UUID `json:"location_id"`
+	LocationCode string    `json:"location_code"`
+	LocationName string    `json:"location_name"`
+	IsSellable   bool      `json:"is_sellable"`
+	IsActive     bool      `json:"is_active"`
+	Quantity     int       `json:"quantity"`
+}
+
+// CreateStockTransferRequest represents the request body for moving stock between two locations.
+type CreateStockTransferRequest struct {
+	FromLocationID uuid.UUID `json:"from_location_id" validate:"required"`
+	ToLocationID   uuid.UUID `json:"to_location_id" validate:"required,nefield=FromLocationID"`
+	Quantity       int       `json:"quantity" validate:"required,min=1"`
+	Note           *string   `json:"note,omitempty" validate:"omitempty,max=500"`
+}
+
+// Validate validates the CreateStockTransferRequest struct.
+func (r *CreateStockTransferRequest) Validate() error {
+	return Validate.Struct(r)
+}
+
+// StockTransfer represents a completed transfer between two locations.
+type StockTransfer struct {
+	ID             uuid.UUID       `json:"id"`
+	ProductID      uuid.UUID       `json:"product_id"`
+	FromLocationID uuid.UUID       `json:"from_location_id"`
+	ToLocationID   uuid.UUID       `json:"to_location_id"`
+	Quantity       int             `json:"quantity"`
+	Note           *string         `json:"note,omitempty"`
+	Movements      []StockMovement `json:"movements"` // The transfer_out and transfer_in ledger entries
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// StockMovement represents a single entry of the append-only stock ledger.
+type StockMovement struct {
+	ID                int64      `json:"id"`
+	ProductID         uuid.UUID  `json:"product_id"`
+	LocationID        uuid.UUID  `json:"location_id"`
+	LocationCode      string     `json:"location_code,omitempty"`
+	Delta             int        `json:"delta"`              // Signed change applied to the stock at the location
+	ResultingQuantity int        `json:"resulting_quantity"` // Stock at the location right after this movement
+	Reason            string     `json:"reason"`
+	OrderID           *uuid.UUID `json:"order_id,omitempty"`
+	TransferID        *uuid.UUID `json:"transfer_id,omitempty"`
+	ActorID           *uuid.UUID `json:"actor_id,omitempty"`
+	ActorEmail        *string    `json:"actor_email,omitempty"`
+	Note              *string    `json:"note,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// CreateStockMovementRequest represents an admin-recorded stock movement (e.g., a customer return or a delivery from a supplier).
+// Sales and cancellations are recorded automatically by the order workflow, transfers through CreateStockTransferRequest.
+type CreateStockMovementRequest struct {
+	LocationID *uuid.UUID `json:"location_id,omitempty"` // Defaults to the default location
+	Delta      int        `json:"delta" validate:"required,ne=0"`
+	Reason     string     `json:"reason" validate:"required,oneof=manual_adjustment return restock"`
+	OrderID    *uuid.UUID `json:"order_id,omitempty"` // Optional reference, typically for returns
+	Note       *string    `json:"note,omitempty" validate:"omitempty,max=500"`
+}
+
+// Validate validates the CreateStockMovementRequest struct.
+func (r *CreateStockMovementRequest) Validate() error {
+	return Validate.Struct(r)
+}
+
+// StockReconciliation compares a product's stock at a location with the sum of its ledger movements there.
+type StockReconciliation struct {
+	ProductID      uuid.UUID `json:"product_id"`
+	ProductName    string    `json:"product_name"`
+	LocationID     uuid.UUID `json:"location_id"`
+	LocationCode   string    `json:"location_code"`
+	Quantity       int       `json:"quantity"`
+	LedgerQuantity int64     `json:"ledger_quantity"` // Sum of the movement deltas at the location
+	Difference     int64     `json:"difference"`      // quantity - ledger_quantity
+	Consistent     bool      `json:"consistent"`
+}
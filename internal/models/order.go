@@ -1,12 +1,14 @@
 package models
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 // Address represents the structure for shipping and billing addresses stored as JSONB.
+// Province and city must name a wilaya and one of its communes, see UsePlaceResolver.
 type Address struct {
 	FullName     string  `json:"full_name" validate:"required"`      // Required
 	PhoneNumber1 string  `json:"phone_number_1" validate:"required"` // Required
@@ -25,13 +27,20 @@ func (i *BulkAddItemRequest_Item) Validate() error {
 }
 
 // CreateOrderFromCartRequest represents the request body for creating an order from the current cart state.
+// The shipping address is given either inline or as the ID of one of the customer's saved addresses.
 type CreateOrderFromCartRequest struct {
-	ShippingAddress   Address   `json:"shipping_address"`
-	Notes             *string   `json:"notes,omitempty"`     // Optional notes for the order
-	DeliveryServiceID uuid.UUID `json:"delivery_service_id"` // Required delivery service ID
+	ShippingAddress   *Address   `json:"shipping_address,omitempty"`
+	AddressID         *uuid.UUID `json:"address_id,omitempty"` // Saved address, for authenticated users only
+	Notes             *string    `json:"notes,omitempty"`      // Optional notes for the order
+	DeliveryServiceID uuid.UUID  `json:"delivery_service_id"`  // Required delivery service ID
+	// Home delivery unless stop_desk is requested
+	DeliveryType string `json:"delivery_type,omitempty" validate:"omitempty,oneof=home stop_desk"`
 }
 
 func (r *CreateOrderFromCartRequest) Validate() error {
+	if (r.ShippingAddress == nil) == (r.AddressID == nil) {
+		return errors.New("exactly one of shipping_address or address_id is required")
+	}
 	return Validate.Struct(r)
 }
 
@@ -63,9 +72,13 @@ type Order struct {
 	PaymentMethod     string     `json:"payment_method"`
 	Province          string     `json:"province"`
 	City              string     `json:"city"`
+	WilayaCode        *int16     `json:"wilaya_code,omitempty"`  // Unset for orders placed before addresses were normalised
+	CommuneCode       *int32     `json:"commune_code,omitempty"` // Set along with wilaya_code
 	PhoneNumber1      string     `json:"phone_number_1"`
 	PhoneNumber2      *string    `json:"phone_number_2"`
 	DeliveryServiceID uuid.UUID  `json:"delivery_service_id"`
+	DeliveryType      string     `json:"delivery_type"`
+	DeliveryFeeCents  *int64     `json:"delivery_fee_cents,omitempty"` // Included in total_amount_cents; unset for orders placed before rate tables
 	Notes             *string    `json:"notes,omitempty"`
 	CreatedAt         time.Time  `json:"created_at"`
 	UpdatedAt         time.Time  `json:"updated_at"`
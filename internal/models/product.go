@@ -21,6 +21,7 @@ type Product struct {
 	NumRatings                         *int32                 `json:"num_ratings,omitempty"` // Nullable, count of reviews
 	Status                             string                 `json:"status"`
 	Brand                              string                 `json:"brand"`
+	WeightGrams                        *int32                 `json:"weight_grams,omitempty"`
 	ImageURLs                          []string               `json:"image_urls"`           // Different type
 	SpecHighlights                     map[string]interface{} `json:"spec_highlights"`      // Different type
 	CreatedAt                          time.Time              `json:"created_at"`           // Different type
@@ -47,6 +48,7 @@ type CreateProductRequest struct {
 	Brand            string         `json:"brand" validate:"required,max=100"`
 	ImageUrls        []string       `json:"image_urls" validate:"max=10"`
 	SpecHighlights   map[string]any `json:"spec_highlights"`
+	WeightGrams      *int32         `json:"weight_grams,omitempty" validate:"omitempty,gt=0"`
 }
 
 type ProductFilter struct {
@@ -76,11 +78,12 @@ type UpdateProductRequest struct {
 	Description      *string         `json:"description,omitempty"`
 	ShortDescription *string         `json:"short_description,omitempty"`
 	PriceCents       *int64          `json:"price_cents,omitempty" validate:"omitempty,min=0"`
-	StockQuantity    *int            `json:"stock_quantity,omitempty" validate:"omitempty,min=0"`
+	StockQuantity    *int            `json:"stock_quantity,omitempty" validate:"omitempty,min=0"` // Sets the stock at the default location; rejected once other locations hold stock
 	Status           *string         `json:"status,omitempty" validate:"omitempty,oneof=draft active discontinued"`
 	Brand            *string         `json:"brand,omitempty" validate:"omitempty,max=100"`
 	ImageUrls        *[]string       `json:"image_urls,omitempty" validate:"omitempty,max=10"`
 	SpecHighlights   *map[string]any `json:"spec_highlights,omitempty"`
+	WeightGrams      *int32          `json:"weight_grams,omitempty" validate:"omitempty,gt=0"`
 }
 
 func (r *CreateProductRequest) Validate() error {
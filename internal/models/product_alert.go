@@ -0,0 +1,59 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Product alert types.
+const (
+	ProductAlertBackInStock = "back_in_stock"
+	ProductAlertPriceBelow  = "price_below"
+)
+
+// ProductAlert represents a customer's subscription to a back-in-stock or price-drop notification.
+type ProductAlert struct {
+	ID               uuid.UUID  `json:"id"`
+	UserID           uuid.UUID  `json:"user_id"`
+	ProductID        uuid.UUID  `json:"product_id"`
+	ProductName      string     `json:"product_name,omitempty"`
+	ProductSlug      string     `json:"product_slug,omitempty"`
+	AlertType        string     `json:"alert_type"`                   // "back_in_stock" or "price_below"
+	TargetPriceCents *int64     `json:"target_price_cents,omitempty"` // Set for "price_below" alerts
+	NotifiedAt       *time.Time `json:"notified_at,omitempty"`        // Set once the alert has fired
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// CreateProductAlertRequest represents the request body for subscribing to a product alert.
+type CreateProductAlertRequest struct {
+	ProductID        uuid.UUID `json:"product_id" validate:"required"`
+	AlertType        string    `json:"alert_type" validate:"required,oneof=back_in_stock price_below"`
+	TargetPriceCents *int64    `json:"target_price_cents,omitempty" validate:"omitempty,gt=0"` // Required for "price_below" alerts
+}
+
+// Validate validates the CreateProductAlertRequest struct.
+// A target price is required for "price_below" alerts and not allowed for "back_in_stock" alerts.
+func (r *CreateProductAlertRequest) Validate() error {
+	if err := Validate.Struct(r); err != nil {
+		return err
+	}
+	if r.AlertType == ProductAlertPriceBelow && r.TargetPriceCents == nil {
+		return errors.New("target_price_cents is required for price_below alerts")
+	}
+	if r.AlertType == ProductAlertBackInStock && r.TargetPriceCents != nil {
+		return errors.New("target_price_cents is not allowed for back_in_stock alerts")
+	}
+	return nil
+}
+
+// ProductAlertNotification carries the details needed to notify a subscriber that an alert fired.
+type ProductAlertNotification struct {
+	AlertType         string
+	ProductName       string
+	ProductSlug       string
+	TargetPriceCents  *int64
+	CurrentPriceCents int64
+	StockQuantity     int
+}
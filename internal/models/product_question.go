@@ -0,0 +1,117 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Product question and answer moderation statuses.
+const (
+	ProductQAStatusPending  = "pending"
+	ProductQAStatusApproved = "approved"
+	ProductQAStatusRejected = "rejected"
+)
+
+// ProductQuestion represents a customer question about a product, with its published answers.
+type ProductQuestion struct {
+	ID          uuid.UUID       `json:"id"`
+	ProductID   uuid.UUID       `json:"product_id"`
+	AskerName   string          `json:"asker_name,omitempty"`
+	Body        string          `json:"body"`
+	Status      string          `json:"status,omitempty"` // Only returned to the asker and to moderators
+	AnswerCount int             `json:"answer_count"`
+	Answers     []ProductAnswer `json:"answers"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// ProductAnswer represents an answer to a product question.
+type ProductAnswer struct {
+	ID          uuid.UUID `json:"id"`
+	QuestionID  uuid.UUID `json:"question_id"`
+	AuthorName  string    `json:"author_name,omitempty"`
+	Body        string    `json:"body"`
+	IsOfficial  bool      `json:"is_official"`      // Written by store staff
+	Status      string    `json:"status,omitempty"` // Only returned to the author and to moderators
+	UpvoteCount int       `json:"upvote_count"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// AdminProductQuestionListItem represents a question in the admin moderation queue.
+type AdminProductQuestionListItem struct {
+	ID               uuid.UUID  `json:"id"`
+	ProductID        uuid.UUID  `json:"product_id"`
+	ProductName      string     `json:"product_name"`
+	UserID           uuid.UUID  `json:"user_id"`
+	AskerName        string     `json:"asker_name"`
+	AskerEmail       string     `json:"asker_email"`
+	Body             string     `json:"body"`
+	Status           string     `json:"status"`
+	ModerationReason *string    `json:"moderation_reason,omitempty"`
+	ModeratedBy      *uuid.UUID `json:"moderated_by,omitempty"`
+	ModeratedAt      *time.Time `json:"moderated_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// AdminProductAnswerListItem represents an answer in the admin moderation queue.
+type AdminProductAnswerListItem struct {
+	ID               uuid.UUID  `json:"id"`
+	QuestionID       uuid.UUID  `json:"question_id"`
+	QuestionBody     string     `json:"question_body"`
+	ProductID        uuid.UUID  `json:"product_id"`
+	UserID           *uuid.UUID `json:"user_id,omitempty"`
+	AuthorName       string     `json:"author_name"`
+	AuthorEmail      string     `json:"author_email"`
+	Body             string     `json:"body"`
+	IsOfficial       bool       `json:"is_official"`
+	Status           string     `json:"status"`
+	ModerationReason *string    `json:"moderation_reason,omitempty"`
+	UpvoteCount      int        `json:"upvote_count"`
+	ModeratedBy      *uuid.UUID `json:"moderated_by,omitempty"`
+	ModeratedAt      *time.Time `json:"moderated_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// ProductAnswerVoteSummary represents an answer's upvote total after a vote.
+type ProductAnswerVoteSummary struct {
+	AnswerID    uuid.UUID `json:"answer_id"`
+	UpvoteCount int       `json:"upvote_count"`
+}
+
+// ProductAnswerNotification carries the details needed to tell an asker their question was answered.
+type ProductAnswerNotification struct {
+	ProductName  string
+	ProductSlug  string
+	QuestionBody string
+	AnswerBody   string
+	IsOfficial   bool
+}
+
+// CreateProductQuestionRequest represents the request body for asking a question about a product.
+// Questions are held for moderation before they appear on the product page.
+type CreateProductQuestionRequest struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required,uuid"`
+	Body      string    `json:"body" validate:"required,min=5,max=2000"`
+}
+
+// CreateProductAnswerRequest represents the request body for answering a product question.
+type CreateProductAnswerRequest struct {
+	Body string `json:"body" validate:"required,max=5000"`
+}
+
+// RejectProductQARequest represents the request body for rejecting a question or an answer.
+type RejectProductQARequest struct {
+	Reason string `json:"reason" validate:"required,max=1000"`
+}
+
+func (r *CreateProductQuestionRequest) Validate() error {
+	return Validate.Struct(r)
+}
+
+func (r *CreateProductAnswerRequest) Validate() error {
+	return Validate.Struct(r)
+}
+
+func (r *RejectProductQARequest) Validate() error {
+	return Validate.Struct(r)
+}
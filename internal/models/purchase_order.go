@@ -0,0 +1,203 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Purchase order statuses.
+const (
+	PurchaseOrderStatusDraft             = "draft"
+	PurchaseOrderStatusOrdered           = "ordered"
+	PurchaseOrderStatusPartiallyReceived = "partially_received"
+	PurchaseOrderStatusReceived          = "received"
+	PurchaseOrderStatusCancelled         = "cancelled"
+)
+
+// Supplier represents a company the shop buys stock from.
+type Supplier struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	ContactName *string   `json:"contact_name,omitempty"`
+	Email       *string   `json:"email,omitempty"`
+	Phone       *string   `json:"phone,omitempty"`
+	Notes       *string   `json:"notes,omitempty"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CreateSupplierRequest represents the request body for creating a supplier.
+type CreateSupplierRequest struct {
+	Name        string  `json:"name" validate:"required,max=255"`
+	ContactName *string `json:"contact_name,omitempty" validate:"omitempty,max=255"`
+	Email       *string `json:"email,omitempty" validate:"omitempty,email,max=255"`
+	Phone       *string `json:"phone,omitempty" validate:"omitempty,max=50"`
+	Notes       *string `json:"notes,omitempty"`
+}
+
+// Validate validates the CreateSupplierRequest struct.
+func (r *CreateSupplierRequest) Validate() error {
+	return Validate.Struct(r)
+}
+
+// UpdateSupplierRequest represents the request body for updating a supplier.
+// Fields are optional; only provided fields are updated.
+type UpdateSupplierRequest struct {
+	Name        *string `json:"name,omitempty" validate:"omitempty,max=255"`
+	ContactName *string `json:"contact_name,omitempty" validate:"omitempty,max=255"`
+	Email       *string `json:"email,omitempty" validate:"omitempty,email,max=255"`
+	Phone       *string `json:"phone,omitempty" validate:"omitempty,max=50"`
+	Notes       *string `json:"notes,omitempty"`
+	IsActive    *bool   `json:"is_active,omitempty"`
+}
+
+// Validate validates the UpdateSupplierRequest struct.
+func (r *UpdateSupplierRequest) Validate() error {
+	return Validate.Struct(r)
+}
+
+// PurchaseOrder represents an order placed with a supplier, with its lines and receipts.
+type PurchaseOrder struct {
+	ID             uuid.UUID              `json:"id"`
+	SupplierID     uuid.UUID              `json:"supplier_id"`
+	SupplierName   string                 `json:"supplier_name"`
+	LocationID     uuid.UUID              `json:"location_id"` // Where the goods are received
+	Status         string                 `json:"status"`
+	Reference      *string                `json:"reference,omitempty"`
+	Notes          *string                `json:"notes,omitempty"`
+	ExpectedAt     *time.Time             `json:"expected_at,omitempty"`
+	CreatedBy      *uuid.UUID             `json:"created_by,omitempty"`
+	OrderedAt      *time.Time             `json:"ordered_at,omitempty"`
+	ReceivedAt     *time.Time             `json:"received_at,omitempty"`
+	TotalCostCents int64                  `json:"total_cost_cents"` // Sum of quantity_ordered * unit_cost_cents
+	Items          []PurchaseOrderItem    `json:"items"`
+	Receipts       []PurchaseOrderReceipt `json:"receipts"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+}
+
+// PurchaseOrderListItem represents a purchase order in a list, without its lines.
+type PurchaseOrderListItem struct {
+	ID                    uuid.UUID  `json:"id"`
+	SupplierID            uuid.UUID  `json:"supplier_id"`
+	SupplierName          string     `json:"supplier_name"`
+	LocationID            uuid.UUID  `json:"location_id"`
+	Status                string     `json:"status"`
+	Reference             *string    `json:"reference,omitempty"`
+	ExpectedAt            *time.Time `json:"expected_at,omitempty"`
+	OrderedAt             *time.Time `json:"ordered_at,omitempty"`
+	ReceivedAt            *time.Time `json:"received_at,omitempty"`
+	TotalCostCents        int64      `json:"total_cost_cents"`
+	TotalQuantityOrdered  int64      `json:"total_quantity_ordered"`
+	TotalQuantityReceived int64      `json:"total_quantity_received"`
+	CreatedAt             time.Time  `json:"created_at"`
+}
+
+// PurchaseOrderItem represents a line of a purchase order.
+type PurchaseOrderItem struct {
+	ID               uuid.UUID `json:"id"`
+	ProductID        uuid.UUID `json:"product_id"`
+	ProductName      string    `json:"product_name"`
+	QuantityOrdered  int       `json:"quantity_ordered"`
+	QuantityReceived int       `json:"quantity_received"`
+	UnitCostCents    int64     `json:"unit_cost_cents"`
+	LineTotalCents   int64     `json:"line_total_cents"` // quantity_ordered * unit_cost_cents
+}
+
+// PurchaseOrderReceipt represents a delivery received against a purchase order line.
+type PurchaseOrderReceipt struct {
+	ID                  uuid.UUID  `json:"id"`
+	PurchaseOrderItemID uuid.UUID  `json:"purchase_order_item_id"`
+	ProductID           uuid.UUID  `json:"product_id"`
+	LocationID          uuid.UUID  `json:"location_id"`
+	Quantity            int        `json:"quantity"`
+	UnitCostCents       int64      `json:"unit_cost_cents"` // Cost actually paid
+	StockMovementID     int64      `json:"stock_movement_id"`
+	ReceivedBy          *uuid.UUID `json:"received_by,omitempty"`
+	ReceivedAt          time.Time  `json:"received_at"`
+}
+
+// PurchaseOrderItemRequest represents a line in a purchase order request.
+type PurchaseOrderItemRequest struct {
+	ProductID     uuid.UUID `json:"product_id" validate:"required"`
+	Quantity      int       `json:"quantity" validate:"required,min=1"`
+	UnitCostCents int64     `json:"unit_cost_cents" validate:"min=0"`
+}
+
+// CreatePurchaseOrderRequest represents the request body for creating a draft purchase order.
+type CreatePurchaseOrderRequest struct {
+	SupplierID uuid.UUID                  `json:"supplier_id" validate:"required"`
+	LocationID *uuid.UUID                 `json:"location_id,omitempty"` // Defaults to the default stock location
+	Reference  *string                    `json:"reference,omitempty" validate:"omitempty,max=100"`
+	Notes      *string                    `json:"notes,omitempty"`
+	ExpectedAt *time.Time                 `json:"expected_at,omitempty"`
+	Items      []PurchaseOrderItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+// Validate validates the CreatePurchaseOrderRequest struct.
+// A product may appear on only one line.
+func (r *CreatePurchaseOrderRequest) Validate() error {
+	if err := Validate.Struct(r); err != nil {
+		return err
+	}
+	return validateUniquePurchaseOrderProducts(r.Items)
+}
+
+// UpdatePurchaseOrderRequest represents the request body for updating a draft purchase order.
+// Fields are optional; if items are given, they replace all existing lines.
+type UpdatePurchaseOrderRequest struct {
+	LocationID *uuid.UUID                 `json:"location_id,omitempty"`
+	Reference  *string                    `json:"reference,omitempty" validate:"omitempty,max=100"`
+	Notes      *string                    `json:"notes,omitempty"`
+	ExpectedAt *time.Time                 `json:"expected_at,omitempty"`
+	Items      []PurchaseOrderItemRequest `json:"items,omitempty" validate:"omitempty,min=1,dive"`
+}
+
+// Validate validates the UpdatePurchaseOrderRequest struct.
+func (r *UpdatePurchaseOrderRequest) Validate() error {
+	if err := Validate.Struct(r); err != nil {
+		return err
+	}
+	return validateUniquePurchaseOrderProducts(r.Items)
+}
+
+// ReceivePurchaseOrderItemRequest represents the quantity of a product received in a delivery.
+type ReceivePurchaseOrderItemRequest struct {
+	ProductID     uuid.UUID `json:"product_id" validate:"required"`
+	Quantity      int       `json:"quantity" validate:"required,min=1"`
+	UnitCostCents *int64    `json:"unit_cost_cents,omitempty" validate:"omitempty,min=0"` // Defaults to the cost on the purchase order line
+}
+
+// ReceivePurchaseOrderRequest represents the request body for receiving (part of) a purchase order.
+type ReceivePurchaseOrderRequest struct {
+	Items []ReceivePurchaseOrderItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+// Validate validates the ReceivePurchaseOrderRequest struct.
+func (r *ReceivePurchaseOrderRequest) Validate() error {
+	if err := Validate.Struct(r); err != nil {
+		return err
+	}
+	seen := make(map[uuid.UUID]bool, len(r.Items))
+	for _, item := range r.Items {
+		if seen[item.ProductID] {
+			return errors.New("each product may appear only once per receipt")
+		}
+		seen[item.ProductID] = true
+	}
+	return nil
+}
+
+func validateUniquePurchaseOrderProducts(items []PurchaseOrderItemRequest) error {
+	seen := make(map[uuid.UUID]bool, len(items))
+	for _, item := range items {
+		if seen[item.ProductID] {
+			return errors.New("each product may appear only once per purchase order")
+		}
+		seen[item.ProductID] = true
+	}
+	return nil
+}
@@ -1,59 +1,215 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// Review moderation statuses.
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+)
+
+// MaxReviewImages is the maximum number of photos attached to a review.
+const MaxReviewImages = 4
+
+// Review list sort orders.
+const (
+	ReviewSortNewest      = "newest"
+	ReviewSortMostHelpful = "most_helpful"
+	ReviewSortHighest     = "highest"
+	ReviewSortLowest      = "lowest"
+)
+
 // Review represents a user's rating for a product (core model, potentially used internally).
 type Review struct {
-	ID        uuid.UUID `json:"id"`
-	UserID    uuid.UUID `json:"user_id"` // Core ID
-	ProductID uuid.UUID `json:"product_id"`
-	Rating    int       `json:"rating" validate:"required,min=1,max=5"` // Star rating (1 to 5)
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID               uuid.UUID `json:"id"`
+	UserID           uuid.UUID `json:"user_id"` // Core ID
+	ProductID        uuid.UUID `json:"product_id"`
+	Rating           int       `json:"rating" validate:"required,min=1,max=5"` // Star rating (1 to 5)
+	Title            *string   `json:"title,omitempty"`
+	Body             *string   `json:"body,omitempty"`
+	Pros             *string   `json:"pros,omitempty"`
+	Cons             *string   `json:"cons,omitempty"`
+	Status           string    `json:"status"`                      // Moderation status: pending, approved or rejected
+	ModerationReason *string   `json:"moderation_reason,omitempty"` // Why the review was rejected
+	VerifiedPurchase bool      `json:"verified_purchase"`           // The reviewer has a delivered order containing the product
+	ImageURLs        []string  `json:"image_urls"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
 }
 
 // ReviewListItem represents a review for display purposes, including the reviewer's name.
 type ReviewListItem struct {
-	ID           uuid.UUID `json:"id"`
-	UserID       uuid.UUID `json:"user_id,omitempty"` // Potentially omit if name is shown
-	ReviewerName string    `json:"reviewer_name"`     // Added field for display
-	ProductID    uuid.UUID `json:"product_id"`        // Might be omitted if fetched for a specific product
-	Rating       int       `json:"rating"`            // The star rating (1-5)
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID               uuid.UUID    `json:"id"`
+	UserID           uuid.UUID    `json:"user_id,omitempty"` // Potentially omit if name is shown
+	ReviewerName     string       `json:"reviewer_name"`     // Added field for display
+	ProductID        uuid.UUID    `json:"product_id"`        // Might be omitted if fetched for a specific product
+	Rating           int          `json:"rating"`            // The star rating (1-5)
+	Title            *string      `json:"title,omitempty"`
+	Body             *string      `json:"body,omitempty"`
+	Pros             *string      `json:"pros,omitempty"`
+	Cons             *string      `json:"cons,omitempty"`
+	VerifiedPurchase bool         `json:"verified_purchase"` // Shown as a "verified purchase" badge
+	ImageURLs        []string     `json:"image_urls"`
+	HelpfulCount     int          `json:"helpful_count"`
+	UnhelpfulCount   int          `json:"unhelpful_count"`
+	Reply            *ReviewReply `json:"reply,omitempty"` // Official reply from the store
+	CreatedAt        time.Time    `json:"created_at"`
+	UpdatedAt        time.Time    `json:"updated_at"`
 }
 
 // ReviewByUserListItem represents a review submitted by the user, including the product name.
 type ReviewByUserListItem struct {
-	ID          uuid.UUID `json:"id"`
-	UserID      uuid.UUID `json:"user_id,omitempty"` // Potentially omit if context is clear
-	ProductID   uuid.UUID `json:"product_id"`
-	ProductName string    `json:"product_name"` // Added field for display
-	Rating      int       `json:"rating"`       // The star rating (1-5)
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID               uuid.UUID    `json:"id"`
+	UserID           uuid.UUID    `json:"user_id,omitempty"` // Potentially omit if context is clear
+	ProductID        uuid.UUID    `json:"product_id"`
+	ProductName      string       `json:"product_name"` // Added field for display
+	Rating           int          `json:"rating"`       // The star rating (1-5)
+	Title            *string      `json:"title,omitempty"`
+	Body             *string      `json:"body,omitempty"`
+	Pros             *string      `json:"pros,omitempty"`
+	Cons             *string      `json:"cons,omitempty"`
+	Status           string       `json:"status"`
+	ModerationReason *string      `json:"moderation_reason,omitempty"`
+	VerifiedPurchase bool         `json:"verified_purchase"`
+	ImageURLs        []string     `json:"image_urls"`
+	Reply            *ReviewReply `json:"reply,omitempty"`
+	CreatedAt        time.Time    `json:"created_at"`
+	UpdatedAt        time.Time    `json:"updated_at"`
+}
+
+// AdminReviewListItem represents a review in the admin moderation queue.
+type AdminReviewListItem struct {
+	ID               uuid.UUID      `json:"id"`
+	UserID           uuid.UUID      `json:"user_id"`
+	ReviewerName     string         `json:"reviewer_name"`
+	ReviewerEmail    string         `json:"reviewer_email"`
+	ProductID        uuid.UUID      `json:"product_id"`
+	ProductName      string         `json:"product_name"`
+	Rating           int            `json:"rating"`
+	Title            *string        `json:"title,omitempty"`
+	Body             *string        `json:"body,omitempty"`
+	Pros             *string        `json:"pros,omitempty"`
+	Cons             *string        `json:"cons,omitempty"`
+	Status           string         `json:"status"`
+	ModerationReason *string        `json:"moderation_reason,omitempty"`
+	VerifiedPurchase bool           `json:"verified_purchase"`
+	ImageURLs        []string       `json:"image_urls"`
+	HelpfulCount     int            `json:"helpful_count"`
+	UnhelpfulCount   int            `json:"unhelpful_count"`
+	OpenReportCount  int64          `json:"open_report_count"` // Abuse reports not yet handled by a moderator
+	Reports          []ReviewReport `json:"reports,omitempty"` // Open abuse reports (single review view only)
+	Reply            *ReviewReply   `json:"reply,omitempty"`
+	ModeratedBy      *uuid.UUID     `json:"moderated_by,omitempty"`
+	ModeratedAt      *time.Time     `json:"moderated_at,omitempty"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+}
+
+// ReviewReply represents the store's official reply to a review.
+type ReviewReply struct {
+	ID        uuid.UUID  `json:"id"`
+	ReviewID  uuid.UUID  `json:"review_id"`
+	AuthorID  *uuid.UUID `json:"author_id,omitempty"` // Staff member who wrote or last edited the reply
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// ReviewReplyNotification carries the details needed to tell a reviewer their review was answered.
+type ReviewReplyNotification struct {
+	ProductName string
+	ProductSlug string
+	ReplyBody   string
+}
+
+// ReviewReport represents an abuse report on a review.
+type ReviewReport struct {
+	ID            uuid.UUID `json:"id"`
+	UserID        uuid.UUID `json:"user_id"`
+	ReporterEmail string    `json:"reporter_email,omitempty"`
+	Reason        string    `json:"reason"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// RatingCount represents the number of approved reviews with a given star rating.
+type RatingCount struct {
+	Rating int   `json:"rating"`
+	Count  int64 `json:"count"`
+}
+
+// ReviewVoteSummary represents a review's vote totals after a vote.
+type ReviewVoteSummary struct {
+	ReviewID       uuid.UUID `json:"review_id"`
+	HelpfulCount   int       `json:"helpful_count"`
+	UnhelpfulCount int       `json:"unhelpful_count"`
 }
 
 // CreateReviewRequest represents the request body for creating a review.
+// A review with text or photos is held for moderation; a rating-only review is published immediately.
+// Photos are uploaded as multipart "images" files alongside the other fields.
 type CreateReviewRequest struct {
 	ProductID uuid.UUID `json:"product_id" validate:"required,uuid"`
 	Rating    int       `json:"rating" validate:"required,min=1,max=5"`
+	Title     *string   `json:"title,omitempty" validate:"omitempty,max=200"`
+	Body      *string   `json:"body,omitempty" validate:"omitempty,max=5000"`
+	Pros      *string   `json:"pros,omitempty" validate:"omitempty,max=1000"`
+	Cons      *string   `json:"cons,omitempty" validate:"omitempty,max=1000"`
 }
 
-// UpdateReviewRequest represents the request body for updating a review's rating.
+// HasText reports whether the review carries any text that needs moderation.
+func (cr *CreateReviewRequest) HasText() bool {
+	return reviewHasText(cr.Title, cr.Body, cr.Pros, cr.Cons)
+}
+
+// UpdateReviewRequest represents the request body for updating a review.
+// The text fields replace the existing ones; omitted fields are cleared.
 type UpdateReviewRequest struct {
-	Rating int `json:"rating" validate:"required,min=1,max=5"`
+	Rating int     `json:"rating" validate:"required,min=1,max=5"`
+	Title  *string `json:"title,omitempty" validate:"omitempty,max=200"`
+	Body   *string `json:"body,omitempty" validate:"omitempty,max=5000"`
+	Pros   *string `json:"pros,omitempty" validate:"omitempty,max=1000"`
+	Cons   *string `json:"cons,omitempty" validate:"omitempty,max=1000"`
+}
+
+// HasText reports whether the review carries any text that needs moderation.
+func (ur *UpdateReviewRequest) HasText() bool {
+	return reviewHasText(ur.Title, ur.Body, ur.Pros, ur.Cons)
+}
+
+// VoteReviewRequest represents the request body for voting a review helpful or unhelpful.
+type VoteReviewRequest struct {
+	Helpful *bool `json:"helpful" validate:"required"`
+}
+
+// ReportReviewRequest represents the request body for reporting an abusive review.
+type ReportReviewRequest struct {
+	Reason string `json:"reason" validate:"required,max=1000"`
+}
+
+// RejectReviewRequest represents the request body for rejecting a review.
+type RejectReviewRequest struct {
+	Reason string `json:"reason" validate:"required,max=1000"`
+}
+
+// ReviewReplyRequest represents the request body for posting or editing the official reply to a review.
+type ReviewReplyRequest struct {
+	Body string `json:"body" validate:"required,max=2000"`
 }
 
 type GetReviewsByProductResponse struct {
-	Reviews []ReviewListItem `json:"reviews"`
-	Page    int              `json:"page,omitempty"`
-	Limit   int              `json:"limit,omitempty"`
-	Total   int64            `json:"total,omitempty"`
+	Reviews      []ReviewListItem `json:"reviews"`
+	Page         int              `json:"page,omitempty"`
+	Limit        int              `json:"limit,omitempty"`
+	Total        int64            `json:"total,omitempty"`
+	Sort         string           `json:"sort"`
+	Rating       int              `json:"rating,omitempty"`    // Star filter, if any
+	Distribution []RatingCount    `json:"rating_distribution"` // Approved review counts for 1 to 5 stars, regardless of the star filter
 }
 
 type GetReviewsByUserResponse struct {
@@ -70,3 +226,28 @@ func (cr *CreateReviewRequest) Validate() error {
 func (ur *UpdateReviewRequest) Validate() error {
 	return Validate.Struct(ur)
 }
+
+func (vr *VoteReviewRequest) Validate() error {
+	return Validate.Struct(vr)
+}
+
+func (rr *ReportReviewRequest) Validate() error {
+	return Validate.Struct(rr)
+}
+
+func (rr *RejectReviewRequest) Validate() error {
+	return Validate.Struct(rr)
+}
+
+func (rr *ReviewReplyRequest) Validate() error {
+	return Validate.Struct(rr)
+}
+
+func reviewHasText(fields ...*string) bool {
+	for _, f := range fields {
+		if f != nil && strings.TrimSpace(*f) != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Permissions checked by the admin routes. Roles grant them to staff members.
+const (
+	PermProductsWrite   = "products:write"
+	PermInventoryWrite  = "inventory:write"
+	PermOrdersRead      = "orders:read"
+	PermOrdersWrite     = "orders:write"
+	PermDeliveryWrite   = "delivery:write"
+	PermDiscountsWrite  = "discounts:write"
+	PermUsersRead       = "users:read"
+	PermUsersWrite      = "users:write"
+	PermRolesWrite      = "roles:write"
+	PermReviewsModerate = "reviews:moderate"
+	PermAnalyticsRead   = "analytics:read"
+	PermAuditRead       = "audit:read"
+	PermAPIKeysManage   = "api_keys:manage"
+)
+
+// RoleSuperuser is the role holding every permission. At least one active user always keeps it.
+const RoleSuperuser = "superuser"
+
+// Role represents a named set of permissions.
+type Role struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Permission represents a permission that can be granted to a role.
+type Permission struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+// UserRoles represents the roles assigned to a user and the permissions they grant.
+type UserRoles struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Roles       []string  `json:"roles"`
+	Permissions []string  `json:"permissions"`
+}
+
+// SetUserRolesRequest represents the request body for replacing a user's roles.
+// An empty list removes the user's access to the admin area.
+type SetUserRolesRequest struct {
+	Roles []string `json:"roles" validate:"required,dive,required,max=50"`
+}
+
+func (r *SetUserRolesRequest) Validate() error {
+	return Validate.Struct(r)
+}
+
+// HasPermission reports whether the user's token grants the permission.
+func (u *User) HasPermission(permission string) bool {
+	return slices.Contains(u.Permissions, permission)
+}
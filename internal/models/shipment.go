@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Shipment is a parcel handed over to a courier for an order.
+type Shipment struct {
+	ID              uuid.UUID       `json:"id"`
+	OrderID         uuid.UUID       `json:"order_id"`
+	Courier         string          `json:"courier"`
+	TrackingNumber  string          `json:"tracking_number"`
+	Status          string          `json:"status"`
+	StatusUpdatedAt time.Time       `json:"status_updated_at"` // When the courier reported the current status
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	Events          []ShipmentEvent `json:"events"` // Status history, oldest first
+}
+
+// ShipmentEvent is a status change of a shipment.
+type ShipmentEvent struct {
+	Status      string    `json:"status"`
+	Description *string   `json:"description,omitempty"`
+	OccurredAt  time.Time `json:"occurred_at"`
+	Source      string    `json:"source"` // api, webhook or poll
+}
@@ -0,0 +1,38 @@
+package models
+
+// TwoFactorStatus reports whether a user has two-factor authentication enabled.
+type TwoFactorStatus struct {
+	Enabled                bool  `json:"enabled"`
+	Pending                bool  `json:"pending"` // Enrolment started but not confirmed yet
+	RecoveryCodesRemaining int64 `json:"recovery_codes_remaining"`
+}
+
+// TwoFactorEnrollment is returned when a user starts enrolling an authenticator app.
+type TwoFactorEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"` // otpauth:// URI, usually rendered as a QR code
+}
+
+// RecoveryCodesResponse carries newly generated recovery codes. They are shown only once.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TwoFactorCodeRequest carries a code from the authenticator app or a recovery code.
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required,max=32"`
+}
+
+func (r *TwoFactorCodeRequest) Validate() error {
+	return Validate.Struct(r)
+}
+
+// TwoFactorLoginRequest completes a login that requires a second factor.
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required,max=32"`
+}
+
+func (r *TwoFactorLoginRequest) Validate() error {
+	return Validate.Struct(r)
+}
@@ -7,14 +7,18 @@ import (
 )
 
 type User struct {
-	ID        uuid.UUID  `json:"id"`
-	Email     string     `json:"email"`
-	Password  string     `json:"-" validate:"required"`
-	FullName  string     `json:"full_name"`
-	IsAdmin   bool       `json:"is_admin"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	ID          uuid.UUID  `json:"id"`
+	Email       string     `json:"email"`
+	Password    string     `json:"-" validate:"required"`
+	FullName    string     `json:"full_name"`
+	IsAdmin     bool       `json:"is_admin"`
+	Permissions []string   `json:"permissions,omitempty"` // Granted by the user's roles
+	SessionID   uuid.UUID  `json:"-"`                     // Session of the access token, set by JWTMiddleware
+	TwoFactor   bool       `json:"-"`                     // The session signed in with a second factor
+	APIKeyID    uuid.UUID  `json:"-"`                     // Set instead of a session when the request used an API key
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
 }
 
 type UserLogin struct {
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WishlistItem represents a saved product with its live price and stock.
+type WishlistItem struct {
+	ID                   uuid.UUID `json:"id"`
+	ProductID            uuid.UUID `json:"product_id"`
+	Name                 string    `json:"name"`
+	Slug                 string    `json:"slug"`
+	Brand                string    `json:"brand"`
+	ImageUrls            []string  `json:"image_urls"`
+	Status               string    `json:"status"`
+	StockQuantity        int       `json:"stock_quantity"`
+	InStock              bool      `json:"in_stock"`
+	OriginalPriceCents   int64     `json:"original_price_cents"`
+	DiscountedPriceCents int64     `json:"discounted_price_cents"` // Price after any active discounts
+	HasActiveDiscount    bool      `json:"has_active_discount"`
+	AddedAt              time.Time `json:"added_at"`
+}
+
+// Wishlist represents the complete wishlist of a user or guest.
+type Wishlist struct {
+	Items      []WishlistItem `json:"items"`
+	TotalItems int            `json:"total_items"`
+}
+
+// AddWishlistItemRequest represents the request body for adding a product to the wishlist.
+type AddWishlistItemRequest struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+}
+
+// Validate validates the AddWishlistItemRequest struct.
+func (r *AddWishlistItemRequest) Validate() error {
+	return Validate.Struct(r)
+}
+
+// MoveWishlistItemToCartRequest represents the request body for moving a wishlist item to the cart.
+type MoveWishlistItemToCartRequest struct {
+	Quantity int `json:"quantity" validate:"omitempty,min=1"` // Defaults to 1
+}
+
+// Validate validates the MoveWishlistItemToCartRequest struct.
+func (r *MoveWishlistItemToCartRequest) Validate() error {
+	return Validate.Struct(r)
+}
@@ -1,26 +1,32 @@
 package router
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/MihoZaki/DzTech/db"
 	"github.com/MihoZaki/DzTech/internal/config"
+	"github.com/MihoZaki/DzTech/internal/courier"
 	db_queries "github.com/MihoZaki/DzTech/internal/db" // SQLC generated code
 	"github.com/MihoZaki/DzTech/internal/handlers"
 	"github.com/MihoZaki/DzTech/internal/middleware"
+	"github.com/MihoZaki/DzTech/internal/models"
 	"github.com/MihoZaki/DzTech/internal/services"
 	"github.com/MihoZaki/DzTech/internal/storage"
+	"github.com/MihoZaki/DzTech/internal/utils"
 	"github.com/go-chi/chi/v5"
 	"github.com/redis/go-redis/v9"
 )
 
-func New(cfg *config.Config, redisClient *redis.Client) http.Handler {
+// New builds the HTTP handler and starts the background jobs of the services, which stop when ctx is cancelled.
+func New(ctx context.Context, cfg *config.Config, redisClient *redis.Client) http.Handler {
 
 	r := chi.NewRouter()
 
 	// Apply middleware
-	middleware.ApplyMiddleware(r)
+	middleware.ApplyMiddleware(r, cfg.TrustedProxies)
 
 	// Health check endpoint
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -46,18 +52,55 @@ func New(cfg *config.Config, redisClient *redis.Client) http.Handler {
 	// Initialize database querier
 	querier := db_queries.New(pool)
 
+	jwtKeys, err := utils.LoadJWTKeySet(cfg.JWTSecret, cfg.JWTKeys)
+	if err != nil {
+		slog.Error("Failed to load JWT keys", "error", err)
+		panic("failed to load JWT keys: " + err.Error())
+	}
+
+	geographyService, err := services.NewGeographyService(context.Background(), querier, slog.Default())
+	if err != nil {
+		slog.Error("Failed to load geography reference data", "error", err)
+		panic("failed to load geography reference data: " + err.Error())
+	}
+	models.UsePlaceResolver(geographyService)
+
+	// Couriers delivery services can hand their parcels over to
+	couriers := courier.NewRegistry()
+	if cfg.Couriers.FakeEnabled {
+		couriers.Register("fake", courier.NewFakeCourier(time.Duration(cfg.Couriers.FakeStepSeconds)*time.Second, cfg.Couriers.FakeWebhookSecret))
+		slog.Warn("Fake courier enabled, shipments will not reach a real delivery company")
+	}
+
 	// Initialize services
+	emailService := services.NewEmailService(cfg, slog.Default())
+	productAlertService := services.NewProductAlertService(querier, emailService, slog.Default())
 	userService := services.NewUserService(querier) // Initialize services (add redisClient if needed in constructor)
-	productService := services.NewProductService(querier, storer, redisClient, slog.Default())
+	productService := services.NewProductService(querier, pool, storer, redisClient, productAlertService, slog.Default())
 	cartService := services.NewCartService(querier, productService, slog.Default())
-	orderService := services.NewOrderService(querier, pool, cartService, redisClient, productService, slog.Default())
-	authService := services.NewAuthService(querier, userService, cartService, cfg.JWTSecret, slog.Default())
-	deliveryService := services.NewDeliveryServiceService(querier, slog.Default())
-	adminUserService := services.NewAdminUserService(querier, slog.Default())
-	reviewService := services.NewReviewService(querier, pool, slog.Default())
-	discountService := services.NewDiscountService(querier, redisClient, slog.Default())
+	reviewService := services.NewReviewService(querier, pool, storer, emailService, cfg.Reviews, slog.Default())
+	deliveryService := services.NewDeliveryServiceService(querier, pool, geographyService, couriers, cfg.Delivery, slog.Default())
+	orderService := services.NewOrderService(querier, pool, cartService, redisClient, productService, productAlertService, reviewService, geographyService, deliveryService, slog.Default())
+	shipmentService := services.NewShipmentService(querier, pool, orderService, deliveryService, couriers, cfg.Couriers, slog.Default())
+	wishlistService := services.NewWishlistService(querier, cartService, slog.Default())
+	addressService := services.NewAddressService(querier, pool, slog.Default())
+	sessionService := services.NewSessionService(querier, redisClient, slog.Default())
+	twoFactorService := services.NewTwoFactorService(querier, pool, sessionService, cfg.TwoFactor, slog.Default())
+	loginThrottleService := services.NewLoginThrottleService(querier, redisClient, emailService, cfg.LoginProtection, slog.Default())
+	oidcService := services.NewOIDCService(querier, pool, userService, redisClient, cfg.OIDC, slog.Default())
+	authService := services.NewAuthService(querier, userService, cartService, wishlistService, twoFactorService, loginThrottleService, oidcService, redisClient, jwtKeys, slog.Default())
+	adminUserService := services.NewAdminUserService(querier, pool, authService, loginThrottleService, slog.Default())
+	discountService := services.NewDiscountService(querier, redisClient, productAlertService, slog.Default())
 	categoryService := services.NewCategoryService(querier, redisClient, slog.Default())
 	analyticsService := services.NewAnalyticsService(querier, redisClient, slog.Default())
+	inventoryService := services.NewInventoryService(querier, pool, redisClient, productAlertService, slog.Default())
+	purchaseOrderService := services.NewPurchaseOrderService(querier, pool, redisClient, productAlertService, slog.Default())
+	productQuestionService := services.NewProductQuestionService(querier, emailService, slog.Default())
+	roleService := services.NewRoleService(querier, pool, authService, slog.Default())
+	auditService := services.NewAuditService(querier, cfg.Audit, slog.Default())
+	apiKeyService := services.NewAPIKeyService(querier, slog.Default())
+	auditService.StartRetentionWorker(ctx)
+	shipmentService.StartTrackingPoller(ctx)
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(authService)
@@ -65,7 +108,7 @@ func New(cfg *config.Config, redisClient *redis.Client) http.Handler {
 	adminProductHandler := handlers.NewProductHandler(productService)
 	adminOrderHandler := handlers.NewOrderHandler(orderService, slog.Default())
 	adminDeliveryHandler := handlers.NewDeliveryServiceHandler(deliveryService, slog.Default())
-	cartHandler := handlers.NewCartHandler(cartService, productService, slog.Default())
+	cartHandler := handlers.NewCartHandler(cartService, productService, deliveryService, slog.Default())
 	orderHandler := handlers.NewOrderHandler(orderService, slog.Default())
 	deliveryOptionsHandler := handlers.NewDeliveryOptionsHandler(deliveryService, slog.Default())
 	adminUserHandler := handlers.NewAdminUserHandler(adminUserService, slog.Default())
@@ -74,6 +117,20 @@ func New(cfg *config.Config, redisClient *redis.Client) http.Handler {
 	categoryHandler := handlers.NewCategoryHandler(categoryService, slog.Default())
 	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService, slog.Default())
 	profileHandler := handlers.NewProfileHandler(userService, slog.Default())
+	productAlertHandler := handlers.NewProductAlertHandler(productAlertService, slog.Default())
+	wishlistHandler := handlers.NewWishlistHandler(wishlistService, slog.Default())
+	addressHandler := handlers.NewAddressHandler(addressService, slog.Default())
+	geographyHandler := handlers.NewGeographyHandler(geographyService, slog.Default())
+	inventoryHandler := handlers.NewInventoryHandler(inventoryService, slog.Default())
+	purchaseOrderHandler := handlers.NewPurchaseOrderHandler(purchaseOrderService, slog.Default())
+	productQuestionHandler := handlers.NewProductQuestionHandler(productQuestionService, slog.Default())
+	roleHandler := handlers.NewRoleHandler(roleService, slog.Default())
+	twoFactorHandler := handlers.NewTwoFactorHandler(twoFactorService, slog.Default())
+	sessionHandler := handlers.NewSessionHandler(sessionService, slog.Default())
+	auditHandler := handlers.NewAuditHandler(auditService, slog.Default())
+	jwksHandler := handlers.NewJWKSHandler(jwtKeys, slog.Default())
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService, slog.Default())
+	shipmentHandler := handlers.NewShipmentHandler(shipmentService, slog.Default())
 
 	// Create sub-routers
 	authRouter := chi.NewRouter()
@@ -87,6 +144,7 @@ func New(cfg *config.Config, redisClient *redis.Client) http.Handler {
 	productRouter := chi.NewRouter()
 	productRouter.Get("/", productHandler.ListAllProducts)
 	productRouter.Get("/{id}", productHandler.GetProduct)
+	productRouter.Get("/{id}/questions", productQuestionHandler.ListProductQuestions)
 	productRouter.Get("/search", productHandler.SearchProducts)
 	productRouter.Get("/categories", productHandler.ListCategories)
 	productRouter.Get("/categories/{id}", productHandler.GetCategory)
@@ -95,51 +153,123 @@ func New(cfg *config.Config, redisClient *redis.Client) http.Handler {
 	orderHandler.RegisterGuestRoutes(guestRouter)
 
 	adminRouter := chi.NewRouter()
-	adminRouter.Use(middleware.JWTMiddleware(cfg))
+	adminRouter.Use(middleware.APIKeyMiddleware(apiKeyService)) // Integrations authenticate with X-API-Key instead of a JWT
+	adminRouter.Use(middleware.JWTMiddleware(jwtKeys, authService))
 	adminRouter.Use(middleware.RequireAdmin)
+	if cfg.TwoFactor.RequiredForAdmins {
+		adminRouter.Use(middleware.RequireTwoFactor)
+	}
+	adminRouter.Use(middleware.Audit(auditService)) // Records every successful mutating admin request
+	// Each admin area additionally requires the permission granted by the staff member's roles
 	adminRouter.Route("/products", func(r chi.Router) {
+		r.Use(middleware.RequirePermission(models.PermProductsWrite))
 		adminProductHandler.RegisterRoutes(r)
 	})
 	adminRouter.Route("/orders", func(r chi.Router) {
+		r.Use(middleware.RequireReadWritePermission(models.PermOrdersRead, models.PermOrdersWrite))
 		adminOrderHandler.RegisterAdminRoutes(r)
+		shipmentHandler.RegisterAdminRoutes(r)
 	})
 	adminRouter.Route("/delivery-services", func(r chi.Router) {
+		r.Use(middleware.RequirePermission(models.PermDeliveryWrite))
 		adminDeliveryHandler.RegisterRoutes(r)
 	})
 	adminRouter.Route("/users", func(r chi.Router) {
+		r.Use(middleware.RequireReadWritePermission(models.PermUsersRead, models.PermUsersWrite))
 		adminUserHandler.RegisterRoutes(r)
+		sessionHandler.RegisterAdminRoutes(r)
+	})
+	adminRouter.Route("/roles", func(r chi.Router) {
+		r.Use(middleware.RequirePermission(models.PermRolesWrite))
+		roleHandler.RegisterRoutes(r)
 	})
 	adminRouter.Route("/discounts", func(r chi.Router) {
+		r.Use(middleware.RequirePermission(models.PermDiscountsWrite))
 		discountHandler.RegisterRoutes(r)
 	})
 	adminRouter.Route("/categories", func(r chi.Router) {
+		r.Use(middleware.RequirePermission(models.PermProductsWrite))
 		categoryHandler.RegisterRoutes(r)
 	})
+	adminRouter.Route("/reviews", func(r chi.Router) {
+		r.Use(middleware.RequirePermission(models.PermReviewsModerate))
+		reviewHandler.RegisterAdminRoutes(r)
+	})
 	adminRouter.Route("/analytics", func(r chi.Router) {
+		r.Use(middleware.RequirePermission(models.PermAnalyticsRead))
 		analyticsHandler.RegisterRoutes(r)
 	})
+	adminRouter.Route("/inventory", func(r chi.Router) {
+		r.Use(middleware.RequirePermission(models.PermInventoryWrite))
+		inventoryHandler.RegisterRoutes(r)
+	})
+	adminRouter.Route("/suppliers", func(r chi.Router) {
+		r.Use(middleware.RequirePermission(models.PermInventoryWrite))
+		purchaseOrderHandler.RegisterSupplierRoutes(r)
+	})
+	adminRouter.Route("/purchase-orders", func(r chi.Router) {
+		r.Use(middleware.RequirePermission(models.PermInventoryWrite))
+		purchaseOrderHandler.RegisterRoutes(r)
+	})
+	adminRouter.Route("/questions", func(r chi.Router) {
+		r.Use(middleware.RequirePermission(models.PermReviewsModerate))
+		productQuestionHandler.RegisterAdminRoutes(r)
+	})
+	adminRouter.Route("/audit-log", func(r chi.Router) {
+		r.Use(middleware.RequirePermission(models.PermAuditRead))
+		auditHandler.RegisterRoutes(r)
+	})
+	adminRouter.Route("/api-keys", func(r chi.Router) {
+		r.Use(middleware.RequirePermission(models.PermAPIKeysManage))
+		apiKeyHandler.RegisterRoutes(r)
+	})
 
 	// Create user-specific sub-router (protected)
 	userRouter := chi.NewRouter()
-	userRouter.Use(middleware.JWTMiddleware(cfg)) // Apply JWT middleware to user routes
+	userRouter.Use(middleware.JWTMiddleware(jwtKeys, authService)) // Apply JWT middleware to user routes
 	profileHandler.RegisterRoutes(userRouter)
+	sessionHandler.RegisterRoutes(userRouter)
+	twoFactorHandler.RegisterRoutes(userRouter)
+	userRouter.Route("/product-alerts", func(r chi.Router) {
+		productAlertHandler.RegisterRoutes(r)
+	})
+	userRouter.Route("/wishlist", func(r chi.Router) {
+		wishlistHandler.RegisterRoutes(r)
+	})
+	userRouter.Route("/addresses", func(r chi.Router) {
+		addressHandler.RegisterRoutes(r)
+	})
 
 	cartRouter := chi.NewRouter()
-	cartRouter.Use(middleware.JWTMiddleware(cfg))
+	cartRouter.Use(middleware.JWTMiddleware(jwtKeys, authService))
 	cartHandler.RegisterRoutes(cartRouter)
 
 	orderRouter := chi.NewRouter()
-	orderRouter.Use(middleware.JWTMiddleware(cfg))
+	orderRouter.Use(middleware.JWTMiddleware(jwtKeys, authService))
 	orderHandler.RegisterUserRoutes(orderRouter)
+	shipmentHandler.RegisterUserRoutes(orderRouter)
 
 	deliveryOptionsRouter := chi.NewRouter()
-	deliveryOptionsRouter.Use(middleware.JWTMiddleware(cfg))
+	deliveryOptionsRouter.Use(middleware.JWTMiddleware(jwtKeys, authService))
 	deliveryOptionsHandler.RegisterRoutes(deliveryOptionsRouter)
 
 	reviewRouter := chi.NewRouter()
-	reviewRouter.Use(middleware.JWTMiddleware(cfg))
+	reviewRouter.Use(middleware.JWTMiddleware(jwtKeys, authService))
 	reviewHandler.RegisterRoutes(reviewRouter)
 
+	questionRouter := chi.NewRouter()
+	questionRouter.Use(middleware.JWTMiddleware(jwtKeys, authService))
+	productQuestionHandler.RegisterRoutes(questionRouter)
+
+	// Public keys for services verifying our tokens
+	r.Get("/.well-known/jwks.json", jwksHandler.GetJWKS)
+
+	// Reference data for address forms
+	r.Route("/api/v1/geography", geographyHandler.RegisterRoutes)
+
+	// Shipment status notifications pushed by couriers
+	r.Route("/api/v1/webhooks/couriers", shipmentHandler.RegisterWebhookRoutes)
+
 	// Mount sub-routers
 	r.Mount("/api/v1/auth", authRouter)
 	r.Mount("/api/v1/products", productRouter)
@@ -149,6 +279,7 @@ func New(cfg *config.Config, redisClient *redis.Client) http.Handler {
 	r.Mount("/api/v1/orders", orderRouter)
 	r.Mount("/api/v1/delivery-options", deliveryOptionsRouter)
 	r.Mount("/api/v1/reviews", reviewRouter)
+	r.Mount("/api/v1/questions", questionRouter)
 	r.Mount("/api/v1/checkout", guestRouter)
 
 	slog.Info("Router initialized")
@@ -17,9 +17,10 @@ import (
 )
 
 type Server struct {
-	httpServer  *http.Server
-	cfg         *config.Config
-	redisClient *redis.Client
+	httpServer       *http.Server
+	cfg              *config.Config
+	redisClient      *redis.Client
+	stopBackgroundFn context.CancelFunc // Stops the background jobs started by the router
 }
 
 func New(cfg *config.Config) *Server {
@@ -56,15 +57,18 @@ func New(cfg *config.Config) *Server {
 	}
 	slog.Info("Connected to Redis", "pong", pong)
 
-	httpRouter := router.New(cfg, redisClient)
+	// Background jobs (audit retention, shipment tracking) run until the server shuts down
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	httpRouter := router.New(backgroundCtx, cfg, redisClient)
 
 	return &Server{
 		httpServer: &http.Server{
 			Addr:    ":" + cfg.ServerPort,
 			Handler: httpRouter,
 		},
-		cfg:         cfg,
-		redisClient: redisClient,
+		cfg:              cfg,
+		redisClient:      redisClient,
+		stopBackgroundFn: stopBackground,
 	}
 }
 
@@ -82,6 +86,7 @@ func (s *Server) Start() error {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	slog.Info("Shutting down server...")
+	s.stopBackgroundFn()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -99,6 +104,8 @@ func (s *Server) Stop() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	s.stopBackgroundFn()
+
 	// Shutdown HTTP server first
 	httpErr := s.httpServer.Shutdown(ctx)
 
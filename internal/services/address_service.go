@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/MihoZaki/DzTech/internal/db"
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrAddressNotFound = errors.New("address not found")
+)
+
+// AddressService manages the saved shipping addresses of customers.
+// A customer with any address always has exactly one default address.
+type AddressService struct {
+	querier db.Querier
+	pool    *pgxpool.Pool // Default changes touch several rows
+	logger  *slog.Logger
+}
+
+// NewAddressService creates a new instance of AddressService.
+func NewAddressService(querier db.Querier, pool *pgxpool.Pool, logger *slog.Logger) *AddressService {
+	return &AddressService{
+		querier: querier,
+		pool:    pool,
+		logger:  logger,
+	}
+}
+
+// ListAddresses returns a user's saved addresses, the default one first.
+func (s *AddressService) ListAddresses(ctx context.Context, userID uuid.UUID) ([]models.UserAddress, error) {
+	dbAddresses, err := s.querier.ListUserAddresses(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list addresses: %w", err)
+	}
+	addresses := make([]models.UserAddress, len(dbAddresses))
+	for i, a := range dbAddresses {
+		addresses[i] = toUserAddressModel(a)
+	}
+	return addresses, nil
+}
+
+// GetAddress retrieves one of a user's saved addresses.
+func (s *AddressService) GetAddress(ctx context.Context, userID, addressID uuid.UUID) (*models.UserAddress, error) {
+	dbAddress, err := s.querier.GetUserAddress(ctx, db.GetUserAddressParams{ID: addressID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAddressNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch address: %w", err)
+	}
+	address := toUserAddressModel(dbAddress)
+	return &address, nil
+}
+
+// CreateAddress saves a new address for a user. The user's first address becomes the default one.
+func (s *AddressService) CreateAddress(ctx context.Context, userID uuid.UUID, req models.SaveUserAddressRequest) (*models.UserAddress, error) {
+	var dbAddress db.UserAddress
+	err := s.withTx(ctx, func(txQuerier *db.Queries) error {
+		if err := txQuerier.LockUserAddresses(ctx, userID); err != nil {
+			return fmt.Errorf("failed to lock address book: %w", err)
+		}
+		count, err := txQuerier.CountUserAddresses(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to count addresses: %w", err)
+		}
+		isDefault := req.IsDefault || count == 0
+		if isDefault {
+			if err := txQuerier.ClearDefaultUserAddress(ctx, userID); err != nil {
+				return fmt.Errorf("failed to clear default address: %w", err)
+			}
+		}
+		dbAddress, err = txQuerier.CreateUserAddress(ctx, db.CreateUserAddressParams{
+			UserID:       userID,
+			Label:        req.Label,
+			FullName:     req.FullName,
+			PhoneNumber1: req.PhoneNumber1,
+			PhoneNumber2: req.PhoneNumber2,
+			Province:     req.Province,
+			City:         req.City,
+			IsDefault:    isDefault,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create address: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	address := toUserAddressModel(dbAddress)
+	return &address, nil
+}
+
+// UpdateAddress replaces the details of a saved address. Setting is_default makes it the default address;
+// leaving it unset keeps the current default, since a user with addresses always has one.
+// Orders placed with the address keep the details they were placed with.
+func (s *AddressService) UpdateAddress(ctx context.Context, userID, addressID uuid.UUID, req models.SaveUserAddressRequest) (*models.UserAddress, error) {
+	var dbAddress db.UserAddress
+	err := s.withTx(ctx, func(txQuerier *db.Queries) error {
+		var err error
+		dbAddress, err = txQuerier.UpdateUserAddress(ctx, db.UpdateUserAddressParams{
+			Label:        req.Label,
+			FullName:     req.FullName,
+			PhoneNumber1: req.PhoneNumber1,
+			PhoneNumber2: req.PhoneNumber2,
+			Province:     req.Province,
+			City:         req.City,
+			ID:           addressID,
+			UserID:       userID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrAddressNotFound
+			}
+			return fmt.Errorf("failed to update address: %w", err)
+		}
+		if req.IsDefault && !dbAddress.IsDefault {
+			if err := makeDefaultAddress(ctx, txQuerier, userID, addressID); err != nil {
+				return err
+			}
+			dbAddress.IsDefault = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	address := toUserAddressModel(dbAddress)
+	return &address, nil
+}
+
+// SetDefaultAddress makes one of a user's saved addresses the default one.
+func (s *AddressService) SetDefaultAddress(ctx context.Context, userID, addressID uuid.UUID) error {
+	return s.withTx(ctx, func(txQuerier *db.Queries) error {
+		return makeDefaultAddress(ctx, txQuerier, userID, addressID)
+	})
+}
+
+// DeleteAddress removes a saved address. If it was the default one, the most recently added
+// remaining address becomes the default.
+func (s *AddressService) DeleteAddress(ctx context.Context, userID, addressID uuid.UUID) error {
+	return s.withTx(ctx, func(txQuerier *db.Queries) error {
+		if err := txQuerier.LockUserAddresses(ctx, userID); err != nil {
+			return fmt.Errorf("failed to lock address book: %w", err)
+		}
+		wasDefault, err := txQuerier.DeleteUserAddress(ctx, db.DeleteUserAddressParams{ID: addressID, UserID: userID})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrAddressNotFound
+			}
+			return fmt.Errorf("failed to delete address: %w", err)
+		}
+		if wasDefault {
+			if err := txQuerier.PromoteLatestUserAddress(ctx, userID); err != nil {
+				return fmt.Errorf("failed to promote default address: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// makeDefaultAddress moves the default flag of a user's address book to addressID.
+func makeDefaultAddress(ctx context.Context, txQuerier *db.Queries, userID, addressID uuid.UUID) error {
+	if err := txQuerier.LockUserAddresses(ctx, userID); err != nil {
+		return fmt.Errorf("failed to lock address book: %w", err)
+	}
+	if err := txQuerier.ClearDefaultUserAddress(ctx, userID); err != nil {
+		return fmt.Errorf("failed to clear default address: %w", err)
+	}
+	rows, err := txQuerier.SetDefaultUserAddress(ctx, db.SetDefaultUserAddressParams{ID: addressID, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("failed to set default address: %w", err)
+	}
+	if rows == 0 {
+		return ErrAddressNotFound
+	}
+	return nil
+}
+
+func (s *AddressService) withTx(ctx context.Context, fn func(txQuerier *db.Queries) error) error {
+	queries, ok := s.querier.(*db.Queries)
+	if !ok {
+		return errors.New("querier type assertion to *db.Queries failed, cannot create transactional querier")
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin address transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			s.logger.Error("Error during address transaction rollback", "error", err)
+		}
+	}()
+
+	if err := fn(queries.WithTx(tx)); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit address transaction: %w", err)
+	}
+	return nil
+}
+
+func toUserAddressModel(a db.UserAddress) models.UserAddress {
+	return models.UserAddress{
+		ID:    a.ID,
+		Label: a.Label,
+		Address: models.Address{
+			FullName:     a.FullName,
+			PhoneNumber1: a.PhoneNumber1,
+			PhoneNumber2: a.PhoneNumber2,
+			Province:     a.Province,
+			City:         a.City,
+		},
+		IsDefault: a.IsDefault,
+		CreatedAt: a.CreatedAt.Time,
+		UpdatedAt: a.UpdatedAt.Time,
+	}
+}
@@ -14,19 +14,26 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // AdminUserService handles business logic for admin user management operations.
 type AdminUserService struct {
-	querier db.Querier
-	logger  *slog.Logger
+	querier  db.Querier
+	pool     *pgxpool.Pool         // Need for transactions
+	auth     *AuthService          // Publishes the token version of deactivated users
+	throttle *LoginThrottleService // Lifts login lockouts
+	logger   *slog.Logger
 }
 
 // NewAdminUserService creates a new instance of AdminUserService.
-func NewAdminUserService(querier db.Querier, logger *slog.Logger) *AdminUserService {
+func NewAdminUserService(querier db.Querier, pool *pgxpool.Pool, auth *AuthService, throttle *LoginThrottleService, logger *slog.Logger) *AdminUserService {
 	return &AdminUserService{
-		querier: querier,
-		logger:  logger,
+		querier:  querier,
+		pool:     pool,
+		auth:     auth,
+		throttle: throttle,
+		logger:   logger,
 	}
 }
 
@@ -91,6 +98,10 @@ func (s *AdminUserService) GetUser(ctx context.Context, id uuid.UUID) (*models.A
 }
 
 func (s *AdminUserService) ActivateUser(ctx context.Context, id uuid.UUID) error {
+	// The previous state is only needed for the audit log, so a failed lookup is not fatal
+	if before, err := s.GetUser(ctx, id); err == nil {
+		models.SetAuditBefore(ctx, before)
+	}
 	err := s.querier.ActivateUser(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to activate user: %w", err)
@@ -98,11 +109,64 @@ func (s *AdminUserService) ActivateUser(ctx context.Context, id uuid.UUID) error
 	return nil
 }
 
+// DeactivateUser soft-deletes a user and signs them out everywhere in the same transaction, so a
+// deactivated user never keeps working tokens. The last active superuser cannot be deactivated.
 func (s *AdminUserService) DeactivateUser(ctx context.Context, id uuid.UUID) error {
-	err := s.querier.SoftDeleteUser(ctx, id)
+	// The previous state is only needed for the audit log, so a failed lookup is not fatal
+	if before, err := s.GetUser(ctx, id); err == nil {
+		models.SetAuditBefore(ctx, before)
+	}
+
+	queries, ok := s.querier.(*db.Queries)
+	if !ok {
+		return errors.New("querier type assertion to *db.Queries failed, cannot create transactional querier")
+	}
+	tx, err := s.pool.Begin(ctx)
 	if err != nil {
+		return fmt.Errorf("failed to begin transaction for user deactivation: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			s.logger.Error("Error during user deactivation transaction rollback", "error", err)
+		}
+	}()
+
+	txQuerier := queries.WithTx(tx)
+
+	if err := lockSuperusers(ctx, txQuerier); err != nil {
+		return err
+	}
+	if err := txQuerier.SoftDeleteUser(ctx, id); err != nil {
 		return fmt.Errorf("failed to deactivate user: %w", err)
 	}
+	if err := ensureSuperuserRemains(ctx, txQuerier); err != nil {
+		return err
+	}
+	tokenVersion, err := revokeUserTokens(ctx, txQuerier, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke tokens of deactivated user: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit user deactivation transaction: %w", err)
+	}
+	if err := s.auth.cacheTokenVersion(ctx, id, tokenVersion); err != nil {
+		// The new version is committed; tokens are rejected once the cached version expires
+		s.logger.Error("Failed to publish token version after deactivation", "user_id", id, "error", err)
+	}
+	return nil
+}
+
+// UnlockUser lifts a login lockout of a user's account before it expires.
+func (s *AdminUserService) UnlockUser(ctx context.Context, id uuid.UUID) error {
+	user, err := s.GetUser(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.throttle.Unlock(ctx, user.Email); err != nil {
+		return err
+	}
+	s.logger.Info("User account unlocked", "user_id", id, "unlocked_by", actorIDFromContext(ctx))
 	return nil
 }
 
@@ -9,6 +9,7 @@ import (
 
 	"github.com/MihoZaki/DzTech/internal/db"
 	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -175,6 +176,57 @@ func (s *AnalyticsService) GetTopSellingCategories(ctx context.Context, startDat
 	return response, nil
 }
 
+// GetMarginReport retrieves the gross margin within a time range, with the N products with the highest margin.
+func (s *AnalyticsService) GetMarginReport(ctx context.Context, startDate, endDate time.Time, limit int) (*models.MarginReportResponse, error) {
+	summary, err := s.querier.GetMarginSummary(ctx, db.GetMarginSummaryParams{
+		StartDate: ToPgTimestamptz(startDate),
+		EndDate:   ToPgTimestamptz(endDate),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch margin summary: %w", err)
+	}
+
+	dbResults, err := s.querier.GetProductMargins(ctx, db.GetProductMarginsParams{
+		StartDate: ToPgTimestamptz(startDate),
+		EndDate:   ToPgTimestamptz(endDate),
+		Limits:    int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch product margins: %w", err)
+	}
+
+	products := make([]models.ProductMargin, len(dbResults))
+	for i, row := range dbResults {
+		products[i] = models.ProductMargin{
+			ID:           row.ProductID,
+			Name:         row.ProductName,
+			UnitsSold:    row.UnitsSold,
+			RevenueCents: row.RevenueCents,
+			CostCents:    row.CostCents,
+			MarginCents:  row.MarginCents,
+		}
+	}
+
+	grossMargin := summary.CostedRevenueCents - summary.TotalCostCents
+	var grossMarginPercent float64
+	if summary.CostedRevenueCents > 0 {
+		grossMarginPercent = float64(grossMargin) / float64(summary.CostedRevenueCents) * 100
+	}
+
+	return &models.MarginReportResponse{
+		TotalRevenueCents:  summary.TotalRevenueCents,
+		CostedRevenueCents: summary.CostedRevenueCents,
+		TotalCostCents:     summary.TotalCostCents,
+		GrossMarginCents:   grossMargin,
+		GrossMarginPercent: grossMarginPercent,
+		UncostedUnits:      summary.UncostedUnits,
+		Products:           products,
+		StartDate:          startDate,
+		EndDate:            endDate,
+		Limit:              limit,
+	}, nil
+}
+
 // GetLowStockProducts retrieves products with stock below a threshold.
 func (s *AnalyticsService) GetLowStockProducts(ctx context.Context, threshold int) (*models.LowStockProductsResponse, error) {
 	dbResults, err := s.querier.GetLowStockProducts(ctx, int32(threshold)) // Pass threshold directly
@@ -201,6 +253,41 @@ func (s *AnalyticsService) GetLowStockProducts(ctx context.Context, threshold in
 	return response, nil
 }
 
+// GetLowStockProductsByLocation retrieves, for each active stock location, the products whose stock there is below a threshold.
+// A nil locationID covers all active locations.
+func (s *AnalyticsService) GetLowStockProductsByLocation(ctx context.Context, threshold int, locationID *uuid.UUID) (*models.LowStockByLocationResponse, error) {
+	params := db.GetLowStockProductsByLocationParams{
+		Threshold:  int32(threshold),
+		LocationID: uuid.Nil,
+	}
+	if locationID != nil {
+		params.LocationID = *locationID
+	}
+
+	dbResults, err := s.querier.GetLowStockProductsByLocation(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch low stock products by location: %w", err)
+	}
+
+	data := make([]models.LowStockLocationProduct, len(dbResults))
+	for i, row := range dbResults {
+		data[i] = models.LowStockLocationProduct{
+			ID:           row.ProductID,
+			Name:         row.ProductName,
+			LocationID:   row.LocationID,
+			LocationCode: row.LocationCode,
+			LocationName: row.LocationName,
+			Quantity:     int(row.Quantity),
+		}
+	}
+
+	return &models.LowStockByLocationResponse{
+		Data:       data,
+		Threshold:  threshold,
+		LocationID: locationID,
+	}, nil
+}
+
 // GetNewCustomersCount counts new customers registered within a time range.
 func (s *AnalyticsService) GetNewCustomersCount(ctx context.Context, startDate, endDate time.Time) (*models.CustomerInsightsResponse, error) {
 	// Prepare query parameters
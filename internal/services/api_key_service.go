@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"slices"
+	"time"
+
+	"github.com/MihoZaki/DzTech/internal/db"
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	apiKeyPrefix       = "dzk_" // Makes keys recognisable, e.g. by secret scanners
+	apiKeyRandomBytes  = 32
+	apiKeyPrefixLength = 12 // Characters of the key kept in clear to identify it
+	apiKeyUsageTimeout = 5 * time.Second
+)
+
+var (
+	ErrAPIKeyNotFound      = errors.New("API key not found")
+	ErrInvalidAPIKeyScope  = errors.New("API key scopes must be permissions you hold")
+	ErrInvalidAPIKeyExpiry = errors.New("API key expiry must be in the future")
+)
+
+// APIKeyService handles business logic for the API keys integrations use instead of a user session.
+type APIKeyService struct {
+	querier db.Querier
+	logger  *slog.Logger
+}
+
+// NewAPIKeyService creates a new instance of APIKeyService.
+func NewAPIKeyService(querier db.Querier, logger *slog.Logger) *APIKeyService {
+	return &APIKeyService{
+		querier: querier,
+		logger:  logger,
+	}
+}
+
+// CreateAPIKey creates a key granting the requested scopes. A key cannot grant permissions its creator
+// does not hold. The returned key is shown once; only its hash is stored.
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, req models.CreateAPIKeyRequest) (*models.CreatedAPIKey, error) {
+	actor, ok := models.GetUserFromContext(ctx)
+	if !ok || actor == nil {
+		return nil, ErrInvalidAPIKeyScope
+	}
+	scopes := slices.Clone(req.Scopes)
+	slices.Sort(scopes)
+	scopes = slices.Compact(scopes)
+	for _, scope := range scopes {
+		if !actor.HasPermission(scope) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidAPIKeyScope, scope)
+		}
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, ErrInvalidAPIKeyExpiry
+	}
+	allowedIPs := req.AllowedIPs
+	if allowedIPs == nil {
+		allowedIPs = []string{}
+	}
+
+	random := make([]byte, apiKeyRandomBytes)
+	if _, err := rand.Read(random); err != nil {
+		return nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(random)
+
+	dbKey, err := s.querier.CreateAPIKey(ctx, db.CreateAPIKeyParams{
+		Name:       req.Name,
+		KeyPrefix:  key[:apiKeyPrefixLength],
+		KeyHash:    hashAPIKey(key),
+		Scopes:     scopes,
+		AllowedIps: allowedIPs,
+		ExpiresAt:  optionalTimestamptz(req.ExpiresAt),
+		CreatedBy:  actor.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	apiKey := toAPIKeyModel(dbKey)
+	// The audit log keeps the key's details, not the response carrying the key itself
+	models.SetAuditAfter(ctx, apiKey)
+	s.logger.Info("API key created", "api_key_id", apiKey.ID, "name", apiKey.Name, "scopes", scopes, "created_by", actor.ID)
+	return &models.CreatedAPIKey{APIKey: apiKey, Key: key}, nil
+}
+
+// ListAPIKeys retrieves every API key, revoked and expired ones included.
+func (s *APIKeyService) ListAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	dbKeys, err := s.querier.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	keys := make([]models.APIKey, len(dbKeys))
+	for i, k := range dbKeys {
+		keys[i] = toAPIKeyModel(k)
+	}
+	return keys, nil
+}
+
+// GetAPIKey retrieves an API key by ID.
+func (s *APIKeyService) GetAPIKey(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
+	dbKey, err := s.querier.GetAPIKey(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch API key: %w", err)
+	}
+	apiKey := toAPIKeyModel(dbKey)
+	return &apiKey, nil
+}
+
+// RevokeAPIKey revokes a key. Keys are looked up on every request, so it stops working immediately.
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	before, err := s.GetAPIKey(ctx, id)
+	if err != nil {
+		return err
+	}
+	models.SetAuditBefore(ctx, before)
+
+	rows, err := s.querier.RevokeAPIKey(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	if rows > 0 {
+		s.logger.Info("API key revoked", "api_key_id", id, "revoked_by", actorIDFromContext(ctx))
+	}
+	return nil
+}
+
+// AuthenticateAPIKey checks a key presented by a client at ip and returns the principal it acts as:
+// a staff user holding the key's scopes as permissions. Scopes its creator no longer holds are dropped,
+// so a key never grants more than the creator's current roles. Each use is counted.
+// It returns a nil user for unknown, expired and revoked keys, keys of deactivated or deleted creators and
+// clients outside the key's allowlist, so callers cannot tell these apart; an error means the key could not be checked.
+func (s *APIKeyService) AuthenticateAPIKey(ctx context.Context, key, ip string) (*models.User, error) {
+	dbKey, err := s.querier.GetActiveAPIKeyByHash(ctx, hashAPIKey(key))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch API key: %w", err)
+	}
+	if !apiKeyAllowsIP(dbKey.AllowedIps, ip) {
+		s.logger.Warn("API key used from a disallowed IP address", "api_key_id", dbKey.ID, "ip_address", ip)
+		return nil, nil
+	}
+
+	// GetUser only returns active users
+	if _, err := s.querier.GetUser(ctx, dbKey.CreatedBy); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.logger.Warn("API key of a deactivated or deleted user used", "api_key_id", dbKey.ID, "created_by", dbKey.CreatedBy)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch API key creator: %w", err)
+	}
+	creatorPermissions, err := s.querier.ListUserPermissions(ctx, dbKey.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch permissions of API key creator: %w", err)
+	}
+	scopes := make([]string, 0, len(dbKey.Scopes))
+	for _, scope := range dbKey.Scopes {
+		if slices.Contains(creatorPermissions, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	// Usage tracking must not slow down or fail the request
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), apiKeyUsageTimeout)
+		defer cancel()
+		if err := s.querier.RecordAPIKeyUsage(ctx, dbKey.ID); err != nil {
+			s.logger.Error("Failed to record API key usage", "api_key_id", dbKey.ID, "error", err)
+		}
+	}()
+
+	return &models.User{
+		ID:          dbKey.CreatedBy, // Actions are attributed to the admin who created the key
+		Email:       "api-key:" + dbKey.KeyPrefix,
+		IsAdmin:     len(scopes) > 0,
+		Permissions: scopes,
+		APIKeyID:    dbKey.ID,
+	}, nil
+}
+
+// apiKeyAllowsIP reports whether ip matches one of the allowed addresses or CIDR ranges.
+// An empty allowlist allows any client.
+func apiKeyAllowsIP(allowed []string, ip string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, entry := range allowed {
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			if prefix.Contains(addr) {
+				return true
+			}
+		} else if allowedAddr, err := netip.ParseAddr(entry); err == nil && allowedAddr.Unmap() == addr {
+			return true
+		}
+	}
+	return false
+}
+
+func hashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+func toAPIKeyModel(k db.ApiKey) models.APIKey {
+	apiKey := models.APIKey{
+		ID:           k.ID,
+		Name:         k.Name,
+		KeyPrefix:    k.KeyPrefix,
+		Scopes:       k.Scopes,
+		AllowedIPs:   k.AllowedIps,
+		CreatedBy:    uuidPtrOrNil(k.CreatedBy),
+		CreatedAt:    k.CreatedAt.Time,
+		RequestCount: k.RequestCount,
+	}
+	if k.ExpiresAt.Valid {
+		apiKey.ExpiresAt = &k.ExpiresAt.Time
+	}
+	if k.LastUsedAt.Valid {
+		apiKey.LastUsedAt = &k.LastUsedAt.Time
+	}
+	if k.RevokedAt.Valid {
+		apiKey.RevokedAt = &k.RevokedAt.Time
+	}
+	return apiKey
+}
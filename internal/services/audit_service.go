@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/MihoZaki/DzTech/internal/config"
+	"github.com/MihoZaki/DzTech/internal/db"
+	"github.com/MihoZaki/DzTech/internal/models"
+)
+
+// auditRetentionInterval is how often entries older than the retention period are purged.
+const auditRetentionInterval = 24 * time.Hour
+
+// AuditService records mutating admin actions and serves the audit log.
+type AuditService struct {
+	querier db.Querier
+	cfg     config.Audit
+	logger  *slog.Logger
+}
+
+// NewAuditService creates a new instance of AuditService.
+func NewAuditService(querier db.Querier, cfg config.Audit, logger *slog.Logger) *AuditService {
+	return &AuditService{
+		querier: querier,
+		cfg:     cfg,
+		logger:  logger,
+	}
+}
+
+// RecordAdminAction stores an audit log entry. Failures are logged rather than returned:
+// the admin action has already succeeded by the time it is recorded.
+func (s *AuditService) RecordAdminAction(ctx context.Context, entry models.AuditEntry) {
+	var before []byte
+	if entry.Before != nil {
+		b, err := json.Marshal(entry.Before)
+		if err != nil {
+			s.logger.Error("Failed to marshal audit before state", "action", entry.Action, "error", err)
+		} else {
+			before = b
+		}
+	}
+	var after []byte
+	if len(entry.After) > 0 && json.Valid(entry.After) {
+		after = entry.After
+	}
+
+	err := s.querier.CreateAuditLogEntry(ctx, db.CreateAuditLogEntryParams{
+		ActorID:    entry.ActorID,
+		ActorEmail: entry.ActorEmail,
+		Action:     entry.Action,
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		Before:     before,
+		After:      after,
+		Diff:       auditDiff(before, after),
+		RequestID:  entry.RequestID,
+		IpAddress:  entry.IPAddress,
+	})
+	if err != nil {
+		s.logger.Error("Failed to record audit log entry", "action", entry.Action, "entity_id", entry.EntityID, "actor_id", entry.ActorID, "error", err)
+	}
+}
+
+// ListAuditLog returns the paginated audit log, newest first.
+func (s *AuditService) ListAuditLog(ctx context.Context, filter models.AuditLogFilter, page, limit int) (*models.PaginatedResponse, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if page <= 0 {
+		page = 1
+	}
+	offset := (page - 1) * limit
+
+	rows, err := s.querier.ListAuditLog(ctx, db.ListAuditLogParams{
+		ActorID:    filter.ActorID,
+		EntityType: filter.EntityType,
+		EntityID:   filter.EntityID,
+		Action:     filter.Action,
+		FromTime:   optionalTimestamptz(filter.From),
+		ToTime:     optionalTimestamptz(filter.To),
+		PageLimit:  int32(limit),
+		PageOffset: int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit log: %w", err)
+	}
+	total, err := s.querier.CountAuditLog(ctx, db.CountAuditLogParams{
+		ActorID:    filter.ActorID,
+		EntityType: filter.EntityType,
+		EntityID:   filter.EntityID,
+		Action:     filter.Action,
+		FromTime:   optionalTimestamptz(filter.From),
+		ToTime:     optionalTimestamptz(filter.To),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count audit log: %w", err)
+	}
+
+	entries := make([]models.AuditLogEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = models.AuditLogEntry{
+			ID:         row.ID,
+			ActorID:    uuidPtrOrNil(row.ActorID),
+			ActorEmail: row.ActorEmail,
+			Action:     row.Action,
+			EntityType: row.EntityType,
+			EntityID:   row.EntityID,
+			Before:     row.Before,
+			After:      row.After,
+			Diff:       row.Diff,
+			RequestID:  row.RequestID,
+			IPAddress:  row.IpAddress,
+			CreatedAt:  row.CreatedAt.Time,
+		}
+	}
+
+	return &models.PaginatedResponse{
+		Data:       entries,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}, nil
+}
+
+// PurgeExpired deletes the entries older than the configured retention period.
+func (s *AuditService) PurgeExpired(ctx context.Context) (int64, error) {
+	if s.cfg.RetentionDays <= 0 {
+		return 0, nil
+	}
+	cutoff := time.Now().AddDate(0, 0, -s.cfg.RetentionDays)
+	deleted, err := s.querier.DeleteAuditLogBefore(ctx, ToPgTimestamptz(cutoff))
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge audit log: %w", err)
+	}
+	return deleted, nil
+}
+
+// StartRetentionWorker purges expired entries once a day until ctx is cancelled.
+// It does nothing when retention is disabled.
+func (s *AuditService) StartRetentionWorker(ctx context.Context) {
+	if s.cfg.RetentionDays <= 0 {
+		s.logger.Info("Audit log retention disabled, entries are kept forever")
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(auditRetentionInterval)
+		defer ticker.Stop()
+		for {
+			deleted, err := s.PurgeExpired(ctx)
+			if err != nil {
+				s.logger.Error("Audit log retention run failed", "error", err)
+			} else if deleted > 0 {
+				s.logger.Info("Purged expired audit log entries", "deleted", deleted, "retention_days", s.cfg.RetentionDays)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// auditDiff returns the top-level fields that differ between two JSON objects, as
+// {"field": {"before": ..., "after": ...}}. Fields present on only one side are ignored because
+// the service's before state and the API response do not always expose the same fields.
+// It returns nil if either side is missing or not an object.
+func auditDiff(before, after []byte) []byte {
+	if before == nil || after == nil {
+		return nil
+	}
+	var beforeFields, afterFields map[string]any
+	if json.Unmarshal(before, &beforeFields) != nil || json.Unmarshal(after, &afterFields) != nil {
+		return nil
+	}
+
+	type change struct {
+		Before any `json:"before"`
+		After  any `json:"after"`
+	}
+	changes := make(map[string]change)
+	for field, oldValue := range beforeFields {
+		newValue, ok := afterFields[field]
+		if !ok || reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		changes[field] = change{Before: oldValue, After: newValue}
+	}
+
+	diff, err := json.Marshal(changes)
+	if err != nil {
+		return nil
+	}
+	return diff
+}
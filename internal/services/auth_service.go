@@ -7,14 +7,31 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
 	"time"
 
 	"github.com/MihoZaki/DzTech/internal/db"
 	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/MihoZaki/DzTech/internal/utils"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	CacheKeyUserTokenVersion  = "auth:token_version:%s"   // Format: auth:token_version:{user_id}
+	CacheKeyRevokedSession    = "auth:revoked_session:%s" // Format: auth:revoked_session:{session_id}
+	CacheKeyTwoFactorAttempts = "auth:2fa_attempts:%s"    // Format: auth:2fa_attempts:{challenge_jti}
+	tokenVersionCacheTTL      = time.Hour
+	accessTokenTTL            = 15 * time.Minute
+	refreshTokenTTL           = 7 * 24 * time.Hour
+	maxSessionUserAgentLength = 512
+
+	twoFactorChallengeAudience = "2fa_challenge"
+	twoFactorChallengeTTL      = 5 * time.Minute
+	maxTwoFactorAttempts       = 5
 )
 
 // AuthService handles authentication-related business logic, including JWT and refresh tokens.
@@ -22,32 +39,161 @@ type AuthService struct {
 	querier     db.Querier
 	userService *UserService
 	cartService *CartService
-	jwtSecret   []byte // Secret for access/refresh token signing
+	wishlistSvc *WishlistService
+	twoFactor   *TwoFactorService
+	throttle    *LoginThrottleService
+	oidc        *OIDCService
+	cache       *redis.Client    // Caches token versions checked on every authenticated request
+	jwtKeys     *utils.JWTKeySet // Signs and verifies access, refresh and challenge tokens
 	logger      *slog.Logger
 }
 
 // NewAuthService creates a new instance of AuthService.
-func NewAuthService(querier db.Querier, userService *UserService, cartService *CartService, jwtSecret string, logger *slog.Logger) *AuthService {
+func NewAuthService(querier db.Querier, userService *UserService, cartService *CartService, wishlistSvc *WishlistService, twoFactor *TwoFactorService, throttle *LoginThrottleService, oidc *OIDCService, cache *redis.Client, jwtKeys *utils.JWTKeySet, logger *slog.Logger) *AuthService {
 	return &AuthService{
 		querier:     querier,
 		userService: userService,
 		cartService: cartService,
-		jwtSecret:   []byte(jwtSecret),
+		wishlistSvc: wishlistSvc,
+		twoFactor:   twoFactor,
+		throttle:    throttle,
+		oidc:        oidc,
+		cache:       cache,
+		jwtKeys:     jwtKeys,
 		logger:      logger,
 	}
 }
 
 // Login authenticates a user and returns access token, refresh token string, and user details.
-func (s *AuthService) Login(ctx context.Context, email, password string, sessionID string) (*models.LoginResponse, string, error) {
+// If the user has 2FA enabled, it returns a challenge token instead and no refresh token;
+// the login is completed by VerifyTwoFactorLogin.
+// Repeated failures for an account or from an IP address make Login return a *LoginThrottledError.
+func (s *AuthService) Login(ctx context.Context, email, password string, sessionID string, device models.DeviceInfo) (*models.LoginResponse, string, error) {
+	if err := s.throttle.Check(ctx, email, device.IPAddress); err != nil {
+		return nil, "", err
+	}
 	user, err := s.userService.Authenticate(ctx, email, password)
 	if err != nil {
+		if errors.Is(err, ErrInvalidCredentials) {
+			s.throttle.RecordFailure(ctx, email, device.IPAddress)
+		}
 		return nil, "", err
 	}
-	err = s.querier.RevokeAllRefreshTokensByUserID(ctx, user.ID)
+
+	if challenge, err := s.twoFactorChallenge(ctx, user); err != nil || challenge != nil {
+		return challenge, "", err
+	}
+
+	s.throttle.RecordSuccess(ctx, email)
+	return s.completeLogin(ctx, user, sessionID, device, false)
+}
+
+// StartOIDCLogin starts a social login. It returns the identity provider URL to send the user to
+// and the state the provider redirects back with.
+func (s *AuthService) StartOIDCLogin(ctx context.Context) (string, string, error) {
+	return s.oidc.AuthorizationURL(ctx)
+}
+
+// LoginWithOIDC completes a social login with the code and state the identity provider redirected back with.
+// The identity is linked to the account with the same verified email, or gets a new account. Like Login,
+// it returns a challenge token instead of tokens if the user has 2FA enabled.
+func (s *AuthService) LoginWithOIDC(ctx context.Context, code, state string, sessionID string, device models.DeviceInfo) (*models.LoginResponse, string, error) {
+	user, err := s.oidc.Authenticate(ctx, code, state)
 	if err != nil {
-		s.logger.Error("Failed to revoke existing refresh tokens during login", "error", err, "user_id", user.ID)
+		return nil, "", err
+	}
+	if challenge, err := s.twoFactorChallenge(ctx, user); err != nil || challenge != nil {
+		return challenge, "", err
+	}
+	return s.completeLogin(ctx, user, sessionID, device, false)
+}
+
+// twoFactorChallenge returns the response asking for a second factor if user has 2FA enabled, or nil.
+func (s *AuthService) twoFactorChallenge(ctx context.Context, user *models.User) (*models.LoginResponse, error) {
+	twoFactorEnabled, err := s.twoFactor.IsEnabled(ctx, user.ID)
+	if err != nil || !twoFactorEnabled {
+		return nil, err
+	}
+	challengeToken, err := s.createTwoFactorChallenge(user.ID)
+	if err != nil {
+		s.logger.Error("Failed to create two-factor challenge during login", "error", err, "user_id", user.ID)
+		return nil, fmt.Errorf("failed to create two-factor challenge: %w", err)
+	}
+	return &models.LoginResponse{
+		TwoFactorRequired: true,
+		ChallengeToken:    challengeToken,
+	}, nil
+}
+
+// VerifyTwoFactorLogin completes a login started by Login for a user with 2FA enabled.
+// code is a code from the user's authenticator app or one of their recovery codes.
+func (s *AuthService) VerifyTwoFactorLogin(ctx context.Context, challengeToken, code string, sessionID string, device models.DeviceInfo) (*models.LoginResponse, string, error) {
+	token, err := jwt.ParseWithClaims(challengeToken, &jwt.RegisteredClaims{}, s.jwtKeys.Keyfunc, jwt.WithAudience(twoFactorChallengeAudience))
+	if err != nil || !token.Valid {
+		s.logger.Warn("Invalid two-factor challenge token", "error", err)
+		return nil, "", ErrInvalidTwoFactorChallenge
+	}
+	claims, ok := token.Claims.(*jwt.RegisteredClaims)
+	if !ok || claims.ID == "" {
+		return nil, "", ErrInvalidTwoFactorChallenge
 	}
-	accessToken, refreshTokenStr, err := s.generateTokens(ctx, user.ID, user.Email, user.IsAdmin)
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, "", ErrInvalidTwoFactorChallenge
+	}
+
+	// Each challenge allows a few attempts, so codes cannot be guessed within its lifetime
+	attemptsKey := fmt.Sprintf(CacheKeyTwoFactorAttempts, claims.ID)
+	attempts, err := s.cache.Incr(ctx, attemptsKey).Result()
+	if err != nil {
+		s.logger.Error("Redis error counting two-factor attempts", "key", attemptsKey, "error", err)
+	} else {
+		if attempts == 1 {
+			if err := s.cache.Expire(ctx, attemptsKey, twoFactorChallengeTTL).Err(); err != nil {
+				s.logger.Error("Failed to set expiry of two-factor attempts", "key", attemptsKey, "error", err)
+			}
+		}
+		if attempts > maxTwoFactorAttempts {
+			s.logger.Warn("Too many two-factor attempts for challenge", "user_id", userID)
+			return nil, "", ErrTooManyTwoFactorAttempts
+		}
+	}
+
+	user, err := s.userService.GetByID(ctx, userID.String())
+	if err != nil {
+		s.logger.Warn("User of two-factor challenge not found", "error", err, "user_id", userID)
+		return nil, "", ErrInvalidTwoFactorChallenge
+	}
+	// Wrong codes count towards the same lockout as wrong passwords
+	if err := s.throttle.Check(ctx, user.Email, device.IPAddress); err != nil {
+		return nil, "", err
+	}
+
+	if err := s.twoFactor.VerifyCode(ctx, userID, code); err != nil {
+		if errors.Is(err, ErrInvalidTwoFactorCode) || errors.Is(err, ErrTwoFactorNotEnabled) {
+			s.logger.Info("Two-factor login failed: invalid code", "user_id", userID)
+			s.throttle.RecordFailure(ctx, user.Email, device.IPAddress)
+			return nil, "", ErrInvalidTwoFactorCode
+		}
+		return nil, "", err
+	}
+	// The challenge is spent once it has been used to sign in
+	if err := s.cache.Set(ctx, attemptsKey, maxTwoFactorAttempts+1, twoFactorChallengeTTL).Err(); err != nil {
+		s.logger.Error("Failed to mark two-factor challenge as used", "key", attemptsKey, "error", err)
+	}
+
+	s.throttle.RecordSuccess(ctx, user.Email)
+	return s.completeLogin(ctx, user, sessionID, device, true)
+}
+
+// completeLogin issues tokens for an authenticated user and merges their guest cart and wishlist.
+// Each login starts a new session; sessions on other devices stay signed in and can be revoked by the user.
+// twoFactor records that the user presented a second factor, for the whole session.
+func (s *AuthService) completeLogin(ctx context.Context, user *models.User, sessionID string, device models.DeviceInfo, twoFactor bool) (*models.LoginResponse, string, error) {
+	if err := s.loadPermissions(ctx, user); err != nil {
+		return nil, "", err
+	}
+	accessToken, refreshTokenStr, err := s.generateTokens(ctx, user.ID, user.Email, user.IsAdmin, user.Permissions, uuid.New(), twoFactor, device)
 	if err != nil {
 		s.logger.Error("Failed to generate tokens during login", "error", err, "user_id", user.ID)
 		return nil, "", fmt.Errorf("failed to generate tokens: %w", err)
@@ -59,15 +205,18 @@ func (s *AuthService) Login(ctx context.Context, email, password string, session
 		} else {
 			s.logger.Info("Guest cart synced successfully after login", "user_id", user.ID, "session_id", sessionID)
 		}
+		if err := s.wishlistSvc.MergeGuestWishlist(ctx, sessionID, user.ID); err != nil {
+			s.logger.Error("Failed to merge guest wishlist after login", "user_id", user.ID, "session_id", sessionID, "error", err)
+		}
 	}
 	return &models.LoginResponse{
 		Token: accessToken,
-		User:  *user,
+		User:  user,
 	}, refreshTokenStr, nil
 }
 
 // Register registers a new user and returns access token, refresh token string, and user details.
-func (s *AuthService) Register(ctx context.Context, email, password, fullName string, sessionID string) (*models.LoginResponse, string, error) {
+func (s *AuthService) Register(ctx context.Context, email, password, fullName string, sessionID string, device models.DeviceInfo) (*models.LoginResponse, string, error) {
 	userID, err := s.userService.Register(ctx, email, password, fullName)
 	if err != nil {
 		return nil, "", err
@@ -83,7 +232,10 @@ func (s *AuthService) Register(ctx context.Context, email, password, fullName st
 	if err != nil {
 		s.logger.Error("Failed to revoke existing refresh tokens during registration", "error", err, "user_id", user.ID)
 	}
-	accessToken, refreshTokenStr, err := s.generateTokens(ctx, user.ID, user.Email, user.IsAdmin)
+	if err := s.loadPermissions(ctx, user); err != nil {
+		return nil, "", err
+	}
+	accessToken, refreshTokenStr, err := s.generateTokens(ctx, user.ID, user.Email, user.IsAdmin, user.Permissions, uuid.New(), false, device)
 	if err != nil {
 		s.logger.Error("Failed to generate tokens during registration", "error", err, "user_id", user.ID)
 		return nil, "", fmt.Errorf("failed to generate tokens: %w", err)
@@ -95,28 +247,26 @@ func (s *AuthService) Register(ctx context.Context, email, password, fullName st
 		} else {
 			s.logger.Info("Guest cart synced successfully after login", "user_id", user.ID, "session_id", sessionID)
 		}
+		if err := s.wishlistSvc.MergeGuestWishlist(ctx, sessionID, user.ID); err != nil {
+			s.logger.Error("Failed to merge guest wishlist after registration", "user_id", user.ID, "session_id", sessionID, "error", err)
+		}
 	}
 
 	return &models.LoginResponse{
 		Token: accessToken,
-		User:  *user,
+		User:  user,
 	}, refreshTokenStr, nil
 }
 
 // Refresh exchanges a valid refresh token (received from cookie) for a new access token and refresh token.
-func (s *AuthService) Refresh(ctx context.Context, refreshTokenStr string) (string, string, error) {
+func (s *AuthService) Refresh(ctx context.Context, refreshTokenStr string, device models.DeviceInfo) (string, string, error) {
 	s.logger.Debug("Refreshing token", "received_token_str_len", len(refreshTokenStr))
 
 	// Hash the received token string for DB lookup comparison
 	receivedTokenHash := s.hashToken(refreshTokenStr)
 
 	// Parse the JWT to extract the JTI and verify its signature
-	token, err := jwt.ParseWithClaims(refreshTokenStr, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return s.jwtSecret, nil
-	})
+	token, err := jwt.ParseWithClaims(refreshTokenStr, &jwt.RegisteredClaims{}, s.jwtKeys.Keyfunc)
 
 	if err != nil || !token.Valid {
 		s.logger.Warn("Invalid or malformed refresh token JWT during refresh", "error", err, "token_valid", token.Valid)
@@ -135,11 +285,11 @@ func (s *AuthService) Refresh(ctx context.Context, refreshTokenStr string) (stri
 		return "", "", errors.New("invalid refresh token")
 	}
 
-	// Lookup DB record by JTI (this gets the stored hash)
-	dbRefreshToken, err := s.querier.GetValidRefreshTokenRecord(ctx, jti)
+	// Lookup DB record by JTI (this gets the stored hash). Revoked tokens are returned too, to detect reuse.
+	dbRefreshToken, err := s.querier.GetRefreshTokenRecord(ctx, jti)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			s.logger.Warn("Refresh token JTI not found in DB or is expired/revoked", "jti", jti)
+			s.logger.Warn("Refresh token JTI not found in DB or is expired", "jti", jti)
 			return "", "", errors.New("invalid or expired refresh token")
 		}
 		s.logger.Error("Failed to fetch refresh token record from DB", "error", err, "jti", jti)
@@ -152,16 +302,35 @@ func (s *AuthService) Refresh(ctx context.Context, refreshTokenStr string) (stri
 		return "", "", errors.New("invalid refresh token")
 	}
 
+	if dbRefreshToken.RevokedAt.Valid {
+		// A token that was already exchanged for a successor is being presented again: either it was stolen
+		// or its successor was. Revoke the whole family so whichever party holds the live token is signed out.
+		if dbRefreshToken.RevokedReason != nil && *dbRefreshToken.RevokedReason == refreshTokenRevokedRotated {
+			s.revokeFamilyOnReuse(ctx, dbRefreshToken)
+			return "", "", ErrRefreshTokenReused
+		}
+		s.logger.Warn("Revoked refresh token presented", "jti", jti, "user_id", dbRefreshToken.UserID, "revoked_reason", dbRefreshToken.RevokedReason)
+		return "", "", ErrInvalidRefreshToken
+	}
+
 	// --- IMMEDIATELY REVOKE THE OLD TOKEN (Token Rotation) ---
-	err = s.querier.RevokeRefreshTokenByJTI(ctx, jti)
+	// The update only succeeds for a live token, so of two requests racing with the same token only one rotates it
+	rotated, err := s.querier.RotateRefreshToken(ctx, jti)
 	if err != nil {
-		s.logger.Warn("Could not revoke old refresh token during refresh (might be concurrent request)", "jti", jti, "error", err)
+		s.logger.Error("Failed to revoke old refresh token during refresh", "jti", jti, "error", err)
+		return "", "", fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	if rotated == 0 {
+		s.revokeFamilyOnReuse(ctx, dbRefreshToken)
+		return "", "", ErrRefreshTokenReused
 	}
 
+	// GetUser only returns active users, so deactivated accounts cannot refresh
 	dbUser, err := s.querier.GetUser(ctx, dbRefreshToken.UserID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return "", "", errors.New("user associated with refresh token not found")
+			s.logger.Warn("Refresh attempted for a deactivated or deleted user", "user_id", dbRefreshToken.UserID, "jti", jti)
+			return "", "", ErrInvalidRefreshToken
 		}
 		s.logger.Error("Failed to fetch user associated with refresh token", "error", err, "user_id", dbRefreshToken.UserID)
 		return "", "", fmt.Errorf("failed to validate user for refresh: %w", err)
@@ -173,7 +342,11 @@ func (s *AuthService) Refresh(ctx context.Context, refreshTokenStr string) (stri
 		IsAdmin: dbUser.IsAdmin,
 	}
 
-	newAccessToken, newRefreshTokenStr, err := s.generateTokens(ctx, user.ID, user.Email, user.IsAdmin)
+	if err := s.loadPermissions(ctx, user); err != nil {
+		return "", "", err
+	}
+	// The session keeps the factors it signed in with, whatever the user's 2FA settings are now
+	newAccessToken, newRefreshTokenStr, err := s.generateTokens(ctx, user.ID, user.Email, user.IsAdmin, user.Permissions, dbRefreshToken.FamilyID, dbRefreshToken.Mfa, device)
 	if err != nil {
 		s.logger.Error("Failed to generate new tokens during refresh", "error", err, "user_id", user.ID)
 		return "", "", fmt.Errorf("failed to generate new tokens: %w", err)
@@ -187,12 +360,7 @@ func (s *AuthService) Logout(ctx context.Context, refreshTokenStr string) error
 	s.logger.Debug("Logging out", "refresh_token_str_len", len(refreshTokenStr))
 
 	// Parse the JWT to extract the JTI and verify its signature
-	token, err := jwt.ParseWithClaims(refreshTokenStr, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return s.jwtSecret, nil
-	})
+	token, err := jwt.ParseWithClaims(refreshTokenStr, &jwt.RegisteredClaims{}, s.jwtKeys.Keyfunc)
 
 	if err != nil || !token.Valid {
 		s.logger.Warn("Invalid or malformed refresh token JWT during logout", "error", err, "token_valid", token.Valid)
@@ -227,18 +395,24 @@ func (s *AuthService) Logout(ctx context.Context, refreshTokenStr string) error
 }
 
 // generateTokens creates a new access token and refresh token pair.
-// It stores the refresh token hash in the database using the token's JTI.
+// It stores the refresh token hash in the database using the token's JTI, in the given token family:
+// a new family on login, the presented token's family on refresh. twoFactor is stored with the family
+// and sets the access token's "mfa" claim.
 // The hash is SHA-256 of the *entire signed refresh token string*.
-func (s *AuthService) generateTokens(ctx context.Context, userID uuid.UUID, email string, isAdmin bool) (accessToken, refreshTokenStr string, err error) {
+func (s *AuthService) generateTokens(ctx context.Context, userID uuid.UUID, email string, isAdmin bool, permissions []string, familyID uuid.UUID, twoFactor bool, device models.DeviceInfo) (accessToken, refreshTokenStr string, err error) {
 	// Generate a unique JTI (JWT ID) - this will be the unique identifier for the DB record
 	refreshTokenJTI := uuid.NewString()
 
 	// Define expiry times
-	accessTokenExpiry := time.Now().Add(15 * time.Minute)    // Short-lived
-	refreshTokenExpiry := time.Now().Add(7 * 24 * time.Hour) // Long-lived (7 days)
+	accessTokenExpiry := time.Now().Add(accessTokenTTL)   // Short-lived
+	refreshTokenExpiry := time.Now().Add(refreshTokenTTL) // Long-lived (7 days)
 
+	tokenVersion, err := s.querier.GetUserTokenVersion(ctx, userID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to fetch token version: %w", err)
+	}
 	// Create the access token
-	accessToken, err = s.createAccessToken(userID, email, isAdmin, accessTokenExpiry)
+	accessToken, err = s.createAccessToken(userID, email, isAdmin, permissions, familyID, int(tokenVersion), twoFactor, accessTokenExpiry)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to create access token: %w", err)
 	}
@@ -251,8 +425,7 @@ func (s *AuthService) generateTokens(ctx context.Context, userID uuid.UUID, emai
 		Audience:  jwt.ClaimStrings{"client"}, // Optional: Intended audience
 		ExpiresAt: &jwt.NumericDate{Time: refreshTokenExpiry},
 	}
-	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshTokenClaims)
-	refreshTokenStr, err = refreshToken.SignedString(s.jwtSecret) // Sign with the main app secret
+	refreshTokenStr, err = s.jwtKeys.Sign(refreshTokenClaims)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to sign refresh token: %w", err)
 	}
@@ -266,6 +439,10 @@ func (s *AuthService) generateTokens(ctx context.Context, userID uuid.UUID, emai
 		UserID:    userID,          // Link to the user
 		TokenHash: tokenHash,       // Store the SHA-256 hash of the *entire signed token string*
 		ExpiresAt: pgtype.Timestamptz{Time: refreshTokenExpiry, Valid: true},
+		FamilyID:  familyID,
+		UserAgent: truncateString(device.UserAgent, maxSessionUserAgentLength),
+		IpAddress: device.IPAddress,
+		Mfa:       twoFactor,
 	})
 	if err != nil {
 		s.logger.Error("Failed to store refresh token in DB", "error", err, "user_id", userID, "jti", refreshTokenJTI)
@@ -275,6 +452,108 @@ func (s *AuthService) generateTokens(ctx context.Context, userID uuid.UUID, emai
 	return accessToken, refreshTokenStr, nil
 }
 
+// revokeFamilyOnReuse revokes every token of the family of a refresh token that was presented after rotation.
+func (s *AuthService) revokeFamilyOnReuse(ctx context.Context, reused db.GetRefreshTokenRecordRow) {
+	revoked, err := s.querier.RevokeRefreshTokenFamily(ctx, reused.FamilyID)
+	if err != nil {
+		s.logger.Error("Security event: refresh token reuse detected, but revoking the token family failed",
+			"event", "refresh_token_reuse",
+			"user_id", reused.UserID,
+			"jti", reused.Jti,
+			"family_id", reused.FamilyID,
+			"error", err,
+		)
+		return
+	}
+	s.logger.Warn("Security event: refresh token reuse detected, token family revoked",
+		"event", "refresh_token_reuse",
+		"user_id", reused.UserID,
+		"jti", reused.Jti,
+		"family_id", reused.FamilyID,
+		"revoked_tokens", revoked,
+	)
+}
+
+// revokeUserTokens invalidates every session of a user: all refresh tokens are revoked and the user's token
+// version is bumped, so access tokens already issued are rejected on their next use. It runs with q, so callers
+// do it in the transaction that deactivates the user or changes their roles; the new version must then be
+// cached with cacheTokenVersion.
+func revokeUserTokens(ctx context.Context, q db.Querier, userID uuid.UUID) (int32, error) {
+	tokenVersion, err := q.BumpUserTokenVersion(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrUserNotFound
+		}
+		return 0, fmt.Errorf("failed to bump token version: %w", err)
+	}
+	if err := q.RevokeAllRefreshTokensByUserID(ctx, userID); err != nil {
+		return 0, fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return tokenVersion, nil
+}
+
+// cacheTokenVersion publishes a bumped token version to the access token check.
+func (s *AuthService) cacheTokenVersion(ctx context.Context, userID uuid.UUID, tokenVersion int32) error {
+	cacheKey := fmt.Sprintf(CacheKeyUserTokenVersion, userID.String())
+	if err := s.cache.Set(ctx, cacheKey, tokenVersion, tokenVersionCacheTTL).Err(); err != nil {
+		// Drop the stale entry so the next check falls through to the database
+		if delErr := s.cache.Del(ctx, cacheKey).Err(); delErr != nil {
+			s.logger.Error("Failed to update cached token version after revocation", "user_id", userID, "error", err, "delete_error", delErr)
+			return fmt.Errorf("failed to update cached token version: %w", err)
+		}
+	}
+	return nil
+}
+
+// IsAccessTokenCurrent reports whether an access token issued for a session with tokenVersion is still valid.
+// It is false once the session or all of the user's tokens have been revoked, or the user has been deactivated.
+func (s *AuthService) IsAccessTokenCurrent(ctx context.Context, userID, sessionID uuid.UUID, tokenVersion int) (bool, error) {
+	if sessionID != uuid.Nil {
+		revoked, err := s.cache.Exists(ctx, fmt.Sprintf(CacheKeyRevokedSession, sessionID.String())).Result()
+		if err != nil {
+			// The deny-list only lives in Redis; the token version below is still enforced
+			s.logger.Error("Redis error checking revoked session", "session_id", sessionID, "error", err)
+		} else if revoked > 0 {
+			return false, nil
+		}
+	}
+
+	cacheKey := fmt.Sprintf(CacheKeyUserTokenVersion, userID.String())
+	cached, err := s.cache.Get(ctx, cacheKey).Result()
+	if err == nil {
+		if current, convErr := strconv.Atoi(cached); convErr == nil {
+			return tokenVersion == current, nil
+		}
+		s.logger.Error("Invalid cached token version", "key", cacheKey, "value", cached)
+	} else if !errors.Is(err, redis.Nil) {
+		s.logger.Error("Redis error fetching token version", "key", cacheKey, "error", err)
+	}
+
+	current, err := s.querier.GetUserTokenVersion(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil // Deactivated or deleted
+		}
+		return false, fmt.Errorf("failed to fetch token version: %w", err)
+	}
+	if err := s.cache.Set(ctx, cacheKey, current, tokenVersionCacheTTL).Err(); err != nil {
+		s.logger.Error("Failed to cache token version", "key", cacheKey, "error", err)
+	}
+	return tokenVersion == int(current), nil
+}
+
+// loadPermissions fills in the permissions the user holds through their roles.
+// They are carried in the access token; role changes revoke the user's tokens so stale permissions do not linger.
+func (s *AuthService) loadPermissions(ctx context.Context, user *models.User) error {
+	permissions, err := s.querier.ListUserPermissions(ctx, user.ID)
+	if err != nil {
+		s.logger.Error("Failed to load user permissions", "error", err, "user_id", user.ID)
+		return fmt.Errorf("failed to load user permissions: %w", err)
+	}
+	user.Permissions = permissions
+	return nil
+}
+
 // hashToken creates a SHA-256 hash of the input string and returns it as a hex string.
 func (s *AuthService) hashToken(token string) string {
 	hasher := sha256.New()
@@ -283,20 +562,42 @@ func (s *AuthService) hashToken(token string) string {
 }
 
 // createAccessToken generates the actual JWT access token string.
-func (s *AuthService) createAccessToken(userID uuid.UUID, email string, isAdmin bool, expiry time.Time) (string, error) {
+func (s *AuthService) createAccessToken(userID uuid.UUID, email string, isAdmin bool, permissions []string, sessionID uuid.UUID, tokenVersion int, twoFactor bool, expiry time.Time) (string, error) {
 	claims := jwt.MapClaims{
-		"user_id":  userID.String(),
-		"email":    email,
-		"is_admin": isAdmin,
-		"exp":      expiry.Unix(),
+		"user_id":     userID.String(),
+		"email":       email,
+		"is_admin":    isAdmin,
+		"permissions": permissions,
+		"sid":         sessionID.String(), // Refresh token family, so a revoked session can be rejected
+		"ver":         tokenVersion,       // Compared with users.token_version by JWTMiddleware
+		"mfa":         twoFactor,          // Checked by RequireTwoFactor on the admin routes
+		"exp":         expiry.Unix(),
 		// Add other claims as needed
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(s.jwtSecret)
+	return s.jwtKeys.Sign(claims)
+}
+
+// createTwoFactorChallenge issues the short-lived token that proves a user passed the password step of a login.
+func (s *AuthService) createTwoFactorChallenge(userID uuid.UUID) (string, error) {
+	claims := jwt.RegisteredClaims{
+		ID:        uuid.NewString(),
+		Subject:   userID.String(),
+		Issuer:    "tech-store-backend",
+		Audience:  jwt.ClaimStrings{twoFactorChallengeAudience}, // Not accepted as an access or refresh token
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(twoFactorChallengeTTL)),
+	}
+	return s.jwtKeys.Sign(claims)
 }
 
 // --- Error Definitions ---
 var (
 	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token has already been used")
+
+	ErrInvalidTwoFactorChallenge = errors.New("invalid or expired two-factor challenge")
+	ErrTooManyTwoFactorAttempts  = errors.New("too many two-factor attempts, please sign in again")
 )
+
+// refreshTokenRevokedRotated is the revoked_reason of a refresh token exchanged for its successor.
+const refreshTokenRevokedRotated = "rotated"
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/MihoZaki/DzTech/internal/config"
+	"github.com/MihoZaki/DzTech/internal/db"
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/MihoZaki/DzTech/internal/utils"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// refreshTokenQuerier serves a single refresh token record and records family revocations.
+// Queries a test does not expect panic through the nil embedded Querier.
+type refreshTokenQuerier struct {
+	db.Querier
+	record     db.GetRefreshTokenRecordRow
+	recordErr  error
+	rotated    int64
+	revoked    int64
+	revokeErr  error
+	revokedFam []uuid.UUID
+}
+
+func (q *refreshTokenQuerier) GetRefreshTokenRecord(ctx context.Context, jti string) (db.GetRefreshTokenRecordRow, error) {
+	if q.recordErr != nil {
+		return db.GetRefreshTokenRecordRow{}, q.recordErr
+	}
+	return q.record, nil
+}
+
+func (q *refreshTokenQuerier) RotateRefreshToken(ctx context.Context, jti string) (int64, error) {
+	return q.rotated, nil
+}
+
+func (q *refreshTokenQuerier) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID) (int64, error) {
+	q.revokedFam = append(q.revokedFam, familyID)
+	return q.revoked, q.revokeErr
+}
+
+// newRefreshTestService returns an AuthService signing with an HS256 test secret that logs JSON into logs.
+func newRefreshTestService(t *testing.T, querier db.Querier, logs *bytes.Buffer) *AuthService {
+	t.Helper()
+	keys, err := utils.LoadJWTKeySet("test-secret", config.JWTKeys{})
+	if err != nil {
+		t.Fatalf("LoadJWTKeySet() error = %v", err)
+	}
+	return &AuthService{
+		querier: querier,
+		jwtKeys: keys,
+		logger:  slog.New(slog.NewJSONHandler(logs, nil)),
+	}
+}
+
+// logEntries decodes the JSON log lines written by a test service.
+func logEntries(t *testing.T, logs *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var entries []map[string]any
+	dec := json.NewDecoder(logs)
+	for dec.More() {
+		var entry map[string]any
+		if err := dec.Decode(&entry); err != nil {
+			t.Fatalf("invalid log output %q: %v", logs.String(), err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestRefreshDetectsReuse(t *testing.T) {
+	rotatedReason := refreshTokenRevokedRotated
+	loggedOutReason := "logout"
+	revokedAt := pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true}
+
+	tests := []struct {
+		name          string
+		revokedAt     pgtype.Timestamptz
+		revokedReason *string
+		rotated       int64 // Rows RotateRefreshToken reports for a live token
+		wrongHash     bool
+		recordErr     error
+		wantErr       error
+		wantFamily    bool // Whether the token family gets revoked
+	}{
+		{
+			name:          "rotated token presented again",
+			revokedAt:     revokedAt,
+			revokedReason: &rotatedReason,
+			wantErr:       ErrRefreshTokenReused,
+			wantFamily:    true,
+		},
+		{
+			name:       "live token loses the rotation race",
+			rotated:    0,
+			wantErr:    ErrRefreshTokenReused,
+			wantFamily: true,
+		},
+		{
+			name:          "token revoked by logout",
+			revokedAt:     revokedAt,
+			revokedReason: &loggedOutReason,
+			wantErr:       ErrInvalidRefreshToken,
+		},
+		{
+			name:      "token not matching the stored hash",
+			revokedAt: revokedAt,
+			wrongHash: true,
+		},
+		{
+			name:      "unknown or expired token",
+			recordErr: pgx.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			querier := &refreshTokenQuerier{rotated: tt.rotated, recordErr: tt.recordErr, revoked: 2}
+			s := newRefreshTestService(t, querier, &logs)
+
+			jti := uuid.NewString()
+			tokenStr, err := s.jwtKeys.Sign(jwt.RegisteredClaims{
+				ID:        jti,
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			})
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+			querier.record = db.GetRefreshTokenRecordRow{
+				Jti:           jti,
+				UserID:        uuid.New(),
+				TokenHash:     s.hashToken(tokenStr),
+				FamilyID:      uuid.New(),
+				RevokedAt:     tt.revokedAt,
+				RevokedReason: tt.revokedReason,
+			}
+			if tt.wrongHash {
+				querier.record.TokenHash = s.hashToken("another token")
+			}
+
+			access, refresh, err := s.Refresh(context.Background(), tokenStr, models.DeviceInfo{})
+			if err == nil || access != "" || refresh != "" {
+				t.Fatalf("Refresh() = (%q, %q, %v), want an error and no tokens", access, refresh, err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Refresh() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !tt.wantFamily {
+				if len(querier.revokedFam) != 0 {
+					t.Errorf("Refresh() revoked families %v, want none", querier.revokedFam)
+				}
+				return
+			}
+			if len(querier.revokedFam) != 1 || querier.revokedFam[0] != querier.record.FamilyID {
+				t.Errorf("Refresh() revoked families %v, want [%s]", querier.revokedFam, querier.record.FamilyID)
+			}
+			var reported bool
+			for _, entry := range logEntries(t, &logs) {
+				if entry["event"] == "refresh_token_reuse" && entry["jti"] == jti {
+					reported = true
+				}
+			}
+			if !reported {
+				t.Errorf("Refresh() did not log a refresh_token_reuse security event:\n%s", logs.String())
+			}
+		})
+	}
+}
+
+func TestRevokeFamilyOnReuse(t *testing.T) {
+	reused := db.GetRefreshTokenRecordRow{
+		Jti:      "reused-jti",
+		UserID:   uuid.New(),
+		FamilyID: uuid.New(),
+	}
+
+	tests := []struct {
+		name      string
+		revoked   int64
+		revokeErr error
+		wantLevel string
+		wantMsg   string
+	}{
+		{
+			name:      "family revoked",
+			revoked:   3,
+			wantLevel: "WARN",
+			wantMsg:   "Security event: refresh token reuse detected, token family revoked",
+		},
+		{
+			name:      "revocation failed",
+			revokeErr: errors.New("connection reset"),
+			wantLevel: "ERROR",
+			wantMsg:   "Security event: refresh token reuse detected, but revoking the token family failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			querier := &refreshTokenQuerier{revoked: tt.revoked, revokeErr: tt.revokeErr}
+			s := newRefreshTestService(t, querier, &logs)
+
+			s.revokeFamilyOnReuse(context.Background(), reused)
+
+			entries := logEntries(t, &logs)
+			if len(entries) != 1 {
+				t.Fatalf("logged %d entries, want 1:\n%s", len(entries), logs.String())
+			}
+			entry := entries[0]
+			if entry["level"] != tt.wantLevel || entry["msg"] != tt.wantMsg {
+				t.Errorf("logged %v %q, want %v %q", entry["level"], entry["msg"], tt.wantLevel, tt.wantMsg)
+			}
+			if entry["user_id"] != reused.UserID.String() || entry["family_id"] != reused.FamilyID.String() {
+				t.Errorf("log entry %v does not identify the reused token", entry)
+			}
+			if _, ok := entry["revoked_tokens"]; ok != (tt.revokeErr == nil) {
+				t.Errorf("log entry %v: revoked_tokens should only be reported for a successful revocation", entry)
+			}
+		})
+	}
+}
@@ -158,6 +158,7 @@ func (s *CategoryService) UpdateCategory(ctx context.Context, id uuid.UUID, req
 		}
 		return nil, fmt.Errorf("failed to fetch existing category: %w", err)
 	}
+	models.SetAuditBefore(ctx, s.toCategoryModel(existingDBCat))
 
 	// Prepare update parameters, using existing values if not provided in request
 	name := CoalesceString(req.Name, existingDBCat.Name)
@@ -5,27 +5,43 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 
+	"github.com/MihoZaki/DzTech/internal/config"
+	"github.com/MihoZaki/DzTech/internal/courier"
 	"github.com/MihoZaki/DzTech/internal/db"
 	"github.com/MihoZaki/DzTech/internal/models"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-var ErrDeliveryServiceInUse = errors.New("delivery service cannot be deleted: it is currently in use by one or more orders")
+var (
+	ErrDeliveryServiceInUse = errors.New("delivery service cannot be deleted: it is currently in use by one or more orders")
+	ErrDeliveryUnavailable  = errors.New("delivery service does not deliver this cart to this wilaya with this delivery type")
+	ErrUnknownCourier       = errors.New("courier is not configured")
+)
 
-// DeliveryServiceService handles business logic for delivery services.
+// DeliveryServiceService handles business logic for delivery services, their rate tables and delivery pricing.
 type DeliveryServiceService struct {
-	querier db.Querier
-	logger  *slog.Logger
+	querier   db.Querier
+	pool      *pgxpool.Pool     // Rate tables are replaced as a whole
+	geography *GeographyService // Resolves the wilaya a quote is asked for
+	couriers  *courier.Registry // Couriers a service may be linked to
+	cfg       config.Delivery
+	logger    *slog.Logger
 }
 
 // NewDeliveryServiceService creates a new instance of DeliveryServiceService.
-func NewDeliveryServiceService(querier db.Querier, logger *slog.Logger) *DeliveryServiceService {
+func NewDeliveryServiceService(querier db.Querier, pool *pgxpool.Pool, geography *GeographyService, couriers *courier.Registry, cfg config.Delivery, logger *slog.Logger) *DeliveryServiceService {
 	return &DeliveryServiceService{
-		querier: querier,
-		logger:  logger,
+		querier:   querier,
+		pool:      pool,
+		geography: geography,
+		couriers:  couriers,
+		cfg:       cfg,
+		logger:    logger,
 	}
 }
 
@@ -98,12 +114,20 @@ func (s *DeliveryServiceService) CreateDeliveryService(ctx context.Context, req
 	} else {
 		estimatedDays = nil
 	}
+	if err := s.checkCourier(req.Courier); err != nil {
+		return nil, err
+	}
+	if req.Courier != nil && *req.Courier == "" {
+		req.Courier = nil
+	}
 	params := db.CreateDeliveryServiceParams{
-		Name:          req.Name,
-		Description:   req.Description,
-		BaseCostCents: req.BaseCostCents,
-		EstimatedDays: estimatedDays,
-		IsActive:      req.IsActive,
+		Name:                 req.Name,
+		Description:          req.Description,
+		BaseCostCents:        req.BaseCostCents,
+		EstimatedDays:        estimatedDays,
+		IsActive:             req.IsActive,
+		FreeShippingMinCents: req.FreeShippingMinCents,
+		Courier:              req.Courier,
 	}
 
 	dbDeliveryService, err := s.querier.CreateDeliveryService(ctx, params)
@@ -119,13 +143,17 @@ func (s *DeliveryServiceService) CreateDeliveryService(ctx context.Context, req
 // UpdateDeliveryService updates an existing delivery service.
 func (s *DeliveryServiceService) UpdateDeliveryService(ctx context.Context, id uuid.UUID, req models.UpdateDeliveryServiceRequest) (*models.DeliveryService, error) {
 	// First, check if the delivery service exists (regardless of active status)
-	_, err := s.querier.GetDeliveryServiceByID(ctx, id) // Use the dedicated GetByID query
+	existing, err := s.querier.GetDeliveryServiceByID(ctx, id) // Use the dedicated GetByID query
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrDeliveryServiceNotFound
 		}
 		return nil, fmt.Errorf("failed to check existence of delivery service before update: %w", err)
 	}
+	models.SetAuditBefore(ctx, s.toDeliveryServiceModel(existing))
+	if err := s.checkCourier(req.Courier); err != nil {
+		return nil, err
+	}
 
 	var estimatedDays *int32
 	if req.EstimatedDays != nil {
@@ -136,12 +164,15 @@ func (s *DeliveryServiceService) UpdateDeliveryService(ctx context.Context, id u
 	}
 
 	params := db.UpdateDeliveryServiceParams{
-		ID:            id,
-		Name:          req.Name,
-		Description:   req.Description,
-		BaseCostCents: req.BaseCostCents,
-		EstimatedDays: estimatedDays,
-		IsActive:      req.IsActive,
+		ID:                        id,
+		Name:                      req.Name,
+		Description:               req.Description,
+		BaseCostCents:             req.BaseCostCents,
+		EstimatedDays:             estimatedDays,
+		IsActive:                  req.IsActive,
+		FreeShippingMinCents:      req.FreeShippingMinCents,
+		ClearFreeShippingMinCents: req.ClearFreeShippingMinCents,
+		Courier:                   req.Courier,
 	}
 
 	dbDeliveryService, err := s.querier.UpdateDeliveryService(ctx, params)
@@ -157,13 +188,14 @@ func (s *DeliveryServiceService) UpdateDeliveryService(ctx context.Context, id u
 // Consider soft deletion by updating is_active if required.
 func (s *DeliveryServiceService) DeleteDeliveryService(ctx context.Context, id uuid.UUID) error {
 	// First, check if the delivery service exists (regardless of active status)
-	_, err := s.querier.GetDeliveryServiceByID(ctx, id) // Use the dedicated GetByID query
+	existing, err := s.querier.GetDeliveryServiceByID(ctx, id) // Use the dedicated GetByID query
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return ErrDeliveryServiceNotFound
 		}
 		return fmt.Errorf("failed to check existence of delivery service before delete: %w", err)
 	}
+	models.SetAuditBefore(ctx, s.toDeliveryServiceModel(existing))
 
 	err = s.querier.DeleteDeliveryService(ctx, id)
 	if err != nil {
@@ -180,19 +212,317 @@ func (s *DeliveryServiceService) DeleteDeliveryService(ctx context.Context, id u
 	return nil
 }
 
+// GetRateTable retrieves the rate table of a delivery service.
+func (s *DeliveryServiceService) GetRateTable(ctx context.Context, id uuid.UUID) (*models.DeliveryRateTable, error) {
+	if _, err := s.querier.GetDeliveryServiceByID(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDeliveryServiceNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch delivery service by ID: %w", err)
+	}
+	return s.loadRateTable(ctx, s.querier, id)
+}
+
+// ReplaceRateTable replaces the zones and rates of a delivery service with those of the request.
+func (s *DeliveryServiceService) ReplaceRateTable(ctx context.Context, id uuid.UUID, req models.ReplaceDeliveryRatesRequest) (*models.DeliveryRateTable, error) {
+	existing, err := s.GetRateTable(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	models.SetAuditBefore(ctx, existing)
+
+	var table *models.DeliveryRateTable
+	err = s.withTx(ctx, func(txQuerier *db.Queries) error {
+		if err := txQuerier.DeleteDeliveryZones(ctx, id); err != nil {
+			return fmt.Errorf("failed to clear rate table: %w", err)
+		}
+		for _, zone := range req.Zones {
+			zoneID, err := txQuerier.CreateDeliveryZone(ctx, db.CreateDeliveryZoneParams{
+				DeliveryServiceID:    id,
+				Name:                 zone.Name,
+				FreeShippingMinCents: zone.FreeShippingMinCents,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create delivery zone %q: %w", zone.Name, err)
+			}
+			err = txQuerier.AddDeliveryZoneWilayas(ctx, db.AddDeliveryZoneWilayasParams{
+				ZoneID:            zoneID,
+				DeliveryServiceID: id,
+				WilayaCodes:       zone.WilayaCodes,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to add wilayas to delivery zone %q: %w", zone.Name, err)
+			}
+			for _, rate := range zone.Rates {
+				err := txQuerier.CreateDeliveryRate(ctx, db.CreateDeliveryRateParams{
+					ZoneID:         zoneID,
+					DeliveryType:   rate.DeliveryType,
+					MaxWeightGrams: rate.MaxWeightGrams,
+					PriceCents:     rate.PriceCents,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to create rate of delivery zone %q: %w", zone.Name, err)
+				}
+			}
+		}
+		table, err = s.loadRateTable(ctx, txQuerier, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// QuoteCart prices delivery of a cart to a province with each active delivery service, once for every
+// delivery type the service offers there. Services that do not deliver there are left out.
+// The province may be a wilaya code or name, as accepted in addresses.
+func (s *DeliveryServiceService) QuoteCart(ctx context.Context, cart *models.CartSummary, province string) ([]models.DeliveryQuote, error) {
+	wilaya := s.geography.resolveWilaya(province)
+	if wilaya == nil {
+		return nil, ErrUnknownPlace
+	}
+	dbDeliveryServices, err := s.querier.GetActiveDeliveryServices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch active delivery services: %w", err)
+	}
+	weightGrams, err := s.cartWeightGrams(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+
+	quotes := make([]models.DeliveryQuote, 0, len(dbDeliveryServices))
+	for _, dbDS := range dbDeliveryServices {
+		table, err := s.loadRateTable(ctx, s.querier, dbDS.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, deliveryType := range []string{models.DeliveryTypeHome, models.DeliveryTypeStopDesk} {
+			if quote, ok := quoteDelivery(dbDS, table, wilaya.Code, deliveryType, weightGrams, cart.TotalDiscountedValueCents); ok {
+				quotes = append(quotes, quote)
+			}
+		}
+	}
+	return quotes, nil
+}
+
+// QuoteDelivery prices delivery of a cart to a wilaya with one delivery service and delivery type.
+// It returns ErrDeliveryUnavailable if the service does not deliver there with that type or at that weight.
+func (s *DeliveryServiceService) QuoteDelivery(ctx context.Context, deliveryServiceID uuid.UUID, cart *models.CartSummary, wilayaCode int16, deliveryType string) (*models.DeliveryQuote, error) {
+	dbDS, err := s.querier.GetDeliveryServiceByID(ctx, deliveryServiceID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDeliveryServiceNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch delivery service with ID %s: %w", deliveryServiceID, err)
+	}
+	// Deactivated services are not offered by QuoteCart and cannot be booked either
+	if !dbDS.IsActive {
+		return nil, ErrDeliveryUnavailable
+	}
+	table, err := s.loadRateTable(ctx, s.querier, deliveryServiceID)
+	if err != nil {
+		return nil, err
+	}
+	weightGrams, err := s.cartWeightGrams(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, ok := quoteDelivery(dbDS, table, wilayaCode, deliveryType, weightGrams, cart.TotalDiscountedValueCents)
+	if !ok {
+		return nil, ErrDeliveryUnavailable
+	}
+	return &quote, nil
+}
+
+// cartWeightGrams returns the shipping weight of a cart.
+func (s *DeliveryServiceService) cartWeightGrams(ctx context.Context, cart *models.CartSummary) (int64, error) {
+	quantities := make(map[uuid.UUID]int64, len(cart.Items))
+	for _, item := range cart.Items {
+		if item.Product != nil {
+			quantities[item.Product.ID] += int64(item.Quantity)
+		}
+	}
+	return s.parcelWeightGrams(ctx, quantities)
+}
+
+// parcelWeightGrams returns the shipping weight of the given quantities of products, counting products
+// without a weight at the configured default.
+func (s *DeliveryServiceService) parcelWeightGrams(ctx context.Context, quantities map[uuid.UUID]int64) (int64, error) {
+	productIDs := make([]uuid.UUID, 0, len(quantities))
+	for productID := range quantities {
+		productIDs = append(productIDs, productID)
+	}
+	weights, err := s.querier.GetProductWeights(ctx, productIDs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch product weights: %w", err)
+	}
+	weightByProduct := make(map[uuid.UUID]int64, len(weights))
+	for _, w := range weights {
+		if w.WeightGrams != nil {
+			weightByProduct[w.ID] = int64(*w.WeightGrams)
+		}
+	}
+
+	var total int64
+	for productID, quantity := range quantities {
+		weight, ok := weightByProduct[productID]
+		if !ok {
+			weight = int64(s.cfg.DefaultWeightGrams)
+		}
+		total += weight * quantity
+	}
+	return total, nil
+}
+
+func (s *DeliveryServiceService) loadRateTable(ctx context.Context, querier db.Querier, id uuid.UUID) (*models.DeliveryRateTable, error) {
+	dbZones, err := querier.ListDeliveryZones(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list delivery zones: %w", err)
+	}
+	dbRates, err := querier.ListDeliveryRates(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list delivery rates: %w", err)
+	}
+
+	table := &models.DeliveryRateTable{DeliveryServiceID: id, Zones: make([]models.DeliveryZone, len(dbZones))}
+	zoneIndex := make(map[uuid.UUID]int, len(dbZones))
+	for i, z := range dbZones {
+		table.Zones[i] = models.DeliveryZone{
+			ID:                   z.ID,
+			Name:                 z.Name,
+			WilayaCodes:          z.WilayaCodes,
+			FreeShippingMinCents: z.FreeShippingMinCents,
+			Rates:                []models.DeliveryRate{},
+		}
+		zoneIndex[z.ID] = i
+	}
+	for _, r := range dbRates {
+		zone := &table.Zones[zoneIndex[r.ZoneID]]
+		zone.Rates = append(zone.Rates, models.DeliveryRate{
+			DeliveryType:   r.DeliveryType,
+			MaxWeightGrams: r.MaxWeightGrams,
+			PriceCents:     r.PriceCents,
+		})
+	}
+	return table, nil
+}
+
+func (s *DeliveryServiceService) withTx(ctx context.Context, fn func(txQuerier *db.Queries) error) error {
+	queries, ok := s.querier.(*db.Queries)
+	if !ok {
+		return errors.New("querier type assertion to *db.Queries failed, cannot create transactional querier")
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin rate table transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			s.logger.Error("Error during rate table transaction rollback", "error", err)
+		}
+	}()
+
+	if err := fn(queries.WithTx(tx)); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit rate table transaction: %w", err)
+	}
+	return nil
+}
+
 // --- Helper Functions ---
 
+// checkCourier returns ErrUnknownCourier unless name is unset, empty or a configured courier.
+func (s *DeliveryServiceService) checkCourier(name *string) error {
+	if name == nil || *name == "" {
+		return nil
+	}
+	if _, ok := s.couriers.Get(*name); !ok {
+		return fmt.Errorf("%w: %q (configured: %v)", ErrUnknownCourier, *name, s.couriers.Names())
+	}
+	return nil
+}
+
 func (s *DeliveryServiceService) toDeliveryServiceModel(dbDS db.DeliveryService) models.DeliveryService {
 	return models.DeliveryService{
-		ID:            dbDS.ID,
-		Name:          dbDS.Name,
-		Description:   dbDS.Description,
-		BaseCostCents: dbDS.BaseCostCents,
-		EstimatedDays: dbDS.EstimatedDays,
-		IsActive:      dbDS.IsActive,
-		CreatedAt:     dbDS.CreatedAt.Time,
-		UpdatedAt:     dbDS.UpdatedAt.Time,
+		ID:                   dbDS.ID,
+		Name:                 dbDS.Name,
+		Description:          dbDS.Description,
+		BaseCostCents:        dbDS.BaseCostCents,
+		EstimatedDays:        dbDS.EstimatedDays,
+		IsActive:             dbDS.IsActive,
+		FreeShippingMinCents: dbDS.FreeShippingMinCents,
+		Courier:              dbDS.Courier,
+		CreatedAt:            dbDS.CreatedAt.Time,
+		UpdatedAt:            dbDS.UpdatedAt.Time,
+	}
+}
+
+// quoteDelivery prices a parcel of weightGrams for an order worth subtotalCents. A service without zones
+// charges its base cost for home delivery anywhere. Otherwise the zone covering the wilaya is charged the
+// smallest weight bracket of the delivery type the parcel fits under, or its open-ended bracket.
+// It returns false if the service does not deliver there with that type or at that weight.
+func quoteDelivery(dbDS db.DeliveryService, table *models.DeliveryRateTable, wilayaCode int16, deliveryType string, weightGrams, subtotalCents int64) (models.DeliveryQuote, bool) {
+	quote := models.DeliveryQuote{
+		DeliveryServiceID:    dbDS.ID,
+		DeliveryServiceName:  dbDS.Name,
+		EstimatedDays:        dbDS.EstimatedDays,
+		DeliveryType:         deliveryType,
+		WeightGrams:          weightGrams,
+		FreeShippingMinCents: dbDS.FreeShippingMinCents,
+	}
+
+	if len(table.Zones) == 0 {
+		if deliveryType != models.DeliveryTypeHome {
+			return quote, false
+		}
+		quote.FeeCents = dbDS.BaseCostCents
+	} else {
+		zone := findDeliveryZone(table, wilayaCode)
+		if zone == nil {
+			return quote, false
+		}
+		rate := findDeliveryRate(zone, deliveryType, weightGrams)
+		if rate == nil {
+			return quote, false
+		}
+		quote.Zone = &zone.Name
+		quote.FeeCents = rate.PriceCents
+		if zone.FreeShippingMinCents != nil {
+			quote.FreeShippingMinCents = zone.FreeShippingMinCents
+		}
+	}
+
+	if quote.FreeShippingMinCents != nil && subtotalCents >= *quote.FreeShippingMinCents {
+		quote.FeeCents = 0
+		quote.FreeShipping = true
+	}
+	return quote, true
+}
+
+func findDeliveryZone(table *models.DeliveryRateTable, wilayaCode int16) *models.DeliveryZone {
+	for i := range table.Zones {
+		if slices.Contains(table.Zones[i].WilayaCodes, wilayaCode) {
+			return &table.Zones[i]
+		}
 	}
+	return nil
+}
+
+// findDeliveryRate relies on the rates being ordered by weight bracket, the open-ended one last.
+func findDeliveryRate(zone *models.DeliveryZone, deliveryType string, weightGrams int64) *models.DeliveryRate {
+	for i, rate := range zone.Rates {
+		if rate.DeliveryType != deliveryType {
+			continue
+		}
+		if rate.MaxWeightGrams == nil || weightGrams <= int64(*rate.MaxWeightGrams) {
+			return &zone.Rates[i]
+		}
+	}
+	return nil
 }
 
 var (
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/MihoZaki/DzTech/internal/models"
+)
+
+func TestFindDeliveryRate(t *testing.T) {
+	grams := func(n int32) *int32 { return &n }
+	zone := &models.DeliveryZone{
+		Rates: []models.DeliveryRate{
+			{DeliveryType: models.DeliveryTypeHome, MaxWeightGrams: grams(1000), PriceCents: 40000},
+			{DeliveryType: models.DeliveryTypeHome, MaxWeightGrams: grams(5000), PriceCents: 70000},
+			{DeliveryType: models.DeliveryTypeHome, PriceCents: 120000},
+			{DeliveryType: models.DeliveryTypeStopDesk, MaxWeightGrams: grams(5000), PriceCents: 30000},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		deliveryType string
+		weightGrams  int64
+		wantPrice    int64
+		wantNil      bool
+	}{
+		{name: "weightless order takes the first bracket", deliveryType: models.DeliveryTypeHome, weightGrams: 0, wantPrice: 40000},
+		{name: "bracket limit is inclusive", deliveryType: models.DeliveryTypeHome, weightGrams: 1000, wantPrice: 40000},
+		{name: "just over a limit moves up a bracket", deliveryType: models.DeliveryTypeHome, weightGrams: 1001, wantPrice: 70000},
+		{name: "open-ended bracket takes heavy orders", deliveryType: models.DeliveryTypeHome, weightGrams: 30000, wantPrice: 120000},
+		{name: "other delivery types are skipped", deliveryType: models.DeliveryTypeStopDesk, weightGrams: 2000, wantPrice: 30000},
+		{name: "too heavy without an open-ended bracket", deliveryType: models.DeliveryTypeStopDesk, weightGrams: 5001, wantNil: true},
+		{name: "delivery type not offered", deliveryType: "drone", weightGrams: 100, wantNil: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate := findDeliveryRate(zone, tt.deliveryType, tt.weightGrams)
+			if tt.wantNil {
+				if rate != nil {
+					t.Fatalf("findDeliveryRate() = %+v, want nil", *rate)
+				}
+				return
+			}
+			if rate == nil {
+				t.Fatal("findDeliveryRate() = nil, want a rate")
+			}
+			if rate.PriceCents != tt.wantPrice {
+				t.Errorf("findDeliveryRate().PriceCents = %d, want %d", rate.PriceCents, tt.wantPrice)
+			}
+		})
+	}
+}
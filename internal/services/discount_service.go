@@ -23,14 +23,16 @@ import (
 type DiscountService struct {
 	querier db.Querier
 	cache   *redis.Client
+	alerts  *ProductAlertService
 	logger  *slog.Logger
 }
 
 // NewDiscountService creates a new instance of DiscountService.
-func NewDiscountService(querier db.Querier, cache *redis.Client, logger *slog.Logger) *DiscountService {
+func NewDiscountService(querier db.Querier, cache *redis.Client, alerts *ProductAlertService, logger *slog.Logger) *DiscountService {
 	return &DiscountService{
 		querier: querier,
 		cache:   cache,
+		alerts:  alerts,
 		logger:  logger,
 	}
 }
@@ -168,6 +170,7 @@ func (s *DiscountService) UpdateDiscount(ctx context.Context, id uuid.UUID, req
 		}
 		return nil, fmt.Errorf("failed to fetch existing discount: %w", err)
 	}
+	models.SetAuditBefore(ctx, s.mapDbDiscountToModel(existingDBDisc))
 
 	// Prepare update parameters, using existing values if not provided in request
 	code := CoalesceString(req.Code, existingDBDisc.Code)
@@ -261,6 +264,9 @@ func (s *DiscountService) UpdateDiscount(ctx context.Context, id uuid.UUID, req
 	}
 	// ---
 
+	// A larger value, a new validity window or re-activation can push linked products below alert targets
+	s.alerts.CheckDiscountAlertsAsync(ctx, id)
+
 	s.logger.Info("Discount updated successfully", "discount_id", updatedDiscount.ID, "code", updatedDiscount.Code)
 	return updatedDiscount, nil
 }
@@ -275,6 +281,7 @@ func (s *DiscountService) DeleteDiscount(ctx context.Context, id uuid.UUID) erro
 		}
 		return fmt.Errorf("failed to fetch discount for cache invalidation: %w", err)
 	}
+	models.SetAuditBefore(ctx, s.mapDbDiscountToModel(dbDiscount))
 
 	// Execute the delete query
 	err = s.querier.DeleteDiscount(ctx, id)
@@ -497,6 +504,8 @@ func (s *DiscountService) LinkDiscountToProduct(ctx context.Context, discountID,
 		s.logger.Debug("Product cache invalidated by ID after linking discount", "product_id", productID, "discount_id", discountID, "key", productCacheKeyByID)
 	}
 
+	s.alerts.CheckProductAlertsAsync(productID)
+
 	s.logger.Info("Discount linked to product", "discount_id", discountID, "product_id", productID)
 	return nil
 }
@@ -4,16 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"html"
 	"log/slog"
 	"time"
 
 	"github.com/MihoZaki/DzTech/internal/config"
+	"github.com/MihoZaki/DzTech/internal/models"
 	"github.com/wneessen/go-mail"
 )
 
 // EmailService defines the interface for sending emails.
 type EmailService interface {
 	SendPasswordResetEmail(ctx context.Context, toEmail, resetToken string) error
+	SendProductAlertEmail(ctx context.Context, toEmail string, alert models.ProductAlertNotification) error
+	SendReviewReplyEmail(ctx context.Context, toEmail string, reply models.ReviewReplyNotification) error
+	SendProductAnswerEmail(ctx context.Context, toEmail string, answer models.ProductAnswerNotification) error
+	SendAccountLockedEmail(ctx context.Context, toEmail string, lockout models.AccountLockoutNotification) error
 }
 
 // ConcreteEmailService implements the EmailService interface using wneessen/go-mail.
@@ -144,3 +150,232 @@ YC Informatique Team</p>
 	e.logger.Info("Password reset email sent successfully via go-mail", "to", toEmail, "token_preview", resetToken[:10]+"...")
 	return nil
 }
+
+// SendProductAlertEmail notifies a customer that a product they subscribed to is back in stock
+// or has dropped below their target price.
+func (e *ConcreteEmailService) SendProductAlertEmail(ctx context.Context, toEmail string, alert models.ProductAlertNotification) error {
+	productURL := fmt.Sprintf("%s/products/%s", e.config.BaseURL, alert.ProductSlug)
+
+	var subject, summary string
+	switch alert.AlertType {
+	case models.ProductAlertPriceBelow:
+		subject = fmt.Sprintf("Price drop: %s", alert.ProductName)
+		summary = fmt.Sprintf("%s is now available for %s, below your target price of %s.",
+			alert.ProductName, formatPriceCents(alert.CurrentPriceCents), formatPriceCents(*alert.TargetPriceCents))
+	default:
+		subject = fmt.Sprintf("Back in stock: %s", alert.ProductName)
+		summary = fmt.Sprintf("%s is back in stock (%d available) at %s.",
+			alert.ProductName, alert.StockQuantity, formatPriceCents(alert.CurrentPriceCents))
+	}
+
+	textBody := fmt.Sprintf(`Hello,
+
+Good news! %s
+
+View the product here:
+%s
+
+You will not receive this alert again unless you subscribe again.
+
+Best regards,
+YC Informatique Team
+`, summary, productURL)
+
+	htmlBody := fmt.Sprintf(`<html>
+<body>
+<p>Hello,</p>
+
+<p>Good news! %s</p>
+
+<p><a href="%s">View the product</a></p>
+
+<p>You will not receive this alert again unless you subscribe again.</p>
+
+<p>Best regards,<br/>
+YC Informatique Team</p>
+</body>
+</html>`, html.EscapeString(summary), productURL)
+
+	if err := e.send(ctx, toEmail, subject, textBody, htmlBody); err != nil {
+		return err
+	}
+
+	e.logger.Info("Product alert email sent successfully via go-mail", "to", toEmail, "alert_type", alert.AlertType, "product_slug", alert.ProductSlug)
+	return nil
+}
+
+// SendReviewReplyEmail tells a customer that the store has replied to their review.
+func (e *ConcreteEmailService) SendReviewReplyEmail(ctx context.Context, toEmail string, reply models.ReviewReplyNotification) error {
+	productURL := fmt.Sprintf("%s/products/%s", e.config.BaseURL, reply.ProductSlug)
+	subject := fmt.Sprintf("We replied to your review of %s", reply.ProductName)
+
+	textBody := fmt.Sprintf(`Hello,
+
+Thank you for reviewing %s. Our team has replied:
+
+%s
+
+See the review and reply here:
+%s
+
+Best regards,
+YC Informatique Team
+`, reply.ProductName, reply.ReplyBody, productURL)
+
+	htmlBody := fmt.Sprintf(`<html>
+<body>
+<p>Hello,</p>
+
+<p>Thank you for reviewing %s. Our team has replied:</p>
+
+<blockquote>%s</blockquote>
+
+<p><a href="%s">See the review and reply</a></p>
+
+<p>Best regards,<br/>
+YC Informatique Team</p>
+</body>
+</html>`, html.EscapeString(reply.ProductName), html.EscapeString(reply.ReplyBody), productURL)
+
+	if err := e.send(ctx, toEmail, subject, textBody, htmlBody); err != nil {
+		return err
+	}
+
+	e.logger.Info("Review reply email sent successfully via go-mail", "to", toEmail, "product_slug", reply.ProductSlug)
+	return nil
+}
+
+// SendProductAnswerEmail tells a customer that their question about a product has a new answer.
+func (e *ConcreteEmailService) SendProductAnswerEmail(ctx context.Context, toEmail string, answer models.ProductAnswerNotification) error {
+	productURL := fmt.Sprintf("%s/products/%s", e.config.BaseURL, answer.ProductSlug)
+	subject := fmt.Sprintf("Your question about %s has been answered", answer.ProductName)
+	answeredBy := "Another customer"
+	if answer.IsOfficial {
+		answeredBy = "Our team"
+	}
+
+	textBody := fmt.Sprintf(`Hello,
+
+You asked about %s:
+
+%s
+
+%s answered:
+
+%s
+
+See all questions and answers here:
+%s
+
+Best regards,
+YC Informatique Team
+`, answer.ProductName, answer.QuestionBody, answeredBy, answer.AnswerBody, productURL)
+
+	htmlBody := fmt.Sprintf(`<html>
+<body>
+<p>Hello,</p>
+
+<p>You asked about %s:</p>
+
+<blockquote>%s</blockquote>
+
+<p>%s answered:</p>
+
+<blockquote>%s</blockquote>
+
+<p><a href="%s">See all questions and answers</a></p>
+
+<p>Best regards,<br/>
+YC Informatique Team</p>
+</body>
+</html>`, html.EscapeString(answer.ProductName), html.EscapeString(answer.QuestionBody), answeredBy, html.EscapeString(answer.AnswerBody), productURL)
+
+	if err := e.send(ctx, toEmail, subject, textBody, htmlBody); err != nil {
+		return err
+	}
+
+	e.logger.Info("Product answer email sent successfully via go-mail", "to", toEmail, "product_slug", answer.ProductSlug, "official", answer.IsOfficial)
+	return nil
+}
+
+// SendAccountLockedEmail warns a user that their account was temporarily locked after repeated failed logins.
+func (e *ConcreteEmailService) SendAccountLockedEmail(ctx context.Context, toEmail string, lockout models.AccountLockoutNotification) error {
+	subject := "Your account has been temporarily locked"
+	lockedUntil := lockout.LockedUntil.UTC().Format("2006-01-02 15:04 MST")
+	resetURL := fmt.Sprintf("%s/forgot-password", e.config.BaseURL)
+
+	textBody := fmt.Sprintf(`Hello,
+
+We noticed several failed attempts to sign in to your account, the last one from %s.
+To protect your account, signing in is blocked until %s.
+
+If this was you, you can try again after that time. If it was not, we recommend resetting your password:
+%s
+
+Best regards,
+YC Informatique Team
+`, lockout.IPAddress, lockedUntil, resetURL)
+
+	htmlBody := fmt.Sprintf(`<html>
+<body>
+<p>Hello,</p>
+
+<p>We noticed several failed attempts to sign in to your account, the last one from %s.
+To protect your account, signing in is blocked until %s.</p>
+
+<p>If this was you, you can try again after that time. If it was not, we recommend
+<a href="%s">resetting your password</a>.</p>
+
+<p>Best regards,<br/>
+YC Informatique Team</p>
+</body>
+</html>`, html.EscapeString(lockout.IPAddress), lockedUntil, resetURL)
+
+	if err := e.send(ctx, toEmail, subject, textBody, htmlBody); err != nil {
+		return err
+	}
+
+	e.logger.Info("Account locked email sent successfully via go-mail", "to", toEmail, "locked_until", lockout.LockedUntil)
+	return nil
+}
+
+// send builds a plain-text/HTML message and delivers it with the cached client.
+func (e *ConcreteEmailService) send(ctx context.Context, toEmail, subject, textBody, htmlBody string) error {
+	if e.client == nil {
+		return errors.New("email client not initialized, check SMTP configuration logs")
+	}
+	if e.config.SMTP.Sender == "" {
+		return errors.New("SMTP sender address not configured in config")
+	}
+
+	message := mail.NewMsg()
+	if message == nil {
+		return errors.New("failed to create new email message object")
+	}
+	if err := message.From(e.config.SMTP.Sender); err != nil {
+		e.logger.Error("Failed to set sender address in message", "error", err, "sender", e.config.SMTP.Sender)
+		return fmt.Errorf("failed to set sender address: %w", err)
+	}
+	if err := message.To(toEmail); err != nil {
+		e.logger.Error("Failed to set recipient address in message", "error", err, "to", toEmail)
+		return fmt.Errorf("failed to set recipient address: %w", err)
+	}
+
+	message.Subject(subject)
+	message.SetBodyString(mail.TypeTextPlain, textBody)
+	message.SetBodyString(mail.TypeTextHTML, htmlBody)
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	if err := e.client.DialAndSendWithContext(ctxWithTimeout, message); err != nil {
+		e.logger.Error("Failed to send email via go-mail", "to", toEmail, "subject", subject, "error", err)
+		return fmt.Errorf("failed to send email via go-mail: %w", err)
+	}
+	return nil
+}
+
+// formatPriceCents renders an amount in cents as dinars, e.g. 1234500 -> "12345.00 DZD".
+func formatPriceCents(cents int64) string {
+	return fmt.Sprintf("%d.%02d DZD", cents/100, cents%100)
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/MihoZaki/DzTech/internal/db"
+	"github.com/MihoZaki/DzTech/internal/models"
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	ErrWilayaNotFound = errors.New("wilaya not found")
+	ErrUnknownPlace   = errors.New("province or city is not a known wilaya and commune")
+)
+
+// GeographyService serves the wilaya and commune reference data and matches addresses against it.
+// The data only changes with migrations, so it is loaded once and kept in memory.
+type GeographyService struct {
+	wilayas       []models.Wilaya
+	wilayaByCode  map[int16]*models.Wilaya
+	wilayaByName  map[string]*models.Wilaya // Keyed by normalised name, Arabic name and aliases
+	communes      map[int16][]models.Commune
+	communeByCode map[int32]*models.Commune
+	communeByName map[int16]map[string]*models.Commune // Per wilaya, keyed by normalised name and aliases
+	logger        *slog.Logger
+}
+
+// NewGeographyService loads the reference data and returns a GeographyService serving it.
+func NewGeographyService(ctx context.Context, querier db.Querier, logger *slog.Logger) (*GeographyService, error) {
+	dbWilayas, err := querier.ListWilayas(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load wilayas: %w", err)
+	}
+	dbCommunes, err := querier.ListCommunes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load communes: %w", err)
+	}
+
+	s := &GeographyService{
+		wilayas:       make([]models.Wilaya, len(dbWilayas)),
+		wilayaByCode:  make(map[int16]*models.Wilaya, len(dbWilayas)),
+		wilayaByName:  make(map[string]*models.Wilaya),
+		communes:      make(map[int16][]models.Commune, len(dbWilayas)),
+		communeByCode: make(map[int32]*models.Commune, len(dbCommunes)),
+		communeByName: make(map[int16]map[string]*models.Commune, len(dbWilayas)),
+		logger:        logger,
+	}
+	for i, w := range dbWilayas {
+		s.wilayas[i] = models.Wilaya{Code: w.Code, Name: w.Name, NameAr: w.NameAr}
+		wilaya := &s.wilayas[i]
+		s.wilayaByCode[w.Code] = wilaya
+		for _, name := range append([]string{w.Name, w.NameAr}, w.Aliases...) {
+			s.wilayaByName[normalizePlaceName(name)] = wilaya
+		}
+	}
+	for _, c := range dbCommunes {
+		s.communes[c.WilayaCode] = append(s.communes[c.WilayaCode], models.Commune{Code: c.Code, WilayaCode: c.WilayaCode, Name: c.Name})
+	}
+	// Indexed once the slices have stopped growing, so the pointers stay valid
+	aliases := make(map[int32][]string, len(dbCommunes))
+	for _, c := range dbCommunes {
+		aliases[c.Code] = c.Aliases
+	}
+	for wilayaCode, communes := range s.communes {
+		byName := make(map[string]*models.Commune, len(communes))
+		for i := range communes {
+			commune := &communes[i]
+			s.communeByCode[commune.Code] = commune
+			for _, name := range append([]string{commune.Name}, aliases[commune.Code]...) {
+				byName[normalizePlaceName(name)] = commune
+			}
+		}
+		s.communeByName[wilayaCode] = byName
+	}
+
+	logger.Debug("Geography reference data loaded", "wilayas", len(s.wilayas), "communes", len(dbCommunes))
+	return s, nil
+}
+
+// ListWilayas returns every wilaya, ordered by code.
+func (s *GeographyService) ListWilayas() []models.Wilaya {
+	return s.wilayas
+}
+
+// ListCommunes returns the communes of a wilaya, ordered by name.
+func (s *GeographyService) ListCommunes(wilayaCode int16) ([]models.Commune, error) {
+	if _, ok := s.wilayaByCode[wilayaCode]; !ok {
+		return nil, ErrWilayaNotFound
+	}
+	return s.communes[wilayaCode], nil
+}
+
+// ResolvePlace matches a free-text province and city to a wilaya and one of its communes.
+// The province may be given by code ("16", "16 - Alger"), by French or Arabic name, or by a common
+// alternative spelling ("Algiers"); the city by commune name or code. Case, accents, spaces and
+// punctuation are ignored. The wilaya is nil if the province is unknown, the commune if the city is not
+// a commune of that wilaya.
+func (s *GeographyService) ResolvePlace(province, city string) (*models.Wilaya, *models.Commune) {
+	wilaya := s.resolveWilaya(province)
+	if wilaya == nil {
+		return nil, nil
+	}
+	if code, err := strconv.ParseInt(strings.TrimSpace(city), 10, 32); err == nil {
+		if commune, ok := s.communeByCode[int32(code)]; ok && commune.WilayaCode == wilaya.Code {
+			return wilaya, commune
+		}
+		return wilaya, nil
+	}
+	return wilaya, s.communeByName[wilaya.Code][normalizePlaceName(city)]
+}
+
+func (s *GeographyService) resolveWilaya(province string) *models.Wilaya {
+	province = strings.TrimSpace(province)
+	digits := strings.IndexFunc(province, func(r rune) bool { return r < '0' || r > '9' })
+	if digits == -1 {
+		digits = len(province)
+	}
+	if digits > 0 {
+		code, err := strconv.ParseInt(province[:digits], 10, 16)
+		if err != nil {
+			return nil
+		}
+		return s.wilayaByCode[int16(code)]
+	}
+	return s.wilayaByName[normalizePlaceName(province)]
+}
+
+// normalizePlaceName reduces a place name to its lower-case letters and digits without diacritics,
+// so "Béjaïa", "BEJAIA" and "Bejaia" or "M'Sila" and "Msila" compare equal.
+func normalizePlaceName(name string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(name) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Combining marks left by the decomposition of accented letters
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,606 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+
+	"github.com/MihoZaki/DzTech/internal/db"
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrStockLocationNotFound = errors.New("stock location not found")
+	ErrStockLocationExists   = errors.New("a stock location with this code already exists")
+	// ErrStockHeldAtSeveralLocations rejects setting a product's stock as a single quantity once other locations hold some of it.
+	ErrStockHeldAtSeveralLocations = errors.New("product has stock at several locations; adjust it per location with the inventory stock movement and transfer endpoints")
+)
+
+// InventoryService manages stock locations, transfers and the stock movement ledger.
+// Stock is held per product per location; every change goes through the ledger queries (ApplyStockMovement, SetProductStock),
+// so the stock at a location always equals the sum of its movements there.
+// products.stock_quantity is kept by the database as the sum over active, sellable locations.
+type InventoryService struct {
+	querier db.Querier
+	pool    *pgxpool.Pool // Needed for transfers, which touch two locations atomically
+	cache   *redis.Client
+	alerts  *ProductAlertService
+	logger  *slog.Logger
+}
+
+// NewInventoryService creates a new instance of InventoryService.
+func NewInventoryService(querier db.Querier, pool *pgxpool.Pool, cache *redis.Client, alerts *ProductAlertService, logger *slog.Logger) *InventoryService {
+	return &InventoryService{
+		querier: querier,
+		pool:    pool,
+		cache:   cache,
+		alerts:  alerts,
+		logger:  logger,
+	}
+}
+
+// ListProductStockMovements returns the paginated ledger of a product, newest first.
+func (s *InventoryService) ListProductStockMovements(ctx context.Context, productID uuid.UUID, page, limit int) (*models.PaginatedResponse, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if page <= 0 {
+		page = 1
+	}
+	offset := (page - 1) * limit
+
+	if _, err := s.querier.GetProduct(ctx, productID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch product: %w", err)
+	}
+
+	rows, err := s.querier.ListStockMovementsByProduct(ctx, db.ListStockMovementsByProductParams{
+		ProductID:  productID,
+		PageLimit:  int32(limit),
+		PageOffset: int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock movements: %w", err)
+	}
+
+	movements := make([]models.StockMovement, len(rows))
+	for i, row := range rows {
+		movements[i] = models.StockMovement{
+			ID:                row.ID,
+			ProductID:         row.ProductID,
+			LocationID:        row.LocationID,
+			LocationCode:      row.LocationCode,
+			Delta:             int(row.Delta),
+			ResultingQuantity: int(row.ResultingQuantity),
+			Reason:            row.Reason,
+			OrderID:           uuidPtrOrNil(row.OrderID),
+			TransferID:        uuidPtrOrNil(row.TransferID),
+			ActorID:           uuidPtrOrNil(row.ActorID),
+			ActorEmail:        row.ActorEmail,
+			Note:              row.Note,
+			CreatedAt:         row.CreatedAt.Time,
+		}
+	}
+
+	total, err := s.querier.CountStockMovementsByProduct(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count stock movements: %w", err)
+	}
+
+	return &models.PaginatedResponse{
+		Data:       movements,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}, nil
+}
+
+// RecordStockMovement records an admin stock movement (adjustment, return or restock) at a location and applies it.
+// The default location is used if none is given.
+// Returns ErrInsufficientStock if the movement would make the stock at the location negative.
+func (s *InventoryService) RecordStockMovement(ctx context.Context, productID uuid.UUID, req models.CreateStockMovementRequest) (*models.StockMovement, error) {
+	product, err := s.querier.GetProduct(ctx, productID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch product: %w", err)
+	}
+
+	var locationID uuid.UUID
+	if req.LocationID != nil {
+		if _, err := s.querier.GetStockLocation(ctx, *req.LocationID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrStockLocationNotFound
+			}
+			return nil, fmt.Errorf("failed to fetch stock location: %w", err)
+		}
+		locationID = *req.LocationID
+	} else {
+		locationID, err = defaultStockLocationID(ctx, s.querier)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	orderID := uuid.Nil
+	if req.OrderID != nil {
+		if _, err := s.querier.GetOrder(ctx, *req.OrderID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrOrderNotFound
+			}
+			return nil, fmt.Errorf("failed to fetch order: %w", err)
+		}
+		orderID = *req.OrderID
+	}
+
+	movement, err := applyStockMovement(ctx, s.querier, db.ApplyStockMovementParams{
+		ProductID:  productID,
+		LocationID: locationID,
+		Delta:      int32(req.Delta),
+		Reason:     req.Reason,
+		OrderID:    orderID,
+		ActorID:    actorIDFromContext(ctx),
+		Note:       req.Note,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInsufficientStock
+		}
+		return nil, fmt.Errorf("failed to record stock movement: %w", err)
+	}
+
+	s.invalidateProductCache(ctx, product)
+	if movement.Delta > 0 {
+		s.alerts.CheckProductAlertsAsync(productID)
+	}
+
+	s.logger.Info("Stock movement recorded", "product_id", productID, "location_id", locationID, "delta", movement.Delta, "reason", movement.Reason, "resulting_quantity", movement.ResultingQuantity)
+
+	result := toStockMovementModel(movement)
+	return &result, nil
+}
+
+// TransferStock moves stock of a product from one location to another.
+// Both ledger entries are linked to the transfer and written in one transaction.
+// Returns ErrInsufficientStock if the source location does not hold enough stock.
+func (s *InventoryService) TransferStock(ctx context.Context, productID uuid.UUID, req models.CreateStockTransferRequest) (*models.StockTransfer, error) {
+	product, err := s.querier.GetProduct(ctx, productID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch product: %w", err)
+	}
+	for _, locationID := range []uuid.UUID{req.FromLocationID, req.ToLocationID} {
+		if _, err := s.querier.GetStockLocation(ctx, locationID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrStockLocationNotFound
+			}
+			return nil, fmt.Errorf("failed to fetch stock location: %w", err)
+		}
+	}
+
+	queries, ok := s.querier.(*db.Queries)
+	if !ok {
+		return nil, errors.New("querier type assertion to *db.Queries failed, cannot create transactional querier")
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction for stock transfer: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			s.logger.Error("Error during transaction rollback in TransferStock", "error", err)
+		}
+	}()
+	txQuerier := queries.WithTx(tx)
+
+	actorID := actorIDFromContext(ctx)
+	transfer, err := txQuerier.CreateStockTransfer(ctx, db.CreateStockTransferParams{
+		ProductID:      productID,
+		FromLocationID: req.FromLocationID,
+		ToLocationID:   req.ToLocationID,
+		Quantity:       int32(req.Quantity),
+		ActorID:        actorID,
+		Note:           req.Note,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stock transfer: %w", err)
+	}
+
+	out, err := applyStockMovement(ctx, txQuerier, db.ApplyStockMovementParams{
+		ProductID:  productID,
+		LocationID: req.FromLocationID,
+		Delta:      -int32(req.Quantity),
+		Reason:     models.StockReasonTransferOut,
+		ActorID:    actorID,
+		TransferID: transfer.ID,
+		Note:       req.Note,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInsufficientStock
+		}
+		return nil, fmt.Errorf("failed to take stock from source location: %w", err)
+	}
+	in, err := applyStockMovement(ctx, txQuerier, db.ApplyStockMovementParams{
+		ProductID:  productID,
+		LocationID: req.ToLocationID,
+		Delta:      int32(req.Quantity),
+		Reason:     models.StockReasonTransferIn,
+		ActorID:    actorID,
+		TransferID: transfer.ID,
+		Note:       req.Note,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add stock to destination location: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit stock transfer: %w", err)
+	}
+
+	// A transfer between a sellable and a non-sellable location changes the public stock
+	s.invalidateProductCache(ctx, product)
+	s.alerts.CheckProductAlertsAsync(productID)
+
+	s.logger.Info("Stock transferred", "product_id", productID, "from_location_id", req.FromLocationID, "to_location_id", req.ToLocationID, "quantity", req.Quantity)
+
+	return &models.StockTransfer{
+		ID:             transfer.ID,
+		ProductID:      transfer.ProductID,
+		FromLocationID: transfer.FromLocationID,
+		ToLocationID:   transfer.ToLocationID,
+		Quantity:       int(transfer.Quantity),
+		Note:           transfer.Note,
+		Movements:      []models.StockMovement{toStockMovementModel(out), toStockMovementModel(in)},
+		CreatedAt:      transfer.CreatedAt.Time,
+	}, nil
+}
+
+// ListProductStockLevels returns the stock of a product at every location.
+func (s *InventoryService) ListProductStockLevels(ctx context.Context, productID uuid.UUID) ([]models.ProductStockLevel, error) {
+	if _, err := s.querier.GetProduct(ctx, productID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch product: %w", err)
+	}
+
+	rows, err := s.querier.ListProductStockLevels(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product stock levels: %w", err)
+	}
+
+	levels := make([]models.ProductStockLevel, len(rows))
+	for i, row := range rows {
+		levels[i] = models.ProductStockLevel{
+			LocationID:   row.LocationID,
+			LocationCode: row.LocationCode,
+			LocationName: row.LocationName,
+			IsSellable:   row.IsSellable,
+			IsActive:     row.IsActive,
+			Quantity:     int(row.Quantity),
+		}
+	}
+	return levels, nil
+}
+
+// ListStockLocations returns all stock locations in allocation order.
+func (s *InventoryService) ListStockLocations(ctx context.Context) ([]models.StockLocation, error) {
+	rows, err := s.querier.ListStockLocations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock locations: %w", err)
+	}
+
+	locations := make([]models.StockLocation, len(rows))
+	for i, row := range rows {
+		locations[i] = toStockLocationModel(row)
+	}
+	return locations, nil
+}
+
+// CreateStockLocation creates a new stock location. New locations are sellable and active unless specified otherwise.
+func (s *InventoryService) CreateStockLocation(ctx context.Context, req models.CreateStockLocationRequest) (*models.StockLocation, error) {
+	isSellable, isActive := true, true
+	if req.IsSellable != nil {
+		isSellable = *req.IsSellable
+	}
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	location, err := s.querier.CreateStockLocation(ctx, db.CreateStockLocationParams{
+		Code:       req.Code,
+		Name:       req.Name,
+		IsSellable: isSellable,
+		IsActive:   isActive,
+		Priority:   int32(req.Priority),
+	})
+	if err != nil {
+		if IsUniqueViolation(err, "stock_locations_code_key") {
+			return nil, ErrStockLocationExists
+		}
+		return nil, fmt.Errorf("failed to create stock location: %w", err)
+	}
+
+	result := toStockLocationModel(location)
+	return &result, nil
+}
+
+// UpdateStockLocation updates a stock location.
+// Changing is_sellable or is_active changes the public stock of the products held there,
+// so their cache entries are invalidated and their alerts checked.
+func (s *InventoryService) UpdateStockLocation(ctx context.Context, locationID uuid.UUID, req models.UpdateStockLocationRequest) (*models.StockLocation, error) {
+	params := db.UpdateStockLocationParams{
+		ID:         locationID,
+		Name:       req.Name,
+		IsSellable: req.IsSellable,
+		IsActive:   req.IsActive,
+	}
+	if req.Priority != nil {
+		priority := int32(*req.Priority)
+		params.Priority = &priority
+	}
+
+	location, err := s.querier.UpdateStockLocation(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrStockLocationNotFound
+		}
+		return nil, fmt.Errorf("failed to update stock location: %w", err)
+	}
+
+	if req.IsSellable != nil || req.IsActive != nil {
+		productIDs, err := s.querier.ListProductIDsByStockLocation(ctx, locationID)
+		if err != nil {
+			s.logger.Error("Failed to list products held at stock location for cache invalidation", "location_id", locationID, "error", err)
+		}
+		for _, productID := range productIDs {
+			if product, err := s.querier.GetProduct(ctx, productID); err == nil {
+				s.invalidateProductCache(ctx, product)
+			}
+		}
+		s.alerts.CheckProductAlertsAsync(productIDs...)
+	}
+
+	result := toStockLocationModel(location)
+	return &result, nil
+}
+
+// GetProductStockReconciliation compares a product's stock at each location with the sum of its ledger movements there.
+func (s *InventoryService) GetProductStockReconciliation(ctx context.Context, productID uuid.UUID) ([]models.StockReconciliation, error) {
+	if _, err := s.querier.GetProduct(ctx, productID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch product: %w", err)
+	}
+
+	rows, err := s.querier.GetProductStockReconciliation(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile product stock: %w", err)
+	}
+
+	reconciliation := make([]models.StockReconciliation, len(rows))
+	for i, row := range rows {
+		reconciliation[i] = toStockReconciliation(row.ProductID, row.ProductName, row.LocationID, row.LocationCode, row.Quantity, row.LedgerQuantity)
+	}
+	return reconciliation, nil
+}
+
+// ListStockDiscrepancies lists every product stock level that does not match its ledger.
+// An empty list means the whole inventory reconciles.
+func (s *InventoryService) ListStockDiscrepancies(ctx context.Context) ([]models.StockReconciliation, error) {
+	rows, err := s.querier.ListStockDiscrepancies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock discrepancies: %w", err)
+	}
+
+	discrepancies := make([]models.StockReconciliation, len(rows))
+	for i, row := range rows {
+		discrepancies[i] = toStockReconciliation(row.ProductID, row.ProductName, row.LocationID, row.LocationCode, row.Quantity, row.LedgerQuantity)
+	}
+	return discrepancies, nil
+}
+
+// invalidateProductCache removes the cached product entries after its stock changed.
+func (s *InventoryService) invalidateProductCache(ctx context.Context, product db.Product) {
+	keys := []string{
+		fmt.Sprintf(CacheKeyProductByID, product.ID.String()),
+		fmt.Sprintf(CacheKeyProductBySlug, product.Slug),
+	}
+	if err := s.cache.Del(ctx, keys...).Err(); err != nil {
+		s.logger.Error("Failed to invalidate product cache after stock movement", "product_id", product.ID, "keys", keys, "error", err)
+	}
+}
+
+func toStockReconciliation(productID uuid.UUID, productName string, locationID uuid.UUID, locationCode string, quantity int32, ledgerQuantity int64) models.StockReconciliation {
+	difference := int64(quantity) - ledgerQuantity
+	return models.StockReconciliation{
+		ProductID:      productID,
+		ProductName:    productName,
+		LocationID:     locationID,
+		LocationCode:   locationCode,
+		Quantity:       int(quantity),
+		LedgerQuantity: ledgerQuantity,
+		Difference:     difference,
+		Consistent:     difference == 0,
+	}
+}
+
+func toStockLocationModel(location db.StockLocation) models.StockLocation {
+	return models.StockLocation{
+		ID:         location.ID,
+		Code:       location.Code,
+		Name:       location.Name,
+		IsSellable: location.IsSellable,
+		IsActive:   location.IsActive,
+		IsDefault:  location.IsDefault,
+		Priority:   int(location.Priority),
+		CreatedAt:  location.CreatedAt.Time,
+		UpdatedAt:  location.UpdatedAt.Time,
+	}
+}
+
+func toStockMovementModel(movement db.StockMovement) models.StockMovement {
+	return models.StockMovement{
+		ID:                movement.ID,
+		ProductID:         movement.ProductID,
+		LocationID:        movement.LocationID,
+		Delta:             int(movement.Delta),
+		ResultingQuantity: int(movement.ResultingQuantity),
+		Reason:            movement.Reason,
+		OrderID:           uuidPtrOrNil(movement.OrderID),
+		TransferID:        uuidPtrOrNil(movement.TransferID),
+		ActorID:           uuidPtrOrNil(movement.ActorID),
+		Note:              movement.Note,
+		CreatedAt:         movement.CreatedAt.Time,
+	}
+}
+
+// applyStockMovement changes the stock of a product at a location and records it in the ledger.
+// The stock level is created first if the product has never been stocked there.
+// Returns pgx.ErrNoRows if the stock at the location would become negative.
+func applyStockMovement(ctx context.Context, q db.Querier, params db.ApplyStockMovementParams) (db.StockMovement, error) {
+	if err := q.EnsureProductStockLevel(ctx, db.EnsureProductStockLevelParams{
+		ProductID:  params.ProductID,
+		LocationID: params.LocationID,
+	}); err != nil {
+		return db.StockMovement{}, fmt.Errorf("failed to create stock level: %w", err)
+	}
+	return q.ApplyStockMovement(ctx, params)
+}
+
+// defaultStockLocationID returns the location used when no location is given.
+func defaultStockLocationID(ctx context.Context, q db.Querier) (uuid.UUID, error) {
+	location, err := q.GetDefaultStockLocation(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to fetch default stock location: %w", err)
+	}
+	return location.ID, nil
+}
+
+// stockAllocation is the quantity of an order item taken from one location.
+type stockAllocation struct {
+	LocationID uuid.UUID
+	Quantity   int32
+}
+
+// planStockAllocation splits a quantity over the allocatable stock levels, which are in allocation order.
+// A single location holding the whole quantity is preferred over splitting the item across locations.
+// It reports false if the locations together do not hold enough stock.
+func planStockAllocation(levels []db.ListAllocatableStockLevelsForUpdateRow, quantity int32) ([]stockAllocation, bool) {
+	for _, level := range levels {
+		if level.Quantity >= quantity {
+			return []stockAllocation{{LocationID: level.LocationID, Quantity: quantity}}, true
+		}
+	}
+
+	var allocations []stockAllocation
+	remaining := quantity
+	for _, level := range levels {
+		if remaining == 0 {
+			break
+		}
+		take := min(level.Quantity, remaining)
+		allocations = append(allocations, stockAllocation{LocationID: level.LocationID, Quantity: take})
+		remaining -= take
+	}
+	return allocations, remaining == 0
+}
+
+// allocateOrderItemStock takes the stock of an order item from the sellable locations and records
+// the allocation, so that a cancellation can return it to the same locations. Must run in a transaction.
+func allocateOrderItemStock(ctx context.Context, q db.Querier, item db.OrderItem, actorID uuid.UUID) ([]db.StockMovement, error) {
+	levels, err := q.ListAllocatableStockLevelsForUpdate(ctx, item.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock levels: %w", err)
+	}
+
+	allocations, ok := planStockAllocation(levels, item.Quantity)
+	if !ok {
+		return nil, ErrInsufficientStock
+	}
+
+	movements := make([]db.StockMovement, 0, len(allocations))
+	for _, allocation := range allocations {
+		movement, err := applyStockMovement(ctx, q, db.ApplyStockMovementParams{
+			ProductID:  item.ProductID,
+			LocationID: allocation.LocationID,
+			Delta:      -allocation.Quantity,
+			Reason:     models.StockReasonSale,
+			OrderID:    item.OrderID,
+			ActorID:    actorID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrInsufficientStock
+			}
+			return nil, err
+		}
+		if err := q.CreateOrderItemAllocation(ctx, db.CreateOrderItemAllocationParams{
+			OrderID:     item.OrderID,
+			OrderItemID: item.ID,
+			ProductID:   item.ProductID,
+			LocationID:  allocation.LocationID,
+			Quantity:    allocation.Quantity,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to record order item allocation: %w", err)
+		}
+		movements = append(movements, movement)
+	}
+	return movements, nil
+}
+
+// releaseOrderStock returns the stock allocated to an order to the locations it was taken from.
+// Must run in a transaction.
+func releaseOrderStock(ctx context.Context, q db.Querier, orderID uuid.UUID, actorID uuid.UUID) ([]db.StockMovement, error) {
+	allocations, err := q.ListOrderItemAllocations(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list order allocations: %w", err)
+	}
+
+	movements := make([]db.StockMovement, 0, len(allocations))
+	for _, allocation := range allocations {
+		movement, err := applyStockMovement(ctx, q, db.ApplyStockMovementParams{
+			ProductID:  allocation.ProductID,
+			LocationID: allocation.LocationID,
+			Delta:      allocation.Quantity,
+			Reason:     models.StockReasonCancellation,
+			OrderID:    orderID,
+			ActorID:    actorID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to release stock for product %s: %w", allocation.ProductID, err)
+		}
+		movements = append(movements, movement)
+	}
+	return movements, nil
+}
+
+// actorIDFromContext returns the ID of the authenticated user for the ledger, or uuid.Nil (stored as NULL).
+func actorIDFromContext(ctx context.Context) uuid.UUID {
+	if user, ok := models.GetUserFromContext(ctx); ok && user != nil {
+		return user.ID
+	}
+	return uuid.Nil
+}
+
+// uuidPtrOrNil converts a zero UUID (a NULL column) to nil.
+func uuidPtrOrNil(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/MihoZaki/DzTech/internal/config"
+	"github.com/MihoZaki/DzTech/internal/db"
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	CacheKeyLoginAccountFailures = "login:failures:account:%s" // Format: login:failures:account:{email_hash}
+	CacheKeyLoginAccountBackoff  = "login:backoff:account:%s"  // Format: login:backoff:account:{email_hash}
+	CacheKeyLoginAccountLocked   = "login:locked:account:%s"   // Format: login:locked:account:{email_hash}
+	CacheKeyLoginIPFailures      = "login:failures:ip:%s"      // Format: login:failures:ip:{ip_address}
+	CacheKeyLoginIPLocked        = "login:locked:ip:%s"        // Format: login:locked:ip:{ip_address}
+
+	accountLockedEmailTimeout = 30 * time.Second
+)
+
+// LoginThrottledError is returned when a login is refused because of earlier failed attempts,
+// either for the account or for the client's IP address.
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return fmt.Sprintf("too many failed login attempts, retry after %s", e.RetryAfter.Round(time.Second))
+}
+
+// LoginThrottleService tracks failed logins in Redis and slows down or locks out brute-force attempts.
+// Accounts are keyed by a hash of the submitted email, whether or not it belongs to a user,
+// so the responses do not reveal which emails are registered.
+type LoginThrottleService struct {
+	querier      db.Querier
+	cache        *redis.Client
+	emailService EmailService // Warns users whose account gets locked
+	cfg          config.LoginProtection
+	logger       *slog.Logger
+}
+
+// NewLoginThrottleService creates a new instance of LoginThrottleService.
+func NewLoginThrottleService(querier db.Querier, cache *redis.Client, emailService EmailService, cfg config.LoginProtection, logger *slog.Logger) *LoginThrottleService {
+	return &LoginThrottleService{
+		querier:      querier,
+		cache:        cache,
+		emailService: emailService,
+		cfg:          cfg,
+		logger:       logger,
+	}
+}
+
+// Check returns a *LoginThrottledError if logins for email or from ip are currently locked out or backed off.
+// Redis errors are logged and the login is allowed, so an outage of the cache does not lock everyone out.
+func (s *LoginThrottleService) Check(ctx context.Context, email, ip string) error {
+	account := accountKey(email)
+	keys := []string{
+		fmt.Sprintf(CacheKeyLoginAccountLocked, account),
+		fmt.Sprintf(CacheKeyLoginAccountBackoff, account),
+	}
+	if ip != "" {
+		keys = append(keys, fmt.Sprintf(CacheKeyLoginIPLocked, ip))
+	}
+
+	cmds := make([]*redis.DurationCmd, len(keys))
+	_, err := s.cache.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.PTTL(ctx, key)
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("Redis error checking login throttle", "error", err)
+		return nil
+	}
+
+	var retryAfter time.Duration
+	for _, cmd := range cmds {
+		// PTTL is negative for keys that do not exist
+		if ttl := cmd.Val(); ttl > retryAfter {
+			retryAfter = ttl
+		}
+	}
+	if retryAfter > 0 {
+		return &LoginThrottledError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// RecordFailure counts a failed login for email and ip. Each failure backs the account off for twice as
+// long as the previous one; reaching the configured maximum locks the account (or IP) for the lockout
+// duration, and the owner of a locked account is notified by email.
+func (s *LoginThrottleService) RecordFailure(ctx context.Context, email, ip string) {
+	lockout := time.Duration(s.cfg.LockoutMinutes) * time.Minute
+	account := accountKey(email)
+
+	failures, err := s.incrementFailures(ctx, fmt.Sprintf(CacheKeyLoginAccountFailures, account), lockout)
+	if err != nil {
+		s.logger.Error("Redis error recording failed login", "error", err)
+	} else if failures >= int64(s.cfg.MaxAccountFailures) {
+		s.lock(ctx, fmt.Sprintf(CacheKeyLoginAccountLocked, account), lockout,
+			fmt.Sprintf(CacheKeyLoginAccountFailures, account), fmt.Sprintf(CacheKeyLoginAccountBackoff, account))
+		s.logger.Warn("Account locked after too many failed logins", "account", account, "ip_address", ip, "failures", failures)
+		s.notifyLocked(email, models.AccountLockoutNotification{
+			LockedUntil: time.Now().Add(lockout),
+			IPAddress:   ip,
+		})
+	} else if backoff := s.backoff(failures, lockout); backoff > 0 {
+		if err := s.cache.Set(ctx, fmt.Sprintf(CacheKeyLoginAccountBackoff, account), 1, backoff).Err(); err != nil {
+			s.logger.Error("Failed to set login backoff", "error", err)
+		}
+	}
+
+	if ip == "" {
+		return
+	}
+	failures, err = s.incrementFailures(ctx, fmt.Sprintf(CacheKeyLoginIPFailures, ip), lockout)
+	if err != nil {
+		s.logger.Error("Redis error recording failed login", "error", err)
+		return
+	}
+	if failures >= int64(s.cfg.MaxIPFailures) {
+		s.lock(ctx, fmt.Sprintf(CacheKeyLoginIPLocked, ip), lockout, fmt.Sprintf(CacheKeyLoginIPFailures, ip))
+		s.logger.Warn("IP address locked after too many failed logins", "ip_address", ip, "failures", failures)
+	}
+}
+
+// RecordSuccess clears the failed logins of an account after a successful login.
+// The IP counter is kept, so signing in to one's own account does not reset an attack on others.
+func (s *LoginThrottleService) RecordSuccess(ctx context.Context, email string) {
+	account := accountKey(email)
+	if err := s.cache.Del(ctx,
+		fmt.Sprintf(CacheKeyLoginAccountFailures, account),
+		fmt.Sprintf(CacheKeyLoginAccountBackoff, account),
+	).Err(); err != nil {
+		s.logger.Error("Failed to clear failed logins", "error", err)
+	}
+}
+
+// Unlock lifts the lockout and clears the failed logins of an account.
+func (s *LoginThrottleService) Unlock(ctx context.Context, email string) error {
+	account := accountKey(email)
+	if err := s.cache.Del(ctx,
+		fmt.Sprintf(CacheKeyLoginAccountLocked, account),
+		fmt.Sprintf(CacheKeyLoginAccountFailures, account),
+		fmt.Sprintf(CacheKeyLoginAccountBackoff, account),
+	).Err(); err != nil {
+		return fmt.Errorf("failed to unlock account: %w", err)
+	}
+	return nil
+}
+
+// incrementFailures increments a failure counter. Counters expire after window,
+// so occasional typos spread over time never add up to a lockout.
+func (s *LoginThrottleService) incrementFailures(ctx context.Context, key string, window time.Duration) (int64, error) {
+	failures, err := s.cache.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if failures == 1 {
+		if err := s.cache.Expire(ctx, key, window).Err(); err != nil {
+			s.logger.Error("Failed to set expiry of failed login counter", "key", key, "error", err)
+		}
+	}
+	return failures, nil
+}
+
+func (s *LoginThrottleService) lock(ctx context.Context, lockKey string, lockout time.Duration, clearKeys ...string) {
+	if err := s.cache.Set(ctx, lockKey, 1, lockout).Err(); err != nil {
+		s.logger.Error("Failed to set login lockout", "key", lockKey, "error", err)
+		return
+	}
+	if err := s.cache.Del(ctx, clearKeys...).Err(); err != nil {
+		s.logger.Error("Failed to clear failed login counters", "error", err)
+	}
+}
+
+// backoff returns the delay imposed after the given number of consecutive failures: the base delay,
+// doubled for each failure after the first and never longer than the lockout.
+func (s *LoginThrottleService) backoff(failures int64, lockout time.Duration) time.Duration {
+	if s.cfg.BackoffBaseSeconds <= 0 || failures <= 0 {
+		return 0
+	}
+	backoff := time.Duration(s.cfg.BackoffBaseSeconds) * time.Second
+	for i := int64(1); i < failures && backoff < lockout; i++ {
+		backoff *= 2
+	}
+	return min(backoff, lockout)
+}
+
+// notifyLocked emails the owner of a locked account. Nothing is sent for emails without an account.
+func (s *LoginThrottleService) notifyLocked(email string, notification models.AccountLockoutNotification) {
+	if s.emailService == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), accountLockedEmailTimeout)
+		defer cancel()
+		user, err := s.querier.GetUserByEmail(ctx, email)
+		if err != nil {
+			if !errors.Is(err, pgx.ErrNoRows) {
+				s.logger.Error("Failed to look up user of locked account", "error", err)
+			}
+			return
+		}
+		if err := s.emailService.SendAccountLockedEmail(ctx, user.Email, notification); err != nil {
+			s.logger.Error("Failed to send account locked email", "user_id", user.ID, "error", err)
+		}
+	}()
+}
+
+// accountKey identifies an account in Redis keys without storing the email in clear.
+func accountKey(email string) string {
+	hash := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,454 @@
+package services
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MihoZaki/DzTech/internal/config"
+	"github.com/MihoZaki/DzTech/internal/db"
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/MihoZaki/DzTech/internal/utils"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	CacheKeyOIDCState = "auth:oidc_state:%s" // Format: auth:oidc_state:{state}
+
+	oidcStateTTL            = 10 * time.Minute
+	oidcHTTPTimeout         = 10 * time.Second
+	oidcKeysRefreshInterval = time.Minute // Minimum time between fetches of the provider's keys
+	oidcMaxResponseBytes    = 1 << 20
+)
+
+var (
+	ErrOIDCNotConfigured    = errors.New("social login is not configured")
+	ErrInvalidOIDCState     = errors.New("invalid or expired social login state")
+	ErrOIDCProvider         = errors.New("identity provider request failed")
+	ErrOIDCEmailNotVerified = errors.New("the identity provider did not report a verified email")
+	ErrAccountDeactivated   = errors.New("account is deactivated")
+)
+
+// OIDCService signs users in through an OpenID Connect provider using the authorization code flow with PKCE.
+// External identities are kept in user_identities: a new identity is linked to the account with the same
+// verified email, or gets a new account without a password.
+type OIDCService struct {
+	querier     db.Querier
+	pool        *pgxpool.Pool // Need for transactions
+	userService *UserService
+	cache       *redis.Client // Holds the state of logins in progress
+	cfg         config.OIDC
+	httpClient  *http.Client
+	logger      *slog.Logger
+
+	mu            sync.Mutex // Guards the provider metadata and keys, fetched on first use
+	discovery     *oidcDiscovery
+	keys          map[string]crypto.PublicKey
+	keysFetchedAt time.Time
+}
+
+// oidcDiscovery holds the fields used from the provider's /.well-known/openid-configuration.
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// oidcLoginState is stored in Redis between the redirect to the provider and the callback.
+type oidcLoginState struct {
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+type oidcIDTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce         string   `json:"nonce"`
+	Email         string   `json:"email"`
+	EmailVerified oidcBool `json:"email_verified"`
+	Name          string   `json:"name"`
+}
+
+// oidcBool accepts both true and "true": some providers send email_verified as a string.
+type oidcBool bool
+
+func (b *oidcBool) UnmarshalJSON(data []byte) error {
+	*b = oidcBool(strings.Trim(string(data), `"`) == "true")
+	return nil
+}
+
+// NewOIDCService creates a new instance of OIDCService.
+func NewOIDCService(querier db.Querier, pool *pgxpool.Pool, userService *UserService, cache *redis.Client, cfg config.OIDC, logger *slog.Logger) *OIDCService {
+	return &OIDCService{
+		querier:     querier,
+		pool:        pool,
+		userService: userService,
+		cache:       cache,
+		cfg:         cfg,
+		httpClient:  &http.Client{Timeout: oidcHTTPTimeout},
+		logger:      logger,
+	}
+}
+
+// AuthorizationURL starts a login: it stores a new state, nonce and PKCE verifier and returns the provider URL
+// to send the user to, along with the state the provider will redirect back with.
+func (s *OIDCService) AuthorizationURL(ctx context.Context) (string, string, error) {
+	if s.cfg.IssuerURL == "" {
+		return "", "", ErrOIDCNotConfigured
+	}
+	discovery, err := s.providerMetadata(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	state, err := randomURLToken()
+	if err != nil {
+		return "", "", err
+	}
+	loginState := oidcLoginState{}
+	if loginState.Nonce, err = randomURLToken(); err != nil {
+		return "", "", err
+	}
+	if loginState.CodeVerifier, err = randomURLToken(); err != nil {
+		return "", "", err
+	}
+	data, err := json.Marshal(loginState)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode login state: %w", err)
+	}
+	if err := s.cache.Set(ctx, fmt.Sprintf(CacheKeyOIDCState, state), data, oidcStateTTL).Err(); err != nil {
+		return "", "", fmt.Errorf("failed to store login state: %w", err)
+	}
+
+	challenge := sha256.Sum256([]byte(loginState.CodeVerifier))
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", s.cfg.ClientID)
+	params.Set("redirect_uri", s.cfg.RedirectURL)
+	params.Set("scope", "openid email profile")
+	params.Set("state", state)
+	params.Set("nonce", loginState.Nonce)
+	params.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	params.Set("code_challenge_method", "S256")
+
+	authURL := discovery.AuthorizationEndpoint
+	if strings.Contains(authURL, "?") {
+		authURL += "&" + params.Encode()
+	} else {
+		authURL += "?" + params.Encode()
+	}
+	return authURL, state, nil
+}
+
+// Authenticate completes a login: it exchanges the code for an ID token, verifies it and returns the user
+// the identity belongs to, linking or creating the account on first use.
+func (s *OIDCService) Authenticate(ctx context.Context, code, state string) (*models.User, error) {
+	if s.cfg.IssuerURL == "" {
+		return nil, ErrOIDCNotConfigured
+	}
+
+	// The state is single-use, so a callback cannot be replayed
+	data, err := s.cache.GetDel(ctx, fmt.Sprintf(CacheKeyOIDCState, state)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrInvalidOIDCState
+		}
+		return nil, fmt.Errorf("failed to load login state: %w", err)
+	}
+	var loginState oidcLoginState
+	if err := json.Unmarshal(data, &loginState); err != nil {
+		return nil, fmt.Errorf("failed to decode login state: %w", err)
+	}
+
+	rawIDToken, err := s.exchangeCode(ctx, code, loginState.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := s.verifyIDToken(ctx, rawIDToken, loginState.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	return s.resolveUser(ctx, claims)
+}
+
+// resolveUser finds the user of a verified identity. Unknown identities are linked to the account with the
+// same email, or get a new account, but only if the provider verified the email.
+func (s *OIDCService) resolveUser(ctx context.Context, claims *oidcIDTokenClaims) (*models.User, error) {
+	identity, err := s.querier.GetUserIdentity(ctx, db.GetUserIdentityParams{
+		Provider: s.cfg.Provider,
+		Subject:  claims.Subject,
+	})
+	if err == nil {
+		if identity.UserDeletedAt.Valid {
+			return nil, ErrAccountDeactivated
+		}
+		if err := s.querier.TouchUserIdentity(ctx, identity.ID); err != nil {
+			s.logger.Error("Failed to update last login of identity", "identity_id", identity.ID, "error", err)
+		}
+		return s.userService.GetByID(ctx, identity.UserID.String())
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to fetch identity: %w", err)
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, ErrOIDCEmailNotVerified
+	}
+	email := claims.Email
+
+	existing, err := s.querier.GetUserByEmail(ctx, email)
+	if err == nil {
+		if err := s.querier.CreateUserIdentity(ctx, db.CreateUserIdentityParams{
+			UserID:   existing.ID,
+			Provider: s.cfg.Provider,
+			Subject:  claims.Subject,
+			Email:    &email,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to link identity: %w", err)
+		}
+		s.logger.Info("External identity linked to existing user", "user_id", existing.ID, "provider", s.cfg.Provider)
+		return s.userService.GetByID(ctx, existing.ID.String())
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to fetch user by email: %w", err)
+	}
+
+	return s.createUserWithIdentity(ctx, email, claims)
+}
+
+func (s *OIDCService) createUserWithIdentity(ctx context.Context, email string, claims *oidcIDTokenClaims) (*models.User, error) {
+	queries, ok := s.querier.(*db.Queries)
+	if !ok {
+		return nil, errors.New("querier type assertion to *db.Queries failed, cannot create transactional querier")
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction for social sign-up: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			s.logger.Error("Error during social sign-up transaction rollback", "error", err)
+		}
+	}()
+
+	txQuerier := queries.WithTx(tx)
+
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	fullName := claims.Name
+	user, err := txQuerier.CreateUser(ctx, db.CreateUserParams{
+		Email:     email,
+		FullName:  &fullName,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		// GetUserByEmail only sees active users, so the email belongs to a deactivated account
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "users_email_key" {
+			return nil, ErrAccountDeactivated
+		}
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	if err := txQuerier.CreateUserIdentity(ctx, db.CreateUserIdentityParams{
+		UserID:   user.ID,
+		Provider: s.cfg.Provider,
+		Subject:  claims.Subject,
+		Email:    &email,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to link identity: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit social sign-up transaction: %w", err)
+	}
+	s.logger.Info("User registered through social login", "user_id", user.ID, "provider", s.cfg.Provider)
+	return s.userService.GetByID(ctx, user.ID.String())
+}
+
+// exchangeCode redeems an authorization code at the provider's token endpoint and returns the ID token.
+func (s *OIDCService) exchangeCode(ctx context.Context, code, codeVerifier string) (string, error) {
+	discovery, err := s.providerMetadata(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", s.cfg.RedirectURL)
+	form.Set("client_id", s.cfg.ClientID)
+	form.Set("client_secret", s.cfg.ClientSecret)
+	form.Set("code_verifier", codeVerifier)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var tokenResponse struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := s.doJSON(req, &tokenResponse); err != nil {
+		return "", fmt.Errorf("%w: code exchange: %v", ErrOIDCProvider, err)
+	}
+	if tokenResponse.IDToken == "" {
+		return "", fmt.Errorf("%w: token response has no id_token", ErrOIDCProvider)
+	}
+	return tokenResponse.IDToken, nil
+}
+
+// verifyIDToken checks the signature, issuer, audience, expiry and nonce of an ID token.
+func (s *OIDCService) verifyIDToken(ctx context.Context, rawIDToken, nonce string) (*oidcIDTokenClaims, error) {
+	discovery, err := s.providerMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &oidcIDTokenClaims{}
+	_, err = jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return s.providerKey(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "EdDSA"}),
+		jwt.WithIssuer(discovery.Issuer),
+		jwt.WithAudience(s.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid ID token: %v", ErrOIDCProvider, err)
+	}
+	if claims.Subject == "" || claims.Nonce != nonce {
+		return nil, fmt.Errorf("%w: ID token subject or nonce mismatch", ErrOIDCProvider)
+	}
+	return claims, nil
+}
+
+// providerMetadata fetches the provider's discovery document once and keeps it.
+func (s *OIDCService) providerMetadata(ctx context.Context) (*oidcDiscovery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.discovery != nil {
+		return s.discovery, nil
+	}
+
+	issuer := strings.TrimSuffix(s.cfg.IssuerURL, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build discovery request: %w", err)
+	}
+	var discovery oidcDiscovery
+	if err := s.doJSON(req, &discovery); err != nil {
+		return nil, fmt.Errorf("%w: discovery: %v", ErrOIDCProvider, err)
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("%w: discovery issuer %q does not match %q", ErrOIDCProvider, discovery.Issuer, s.cfg.IssuerURL)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, fmt.Errorf("%w: discovery document is incomplete", ErrOIDCProvider)
+	}
+	s.discovery = &discovery
+	return s.discovery, nil
+}
+
+// providerKey returns the provider's signing key with the given kid. The keys are refetched when an
+// unknown kid shows up, since providers rotate them, but not more than once per refresh interval.
+func (s *OIDCService) providerKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	discovery, err := s.providerMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if key, ok := s.lookupProviderKey(kid); ok {
+		return key, nil
+	}
+	if time.Since(s.keysFetchedAt) < oidcKeysRefreshInterval {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discovery.JWKSURI, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build JWKS request: %w", err)
+	}
+	var set utils.JSONWebKeySet
+	if err := s.doJSON(req, &set); err != nil {
+		return nil, fmt.Errorf("failed to fetch provider keys: %w", err)
+	}
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			s.logger.Warn("Skipping unsupported identity provider key", "kid", jwk.KeyID, "error", err)
+			continue
+		}
+		keys[jwk.KeyID] = key
+	}
+	s.keys = keys
+	s.keysFetchedAt = time.Now()
+
+	if key, ok := s.lookupProviderKey(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// lookupProviderKey finds a key by kid; a token without kid is accepted when the provider has a single key.
+// The caller must hold s.mu.
+func (s *OIDCService) lookupProviderKey(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+// doJSON sends req and decodes a successful JSON response into target.
+func (s *OIDCService) doJSON(req *http.Request, target any) error {
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, oidcMaxResponseBytes))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %d: %s", req.URL.Redacted(), resp.StatusCode, truncateString(string(body), 200))
+	}
+	return json.Unmarshal(body, target)
+}
+
+// randomURLToken returns 32 random bytes, base64url-encoded.
+func randomURLToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
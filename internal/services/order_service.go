@@ -26,17 +26,25 @@ type OrderService struct {
 	pool           *pgxpool.Pool // Add pool for transactions
 	cartService    *CartService  // Required for checkout logic
 	cache          *redis.Client
-	productService *ProductService // Required for fetching product details/prices during checkout
+	productService *ProductService         // Required for fetching product details/prices during checkout
+	alerts         *ProductAlertService    // Notified when cancellations put stock back
+	reviews        *ReviewService          // Notified when deliveries verify existing reviews
+	geography      *GeographyService       // Normalises shipping addresses to wilaya and commune codes
+	delivery       *DeliveryServiceService // Prices delivery the same way as the cart's delivery quotes
 	logger         *slog.Logger
 }
 
-func NewOrderService(querier db.Querier, pool *pgxpool.Pool, cartService *CartService, cache *redis.Client, productService *ProductService, logger *slog.Logger) *OrderService {
+func NewOrderService(querier db.Querier, pool *pgxpool.Pool, cartService *CartService, cache *redis.Client, productService *ProductService, alerts *ProductAlertService, reviews *ReviewService, geography *GeographyService, delivery *DeliveryServiceService, logger *slog.Logger) *OrderService {
 	return &OrderService{
 		querier:        querier,
 		pool:           pool, // Store the pool
 		cartService:    cartService,
 		cache:          cache,
 		productService: productService,
+		alerts:         alerts,
+		reviews:        reviews,
+		geography:      geography,
+		delivery:       delivery,
 		logger:         logger,
 	}
 }
@@ -81,29 +89,49 @@ func (s *OrderService) CreateOrder(ctx context.Context, req models.CreateOrderFr
 		s.logger.Debug("successfully fetched cart summary for guest order creation", "summary cart id", cartSummary.ID, "session_user", actualUserID, "session_id", temporaryUserID)
 	}
 
-	// --- STEP 2: Fetch delivery service details ---
-	deliveryService, err := s.querier.GetDeliveryServiceByID(ctx, req.DeliveryServiceID)
+	// --- STEP 1b: Resolve the shipping address ---
+	// A saved address is copied onto the order, so later edits to the address book do not change it.
+	shippingAddress, err := s.resolveShippingAddress(ctx, req, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch delivery service with ID %s: %w", req.DeliveryServiceID, err)
+		return nil, err
+	}
+	// The order stores the reference names and codes, whatever spelling the customer used
+	wilaya, commune := s.geography.ResolvePlace(shippingAddress.Province, shippingAddress.City)
+	if wilaya == nil || commune == nil {
+		return nil, ErrUnknownPlace
+	}
+
+	// --- STEP 2: Price delivery to the shipping address ---
+	deliveryType := req.DeliveryType
+	if deliveryType == "" {
+		deliveryType = models.DeliveryTypeHome
+	}
+	deliveryQuote, err := s.delivery.QuoteDelivery(ctx, req.DeliveryServiceID, cartSummary, wilaya.Code, deliveryType)
+	if err != nil {
+		return nil, err
 	}
 
 	// --- STEP 3: Calculate total amount ---
 	// Use the validated total from the cart summary (sum of final discounted prices) + delivery fee
-	totalAmountCents := cartSummary.TotalDiscountedValueCents + deliveryService.BaseCostCents
+	totalAmountCents := cartSummary.TotalDiscountedValueCents + deliveryQuote.FeeCents
 	totalAmountCentsRounded := utils.RoundToDinarCents(totalAmountCents)
 	// --- STEP 4: Prepare order creation parameters ---
 	createOrderParams := db.CreateOrderParams{
 		UserID:            actualUserID, // Use the determined user ID (original or temporary)
-		UserFullName:      req.ShippingAddress.FullName,
+		UserFullName:      shippingAddress.FullName,
 		Status:            "pending",
 		TotalAmountCents:  totalAmountCentsRounded,
 		PaymentMethod:     "Cash on Delivery", // Or get from req if variable
-		Province:          req.ShippingAddress.Province,
-		City:              req.ShippingAddress.City,
-		PhoneNumber1:      req.ShippingAddress.PhoneNumber1,
-		PhoneNumber2:      req.ShippingAddress.PhoneNumber2,
+		Province:          wilaya.Name,
+		City:              commune.Name,
+		PhoneNumber1:      shippingAddress.PhoneNumber1,
+		PhoneNumber2:      shippingAddress.PhoneNumber2,
 		Notes:             req.Notes,
 		DeliveryServiceID: req.DeliveryServiceID,
+		WilayaCode:        &wilaya.Code,
+		CommuneCode:       &commune.Code,
+		DeliveryType:      deliveryType,
+		DeliveryFeeCents:  &deliveryQuote.FeeCents,
 	}
 
 	// --- STEP 5: TRANSACTION BEGINS ---
@@ -179,6 +207,28 @@ func (s *OrderService) CreateOrder(ctx context.Context, req models.CreateOrderFr
 	return createdOrderWithItems, nil
 }
 
+// resolveShippingAddress returns the inline shipping address of the request, or the saved address it names.
+// Guests have no saved addresses.
+func (s *OrderService) resolveShippingAddress(ctx context.Context, req models.CreateOrderFromCartRequest, userID *uuid.UUID) (models.Address, error) {
+	if req.AddressID == nil {
+		if req.ShippingAddress == nil {
+			return models.Address{}, errors.New("a shipping address is required")
+		}
+		return *req.ShippingAddress, nil
+	}
+	if userID == nil {
+		return models.Address{}, ErrAddressNotFound
+	}
+	dbAddress, err := s.querier.GetUserAddress(ctx, db.GetUserAddressParams{ID: *req.AddressID, UserID: *userID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Address{}, ErrAddressNotFound
+		}
+		return models.Address{}, fmt.Errorf("failed to fetch saved address: %w", err)
+	}
+	return toUserAddressModel(dbAddress).Address, nil
+}
+
 // GetOrder retrieves an order by its ID along with its associated items.
 // It aggregates the results from the GetOrderWithItems query which returns multiple rows.
 func (s *OrderService) GetOrder(ctx context.Context, orderID uuid.UUID) (*models.OrderWithItems, error) {
@@ -220,6 +270,10 @@ func (s *OrderService) GetOrder(ctx context.Context, orderID uuid.UUID) (*models
 				UpdatedAt:         row.UpdatedAt.Time,
 				CompletedAt:       nil, // Initialize, will set if not null
 				CancelledAt:       nil, // Initialize, will set if not null
+				WilayaCode:        row.WilayaCode,
+				CommuneCode:       row.CommuneCode,
+				DeliveryType:      row.DeliveryType,
+				DeliveryFeeCents:  row.DeliveryFeeCents,
 			}
 			// Set nullable timestamps
 			if row.CompletedAt.Valid {
@@ -281,6 +335,10 @@ func (s *OrderService) dbOrderToModelOrder(dbOrder db.Order) models.Order {
 	order.PhoneNumber2 = dbOrder.PhoneNumber2
 	order.DeliveryServiceID = dbOrder.DeliveryServiceID
 	order.Notes = dbOrder.Notes
+	order.WilayaCode = dbOrder.WilayaCode
+	order.CommuneCode = dbOrder.CommuneCode
+	order.DeliveryType = dbOrder.DeliveryType
+	order.DeliveryFeeCents = dbOrder.DeliveryFeeCents
 	order.CreatedAt = dbOrder.CreatedAt.Time
 	order.UpdatedAt = dbOrder.UpdatedAt.Time
 	if dbOrder.CompletedAt.Valid {
@@ -456,6 +514,7 @@ func (s *OrderService) UpdateOrderStatus(ctx context.Context, orderID uuid.UUID,
 		}
 		return nil, fmt.Errorf("failed to fetch current order state: %w", err)
 	}
+	models.SetAuditBefore(ctx, s.dbOrderToModelOrder(currentOrder))
 
 	// 2. Validate the requested status transition
 	if !isValidStatusTransition(currentOrder.Status, req.Status) {
@@ -468,7 +527,7 @@ func (s *OrderService) UpdateOrderStatus(ctx context.Context, orderID uuid.UUID,
 
 	// 3. Determine if stock deduction or release is needed based on the transition
 	needsStockDeduction := (currentOrder.Status == "pending" && req.Status == "confirmed")
-	needsStockRelease := (req.Status == "cancelled") // Stock release happens when the *new* status is 'cancelled'
+	needsStockRelease := (req.Status == "cancelled") // Stock release happens when the *new* status is 'cancelled'; only allocated stock is returned
 
 	// --- Fetch Order Items for Cache Invalidation (Do this *before* the transaction) ---
 	var orderItemsForCache []db.OrderItem
@@ -501,26 +560,15 @@ func (s *OrderService) UpdateOrderStatus(ctx context.Context, orderID uuid.UUID,
 
 	// 5. Handle Stock Release (if cancelling)
 	if needsStockRelease {
-		// 5a. Fetch order items within the transaction (already fetched outside for cache, but ensure TX consistency if needed)
-		// orderItems, err := txQuerier.GetOrderItemsByOrderID(ctx, orderID) // Use txQuerier if needed within TX for absolute consistency
-		orderItems := orderItemsForCache // Use the list fetched before the TX started
-
-		// 5b. Perform stock release for each item within the transaction using the IncrementStock query
-		for _, item := range orderItems {
-			// Call the existing SQLC-generated query to increment stock
-			updatedProduct, err := txQuerier.IncrementStock(ctx, db.IncrementStockParams{
-				ProductID:       item.ProductID,
-				IncrementAmount: item.Quantity, // item.Quantity is int32
-			})
-
-			if err != nil {
-				// Some database error occurred during stock increment
-				// Rollback happens via defer
-				return nil, fmt.Errorf("failed to release stock for product %s (ID: %s) during cancellation (status update): %w", item.ProductName, item.ProductID, err)
-			}
-			// Optionally log the new stock level if needed
+		// 5a. Return the allocated stock to the locations it was taken from, recording it in the stock ledger
+		movements, err := releaseOrderStock(ctx, txQuerier, orderID, actorIDFromContext(ctx))
+		if err != nil {
+			// Rollback happens via defer
+			return nil, fmt.Errorf("failed to release stock during cancellation (status update): %w", err)
+		}
+		for _, movement := range movements {
 			s.logger.Debug("Stock incremented for product during order cancellation (via status update)",
-				"product_id", item.ProductID, "new_stock", updatedProduct.StockQuantity)
+				"product_id", movement.ProductID, "location_id", movement.LocationID, "new_stock", movement.ResultingQuantity)
 		}
 	}
 
@@ -530,17 +578,11 @@ func (s *OrderService) UpdateOrderStatus(ctx context.Context, orderID uuid.UUID,
 		// orderItems, err := txQuerier.GetOrderItemsByOrderID(ctx, orderID) // Use txQuerier if needed within TX for absolute consistency
 		orderItems := orderItemsForCache // Use the list fetched before the TX started
 
-		// 6b. Perform stock deduction for each item within the transaction using the DecrementStockIfSufficient query
+		// 6b. Allocate each item to the sellable locations holding it, recording the deduction in the stock ledger
 		for _, item := range orderItems {
-			updatedProduct, err := txQuerier.DecrementStockIfSufficient(ctx, db.DecrementStockIfSufficientParams{
-				ProductID:       item.ProductID,
-				DecrementAmount: item.Quantity,
-			})
-
+			movements, err := allocateOrderItemStock(ctx, txQuerier, item, actorIDFromContext(ctx))
 			if err != nil {
-				// Check if the error is due to no rows being affected (insufficient stock)
-				if errors.Is(err, pgx.ErrNoRows) {
-					// This means the WHERE condition (stock >= decrement_amount) failed for this product
+				if errors.Is(err, ErrInsufficientStock) {
 					// Rollback happens via defer
 					return nil, fmt.Errorf("insufficient stock for product %s (ID: %s) during confirmation (status update)", item.ProductName, item.ProductID)
 				}
@@ -548,9 +590,15 @@ func (s *OrderService) UpdateOrderStatus(ctx context.Context, orderID uuid.UUID,
 				// Rollback happens via defer
 				return nil, fmt.Errorf("failed to update stock for product %s (ID: %s) during confirmation (status update): %w", item.ProductName, item.ProductID, err)
 			}
-			// Optionally log the new stock level if needed
-			s.logger.Debug("Stock decremented for product during order confirmation (via status update)",
-				"product_id", item.ProductID, "new_stock", updatedProduct.StockQuantity)
+			for _, movement := range movements {
+				s.logger.Debug("Stock decremented for product during order confirmation (via status update)",
+					"product_id", item.ProductID, "location_id", movement.LocationID, "new_stock", movement.ResultingQuantity)
+			}
+		}
+
+		// 6c. Record the cost of the units sold for margin reporting
+		if err := txQuerier.SnapshotOrderItemCosts(ctx, orderID); err != nil {
+			return nil, fmt.Errorf("failed to record item costs during confirmation (status update): %w", err)
 		}
 	}
 
@@ -589,6 +637,16 @@ func (s *OrderService) UpdateOrderStatus(ctx context.Context, orderID uuid.UUID,
 	}
 	// --- END Invalidate Product Caches After Successful Transaction ---
 
+	// Released stock may bring products back in stock for subscribed customers
+	if needsStockRelease {
+		s.alerts.CheckProductAlertsAsync(orderItemProductIDs(orderItemsForCache)...)
+	}
+
+	// Reviews the customer already wrote for the delivered products become verified purchases
+	if req.Status == "delivered" {
+		s.reviews.MarkVerifiedPurchases(ctx, orderID)
+	}
+
 	// 9. Convert the updated db.Order to models.Order using the helper
 	updOrder := s.dbOrderToModelOrder(updatedOrder)
 
@@ -600,6 +658,39 @@ func (s *OrderService) UpdateOrderStatus(ctx context.Context, orderID uuid.UUID,
 // Allow cancelling from 'pending' or 'confirmed'
 // Do NOT allow cancelling from 'shipped', 'delivered', or 'cancelled'
 
+// ReturnShippedOrder moves a shipped order back to confirmed after its shipment was withdrawn from the courier
+// before pickup, so that it can be shipped again. It is not offered through UpdateOrderStatus, where a
+// shipped order can only become delivered.
+func (s *OrderService) ReturnShippedOrder(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
+	currentOrder, err := s.querier.GetOrder(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch current order state: %w", err)
+	}
+	if currentOrder.Status != "shipped" {
+		return nil, &StatusTransitionError{
+			CurrentStatus:   currentOrder.Status,
+			RequestedStatus: "confirmed",
+			Msg:             "only shipped orders can be returned to confirmed",
+		}
+	}
+
+	// Stock stays allocated: the order still holds the items it was confirmed with
+	updatedOrder, err := s.querier.UpdateOrderStatus(ctx, db.UpdateOrderStatusParams{
+		Status:  "confirmed",
+		OrderID: orderID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to return order to confirmed: %w", err)
+	}
+	s.logger.Info("Shipped order returned to confirmed", "order_id", orderID)
+
+	order := s.dbOrderToModelOrder(updatedOrder)
+	return &order, nil
+}
+
 // canCancelOrder checks if an order can be cancelled based on its current status.
 func canCancelOrder(currentStatus string) bool {
 	switch currentStatus {
@@ -623,6 +714,7 @@ func (s *OrderService) CancelOrder(ctx context.Context, orderID uuid.UUID) (*mod
 		}
 		return nil, fmt.Errorf("failed to fetch current order state: %w", err)
 	}
+	models.SetAuditBefore(ctx, s.dbOrderToModelOrder(currentOrder))
 
 	// 2. Validate if cancellation is allowed based on the current status
 	if !canCancelOrder(currentOrder.Status) {
@@ -657,22 +749,15 @@ func (s *OrderService) CancelOrder(ctx context.Context, orderID uuid.UUID) (*mod
 			return nil, fmt.Errorf("failed to fetch order items for stock release: %w", err)
 		}
 
-		// 6. Perform stock release for each item within the transaction using the new query
-		for _, item := range orderItems {
-			// Call the new SQLC-generated query to increment stock
-			updatedProduct, err := txQuerier.IncrementStock(ctx, db.IncrementStockParams{
-				ProductID:       item.ProductID,
-				IncrementAmount: item.Quantity, // item.Quantity is int32
-			})
-
-			if err != nil {
-				// Some database error occurred during stock increment
-				// Rollback happens via defer
-				return nil, fmt.Errorf("failed to release stock for product %s (ID: %s) during cancellation: %w", item.ProductName, item.ProductID, err)
-			}
-			// Optionally log the new stock level if needed
+		// 6. Return the allocated stock to the locations it was taken from, recording it in the stock ledger
+		movements, err := releaseOrderStock(ctx, txQuerier, orderID, actorIDFromContext(ctx))
+		if err != nil {
+			// Rollback happens via defer
+			return nil, fmt.Errorf("failed to release stock during cancellation: %w", err)
+		}
+		for _, movement := range movements {
 			s.logger.Debug("Stock incremented for product during order cancellation",
-				"product_id", item.ProductID, "new_stock", updatedProduct.StockQuantity)
+				"product_id", movement.ProductID, "location_id", movement.LocationID, "new_stock", movement.ResultingQuantity)
 		}
 
 		// 7. Execute the cancellation within the same transaction
@@ -687,6 +772,8 @@ func (s *OrderService) CancelOrder(ctx context.Context, orderID uuid.UUID) (*mod
 			return nil, fmt.Errorf("failed to commit transaction for cancellation and stock release: %w", err)
 		}
 
+		s.alerts.CheckProductAlertsAsync(orderItemProductIDs(orderItems)...)
+
 	} else {
 		// 9. If no stock release needed, execute cancellation directly in a simple transaction
 		// For consistency and to ensure atomicity of the cancellation itself, use a transaction.
@@ -738,3 +825,12 @@ type CannotCancelError struct {
 func (e *CannotCancelError) Error() string {
 	return fmt.Sprintf("cannot cancel order in status '%s': %s", e.CurrentStatus, e.Msg)
 }
+
+// orderItemProductIDs returns the product IDs of the given order items.
+func orderItemProductIDs(items []db.OrderItem) []uuid.UUID {
+	productIDs := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		productIDs[i] = item.ProductID
+	}
+	return productIDs
+}
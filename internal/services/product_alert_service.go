@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/MihoZaki/DzTech/internal/db"
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrProductAlertNotFound = errors.New("product alert not found")
+	ErrProductAlertExists   = errors.New("an identical alert is already pending for this product")
+	ErrProductInStock       = errors.New("product is already in stock")
+)
+
+// productAlertCheckTimeout bounds a background alert check, including the emails it sends.
+const productAlertCheckTimeout = 2 * time.Minute
+
+// ProductAlertService manages back-in-stock and price-drop subscriptions and fires them.
+type ProductAlertService struct {
+	querier      db.Querier
+	emailService EmailService
+	logger       *slog.Logger
+}
+
+// NewProductAlertService creates a new instance of ProductAlertService.
+func NewProductAlertService(querier db.Querier, emailService EmailService, logger *slog.Logger) *ProductAlertService {
+	return &ProductAlertService{
+		querier:      querier,
+		emailService: emailService,
+		logger:       logger,
+	}
+}
+
+// CreateAlert subscribes a user to an alert for a product.
+// Back-in-stock alerts are refused for products in stock; a price alert whose target is already met
+// fires right away.
+func (s *ProductAlertService) CreateAlert(ctx context.Context, userID uuid.UUID, req models.CreateProductAlertRequest) (*models.ProductAlert, error) {
+	product, err := s.querier.GetProduct(ctx, req.ProductID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch product: %w", err)
+	}
+	if req.AlertType == models.ProductAlertBackInStock && product.StockQuantity > 0 {
+		return nil, ErrProductInStock
+	}
+
+	dbAlert, err := s.querier.CreateProductAlert(ctx, db.CreateProductAlertParams{
+		UserID:           userID,
+		ProductID:        req.ProductID,
+		AlertType:        req.AlertType,
+		TargetPriceCents: req.TargetPriceCents,
+	})
+	if err != nil {
+		if IsUniqueViolation(err, "idx_product_alerts_pending_unique") {
+			return nil, ErrProductAlertExists
+		}
+		return nil, fmt.Errorf("failed to create product alert: %w", err)
+	}
+
+	s.CheckProductAlertsAsync(req.ProductID)
+
+	return &models.ProductAlert{
+		ID:               dbAlert.ID,
+		UserID:           dbAlert.UserID,
+		ProductID:        dbAlert.ProductID,
+		AlertType:        dbAlert.AlertType,
+		TargetPriceCents: dbAlert.TargetPriceCents,
+		CreatedAt:        dbAlert.CreatedAt.Time,
+	}, nil
+}
+
+// ListUserAlerts returns all alerts of a user, pending ones first.
+func (s *ProductAlertService) ListUserAlerts(ctx context.Context, userID uuid.UUID) ([]models.ProductAlert, error) {
+	rows, err := s.querier.ListProductAlertsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product alerts: %w", err)
+	}
+
+	alerts := make([]models.ProductAlert, len(rows))
+	for i, row := range rows {
+		alerts[i] = models.ProductAlert{
+			ID:               row.ID,
+			UserID:           row.UserID,
+			ProductID:        row.ProductID,
+			ProductName:      row.ProductName,
+			ProductSlug:      row.ProductSlug,
+			AlertType:        row.AlertType,
+			TargetPriceCents: row.TargetPriceCents,
+			CreatedAt:        row.CreatedAt.Time,
+		}
+		if row.NotifiedAt.Valid {
+			alerts[i].NotifiedAt = &row.NotifiedAt.Time
+		}
+	}
+	return alerts, nil
+}
+
+// DeleteAlert removes one of the user's alerts.
+func (s *ProductAlertService) DeleteAlert(ctx context.Context, userID, alertID uuid.UUID) error {
+	affected, err := s.querier.DeleteProductAlert(ctx, db.DeleteProductAlertParams{
+		AlertID: alertID,
+		UserID:  userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete product alert: %w", err)
+	}
+	if affected == 0 {
+		return ErrProductAlertNotFound
+	}
+	return nil
+}
+
+// CheckProductAlerts fires every pending alert of a product whose condition is met.
+// Each alert is claimed before the email is sent, so concurrent checks never notify twice.
+// If delivery fails the claim is released and the alert stays pending, unless the user has subscribed again.
+func (s *ProductAlertService) CheckProductAlerts(ctx context.Context, productID uuid.UUID) error {
+	triggered, err := s.querier.GetTriggeredProductAlerts(ctx, productID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch triggered product alerts: %w", err)
+	}
+
+	for _, alert := range triggered {
+		claimed, err := s.querier.MarkProductAlertNotified(ctx, alert.ID)
+		if err != nil {
+			s.logger.Error("Failed to claim product alert", "alert_id", alert.ID, "error", err)
+			continue
+		}
+		if claimed == 0 {
+			continue // Already fired by a concurrent check
+		}
+
+		notification := models.ProductAlertNotification{
+			AlertType:         alert.AlertType,
+			ProductName:       alert.ProductName,
+			ProductSlug:       alert.ProductSlug,
+			TargetPriceCents:  alert.TargetPriceCents,
+			CurrentPriceCents: alert.CurrentPriceCents,
+			StockQuantity:     int(alert.StockQuantity),
+		}
+		if err := s.emailService.SendProductAlertEmail(ctx, alert.Email, notification); err != nil {
+			s.logger.Error("Failed to send product alert email, alert stays pending", "alert_id", alert.ID, "user_id", alert.UserID, "error", err)
+			s.releaseProductAlert(ctx, alert.ID, alert.UserID)
+			continue
+		}
+		s.logger.Info("Product alert fired", "alert_id", alert.ID, "user_id", alert.UserID, "product_id", productID, "alert_type", alert.AlertType)
+	}
+	return nil
+}
+
+// releaseProductAlert puts a claimed alert back to pending after its email could not be sent. If the user
+// subscribed again in the meantime, their new alert is the pending one and the claimed row is dropped instead.
+func (s *ProductAlertService) releaseProductAlert(ctx context.Context, alertID, userID uuid.UUID) {
+	err := s.querier.ResetProductAlertNotified(ctx, alertID)
+	if err == nil {
+		return
+	}
+	if !IsUniqueViolation(err, "idx_product_alerts_pending_unique") {
+		s.logger.Error("Failed to release product alert after email failure", "alert_id", alertID, "error", err)
+		return
+	}
+	if _, err := s.querier.DeleteProductAlert(ctx, db.DeleteProductAlertParams{
+		AlertID: alertID,
+		UserID:  userID,
+	}); err != nil {
+		s.logger.Error("Failed to drop product alert superseded by a new subscription", "alert_id", alertID, "error", err)
+	}
+}
+
+// CheckProductAlertsAsync runs CheckProductAlerts for the given products in the background,
+// so stock and price updates are not slowed down by sending emails.
+// It is safe to call on a nil service.
+func (s *ProductAlertService) CheckProductAlertsAsync(productIDs ...uuid.UUID) {
+	if s == nil || len(productIDs) == 0 {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), productAlertCheckTimeout)
+		defer cancel()
+		for _, productID := range productIDs {
+			if err := s.CheckProductAlerts(ctx, productID); err != nil {
+				s.logger.Error("Failed to check product alerts", "product_id", productID, "error", err)
+			}
+		}
+	}()
+}
+
+// CheckDiscountAlertsAsync checks the alerts of every product linked to a discount
+// whose value, validity or activation changed.
+// It is safe to call on a nil service.
+func (s *ProductAlertService) CheckDiscountAlertsAsync(ctx context.Context, discountID uuid.UUID) {
+	if s == nil {
+		return
+	}
+	productIDs, err := s.querier.ListProductIDsWithPendingAlertsByDiscount(ctx, discountID)
+	if err != nil {
+		s.logger.Error("Failed to list products with pending alerts for discount", "discount_id", discountID, "error", err)
+		return
+	}
+	s.CheckProductAlertsAsync(productIDs...)
+}
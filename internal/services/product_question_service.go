@@ -0,0 +1,473 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/MihoZaki/DzTech/internal/db"
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrProductQuestionNotFound   = errors.New("product question not found")
+	ErrProductAnswerNotFound     = errors.New("product answer not found")
+	ErrProductAnswerOwnVote      = errors.New("you cannot upvote your own answer")
+	ErrProductAnswerVoteNotFound = errors.New("you have not upvoted this answer")
+)
+
+// productAnswerEmailTimeout bounds sending the answer notification in the background.
+const productAnswerEmailTimeout = 30 * time.Second
+
+// ProductQuestionService handles business logic for product questions and answers.
+type ProductQuestionService struct {
+	querier      db.Querier
+	emailService EmailService // Notifies askers of new answers
+	logger       *slog.Logger
+}
+
+// NewProductQuestionService creates a new instance of ProductQuestionService.
+func NewProductQuestionService(querier db.Querier, emailService EmailService, logger *slog.Logger) *ProductQuestionService {
+	return &ProductQuestionService{
+		querier:      querier,
+		emailService: emailService,
+		logger:       logger,
+	}
+}
+
+// AskQuestion records a customer's question about a product. It is held for moderation.
+func (s *ProductQuestionService) AskQuestion(ctx context.Context, userID uuid.UUID, req models.CreateProductQuestionRequest) (*models.ProductQuestion, error) {
+	if _, err := s.querier.GetProduct(ctx, req.ProductID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch product: %w", err)
+	}
+
+	question, err := s.querier.CreateProductQuestion(ctx, db.CreateProductQuestionParams{
+		ProductID: req.ProductID,
+		UserID:    userID,
+		Body:      req.Body,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create product question: %w", err)
+	}
+
+	return &models.ProductQuestion{
+		ID:        question.ID,
+		ProductID: question.ProductID,
+		Body:      question.Body,
+		Status:    question.Status,
+		Answers:   []models.ProductAnswer{},
+		CreatedAt: question.CreatedAt.Time,
+	}, nil
+}
+
+// ListProductQuestions retrieves the published questions of a product with their published answers,
+// official answers first and then by upvotes.
+func (s *ProductQuestionService) ListProductQuestions(ctx context.Context, productID uuid.UUID, page, limit int) (*models.PaginatedResponse, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if page <= 0 {
+		page = 1
+	}
+	offset := (page - 1) * limit
+
+	rows, err := s.querier.ListApprovedProductQuestions(ctx, db.ListApprovedProductQuestionsParams{
+		ProductID:  productID,
+		PageOffset: int32(offset),
+		PageLimit:  int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product questions: %w", err)
+	}
+
+	questionIDs := make([]uuid.UUID, len(rows))
+	for i, q := range rows {
+		questionIDs[i] = q.ID
+	}
+	answers := make(map[uuid.UUID][]models.ProductAnswer, len(rows))
+	if len(questionIDs) > 0 {
+		answerRows, err := s.querier.ListApprovedAnswersByQuestionIDs(ctx, questionIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list product answers: %w", err)
+		}
+		for _, a := range answerRows {
+			answers[a.QuestionID] = append(answers[a.QuestionID], models.ProductAnswer{
+				ID:          a.ID,
+				QuestionID:  a.QuestionID,
+				AuthorName:  stringOrEmpty(a.AuthorName),
+				Body:        a.Body,
+				IsOfficial:  a.IsOfficial,
+				UpvoteCount: int(a.UpvoteCount),
+				CreatedAt:   a.CreatedAt.Time,
+			})
+		}
+	}
+
+	questions := make([]models.ProductQuestion, len(rows))
+	for i, q := range rows {
+		questionAnswers := answers[q.ID]
+		if questionAnswers == nil {
+			questionAnswers = []models.ProductAnswer{}
+		}
+		questions[i] = models.ProductQuestion{
+			ID:          q.ID,
+			ProductID:   q.ProductID,
+			AskerName:   stringOrEmpty(q.AskerName),
+			Body:        q.Body,
+			AnswerCount: int(q.AnswerCount),
+			Answers:     questionAnswers,
+			CreatedAt:   q.CreatedAt.Time,
+		}
+	}
+
+	total, err := s.querier.CountApprovedProductQuestions(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count product questions: %w", err)
+	}
+
+	return &models.PaginatedResponse{
+		Data:       questions,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}, nil
+}
+
+// AnswerQuestion records a customer's answer to a published question. It is held for moderation,
+// and the asker is notified once it is approved.
+func (s *ProductQuestionService) AnswerQuestion(ctx context.Context, questionID, userID uuid.UUID, body string) (*models.ProductAnswer, error) {
+	if _, err := s.querier.GetApprovedProductQuestion(ctx, questionID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProductQuestionNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch product question: %w", err)
+	}
+
+	answer, err := s.querier.CreateProductAnswer(ctx, db.CreateProductAnswerParams{
+		QuestionID: questionID,
+		UserID:     userID,
+		Body:       body,
+		IsOfficial: false,
+		Status:     models.ProductQAStatusPending,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create product answer: %w", err)
+	}
+
+	result := toProductAnswerModel(answer)
+	return &result, nil
+}
+
+// PostOfficialAnswer records a staff answer to a question. Official answers are published
+// immediately and the asker is notified.
+func (s *ProductQuestionService) PostOfficialAnswer(ctx context.Context, questionID uuid.UUID, body string) (*models.ProductAnswer, error) {
+	question, err := s.querier.GetProductQuestionForAnswer(ctx, questionID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProductQuestionNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch product question: %w", err)
+	}
+
+	actorID := actorIDFromContext(ctx)
+	answer, err := s.querier.CreateProductAnswer(ctx, db.CreateProductAnswerParams{
+		QuestionID: questionID,
+		UserID:     actorID,
+		Body:       body,
+		IsOfficial: true,
+		Status:     models.ProductQAStatusApproved,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create official product answer: %w", err)
+	}
+
+	if question.UserID != actorID {
+		s.notifyAsker(question, answer.Body, true)
+	}
+
+	result := toProductAnswerModel(answer)
+	return &result, nil
+}
+
+// UpvoteAnswer records the user's upvote on a published answer. Upvoting twice has no effect.
+func (s *ProductQuestionService) UpvoteAnswer(ctx context.Context, answerID, userID uuid.UUID) (*models.ProductAnswerVoteSummary, error) {
+	answer, err := s.querier.GetApprovedProductAnswer(ctx, answerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProductAnswerNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch product answer for vote: %w", err)
+	}
+	if answer.UserID == userID {
+		return nil, ErrProductAnswerOwnVote
+	}
+
+	if err := s.querier.CreateProductAnswerVote(ctx, db.CreateProductAnswerVoteParams{
+		AnswerID: answerID,
+		UserID:   userID,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to record product answer vote: %w", err)
+	}
+	return s.refreshUpvoteCount(ctx, answerID)
+}
+
+// RemoveAnswerUpvote withdraws the user's upvote on an answer.
+func (s *ProductQuestionService) RemoveAnswerUpvote(ctx context.Context, answerID, userID uuid.UUID) (*models.ProductAnswerVoteSummary, error) {
+	affected, err := s.querier.DeleteProductAnswerVote(ctx, db.DeleteProductAnswerVoteParams{
+		AnswerID: answerID,
+		UserID:   userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete product answer vote: %w", err)
+	}
+	if affected == 0 {
+		return nil, ErrProductAnswerVoteNotFound
+	}
+	return s.refreshUpvoteCount(ctx, answerID)
+}
+
+func (s *ProductQuestionService) refreshUpvoteCount(ctx context.Context, answerID uuid.UUID) (*models.ProductAnswerVoteSummary, error) {
+	count, err := s.querier.RefreshProductAnswerUpvoteCount(ctx, answerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh product answer upvote count: %w", err)
+	}
+	return &models.ProductAnswerVoteSummary{
+		AnswerID:    answerID,
+		UpvoteCount: int(count),
+	}, nil
+}
+
+// ListQuestionsForModeration retrieves questions for the admin moderation queue, optionally filtered by status.
+func (s *ProductQuestionService) ListQuestionsForModeration(ctx context.Context, status string, page, limit int) (*models.PaginatedResponse, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if page <= 0 {
+		page = 1
+	}
+	offset := (page - 1) * limit
+
+	rows, err := s.querier.ListProductQuestionsForModeration(ctx, db.ListProductQuestionsForModerationParams{
+		Status:     status,
+		PageOffset: int32(offset),
+		PageLimit:  int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product questions for moderation: %w", err)
+	}
+
+	questions := make([]models.AdminProductQuestionListItem, len(rows))
+	for i, q := range rows {
+		questions[i] = models.AdminProductQuestionListItem{
+			ID:               q.ID,
+			ProductID:        q.ProductID,
+			ProductName:      q.ProductName,
+			UserID:           q.UserID,
+			AskerName:        stringOrEmpty(q.AskerName),
+			AskerEmail:       q.AskerEmail,
+			Body:             q.Body,
+			Status:           q.Status,
+			ModerationReason: q.ModerationReason,
+			ModeratedBy:      uuidPtrOrNil(q.ModeratedBy),
+			ModeratedAt:      timePtrOrNil(q.ModeratedAt),
+			CreatedAt:        q.CreatedAt.Time,
+		}
+	}
+
+	total, err := s.querier.CountProductQuestionsForModeration(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count product questions for moderation: %w", err)
+	}
+
+	return &models.PaginatedResponse{
+		Data:       questions,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}, nil
+}
+
+// ApproveQuestion publishes a question on the product page.
+func (s *ProductQuestionService) ApproveQuestion(ctx context.Context, questionID uuid.UUID) (*models.ProductQuestion, error) {
+	return s.moderateQuestion(ctx, questionID, models.ProductQAStatusApproved, nil)
+}
+
+// RejectQuestion hides a question with a reason.
+func (s *ProductQuestionService) RejectQuestion(ctx context.Context, questionID uuid.UUID, reason string) (*models.ProductQuestion, error) {
+	return s.moderateQuestion(ctx, questionID, models.ProductQAStatusRejected, &reason)
+}
+
+func (s *ProductQuestionService) moderateQuestion(ctx context.Context, questionID uuid.UUID, status string, reason *string) (*models.ProductQuestion, error) {
+	question, err := s.querier.ModerateProductQuestion(ctx, db.ModerateProductQuestionParams{
+		Status:           status,
+		ModerationReason: reason,
+		ModeratedBy:      actorIDFromContext(ctx),
+		ID:               questionID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProductQuestionNotFound
+		}
+		return nil, fmt.Errorf("failed to moderate product question: %w", err)
+	}
+
+	s.logger.Info("Product question moderated", "question_id", questionID, "status", status)
+	return &models.ProductQuestion{
+		ID:        question.ID,
+		ProductID: question.ProductID,
+		Body:      question.Body,
+		Status:    question.Status,
+		Answers:   []models.ProductAnswer{},
+		CreatedAt: question.CreatedAt.Time,
+	}, nil
+}
+
+// ListAnswersForModeration retrieves answers for the admin moderation queue, optionally filtered by status.
+func (s *ProductQuestionService) ListAnswersForModeration(ctx context.Context, status string, page, limit int) (*models.PaginatedResponse, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if page <= 0 {
+		page = 1
+	}
+	offset := (page - 1) * limit
+
+	rows, err := s.querier.ListProductAnswersForModeration(ctx, db.ListProductAnswersForModerationParams{
+		Status:     status,
+		PageOffset: int32(offset),
+		PageLimit:  int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product answers for moderation: %w", err)
+	}
+
+	answers := make([]models.AdminProductAnswerListItem, len(rows))
+	for i, a := range rows {
+		answers[i] = models.AdminProductAnswerListItem{
+			ID:               a.ID,
+			QuestionID:       a.QuestionID,
+			QuestionBody:     a.QuestionBody,
+			ProductID:        a.ProductID,
+			UserID:           uuidPtrOrNil(a.UserID),
+			AuthorName:       stringOrEmpty(a.AuthorName),
+			AuthorEmail:      stringOrEmpty(a.AuthorEmail),
+			Body:             a.Body,
+			IsOfficial:       a.IsOfficial,
+			Status:           a.Status,
+			ModerationReason: a.ModerationReason,
+			UpvoteCount:      int(a.UpvoteCount),
+			ModeratedBy:      uuidPtrOrNil(a.ModeratedBy),
+			ModeratedAt:      timePtrOrNil(a.ModeratedAt),
+			CreatedAt:        a.CreatedAt.Time,
+		}
+	}
+
+	total, err := s.querier.CountProductAnswersForModeration(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count product answers for moderation: %w", err)
+	}
+
+	return &models.PaginatedResponse{
+		Data:       answers,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}, nil
+}
+
+// ApproveAnswer publishes an answer and, the first time, notifies the asker.
+func (s *ProductQuestionService) ApproveAnswer(ctx context.Context, answerID uuid.UUID) (*models.ProductAnswer, error) {
+	return s.moderateAnswer(ctx, answerID, models.ProductQAStatusApproved, nil)
+}
+
+// RejectAnswer hides an answer with a reason.
+func (s *ProductQuestionService) RejectAnswer(ctx context.Context, answerID uuid.UUID, reason string) (*models.ProductAnswer, error) {
+	return s.moderateAnswer(ctx, answerID, models.ProductQAStatusRejected, &reason)
+}
+
+func (s *ProductQuestionService) moderateAnswer(ctx context.Context, answerID uuid.UUID, status string, reason *string) (*models.ProductAnswer, error) {
+	answer, err := s.querier.ModerateProductAnswer(ctx, db.ModerateProductAnswerParams{
+		Status:           status,
+		ModerationReason: reason,
+		ModeratedBy:      actorIDFromContext(ctx),
+		ID:               answerID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProductAnswerNotFound
+		}
+		return nil, fmt.Errorf("failed to moderate product answer: %w", err)
+	}
+	s.logger.Info("Product answer moderated", "answer_id", answerID, "status", status)
+
+	if answer.Status == models.ProductQAStatusApproved && answer.PreviousStatus != models.ProductQAStatusApproved {
+		question, err := s.querier.GetProductQuestionForAnswer(ctx, answer.QuestionID)
+		if err != nil {
+			s.logger.Error("Failed to fetch product question for answer notification", "question_id", answer.QuestionID, "error", err)
+		} else if question.UserID != answer.UserID {
+			s.notifyAsker(question, answer.Body, answer.IsOfficial)
+		}
+	}
+
+	return &models.ProductAnswer{
+		ID:          answer.ID,
+		QuestionID:  answer.QuestionID,
+		Body:        answer.Body,
+		IsOfficial:  answer.IsOfficial,
+		Status:      answer.Status,
+		UpvoteCount: int(answer.UpvoteCount),
+		CreatedAt:   answer.CreatedAt.Time,
+	}, nil
+}
+
+// notifyAsker emails the asker in the background so the request does not wait on SMTP.
+func (s *ProductQuestionService) notifyAsker(question db.GetProductQuestionForAnswerRow, answerBody string, official bool) {
+	if s.emailService == nil {
+		return
+	}
+	notification := models.ProductAnswerNotification{
+		ProductName:  question.ProductName,
+		ProductSlug:  question.ProductSlug,
+		QuestionBody: question.Body,
+		AnswerBody:   answerBody,
+		IsOfficial:   official,
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), productAnswerEmailTimeout)
+		defer cancel()
+		if err := s.emailService.SendProductAnswerEmail(ctx, question.AskerEmail, notification); err != nil {
+			s.logger.Error("Failed to send product answer email", "question_id", question.ID, "error", err)
+		}
+	}()
+}
+
+func toProductAnswerModel(a db.CreateProductAnswerRow) models.ProductAnswer {
+	return models.ProductAnswer{
+		ID:          a.ID,
+		QuestionID:  a.QuestionID,
+		Body:        a.Body,
+		IsOfficial:  a.IsOfficial,
+		Status:      a.Status,
+		UpvoteCount: int(a.UpvoteCount),
+		CreatedAt:   a.CreatedAt.Time,
+	}
+}
+
+// stringOrEmpty dereferences a nullable column, e.g. the name of a user who never set one.
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -18,13 +18,16 @@ import (
 	"github.com/MihoZaki/DzTech/internal/utils"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 )
 
 type ProductService struct {
 	querier db.Querier
+	pool    *pgxpool.Pool // Needed for transactions that touch the stock ledger
 	storer  storage.Storer
 	cache   *redis.Client
+	alerts  *ProductAlertService
 	logger  *slog.Logger
 }
 
@@ -34,11 +37,13 @@ const (
 	ProductCacheTTL       = 30 * time.Minute  // Define TTL for product cache entries
 )
 
-func NewProductService(querier db.Querier, storer storage.Storer, cache *redis.Client, logger *slog.Logger) *ProductService {
+func NewProductService(querier db.Querier, pool *pgxpool.Pool, storer storage.Storer, cache *redis.Client, alerts *ProductAlertService, logger *slog.Logger) *ProductService {
 	return &ProductService{
 		querier: querier,
+		pool:    pool,
 		storer:  storer,
 		cache:   cache,
+		alerts:  alerts,
 		logger:  logger,
 	}
 }
@@ -79,8 +84,9 @@ func (s *ProductService) CreateProduct(ctx context.Context, req models.CreatePro
 		imageUrlsJSON,
 		specHighlightsJSON,
 	)
+	params.WeightGrams = req.WeightGrams
 
-	dbProduct, err := s.querier.CreateProduct(ctx, params)
+	dbProduct, err := s.createProductWithInitialStock(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -143,8 +149,9 @@ func (s *ProductService) CreateProductWithUpload(ctx context.Context, req models
 		imageUrlsJSON,
 		specHighlightsJSON,
 	)
+	params.WeightGrams = req.WeightGrams
 
-	dbProduct, err := s.querier.CreateProduct(ctx, params)
+	dbProduct, err := s.createProductWithInitialStock(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -393,6 +400,7 @@ func (s *ProductService) UpdateProduct(ctx context.Context, id uuid.UUID, req mo
 		}
 		return nil, fmt.Errorf("failed to fetch existing product for cache invalidation: %w", err)
 	}
+	models.SetAuditBefore(ctx, s.toProductModel(existingDbProduct))
 
 	// --- Perform the actual update logic (keeping existing validation and parameter preparation) ---
 	var finalImageUrls []string
@@ -429,7 +437,7 @@ func (s *ProductService) UpdateProduct(ctx context.Context, id uuid.UUID, req mo
 		params.Slug = existingDbProduct.Slug
 	}
 
-	updatedDbProduct, err := s.querier.UpdateProduct(ctx, params)
+	updatedDbProduct, err := s.updateProductAndStock(ctx, params, req.StockQuantity)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, errors.New("product not found") // Should ideally not happen if GetProduct succeeded
@@ -474,6 +482,10 @@ func (s *ProductService) UpdateProduct(ctx context.Context, id uuid.UUID, req mo
 	}
 	// ---
 
+	if stockOrPriceChanged(existingDbProduct, updatedDbProduct) {
+		s.alerts.CheckProductAlertsAsync(id)
+	}
+
 	return updatedProduct, nil
 }
 
@@ -490,6 +502,7 @@ func (s *ProductService) UpdateProductWithUpload(ctx context.Context, productID
 		}
 		return nil, fmt.Errorf("failed to get existing product: %w", err)
 	}
+	models.SetAuditBefore(ctx, s.toProductModel(existingDbProduct))
 
 	// Store the old slug for cache invalidation later
 	oldSlug := existingDbProduct.Slug
@@ -551,7 +564,7 @@ func (s *ProductService) UpdateProductWithUpload(ctx context.Context, productID
 	}
 
 	// Step 4: Perform the database update
-	updatedDbProduct, err := s.querier.UpdateProduct(ctx, params)
+	updatedDbProduct, err := s.updateProductAndStock(ctx, params, req.StockQuantity)
 	if err != nil {
 		// DB update failed. Clean up any newly uploaded files.
 		if len(uploadedUrlsForCleanup) > 0 {
@@ -627,9 +640,145 @@ func (s *ProductService) UpdateProductWithUpload(ctx context.Context, productID
 	}
 	// ---
 
+	if stockOrPriceChanged(existingDbProduct, updatedDbProduct) {
+		s.alerts.CheckProductAlertsAsync(productID)
+	}
+
 	return updatedProduct, nil
 }
 
+// createProductWithInitialStock creates a product and records its initial stock at the default location
+// in the stock ledger, so that the ledger of every product reconciles from its first movement.
+func (s *ProductService) createProductWithInitialStock(ctx context.Context, params db.CreateProductParams) (db.Product, error) {
+	initialStock := params.StockQuantity
+	params.StockQuantity = 0
+
+	queries, ok := s.querier.(*db.Queries)
+	if !ok {
+		return db.Product{}, errors.New("querier type assertion to *db.Queries failed, cannot create transactional querier")
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return db.Product{}, fmt.Errorf("failed to begin transaction for product creation: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			s.logger.Error("Error during transaction rollback in createProductWithInitialStock", "error", err)
+		}
+	}()
+	txQuerier := queries.WithTx(tx)
+
+	dbProduct, err := txQuerier.CreateProduct(ctx, params)
+	if err != nil {
+		return db.Product{}, err
+	}
+
+	if initialStock > 0 {
+		locationID, err := defaultStockLocationID(ctx, txQuerier)
+		if err != nil {
+			return db.Product{}, err
+		}
+		note := "Initial stock"
+		if _, err := applyStockMovement(ctx, txQuerier, db.ApplyStockMovementParams{
+			ProductID:  dbProduct.ID,
+			LocationID: locationID,
+			Delta:      initialStock,
+			Reason:     models.StockReasonRestock,
+			ActorID:    actorIDFromContext(ctx),
+			Note:       &note,
+		}); err != nil {
+			return db.Product{}, fmt.Errorf("failed to record initial stock: %w", err)
+		}
+		// stock_quantity is maintained by the database from the stock levels
+		if dbProduct, err = txQuerier.GetProduct(ctx, dbProduct.ID); err != nil {
+			return db.Product{}, fmt.Errorf("failed to refresh product after recording initial stock: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.Product{}, fmt.Errorf("failed to commit product creation: %w", err)
+	}
+	return dbProduct, nil
+}
+
+// updateProductAndStock updates a product and, if a new stock quantity is given, sets the stock at the
+// default location through the stock ledger as a manual adjustment, in one transaction.
+// Stock at other locations is managed through InventoryService.
+func (s *ProductService) updateProductAndStock(ctx context.Context, params db.UpdateProductParams, stockQuantity *int) (db.Product, error) {
+	queries, ok := s.querier.(*db.Queries)
+	if !ok {
+		return db.Product{}, errors.New("querier type assertion to *db.Queries failed, cannot create transactional querier")
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return db.Product{}, fmt.Errorf("failed to begin transaction for product update: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			s.logger.Error("Error during transaction rollback in updateProductAndStock", "error", err)
+		}
+	}()
+	txQuerier := queries.WithTx(tx)
+
+	dbProduct, err := txQuerier.UpdateProduct(ctx, params)
+	if err != nil {
+		return db.Product{}, err
+	}
+
+	if stockQuantity != nil {
+		locationID, err := defaultStockLocationID(ctx, txQuerier)
+		if err != nil {
+			return db.Product{}, err
+		}
+		// The quantity only replaces the default location's stock, so it would not become the product's total
+		levels, err := txQuerier.ListProductStockLevels(ctx, dbProduct.ID)
+		if err != nil {
+			return db.Product{}, fmt.Errorf("failed to list stock levels: %w", err)
+		}
+		for _, level := range levels {
+			if level.LocationID != locationID && level.Quantity > 0 {
+				return db.Product{}, ErrStockHeldAtSeveralLocations
+			}
+		}
+		if err := txQuerier.EnsureProductStockLevel(ctx, db.EnsureProductStockLevelParams{
+			ProductID:  dbProduct.ID,
+			LocationID: locationID,
+		}); err != nil {
+			return db.Product{}, fmt.Errorf("failed to create stock level: %w", err)
+		}
+		_, err = txQuerier.SetProductStock(ctx, db.SetProductStockParams{
+			ProductID:   dbProduct.ID,
+			LocationID:  locationID,
+			NewQuantity: int32(*stockQuantity),
+			Reason:      models.StockReasonManualAdjustment,
+			ActorID:     actorIDFromContext(ctx),
+		})
+		switch {
+		case err == nil:
+			// stock_quantity is maintained by the database from the stock levels
+			if dbProduct, err = txQuerier.GetProduct(ctx, dbProduct.ID); err != nil {
+				return db.Product{}, fmt.Errorf("failed to refresh product after setting stock: %w", err)
+			}
+		case errors.Is(err, pgx.ErrNoRows):
+			// Quantity unchanged, nothing to record
+		default:
+			return db.Product{}, fmt.Errorf("failed to set product stock: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.Product{}, fmt.Errorf("failed to commit product update: %w", err)
+	}
+	return dbProduct, nil
+}
+
+// stockOrPriceChanged reports whether an update may have triggered a back-in-stock or price-drop alert.
+func stockOrPriceChanged(before, after db.Product) bool {
+	return before.StockQuantity != after.StockQuantity ||
+		before.PriceCents != after.PriceCents ||
+		before.Status != after.Status
+}
+
 func coalesceUUIDPtr(newVal *uuid.UUID, existingVal uuid.UUID) uuid.UUID {
 	if newVal != nil {
 		return *newVal
@@ -675,6 +824,7 @@ func (s *ProductService) DeleteProduct(ctx context.Context, id uuid.UUID) error
 		}
 		return fmt.Errorf("failed to get product for deletion/cleanup/cache invalidation: %w", err)
 	}
+	models.SetAuditBefore(ctx, s.toProductModel(existingDbProduct))
 
 	// Step 2: Perform the soft-delete in the database.
 	err = s.querier.DeleteProduct(ctx, id)
@@ -909,6 +1059,7 @@ func (s *ProductService) toProductModel(dbProduct db.Product) *models.Product {
 		StockQuantity: int(dbProduct.StockQuantity),
 		Status:        dbProduct.Status,
 		Brand:         dbProduct.Brand,
+		WeightGrams:   dbProduct.WeightGrams,
 		CreatedAt:     dbProduct.CreatedAt.Time,
 		UpdatedAt:     dbProduct.UpdatedAt.Time,
 	}
@@ -954,6 +1105,7 @@ func (s *ProductService) toProductModelWithDiscount(dbRow db.GetProductWithDisco
 		NumRatings:    dbRow.NumRatings,
 		Status:        dbRow.Status,
 		Brand:         dbRow.Brand,
+		WeightGrams:   dbRow.WeightGrams,
 		CreatedAt:     dbRow.CreatedAt.Time, // Convert pgtype.Timestamptz to time.Time
 		UpdatedAt:     dbRow.UpdatedAt.Time, // Convert pgtype.Timestamptz to time.Time
 		// Initialize discount fields
@@ -1060,11 +1212,11 @@ func prepareUpdateProductParams(
 		Description:      coalesceStringPtr(updates.Description, existingDbProduct.Description),
 		ShortDescription: coalesceStringPtr(updates.ShortDescription, existingDbProduct.ShortDescription),
 		PriceCents:       coalesceInt64(updates.PriceCents, existingDbProduct.PriceCents),
-		StockQuantity:    coalesceInt32(updates.StockQuantity, existingDbProduct.StockQuantity),
 		Status:           coalesceString(updates.Status, existingDbProduct.Status),
 		Brand:            coalesceString(updates.Brand, existingDbProduct.Brand),
 		ImageUrls:        imageUrlsJSON,
 		SpecHighlights:   existingDbProduct.SpecHighlights,
+		WeightGrams:      updates.WeightGrams, // Kept by the query when unset
 	}
 
 	if updates.SpecHighlights != nil {
@@ -0,0 +1,615 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/MihoZaki/DzTech/internal/db"
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrSupplierNotFound            = errors.New("supplier not found")
+	ErrSupplierExists              = errors.New("a supplier with this name already exists")
+	ErrSupplierInactive            = errors.New("supplier is inactive")
+	ErrPurchaseOrderNotFound       = errors.New("purchase order not found")
+	ErrPurchaseOrderStatus         = errors.New("operation not allowed in the purchase order's current status")
+	ErrPurchaseOrderItemNotFound   = errors.New("product is not on the purchase order")
+	ErrPurchaseOrderOverReceipt    = errors.New("received quantity exceeds the quantity still expected")
+	ErrPurchaseOrderProductMissing = errors.New("one or more products on the purchase order do not exist")
+)
+
+// PurchaseOrderService manages suppliers and purchase orders.
+// A purchase order moves through draft -> ordered -> partially_received -> received (or cancelled before any receipt).
+// Receiving increments the stock at the order's location through the stock ledger and records the cost paid,
+// which feeds the products' weighted average cost used for margin reporting.
+type PurchaseOrderService struct {
+	querier db.Querier
+	pool    *pgxpool.Pool
+	cache   *redis.Client
+	alerts  *ProductAlertService
+	logger  *slog.Logger
+}
+
+// NewPurchaseOrderService creates a new instance of PurchaseOrderService.
+func NewPurchaseOrderService(querier db.Querier, pool *pgxpool.Pool, cache *redis.Client, alerts *ProductAlertService, logger *slog.Logger) *PurchaseOrderService {
+	return &PurchaseOrderService{
+		querier: querier,
+		pool:    pool,
+		cache:   cache,
+		alerts:  alerts,
+		logger:  logger,
+	}
+}
+
+// --- Suppliers ---
+
+// CreateSupplier creates a new supplier.
+func (s *PurchaseOrderService) CreateSupplier(ctx context.Context, req models.CreateSupplierRequest) (*models.Supplier, error) {
+	supplier, err := s.querier.CreateSupplier(ctx, db.CreateSupplierParams{
+		Name:        req.Name,
+		ContactName: req.ContactName,
+		Email:       req.Email,
+		Phone:       req.Phone,
+		Notes:       req.Notes,
+	})
+	if err != nil {
+		if IsUniqueViolation(err, "suppliers_name_key") {
+			return nil, ErrSupplierExists
+		}
+		return nil, fmt.Errorf("failed to create supplier: %w", err)
+	}
+	result := toSupplierModel(supplier)
+	return &result, nil
+}
+
+// GetSupplier retrieves a supplier by ID.
+func (s *PurchaseOrderService) GetSupplier(ctx context.Context, id uuid.UUID) (*models.Supplier, error) {
+	supplier, err := s.querier.GetSupplier(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSupplierNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch supplier: %w", err)
+	}
+	result := toSupplierModel(supplier)
+	return &result, nil
+}
+
+// ListSuppliers lists suppliers by name, optionally only the active ones.
+func (s *PurchaseOrderService) ListSuppliers(ctx context.Context, activeOnly bool) ([]models.Supplier, error) {
+	rows, err := s.querier.ListSuppliers(ctx, activeOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list suppliers: %w", err)
+	}
+	suppliers := make([]models.Supplier, len(rows))
+	for i, row := range rows {
+		suppliers[i] = toSupplierModel(row)
+	}
+	return suppliers, nil
+}
+
+// UpdateSupplier updates a supplier.
+func (s *PurchaseOrderService) UpdateSupplier(ctx context.Context, id uuid.UUID, req models.UpdateSupplierRequest) (*models.Supplier, error) {
+	supplier, err := s.querier.UpdateSupplier(ctx, db.UpdateSupplierParams{
+		ID:          id,
+		Name:        req.Name,
+		ContactName: req.ContactName,
+		Email:       req.Email,
+		Phone:       req.Phone,
+		Notes:       req.Notes,
+		IsActive:    req.IsActive,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSupplierNotFound
+		}
+		if IsUniqueViolation(err, "suppliers_name_key") {
+			return nil, ErrSupplierExists
+		}
+		return nil, fmt.Errorf("failed to update supplier: %w", err)
+	}
+	result := toSupplierModel(supplier)
+	return &result, nil
+}
+
+// --- Purchase orders ---
+
+// CreatePurchaseOrder creates a draft purchase order with its lines.
+func (s *PurchaseOrderService) CreatePurchaseOrder(ctx context.Context, req models.CreatePurchaseOrderRequest) (*models.PurchaseOrder, error) {
+	supplier, err := s.querier.GetSupplier(ctx, req.SupplierID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSupplierNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch supplier: %w", err)
+	}
+	if !supplier.IsActive {
+		return nil, ErrSupplierInactive
+	}
+
+	locationID, err := s.resolveLocation(ctx, req.LocationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkProductsExist(ctx, req.Items); err != nil {
+		return nil, err
+	}
+
+	var purchaseOrderID uuid.UUID
+	err = s.withTx(ctx, "CreatePurchaseOrder", func(q *db.Queries) error {
+		purchaseOrder, err := q.CreatePurchaseOrder(ctx, db.CreatePurchaseOrderParams{
+			SupplierID: req.SupplierID,
+			LocationID: locationID,
+			Reference:  req.Reference,
+			Notes:      req.Notes,
+			ExpectedAt: optionalTimestamptz(req.ExpectedAt),
+			CreatedBy:  actorIDFromContext(ctx),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create purchase order: %w", err)
+		}
+		purchaseOrderID = purchaseOrder.ID
+		return createPurchaseOrderItems(ctx, q, purchaseOrder.ID, req.Items)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Purchase order created", "purchase_order_id", purchaseOrderID, "supplier_id", req.SupplierID, "lines", len(req.Items))
+	return s.GetPurchaseOrder(ctx, purchaseOrderID)
+}
+
+// GetPurchaseOrder retrieves a purchase order with its lines and receipts.
+func (s *PurchaseOrderService) GetPurchaseOrder(ctx context.Context, id uuid.UUID) (*models.PurchaseOrder, error) {
+	purchaseOrder, err := s.querier.GetPurchaseOrder(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPurchaseOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch purchase order: %w", err)
+	}
+	supplier, err := s.querier.GetSupplier(ctx, purchaseOrder.SupplierID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch supplier of purchase order: %w", err)
+	}
+	itemRows, err := s.querier.ListPurchaseOrderItems(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list purchase order items: %w", err)
+	}
+	receiptRows, err := s.querier.ListPurchaseOrderReceipts(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list purchase order receipts: %w", err)
+	}
+
+	result := &models.PurchaseOrder{
+		ID:           purchaseOrder.ID,
+		SupplierID:   purchaseOrder.SupplierID,
+		SupplierName: supplier.Name,
+		LocationID:   purchaseOrder.LocationID,
+		Status:       purchaseOrder.Status,
+		Reference:    purchaseOrder.Reference,
+		Notes:        purchaseOrder.Notes,
+		ExpectedAt:   timePtrOrNil(purchaseOrder.ExpectedAt),
+		CreatedBy:    uuidPtrOrNil(purchaseOrder.CreatedBy),
+		OrderedAt:    timePtrOrNil(purchaseOrder.OrderedAt),
+		ReceivedAt:   timePtrOrNil(purchaseOrder.ReceivedAt),
+		Items:        make([]models.PurchaseOrderItem, len(itemRows)),
+		Receipts:     make([]models.PurchaseOrderReceipt, len(receiptRows)),
+		CreatedAt:    purchaseOrder.CreatedAt.Time,
+		UpdatedAt:    purchaseOrder.UpdatedAt.Time,
+	}
+	for i, row := range itemRows {
+		lineTotal := int64(row.QuantityOrdered) * row.UnitCostCents
+		result.Items[i] = models.PurchaseOrderItem{
+			ID:               row.ID,
+			ProductID:        row.ProductID,
+			ProductName:      row.ProductName,
+			QuantityOrdered:  int(row.QuantityOrdered),
+			QuantityReceived: int(row.QuantityReceived),
+			UnitCostCents:    row.UnitCostCents,
+			LineTotalCents:   lineTotal,
+		}
+		result.TotalCostCents += lineTotal
+	}
+	for i, row := range receiptRows {
+		result.Receipts[i] = models.PurchaseOrderReceipt{
+			ID:                  row.ID,
+			PurchaseOrderItemID: row.PurchaseOrderItemID,
+			ProductID:           row.ProductID,
+			LocationID:          row.LocationID,
+			Quantity:            int(row.Quantity),
+			UnitCostCents:       row.UnitCostCents,
+			StockMovementID:     row.StockMovementID,
+			ReceivedBy:          uuidPtrOrNil(row.ReceivedBy),
+			ReceivedAt:          row.ReceivedAt.Time,
+		}
+	}
+	return result, nil
+}
+
+// ListPurchaseOrders lists purchase orders, newest first, optionally filtered by status and supplier.
+func (s *PurchaseOrderService) ListPurchaseOrders(ctx context.Context, status string, supplierID *uuid.UUID, page, limit int) (*models.PaginatedResponse, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if page <= 0 {
+		page = 1
+	}
+	offset := (page - 1) * limit
+
+	supplierFilter := uuid.Nil
+	if supplierID != nil {
+		supplierFilter = *supplierID
+	}
+
+	rows, err := s.querier.ListPurchaseOrders(ctx, db.ListPurchaseOrdersParams{
+		Status:     status,
+		SupplierID: supplierFilter,
+		PageLimit:  int32(limit),
+		PageOffset: int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list purchase orders: %w", err)
+	}
+
+	purchaseOrders := make([]models.PurchaseOrderListItem, len(rows))
+	for i, row := range rows {
+		purchaseOrders[i] = models.PurchaseOrderListItem{
+			ID:                    row.ID,
+			SupplierID:            row.SupplierID,
+			SupplierName:          row.SupplierName,
+			LocationID:            row.LocationID,
+			Status:                row.Status,
+			Reference:             row.Reference,
+			ExpectedAt:            timePtrOrNil(row.ExpectedAt),
+			OrderedAt:             timePtrOrNil(row.OrderedAt),
+			ReceivedAt:            timePtrOrNil(row.ReceivedAt),
+			TotalCostCents:        row.TotalCostCents,
+			TotalQuantityOrdered:  row.TotalQuantityOrdered,
+			TotalQuantityReceived: row.TotalQuantityReceived,
+			CreatedAt:             row.CreatedAt.Time,
+		}
+	}
+
+	total, err := s.querier.CountPurchaseOrders(ctx, db.CountPurchaseOrdersParams{
+		Status:     status,
+		SupplierID: supplierFilter,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count purchase orders: %w", err)
+	}
+
+	return &models.PaginatedResponse{
+		Data:       purchaseOrders,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}, nil
+}
+
+// UpdatePurchaseOrder updates a draft purchase order. If items are given, they replace all existing lines.
+func (s *PurchaseOrderService) UpdatePurchaseOrder(ctx context.Context, id uuid.UUID, req models.UpdatePurchaseOrderRequest) (*models.PurchaseOrder, error) {
+	locationID := uuid.Nil
+	if req.LocationID != nil {
+		var err error
+		if locationID, err = s.resolveLocation(ctx, req.LocationID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.checkProductsExist(ctx, req.Items); err != nil {
+		return nil, err
+	}
+
+	err := s.withTx(ctx, "UpdatePurchaseOrder", func(q *db.Queries) error {
+		purchaseOrder, err := lockPurchaseOrder(ctx, q, id)
+		if err != nil {
+			return err
+		}
+		if purchaseOrder.Status != models.PurchaseOrderStatusDraft {
+			return fmt.Errorf("%w: only draft purchase orders can be edited (status '%s')", ErrPurchaseOrderStatus, purchaseOrder.Status)
+		}
+
+		if _, err := q.UpdatePurchaseOrderDetails(ctx, db.UpdatePurchaseOrderDetailsParams{
+			ID:         id,
+			LocationID: locationID,
+			Reference:  req.Reference,
+			Notes:      req.Notes,
+			ExpectedAt: optionalTimestamptz(req.ExpectedAt),
+		}); err != nil {
+			return fmt.Errorf("failed to update purchase order: %w", err)
+		}
+
+		if req.Items != nil {
+			if err := q.DeletePurchaseOrderItems(ctx, id); err != nil {
+				return fmt.Errorf("failed to replace purchase order items: %w", err)
+			}
+			return createPurchaseOrderItems(ctx, q, id, req.Items)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetPurchaseOrder(ctx, id)
+}
+
+// PlacePurchaseOrder marks a draft purchase order as ordered from the supplier.
+func (s *PurchaseOrderService) PlacePurchaseOrder(ctx context.Context, id uuid.UUID) (*models.PurchaseOrder, error) {
+	return s.transitionPurchaseOrder(ctx, id, models.PurchaseOrderStatusOrdered, models.PurchaseOrderStatusDraft)
+}
+
+// CancelPurchaseOrder cancels a purchase order that has not received anything yet.
+func (s *PurchaseOrderService) CancelPurchaseOrder(ctx context.Context, id uuid.UUID) (*models.PurchaseOrder, error) {
+	return s.transitionPurchaseOrder(ctx, id, models.PurchaseOrderStatusCancelled, models.PurchaseOrderStatusDraft, models.PurchaseOrderStatusOrdered)
+}
+
+// transitionPurchaseOrder moves a purchase order to a new status if its current status is one of the allowed ones.
+func (s *PurchaseOrderService) transitionPurchaseOrder(ctx context.Context, id uuid.UUID, newStatus string, allowedFrom ...string) (*models.PurchaseOrder, error) {
+	err := s.withTx(ctx, "transitionPurchaseOrder", func(q *db.Queries) error {
+		purchaseOrder, err := lockPurchaseOrder(ctx, q, id)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(allowedFrom, purchaseOrder.Status) {
+			return fmt.Errorf("%w: cannot move from '%s' to '%s'", ErrPurchaseOrderStatus, purchaseOrder.Status, newStatus)
+		}
+		if _, err := q.UpdatePurchaseOrderStatus(ctx, db.UpdatePurchaseOrderStatusParams{ID: id, Status: newStatus}); err != nil {
+			return fmt.Errorf("failed to update purchase order status: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Purchase order status changed", "purchase_order_id", id, "status", newStatus)
+	return s.GetPurchaseOrder(ctx, id)
+}
+
+// ReceivePurchaseOrder receives (part of) an ordered purchase order in one transaction:
+// the stock at the order's location is incremented through the ledger ("restock"), the cost paid is recorded
+// and folded into each product's average cost, and the order becomes partially_received or received.
+func (s *PurchaseOrderService) ReceivePurchaseOrder(ctx context.Context, id uuid.UUID, req models.ReceivePurchaseOrderRequest) (*models.PurchaseOrder, error) {
+	actorID := actorIDFromContext(ctx)
+	var receivedProductIDs []uuid.UUID
+
+	err := s.withTx(ctx, "ReceivePurchaseOrder", func(q *db.Queries) error {
+		purchaseOrder, err := lockPurchaseOrder(ctx, q, id)
+		if err != nil {
+			return err
+		}
+		if purchaseOrder.Status != models.PurchaseOrderStatusOrdered && purchaseOrder.Status != models.PurchaseOrderStatusPartiallyReceived {
+			return fmt.Errorf("%w: only ordered purchase orders can be received (status '%s')", ErrPurchaseOrderStatus, purchaseOrder.Status)
+		}
+
+		lines, err := q.ListPurchaseOrderItems(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to list purchase order items: %w", err)
+		}
+		linesByProduct := make(map[uuid.UUID]db.ListPurchaseOrderItemsRow, len(lines))
+		for _, line := range lines {
+			linesByProduct[line.ProductID] = line
+		}
+
+		note := "Purchase order " + id.String()
+		if purchaseOrder.Reference != nil {
+			note += " (" + *purchaseOrder.Reference + ")"
+		}
+
+		for _, item := range req.Items {
+			line, ok := linesByProduct[item.ProductID]
+			if !ok {
+				return fmt.Errorf("%w: %s", ErrPurchaseOrderItemNotFound, item.ProductID)
+			}
+			quantity := int32(item.Quantity)
+			if _, err := q.ReceivePurchaseOrderItem(ctx, db.ReceivePurchaseOrderItemParams{ID: line.ID, Quantity: quantity}); err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					// line was read before this request's earlier receipts, so report what the row holds now
+					current, err := q.GetPurchaseOrderItemForUpdate(ctx, line.ID)
+					if err != nil {
+						return fmt.Errorf("failed to fetch purchase order item: %w", err)
+					}
+					return fmt.Errorf("%w: %s (received %d, only %d of %d still expected)", ErrPurchaseOrderOverReceipt,
+						line.ProductName, quantity, current.QuantityOrdered-current.QuantityReceived, current.QuantityOrdered)
+				}
+				return fmt.Errorf("failed to update received quantity: %w", err)
+			}
+
+			unitCost := line.UnitCostCents
+			if item.UnitCostCents != nil {
+				unitCost = *item.UnitCostCents
+			}
+			// The average cost is computed against the stock on hand before this receipt
+			if err := q.UpdateProductAverageCost(ctx, db.UpdateProductAverageCostParams{
+				ProductID:     item.ProductID,
+				UnitCostCents: unitCost,
+				Quantity:      quantity,
+			}); err != nil {
+				return fmt.Errorf("failed to update average cost of product %s: %w", item.ProductID, err)
+			}
+
+			movement, err := applyStockMovement(ctx, q, db.ApplyStockMovementParams{
+				ProductID:  item.ProductID,
+				LocationID: purchaseOrder.LocationID,
+				Delta:      quantity,
+				Reason:     models.StockReasonRestock,
+				ActorID:    actorID,
+				Note:       &note,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to add received stock for product %s: %w", item.ProductID, err)
+			}
+
+			if _, err := q.CreatePurchaseOrderReceipt(ctx, db.CreatePurchaseOrderReceiptParams{
+				PurchaseOrderID:     id,
+				PurchaseOrderItemID: line.ID,
+				ProductID:           item.ProductID,
+				LocationID:          purchaseOrder.LocationID,
+				Quantity:            quantity,
+				UnitCostCents:       unitCost,
+				StockMovementID:     movement.ID,
+				ReceivedBy:          actorID,
+			}); err != nil {
+				return fmt.Errorf("failed to record purchase order receipt: %w", err)
+			}
+			receivedProductIDs = append(receivedProductIDs, item.ProductID)
+		}
+
+		outstanding, err := q.CountOutstandingPurchaseOrderItems(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to count outstanding purchase order items: %w", err)
+		}
+		newStatus := models.PurchaseOrderStatusPartiallyReceived
+		if outstanding == 0 {
+			newStatus = models.PurchaseOrderStatusReceived
+		}
+		if _, err := q.UpdatePurchaseOrderStatus(ctx, db.UpdatePurchaseOrderStatusParams{ID: id, Status: newStatus}); err != nil {
+			return fmt.Errorf("failed to update purchase order status: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, productID := range receivedProductIDs {
+		if product, err := s.querier.GetProduct(ctx, productID); err == nil {
+			s.invalidateProductCache(ctx, product)
+		}
+	}
+	s.alerts.CheckProductAlertsAsync(receivedProductIDs...)
+
+	s.logger.Info("Purchase order received", "purchase_order_id", id, "lines", len(req.Items))
+	return s.GetPurchaseOrder(ctx, id)
+}
+
+// withTx runs fn in a transaction and commits it if fn succeeds.
+func (s *PurchaseOrderService) withTx(ctx context.Context, op string, fn func(q *db.Queries) error) error {
+	queries, ok := s.querier.(*db.Queries)
+	if !ok {
+		return errors.New("querier type assertion to *db.Queries failed, cannot create transactional querier")
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			s.logger.Error("Error during transaction rollback in "+op, "error", err)
+		}
+	}()
+
+	if err := fn(queries.WithTx(tx)); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// invalidateProductCache removes the cached product entries after a receipt changed its stock.
+func (s *PurchaseOrderService) invalidateProductCache(ctx context.Context, product db.Product) {
+	keys := []string{
+		fmt.Sprintf(CacheKeyProductByID, product.ID.String()),
+		fmt.Sprintf(CacheKeyProductBySlug, product.Slug),
+	}
+	if err := s.cache.Del(ctx, keys...).Err(); err != nil {
+		s.logger.Error("Failed to invalidate product cache after purchase order receipt", "product_id", product.ID, "keys", keys, "error", err)
+	}
+}
+
+// resolveLocation validates the given stock location, or returns the default location if none is given.
+func (s *PurchaseOrderService) resolveLocation(ctx context.Context, locationID *uuid.UUID) (uuid.UUID, error) {
+	if locationID == nil {
+		return defaultStockLocationID(ctx, s.querier)
+	}
+	if _, err := s.querier.GetStockLocation(ctx, *locationID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, ErrStockLocationNotFound
+		}
+		return uuid.Nil, fmt.Errorf("failed to fetch stock location: %w", err)
+	}
+	return *locationID, nil
+}
+
+// checkProductsExist ensures every product on the purchase order lines exists.
+func (s *PurchaseOrderService) checkProductsExist(ctx context.Context, items []models.PurchaseOrderItemRequest) error {
+	for _, item := range items {
+		if _, err := s.querier.GetProduct(ctx, item.ProductID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("%w: %s", ErrPurchaseOrderProductMissing, item.ProductID)
+			}
+			return fmt.Errorf("failed to fetch product %s: %w", item.ProductID, err)
+		}
+	}
+	return nil
+}
+
+func lockPurchaseOrder(ctx context.Context, q *db.Queries, id uuid.UUID) (db.PurchaseOrder, error) {
+	purchaseOrder, err := q.GetPurchaseOrderForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.PurchaseOrder{}, ErrPurchaseOrderNotFound
+		}
+		return db.PurchaseOrder{}, fmt.Errorf("failed to fetch purchase order: %w", err)
+	}
+	return purchaseOrder, nil
+}
+
+func createPurchaseOrderItems(ctx context.Context, q *db.Queries, purchaseOrderID uuid.UUID, items []models.PurchaseOrderItemRequest) error {
+	for _, item := range items {
+		if _, err := q.CreatePurchaseOrderItem(ctx, db.CreatePurchaseOrderItemParams{
+			PurchaseOrderID: purchaseOrderID,
+			ProductID:       item.ProductID,
+			QuantityOrdered: int32(item.Quantity),
+			UnitCostCents:   item.UnitCostCents,
+		}); err != nil {
+			return fmt.Errorf("failed to create purchase order item for product %s: %w", item.ProductID, err)
+		}
+	}
+	return nil
+}
+
+func toSupplierModel(supplier db.Supplier) models.Supplier {
+	return models.Supplier{
+		ID:          supplier.ID,
+		Name:        supplier.Name,
+		ContactName: supplier.ContactName,
+		Email:       supplier.Email,
+		Phone:       supplier.Phone,
+		Notes:       supplier.Notes,
+		IsActive:    supplier.IsActive,
+		CreatedAt:   supplier.CreatedAt.Time,
+		UpdatedAt:   supplier.UpdatedAt.Time,
+	}
+}
+
+// optionalTimestamptz converts an optional time to a (possibly NULL) timestamptz.
+func optionalTimestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return ToPgTimestamptz(*t)
+}
+
+// timePtrOrNil converts a NULL timestamptz to nil.
+func timePtrOrNil(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
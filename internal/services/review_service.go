@@ -5,32 +5,86 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
+	"mime/multipart"
+	"time"
 
+	"github.com/MihoZaki/DzTech/internal/config"
 	"github.com/MihoZaki/DzTech/internal/db"
 	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/MihoZaki/DzTech/internal/storage"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+var (
+	ErrReviewNotFound         = errors.New("review not found")
+	ErrReviewPurchaseRequired = errors.New("only customers who received this product can review it")
+	ErrReviewOwnVote          = errors.New("you cannot vote on your own review")
+	ErrReviewVoteNotFound     = errors.New("you have not voted on this review")
+	ErrReviewAlreadyReported  = errors.New("you have already reported this review")
+	ErrTooManyReviewImages    = fmt.Errorf("a review can have at most %d images", models.MaxReviewImages)
+	ErrInvalidReviewImage     = errors.New("invalid review image")
+	ErrReviewReplyExists      = errors.New("review already has a reply")
+	ErrReviewReplyNotFound    = errors.New("review reply not found")
+)
+
+// reviewReplyEmailTimeout bounds sending the reply notification in the background.
+const reviewReplyEmailTimeout = 30 * time.Second
+
 // ReviewService handles business logic for reviews.
 type ReviewService struct {
-	querier db.Querier
-	pool    *pgxpool.Pool // Need for transactions
-	logger  *slog.Logger
+	querier      db.Querier
+	pool         *pgxpool.Pool  // Need for transactions
+	storer       storage.Storer // Stores review photos
+	emailService EmailService   // Notifies reviewers of replies
+	config       config.Reviews
+	logger       *slog.Logger
 }
 
-func NewReviewService(querier db.Querier, pool *pgxpool.Pool, logger *slog.Logger) *ReviewService {
+func NewReviewService(querier db.Querier, pool *pgxpool.Pool, storer storage.Storer, emailService EmailService, cfg config.Reviews, logger *slog.Logger) *ReviewService {
 	return &ReviewService{
-		querier: querier,
-		pool:    pool,
-		logger:  logger,
+		querier:      querier,
+		pool:         pool,
+		storer:       storer,
+		emailService: emailService,
+		config:       cfg,
+		logger:       logger,
 	}
 }
 
-// CreateReview creates a new review for a product by a user and updates product stats.
-func (s *ReviewService) CreateReview(ctx context.Context, userID uuid.UUID, req models.CreateReviewRequest) (*models.Review, error) {
+// CreateReview creates a new review for a product by a user, with optional photos, and updates product stats.
+// The review is flagged as a verified purchase if the user has a delivered order containing the product;
+// when reviews are restricted to verified buyers, other users get ErrReviewPurchaseRequired.
+func (s *ReviewService) CreateReview(ctx context.Context, userID uuid.UUID, req models.CreateReviewRequest, imageFileHeaders []*multipart.FileHeader) (*models.Review, error) {
+	if len(imageFileHeaders) > models.MaxReviewImages {
+		return nil, ErrTooManyReviewImages
+	}
+
+	verified, err := s.querier.HasUserPurchasedProduct(ctx, db.HasUserPurchasedProductParams{
+		UserID:    userID,
+		ProductID: req.ProductID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check purchase of reviewed product: %w", err)
+	}
+	if s.config.VerifiedOnly && !verified {
+		return nil, ErrReviewPurchaseRequired
+	}
+
+	imageURLs, err := s.uploadReviewImages(imageFileHeaders)
+	if err != nil {
+		return nil, err
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			s.deleteReviewImageFiles(imageURLs)
+		}
+	}()
+
 	queries, ok := s.querier.(*db.Queries)
 	if !ok {
 		return nil, errors.New("querier type assertion to *db.Queries failed, cannot create transactional querier")
@@ -48,9 +102,15 @@ func (s *ReviewService) CreateReview(ctx context.Context, userID uuid.UUID, req
 	txQuerier := queries.WithTx(tx)
 
 	dbReview, err := txQuerier.CreateReview(ctx, db.CreateReviewParams{
-		UserID:    userID,
-		ProductID: req.ProductID,
-		Rating:    int32(req.Rating),
+		UserID:           userID,
+		ProductID:        req.ProductID,
+		Rating:           int32(req.Rating),
+		Title:            req.Title,
+		Body:             req.Body,
+		Pros:             req.Pros,
+		Cons:             req.Cons,
+		Status:           initialReviewStatus(req.HasText() || len(imageURLs) > 0),
+		VerifiedPurchase: verified,
 	})
 	if err != nil {
 
@@ -64,6 +124,16 @@ func (s *ReviewService) CreateReview(ctx context.Context, userID uuid.UUID, req
 		return nil, fmt.Errorf("failed to create review in transaction: %w", err)
 	}
 
+	for i, url := range imageURLs {
+		if err := txQuerier.CreateReviewImage(ctx, db.CreateReviewImageParams{
+			ReviewID: dbReview.ID,
+			Url:      url,
+			Position: int32(i),
+		}); err != nil {
+			return nil, fmt.Errorf("failed to attach image to review in transaction: %w", err)
+		}
+	}
+
 	// This happens within the same transaction to ensure consistency
 	err = s.updateProductReviewStats(ctx, txQuerier, req.ProductID)
 	if err != nil {
@@ -73,21 +143,98 @@ func (s *ReviewService) CreateReview(ctx context.Context, userID uuid.UUID, req
 	if err = tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("failed to commit review creation transaction: %w", err)
 	}
+	committed = true
 
 	apiReview := &models.Review{
-		ID:        dbReview.ID,
-		UserID:    dbReview.UserID,
-		ProductID: dbReview.ProductID,
-		Rating:    int(dbReview.Rating),
-		CreatedAt: dbReview.CreatedAt.Time,
-		UpdatedAt: dbReview.UpdatedAt.Time,
+		ID:               dbReview.ID,
+		UserID:           dbReview.UserID,
+		ProductID:        dbReview.ProductID,
+		Rating:           int(dbReview.Rating),
+		Title:            dbReview.Title,
+		Body:             dbReview.Body,
+		Pros:             dbReview.Pros,
+		Cons:             dbReview.Cons,
+		Status:           dbReview.Status,
+		ModerationReason: dbReview.ModerationReason,
+		VerifiedPurchase: dbReview.VerifiedPurchase,
+		ImageURLs:        imageURLsOrEmpty(imageURLs),
+		CreatedAt:        dbReview.CreatedAt.Time,
+		UpdatedAt:        dbReview.UpdatedAt.Time,
 	}
 
 	return apiReview, nil
 }
 
+// uploadReviewImages stores the uploaded review photos and returns their URLs.
+// If one upload fails, the photos already stored are deleted.
+func (s *ReviewService) uploadReviewImages(fileHeaders []*multipart.FileHeader) ([]string, error) {
+	var urls []string
+	for _, fileHeader := range fileHeaders {
+		file, err := fileHeader.Open()
+		if err != nil {
+			s.deleteReviewImageFiles(urls)
+			return nil, fmt.Errorf("failed to open uploaded file %s: %w", fileHeader.Filename, err)
+		}
+
+		url, err := s.storer.UploadFile(file, fileHeader)
+		file.Close()
+		if err != nil {
+			s.deleteReviewImageFiles(urls)
+			return nil, fmt.Errorf("%w %s: %v", ErrInvalidReviewImage, fileHeader.Filename, err)
+		}
+		urls = append(urls, url)
+	}
+	return urls, nil
+}
+
+// deleteReviewImageFiles deletes stored review photos. Failures are logged, not returned.
+func (s *ReviewService) deleteReviewImageFiles(urls []string) {
+	for _, url := range urls {
+		if err := s.storer.DeleteFile(url); err != nil {
+			s.logger.Error("Failed to delete review image file", "url", url, "error", err)
+		}
+	}
+}
+
+// reviewImageURLs returns the photo URLs of the given reviews, keyed by review ID.
+func (s *ReviewService) reviewImageURLs(ctx context.Context, querier db.Querier, reviewIDs []uuid.UUID) (map[uuid.UUID][]string, error) {
+	urls := make(map[uuid.UUID][]string, len(reviewIDs))
+	if len(reviewIDs) == 0 {
+		return urls, nil
+	}
+	rows, err := querier.ListReviewImagesByReviewIDs(ctx, reviewIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch review images: %w", err)
+	}
+	for _, row := range rows {
+		urls[row.ReviewID] = append(urls[row.ReviewID], row.Url)
+	}
+	return urls, nil
+}
+
+// imageURLsOrEmpty keeps reviews without photos serialised as an empty list rather than null.
+func imageURLsOrEmpty(urls []string) []string {
+	if urls == nil {
+		return []string{}
+	}
+	return urls
+}
+
+// initialReviewStatus returns the moderation status of a new or edited review.
+// Reviews with text or photos wait in the moderation queue; a bare star rating has nothing to moderate.
+func initialReviewStatus(hasContent bool) string {
+	if hasContent {
+		return models.ReviewStatusPending
+	}
+	return models.ReviewStatusApproved
+}
+
+// updateProductReviewStats recalculates a product's avg_rating and num_ratings from its approved reviews.
 func (s *ReviewService) updateProductReviewStats(ctx context.Context, querier db.Querier, productID uuid.UUID) error {
-	stats, err := querier.CalculateReviewStatsForProduct(ctx, productID)
+	stats, err := querier.CalculateReviewStatsForProduct(ctx, db.CalculateReviewStatsForProductParams{
+		ProductID:      productID,
+		VerifiedWeight: s.config.VerifiedWeight,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to calculate review stats for product %s: %w", productID, err)
 	}
@@ -151,8 +298,18 @@ func (s *ReviewService) UpdateReview(ctx context.Context, reviewID uuid.UUID, us
 		return nil, fmt.Errorf("failed to fetch review for update: %w", err)
 	}
 
+	images, err := s.reviewImageURLs(ctx, txQuerier, []uuid.UUID{reviewID})
+	if err != nil {
+		return nil, err
+	}
+
 	dbReview, err := txQuerier.UpdateReview(ctx, db.UpdateReviewParams{
 		Rating: int32(req.Rating),
+		Title:  req.Title,
+		Body:   req.Body,
+		Pros:   req.Pros,
+		Cons:   req.Cons,
+		Status: initialReviewStatus(req.HasText() || len(images[reviewID]) > 0),
 		ID:     reviewID,
 		UserID: userID,
 	})
@@ -169,18 +326,26 @@ func (s *ReviewService) UpdateReview(ctx context.Context, reviewID uuid.UUID, us
 		return nil, fmt.Errorf("failed to commit review update transaction: %w", err)
 	}
 	apiReview := &models.Review{
-		ID:        dbReview.ID,
-		UserID:    dbReview.UserID,
-		ProductID: dbReview.ProductID,
-		Rating:    int(dbReview.Rating),
-		CreatedAt: dbReview.CreatedAt.Time,
-		UpdatedAt: dbReview.UpdatedAt.Time,
+		ID:               dbReview.ID,
+		UserID:           dbReview.UserID,
+		ProductID:        dbReview.ProductID,
+		Rating:           int(dbReview.Rating),
+		Title:            dbReview.Title,
+		Body:             dbReview.Body,
+		Pros:             dbReview.Pros,
+		Cons:             dbReview.Cons,
+		Status:           dbReview.Status,
+		ModerationReason: dbReview.ModerationReason,
+		VerifiedPurchase: dbReview.VerifiedPurchase,
+		ImageURLs:        imageURLsOrEmpty(images[reviewID]),
+		CreatedAt:        dbReview.CreatedAt.Time,
+		UpdatedAt:        dbReview.UpdatedAt.Time,
 	}
 
 	return apiReview, nil
 }
 
-// DeleteReview deletes an existing review by the user and recalculates product stats.
+// DeleteReview deletes an existing review by the user, with its photos, and recalculates product stats.
 func (s *ReviewService) DeleteReview(ctx context.Context, reviewID uuid.UUID, userID uuid.UUID) error {
 
 	queries, ok := s.querier.(*db.Queries)
@@ -222,6 +387,11 @@ func (s *ReviewService) DeleteReview(ctx context.Context, reviewID uuid.UUID, us
 		return fmt.Errorf("failed to delete review in transaction: %w", err)
 	}
 
+	imageURLs, err := txQuerier.DeleteReviewImages(ctx, reviewID)
+	if err != nil {
+		return fmt.Errorf("failed to delete review images in transaction: %w", err)
+	}
+
 	err = s.updateProductReviewStats(ctx, txQuerier, reviewToDelete.ProductID)
 	if err != nil {
 		return fmt.Errorf("failed to update product review stats in transaction: %w", err)
@@ -231,21 +401,30 @@ func (s *ReviewService) DeleteReview(ctx context.Context, reviewID uuid.UUID, us
 		return fmt.Errorf("failed to commit review deletion transaction: %w", err)
 	}
 
+	// The files are only removed once the review is gone for good
+	s.deleteReviewImageFiles(imageURLs)
+
 	return nil
 }
 
-// GetReviewsByProductID fetches reviews for a specific product.
-func (s *ReviewService) GetReviewsByProductID(ctx context.Context, productID uuid.UUID, page, limit int) (*models.GetReviewsByProductResponse, error) {
+// GetReviewsByProductID fetches the approved reviews for a specific product, sorted by the given order
+// (newest by default) and optionally filtered to one star rating (0 = all), with the product's rating distribution.
+func (s *ReviewService) GetReviewsByProductID(ctx context.Context, productID uuid.UUID, sort string, rating, page, limit int) (*models.GetReviewsByProductResponse, error) {
 	if limit <= 0 {
 		limit = 20 // Default limit
 	}
 	if page <= 0 {
 		page = 1 // Default page
 	}
+	if sort == "" {
+		sort = models.ReviewSortNewest
+	}
 	offset := (page - 1) * limit
 
 	dbReviews, err := s.querier.GetReviewsByProductID(ctx, db.GetReviewsByProductIDParams{
 		ProductID:  productID,
+		Rating:     int32(rating),
+		Sort:       sort,
 		PageOffset: int32(offset),
 		PageLimit:  int32(limit),
 	})
@@ -253,26 +432,150 @@ func (s *ReviewService) GetReviewsByProductID(ctx context.Context, productID uui
 		return nil, fmt.Errorf("failed to fetch reviews for product: %w", err)
 	}
 
+	reviewIDs := make([]uuid.UUID, len(dbReviews))
+	for i, r := range dbReviews {
+		reviewIDs[i] = r.ID
+	}
+	images, err := s.reviewImageURLs(ctx, s.querier, reviewIDs)
+	if err != nil {
+		return nil, err
+	}
+	replies, err := s.reviewReplies(ctx, reviewIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	reviewListItems := make([]models.ReviewListItem, len(dbReviews))
 	for i, r := range dbReviews {
 		reviewListItems[i] = models.ReviewListItem{
-			ID:           r.ID,
-			UserID:       r.UserID,
-			ReviewerName: *r.ReviewerName,
-			ProductID:    r.ProductID,
-			Rating:       int(r.Rating),
-			CreatedAt:    r.CreatedAt.Time,
-			UpdatedAt:    r.UpdatedAt.Time,
+			ID:               r.ID,
+			UserID:           r.UserID,
+			ReviewerName:     *r.ReviewerName,
+			ProductID:        r.ProductID,
+			Rating:           int(r.Rating),
+			Title:            r.Title,
+			Body:             r.Body,
+			Pros:             r.Pros,
+			Cons:             r.Cons,
+			VerifiedPurchase: r.VerifiedPurchase,
+			HelpfulCount:     int(r.HelpfulCount),
+			UnhelpfulCount:   int(r.UnhelpfulCount),
+			ImageURLs:        imageURLsOrEmpty(images[r.ID]),
+			Reply:            replies[r.ID],
+			CreatedAt:        r.CreatedAt.Time,
+			UpdatedAt:        r.UpdatedAt.Time,
+		}
+	}
+
+	total, err := s.querier.CountReviewsByProductID(ctx, db.CountReviewsByProductIDParams{
+		ProductID: productID,
+		Rating:    int32(rating),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reviews for product: %w", err)
+	}
+
+	distributionRows, err := s.querier.GetReviewRatingDistribution(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rating distribution for product: %w", err)
+	}
+	distribution := make([]models.RatingCount, 5)
+	for i := range distribution {
+		distribution[i].Rating = i + 1
+	}
+	for _, row := range distributionRows {
+		if row.Rating >= 1 && row.Rating <= 5 {
+			distribution[row.Rating-1].Count = row.Count
 		}
 	}
 
 	return &models.GetReviewsByProductResponse{
-		Reviews: reviewListItems,
-		Page:    page,
-		Limit:   limit,
+		Reviews:      reviewListItems,
+		Page:         page,
+		Limit:        limit,
+		Total:        total,
+		Sort:         sort,
+		Rating:       rating,
+		Distribution: distribution,
+	}, nil
+}
+
+// VoteReview records the user's helpful or unhelpful vote on an approved review, replacing any previous vote.
+// Users cannot vote on their own reviews.
+func (s *ReviewService) VoteReview(ctx context.Context, reviewID, userID uuid.UUID, helpful bool) (*models.ReviewVoteSummary, error) {
+	review, err := s.querier.GetApprovedReview(ctx, reviewID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch review for vote: %w", err)
+	}
+	if review.UserID == userID {
+		return nil, ErrReviewOwnVote
+	}
+
+	if err := s.querier.UpsertReviewVote(ctx, db.UpsertReviewVoteParams{
+		ReviewID:  reviewID,
+		UserID:    userID,
+		IsHelpful: helpful,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to record review vote: %w", err)
+	}
+	return s.refreshVoteCounts(ctx, reviewID)
+}
+
+// RemoveReviewVote removes the user's vote on a review.
+func (s *ReviewService) RemoveReviewVote(ctx context.Context, reviewID, userID uuid.UUID) (*models.ReviewVoteSummary, error) {
+	deleted, err := s.querier.DeleteReviewVote(ctx, db.DeleteReviewVoteParams{
+		ReviewID: reviewID,
+		UserID:   userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove review vote: %w", err)
+	}
+	if deleted == 0 {
+		return nil, ErrReviewVoteNotFound
+	}
+	return s.refreshVoteCounts(ctx, reviewID)
+}
+
+func (s *ReviewService) refreshVoteCounts(ctx context.Context, reviewID uuid.UUID) (*models.ReviewVoteSummary, error) {
+	counts, err := s.querier.RefreshReviewVoteCounts(ctx, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh review vote counts: %w", err)
+	}
+	return &models.ReviewVoteSummary{
+		ReviewID:       reviewID,
+		HelpfulCount:   int(counts.HelpfulCount),
+		UnhelpfulCount: int(counts.UnhelpfulCount),
 	}, nil
 }
 
+// ReportReview records an abuse report on an approved review, which puts it in the moderation queue.
+// The review stays visible until a moderator rejects it.
+func (s *ReviewService) ReportReview(ctx context.Context, reviewID, userID uuid.UUID, reason string) error {
+	if _, err := s.querier.GetApprovedReview(ctx, reviewID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrReviewNotFound
+		}
+		return fmt.Errorf("failed to fetch review for report: %w", err)
+	}
+
+	if _, err := s.querier.CreateReviewReport(ctx, db.CreateReviewReportParams{
+		ReviewID: reviewID,
+		UserID:   userID,
+		Reason:   reason,
+	}); err != nil {
+		if IsUniqueViolation(err, "idx_review_reports_open_unique") {
+			return ErrReviewAlreadyReported
+		}
+		return fmt.Errorf("failed to report review: %w", err)
+	}
+
+	s.logger.Info("Review reported", "review_id", reviewID, "user_id", userID)
+	return nil
+}
+
 // GetReviewsByUserID fetches reviews submitted by a specific user.
 // This method does not update product stats, just reads reviews.
 func (s *ReviewService) GetReviewsByUserID(ctx context.Context, userID uuid.UUID, page, limit int) (*models.GetReviewsByUserResponse, error) {
@@ -293,16 +596,38 @@ func (s *ReviewService) GetReviewsByUserID(ctx context.Context, userID uuid.UUID
 		return nil, fmt.Errorf("failed to fetch reviews by user: %w", err)
 	}
 
+	reviewIDs := make([]uuid.UUID, len(dbReviews))
+	for i, r := range dbReviews {
+		reviewIDs[i] = r.ID
+	}
+	images, err := s.reviewImageURLs(ctx, s.querier, reviewIDs)
+	if err != nil {
+		return nil, err
+	}
+	replies, err := s.reviewReplies(ctx, reviewIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	reviewByUserListItems := make([]models.ReviewByUserListItem, len(dbReviews))
 	for i, r := range dbReviews {
 		reviewByUserListItems[i] = models.ReviewByUserListItem{
-			ID:          r.ID,
-			UserID:      r.UserID,
-			ProductID:   r.ProductID,
-			ProductName: r.ProductName,
-			Rating:      int(r.Rating),
-			CreatedAt:   r.CreatedAt.Time,
-			UpdatedAt:   r.UpdatedAt.Time,
+			ID:               r.ID,
+			UserID:           r.UserID,
+			ProductID:        r.ProductID,
+			ProductName:      r.ProductName,
+			Rating:           int(r.Rating),
+			Title:            r.Title,
+			Body:             r.Body,
+			Pros:             r.Pros,
+			Cons:             r.Cons,
+			Status:           r.Status,
+			ModerationReason: r.ModerationReason,
+			VerifiedPurchase: r.VerifiedPurchase,
+			ImageURLs:        imageURLsOrEmpty(images[r.ID]),
+			Reply:            replies[r.ID],
+			CreatedAt:        r.CreatedAt.Time,
+			UpdatedAt:        r.UpdatedAt.Time,
 		}
 	}
 
@@ -312,3 +637,342 @@ func (s *ReviewService) GetReviewsByUserID(ctx context.Context, userID uuid.UUID
 		Limit:   limit,
 	}, nil
 }
+
+// MarkVerifiedPurchases flags the reviews a customer already wrote for the products of a delivered order
+// as verified purchases and recalculates the affected products' ratings.
+// It is called after the order is marked delivered; failures are logged, not returned.
+func (s *ReviewService) MarkVerifiedPurchases(ctx context.Context, orderID uuid.UUID) {
+	if s == nil {
+		return
+	}
+	queries, ok := s.querier.(*db.Queries)
+	if !ok {
+		s.logger.Error("querier type assertion to *db.Queries failed, cannot mark verified purchases", "order_id", orderID)
+		return
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction for verified purchases", "order_id", orderID, "error", err)
+		return
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			s.logger.Error("Error during verified purchases transaction rollback", "error", err)
+		}
+	}()
+
+	txQuerier := queries.WithTx(tx)
+
+	productIDs, err := txQuerier.MarkReviewsVerifiedForOrder(ctx, orderID)
+	if err != nil {
+		s.logger.Error("Failed to mark reviews as verified purchases", "order_id", orderID, "error", err)
+		return
+	}
+	for _, productID := range productIDs {
+		if err := s.updateProductReviewStats(ctx, txQuerier, productID); err != nil {
+			s.logger.Error("Failed to update product review stats for verified purchase", "order_id", orderID, "product_id", productID, "error", err)
+			return
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.logger.Error("Failed to commit verified purchases transaction", "order_id", orderID, "error", err)
+		return
+	}
+	if len(productIDs) > 0 {
+		s.logger.Info("Reviews marked as verified purchases", "order_id", orderID, "count", len(productIDs))
+	}
+}
+
+// --- Moderation ---
+
+// ListReviewsForModeration lists reviews for the admin moderation queue, oldest first.
+// An empty status lists reviews of every status; reportedOnly limits the list to reviews with open abuse reports.
+func (s *ReviewService) ListReviewsForModeration(ctx context.Context, status string, reportedOnly bool, page, limit int) (*models.PaginatedResponse, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if page <= 0 {
+		page = 1
+	}
+	offset := (page - 1) * limit
+
+	rows, err := s.querier.ListReviewsForModeration(ctx, db.ListReviewsForModerationParams{
+		Status:       status,
+		ReportedOnly: reportedOnly,
+		PageOffset:   int32(offset),
+		PageLimit:    int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviews for moderation: %w", err)
+	}
+
+	reviewIDs := make([]uuid.UUID, len(rows))
+	for i, r := range rows {
+		reviewIDs[i] = r.ID
+	}
+	images, err := s.reviewImageURLs(ctx, s.querier, reviewIDs)
+	if err != nil {
+		return nil, err
+	}
+	replies, err := s.reviewReplies(ctx, reviewIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews := make([]models.AdminReviewListItem, len(rows))
+	for i, r := range rows {
+		reviews[i] = toAdminReviewListItem(db.GetReviewForModerationRow(r), images[r.ID])
+		reviews[i].Reply = replies[r.ID]
+	}
+
+	total, err := s.querier.CountReviewsForModeration(ctx, db.CountReviewsForModerationParams{
+		Status:       status,
+		ReportedOnly: reportedOnly,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reviews for moderation: %w", err)
+	}
+
+	return &models.PaginatedResponse{
+		Data:       reviews,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}, nil
+}
+
+// GetReviewForModeration retrieves a single review with its author, product and open abuse reports.
+func (s *ReviewService) GetReviewForModeration(ctx context.Context, reviewID uuid.UUID) (*models.AdminReviewListItem, error) {
+	row, err := s.querier.GetReviewForModeration(ctx, reviewID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch review: %w", err)
+	}
+	images, err := s.reviewImageURLs(ctx, s.querier, []uuid.UUID{reviewID})
+	if err != nil {
+		return nil, err
+	}
+	result := toAdminReviewListItem(row, images[reviewID])
+
+	replies, err := s.reviewReplies(ctx, []uuid.UUID{reviewID})
+	if err != nil {
+		return nil, err
+	}
+	result.Reply = replies[reviewID]
+
+	reports, err := s.querier.ListOpenReviewReports(ctx, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list review reports: %w", err)
+	}
+	result.Reports = make([]models.ReviewReport, len(reports))
+	for i, report := range reports {
+		result.Reports[i] = models.ReviewReport{
+			ID:            report.ID,
+			UserID:        report.UserID,
+			ReporterEmail: report.ReporterEmail,
+			Reason:        report.Reason,
+			CreatedAt:     report.CreatedAt.Time,
+		}
+	}
+	return &result, nil
+}
+
+// ApproveReview publishes a review and recalculates the product's rating.
+func (s *ReviewService) ApproveReview(ctx context.Context, reviewID uuid.UUID) (*models.AdminReviewListItem, error) {
+	return s.moderateReview(ctx, reviewID, models.ReviewStatusApproved, nil)
+}
+
+// RejectReview hides a review with a reason shown to its author and recalculates the product's rating.
+func (s *ReviewService) RejectReview(ctx context.Context, reviewID uuid.UUID, reason string) (*models.AdminReviewListItem, error) {
+	return s.moderateReview(ctx, reviewID, models.ReviewStatusRejected, &reason)
+}
+
+func (s *ReviewService) moderateReview(ctx context.Context, reviewID uuid.UUID, status string, reason *string) (*models.AdminReviewListItem, error) {
+	queries, ok := s.querier.(*db.Queries)
+	if !ok {
+		return nil, errors.New("querier type assertion to *db.Queries failed, cannot create transactional querier")
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction for review moderation: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			s.logger.Error("Error during review moderation transaction rollback", "error", err)
+		}
+	}()
+
+	txQuerier := queries.WithTx(tx)
+
+	actorID := actorIDFromContext(ctx)
+	moderated, err := txQuerier.ModerateReview(ctx, db.ModerateReviewParams{
+		Status:           status,
+		ModerationReason: reason,
+		ModeratedBy:      actorID,
+		ID:               reviewID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to moderate review: %w", err)
+	}
+
+	// The moderator's decision answers any open abuse reports
+	if err := txQuerier.ResolveReviewReports(ctx, db.ResolveReviewReportsParams{
+		ReviewID:   reviewID,
+		ResolvedBy: actorID,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to resolve review reports: %w", err)
+	}
+
+	if err := s.updateProductReviewStats(ctx, txQuerier, moderated.ProductID); err != nil {
+		return nil, fmt.Errorf("failed to update product review stats in transaction: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit review moderation transaction: %w", err)
+	}
+
+	s.logger.Info("Review moderated", "review_id", reviewID, "status", status)
+	return s.GetReviewForModeration(ctx, reviewID)
+}
+
+// CreateReviewReply posts the store's official reply to a review and emails the reviewer.
+func (s *ReviewService) CreateReviewReply(ctx context.Context, reviewID uuid.UUID, body string) (*models.ReviewReply, error) {
+	recipient, err := s.querier.GetReviewReplyRecipient(ctx, reviewID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch review: %w", err)
+	}
+
+	reply, err := s.querier.CreateReviewReply(ctx, db.CreateReviewReplyParams{
+		ReviewID: reviewID,
+		AuthorID: actorIDFromContext(ctx),
+		Body:     body,
+	})
+	if err != nil {
+		if IsUniqueViolation(err, "review_replies_review_id_key") {
+			return nil, ErrReviewReplyExists
+		}
+		return nil, fmt.Errorf("failed to create review reply: %w", err)
+	}
+
+	s.notifyReviewReply(recipient.ReviewerEmail, models.ReviewReplyNotification{
+		ProductName: recipient.ProductName,
+		ProductSlug: recipient.ProductSlug,
+		ReplyBody:   reply.Body,
+	})
+
+	result := toReviewReplyModel(reply)
+	return &result, nil
+}
+
+// UpdateReviewReply edits the official reply to a review.
+func (s *ReviewService) UpdateReviewReply(ctx context.Context, reviewID uuid.UUID, body string) (*models.ReviewReply, error) {
+	reply, err := s.querier.UpdateReviewReply(ctx, db.UpdateReviewReplyParams{
+		Body:     body,
+		AuthorID: actorIDFromContext(ctx),
+		ReviewID: reviewID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReviewReplyNotFound
+		}
+		return nil, fmt.Errorf("failed to update review reply: %w", err)
+	}
+
+	result := toReviewReplyModel(reply)
+	return &result, nil
+}
+
+// DeleteReviewReply removes the official reply to a review.
+func (s *ReviewService) DeleteReviewReply(ctx context.Context, reviewID uuid.UUID) error {
+	affected, err := s.querier.DeleteReviewReply(ctx, reviewID)
+	if err != nil {
+		return fmt.Errorf("failed to delete review reply: %w", err)
+	}
+	if affected == 0 {
+		return ErrReviewReplyNotFound
+	}
+	return nil
+}
+
+// notifyReviewReply emails the reviewer in the background so the admin request does not wait on SMTP.
+func (s *ReviewService) notifyReviewReply(toEmail string, notification models.ReviewReplyNotification) {
+	if s.emailService == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), reviewReplyEmailTimeout)
+		defer cancel()
+		if err := s.emailService.SendReviewReplyEmail(ctx, toEmail, notification); err != nil {
+			s.logger.Error("Failed to send review reply email", "to", toEmail, "product_slug", notification.ProductSlug, "error", err)
+		}
+	}()
+}
+
+// reviewReplies returns the official replies of the given reviews, keyed by review ID.
+func (s *ReviewService) reviewReplies(ctx context.Context, reviewIDs []uuid.UUID) (map[uuid.UUID]*models.ReviewReply, error) {
+	replies := make(map[uuid.UUID]*models.ReviewReply, len(reviewIDs))
+	if len(reviewIDs) == 0 {
+		return replies, nil
+	}
+	rows, err := s.querier.ListReviewRepliesByReviewIDs(ctx, reviewIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch review replies: %w", err)
+	}
+	for _, row := range rows {
+		reply := toReviewReplyModel(row)
+		replies[row.ReviewID] = &reply
+	}
+	return replies, nil
+}
+
+func toReviewReplyModel(r db.ReviewReply) models.ReviewReply {
+	return models.ReviewReply{
+		ID:        r.ID,
+		ReviewID:  r.ReviewID,
+		AuthorID:  uuidPtrOrNil(r.AuthorID),
+		Body:      r.Body,
+		CreatedAt: r.CreatedAt.Time,
+		UpdatedAt: r.UpdatedAt.Time,
+	}
+}
+
+func toAdminReviewListItem(r db.GetReviewForModerationRow, imageURLs []string) models.AdminReviewListItem {
+	var reviewerName string
+	if r.ReviewerName != nil {
+		reviewerName = *r.ReviewerName
+	}
+	return models.AdminReviewListItem{
+		ID:               r.ID,
+		UserID:           r.UserID,
+		ReviewerName:     reviewerName,
+		ReviewerEmail:    r.ReviewerEmail,
+		ProductID:        r.ProductID,
+		ProductName:      r.ProductName,
+		Rating:           int(r.Rating),
+		Title:            r.Title,
+		Body:             r.Body,
+		Pros:             r.Pros,
+		Cons:             r.Cons,
+		Status:           r.Status,
+		ModerationReason: r.ModerationReason,
+		VerifiedPurchase: r.VerifiedPurchase,
+		HelpfulCount:     int(r.HelpfulCount),
+		UnhelpfulCount:   int(r.UnhelpfulCount),
+		OpenReportCount:  r.OpenReportCount,
+		ImageURLs:        imageURLsOrEmpty(imageURLs),
+		ModeratedBy:      uuidPtrOrNil(r.ModeratedBy),
+		ModeratedAt:      timePtrOrNil(r.ModeratedAt),
+		CreatedAt:        r.CreatedAt.Time,
+		UpdatedAt:        r.UpdatedAt.Time,
+	}
+}
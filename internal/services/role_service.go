@@ -0,0 +1,261 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/MihoZaki/DzTech/internal/db"
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrRoleNotFound     = errors.New("role not found")
+	ErrLastSuperuser    = errors.New("at least one active user must keep the superuser role")
+	ErrRoleNotGrantable = errors.New("cannot grant a role with permissions you do not hold")
+)
+
+// RoleService handles business logic for roles, permissions and their assignment to staff.
+type RoleService struct {
+	querier db.Querier
+	pool    *pgxpool.Pool // Need for transactions
+	auth    *AuthService  // Revokes tokens carrying outdated permissions
+	logger  *slog.Logger
+}
+
+// NewRoleService creates a new instance of RoleService.
+func NewRoleService(querier db.Querier, pool *pgxpool.Pool, auth *AuthService, logger *slog.Logger) *RoleService {
+	return &RoleService{
+		querier: querier,
+		pool:    pool,
+		auth:    auth,
+		logger:  logger,
+	}
+}
+
+// ListRoles retrieves every role with the permissions it grants.
+func (s *RoleService) ListRoles(ctx context.Context) ([]models.Role, error) {
+	dbRoles, err := s.querier.ListRoles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	grants, err := s.querier.ListRolePermissions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list role permissions: %w", err)
+	}
+
+	permissions := make(map[uuid.UUID][]string, len(dbRoles))
+	for _, g := range grants {
+		permissions[g.RoleID] = append(permissions[g.RoleID], g.PermissionCode)
+	}
+
+	roles := make([]models.Role, len(dbRoles))
+	for i, r := range dbRoles {
+		rolePermissions := permissions[r.ID]
+		if rolePermissions == nil {
+			rolePermissions = []string{}
+		}
+		roles[i] = models.Role{
+			ID:          r.ID,
+			Name:        r.Name,
+			Description: r.Description,
+			Permissions: rolePermissions,
+			CreatedAt:   r.CreatedAt.Time,
+		}
+	}
+	return roles, nil
+}
+
+// ListPermissions retrieves every permission that can be granted.
+func (s *RoleService) ListPermissions(ctx context.Context) ([]models.Permission, error) {
+	dbPermissions, err := s.querier.ListPermissions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list permissions: %w", err)
+	}
+	permissions := make([]models.Permission, len(dbPermissions))
+	for i, p := range dbPermissions {
+		permissions[i] = models.Permission{
+			Code:        p.Code,
+			Description: p.Description,
+		}
+	}
+	return permissions, nil
+}
+
+// GetUserRoles retrieves the roles assigned to a user and the permissions they grant.
+func (s *RoleService) GetUserRoles(ctx context.Context, userID uuid.UUID) (*models.UserRoles, error) {
+	if _, err := s.querier.GetUser(ctx, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+	return s.userRoles(ctx, s.querier, userID)
+}
+
+// SetUserRoles replaces the roles assigned to a user. Users with at least one role are staff and may
+// enter the admin area; removing every role turns them back into customers.
+// The user's existing tokens are revoked, so they sign in again and get tokens carrying the new permissions.
+// Like API key scopes, a role can only be granted by someone holding every permission it grants.
+func (s *RoleService) SetUserRoles(ctx context.Context, userID uuid.UUID, roleNames []string) (*models.UserRoles, error) {
+	if _, err := s.querier.GetUser(ctx, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	slices.Sort(roleNames)
+	roleNames = slices.Compact(roleNames)
+	roles, err := s.querier.GetRolesByNames(ctx, roleNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch roles: %w", err)
+	}
+	if len(roles) != len(roleNames) {
+		return nil, ErrRoleNotFound
+	}
+	if err := s.checkGrantable(ctx, userID, roles); err != nil {
+		return nil, err
+	}
+
+	queries, ok := s.querier.(*db.Queries)
+	if !ok {
+		return nil, errors.New("querier type assertion to *db.Queries failed, cannot create transactional querier")
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction for role assignment: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			s.logger.Error("Error during role assignment transaction rollback", "error", err)
+		}
+	}()
+
+	txQuerier := queries.WithTx(tx)
+
+	if err := lockSuperusers(ctx, txQuerier); err != nil {
+		return nil, err
+	}
+	if err := txQuerier.DeleteUserRoles(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to clear user roles: %w", err)
+	}
+	actorID := actorIDFromContext(ctx)
+	for _, role := range roles {
+		if err := txQuerier.AddUserRole(ctx, db.AddUserRoleParams{
+			UserID:     userID,
+			RoleID:     role.ID,
+			AssignedBy: actorID,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to assign role %s: %w", role.Name, err)
+		}
+	}
+	if err := txQuerier.SetUserAdminFlag(ctx, db.SetUserAdminFlagParams{
+		IsAdmin: len(roles) > 0,
+		ID:      userID,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update user admin flag: %w", err)
+	}
+
+	if err := ensureSuperuserRemains(ctx, txQuerier); err != nil {
+		return nil, err
+	}
+
+	result, err := s.userRoles(ctx, txQuerier, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sessions issued under the old roles end together with the change
+	tokenVersion, err := revokeUserTokens(ctx, txQuerier, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke tokens for role change: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit role assignment transaction: %w", err)
+	}
+	if err := s.auth.cacheTokenVersion(ctx, userID, tokenVersion); err != nil {
+		// The new version is committed; tokens are rejected once the cached version expires
+		s.logger.Error("Failed to publish token version after role change", "user_id", userID, "error", err)
+	}
+
+	s.logger.Info("User roles updated", "user_id", userID, "roles", roleNames, "assigned_by", actorID)
+	return result, nil
+}
+
+// checkGrantable refuses roles newly granted to a user that carry permissions the acting user does not hold.
+func (s *RoleService) checkGrantable(ctx context.Context, userID uuid.UUID, roles []db.GetRolesByNamesRow) error {
+	actor, ok := models.GetUserFromContext(ctx)
+	if !ok || actor == nil {
+		return ErrRoleNotGrantable
+	}
+	current, err := s.querier.ListUserRoleNames(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to list user roles: %w", err)
+	}
+	grants, err := s.querier.ListRolePermissions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list role permissions: %w", err)
+	}
+
+	for _, role := range roles {
+		if slices.Contains(current, role.Name) {
+			continue // Keeping a role the user already has grants nothing new
+		}
+		for _, g := range grants {
+			if g.RoleID == role.ID && !actor.HasPermission(g.PermissionCode) {
+				return fmt.Errorf("%w: %s", ErrRoleNotGrantable, role.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// lockSuperusers must start every transaction that may take the superuser role away from a user. Together with
+// ensureSuperuserRemains at its end, concurrent transactions cannot both remove one of the last two superusers.
+func lockSuperusers(ctx context.Context, q db.Querier) error {
+	if err := q.LockUserRolesByRoleName(ctx, models.RoleSuperuser); err != nil {
+		return fmt.Errorf("failed to lock superuser assignments: %w", err)
+	}
+	return nil
+}
+
+// ensureSuperuserRemains returns ErrLastSuperuser when no active user holds the superuser role any more.
+func ensureSuperuserRemains(ctx context.Context, q db.Querier) error {
+	superusers, err := q.CountActiveUsersWithRole(ctx, models.RoleSuperuser)
+	if err != nil {
+		return fmt.Errorf("failed to count superusers: %w", err)
+	}
+	if superusers == 0 {
+		return ErrLastSuperuser
+	}
+	return nil
+}
+
+func (s *RoleService) userRoles(ctx context.Context, querier db.Querier, userID uuid.UUID) (*models.UserRoles, error) {
+	roleNames, err := querier.ListUserRoleNames(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user roles: %w", err)
+	}
+	permissions, err := querier.ListUserPermissions(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user permissions: %w", err)
+	}
+	if roleNames == nil {
+		roleNames = []string{}
+	}
+	if permissions == nil {
+		permissions = []string{}
+	}
+	return &models.UserRoles{
+		UserID:      userID,
+		Roles:       roleNames,
+		Permissions: permissions,
+	}, nil
+}
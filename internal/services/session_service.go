@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/MihoZaki/DzTech/internal/db"
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+// SessionService lets users and admins list and revoke signed-in sessions.
+// A session is a refresh token family; revoking it also rejects the access tokens it issued.
+type SessionService struct {
+	querier db.Querier
+	cache   *redis.Client // Holds the revoked-session deny-list checked by JWTMiddleware
+	logger  *slog.Logger
+}
+
+// NewSessionService creates a new instance of SessionService.
+func NewSessionService(querier db.Querier, cache *redis.Client, logger *slog.Logger) *SessionService {
+	return &SessionService{
+		querier: querier,
+		cache:   cache,
+		logger:  logger,
+	}
+}
+
+// ListSessions retrieves the live sessions of a user. currentSessionID marks the caller's own session, if any.
+func (s *SessionService) ListSessions(ctx context.Context, userID, currentSessionID uuid.UUID) ([]models.Session, error) {
+	rows, err := s.querier.ListActiveSessionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	sessions := make([]models.Session, len(rows))
+	for i, row := range rows {
+		sessions[i] = models.Session{
+			ID:         row.FamilyID,
+			UserAgent:  row.UserAgent,
+			IPAddress:  row.IpAddress,
+			SignedInAt: row.SignedInAt.Time,
+			LastUsedAt: row.LastUsedAt.Time,
+			ExpiresAt:  row.ExpiresAt.Time,
+			Current:    row.FamilyID == currentSessionID,
+		}
+	}
+	return sessions, nil
+}
+
+// RevokeSession signs a user out of one session.
+func (s *SessionService) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	revoked, err := s.querier.RevokeUserSession(ctx, db.RevokeUserSessionParams{
+		UserID:   userID,
+		FamilyID: sessionID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	if revoked == 0 {
+		return ErrSessionNotFound
+	}
+	s.denySessions(ctx, []uuid.UUID{sessionID})
+
+	s.logger.Info("Session revoked", "user_id", userID, "session_id", sessionID, "revoked_by", actorIDFromContext(ctx))
+	return nil
+}
+
+// RevokeOtherSessions signs a user out of every session except keepSessionID.
+// Pass uuid.Nil to revoke all of them. It returns the number of sessions revoked.
+func (s *SessionService) RevokeOtherSessions(ctx context.Context, userID, keepSessionID uuid.UUID) (int, error) {
+	familyIDs, err := s.querier.RevokeOtherUserSessions(ctx, db.RevokeOtherUserSessionsParams{
+		UserID:       userID,
+		KeepFamilyID: keepSessionID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	s.denySessions(ctx, familyIDs)
+
+	s.logger.Info("Sessions revoked", "user_id", userID, "kept_session_id", keepSessionID, "revoked", len(familyIDs), "revoked_by", actorIDFromContext(ctx))
+	return len(familyIDs), nil
+}
+
+// denySessions rejects the outstanding access tokens of revoked sessions until they would have expired anyway.
+func (s *SessionService) denySessions(ctx context.Context, sessionIDs []uuid.UUID) {
+	for _, id := range sessionIDs {
+		cacheKey := fmt.Sprintf(CacheKeyRevokedSession, id.String())
+		if err := s.cache.Set(ctx, cacheKey, 1, accessTokenTTL).Err(); err != nil {
+			s.logger.Error("Failed to deny-list revoked session, its access tokens stay valid until they expire", "session_id", id, "error", err)
+		}
+	}
+}
+
+// truncateString shortens s to at most maxRunes characters.
+func truncateString(s string, maxRunes int) string {
+	runes := []rune(s)
+	if len(runes) <= maxRunes {
+		return s
+	}
+	return string(runes[:maxRunes])
+}
@@ -0,0 +1,491 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/MihoZaki/DzTech/internal/config"
+	"github.com/MihoZaki/DzTech/internal/courier"
+	"github.com/MihoZaki/DzTech/internal/db"
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// shipmentPollBatchSize caps how many shipments one polling run tracks, least recently updated first.
+const shipmentPollBatchSize = 200
+
+var (
+	ErrShipmentNotFound = errors.New("shipment not found")
+	ErrNoCourier        = errors.New("the order's delivery service is not linked to a courier")
+)
+
+// Sources of shipment events.
+const (
+	shipmentEventSourceAPI     = "api"     // Recorded by our own calls to the courier
+	shipmentEventSourceWebhook = "webhook" // Pushed by the courier
+	shipmentEventSourcePoll    = "poll"    // Found by tracking the shipment
+)
+
+// ShipmentService hands orders over to the courier of their delivery service and follows the shipments
+// until they are delivered, through courier webhooks and by polling. A delivered shipment marks its order
+// as delivered.
+type ShipmentService struct {
+	querier  db.Querier
+	pool     *pgxpool.Pool
+	orders   *OrderService           // Drives the order status transitions
+	delivery *DeliveryServiceService // Weighs parcels the same way delivery is priced
+	couriers *courier.Registry
+	cfg      config.Couriers
+	logger   *slog.Logger
+}
+
+// NewShipmentService creates a new instance of ShipmentService.
+func NewShipmentService(querier db.Querier, pool *pgxpool.Pool, orders *OrderService, delivery *DeliveryServiceService, couriers *courier.Registry, cfg config.Couriers, logger *slog.Logger) *ShipmentService {
+	return &ShipmentService{
+		querier:  querier,
+		pool:     pool,
+		orders:   orders,
+		delivery: delivery,
+		couriers: couriers,
+		cfg:      cfg,
+		logger:   logger,
+	}
+}
+
+// ShipOrder registers a confirmed order with the courier of its delivery service and marks it as shipped.
+func (s *ShipmentService) ShipOrder(ctx context.Context, orderID uuid.UUID) (*models.Shipment, error) {
+	order, err := s.querier.GetOrder(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch order: %w", err)
+	}
+	if !isValidStatusTransition(order.Status, "shipped") {
+		return nil, &StatusTransitionError{
+			CurrentStatus:   order.Status,
+			RequestedStatus: "shipped",
+			Msg:             "only confirmed orders can be shipped",
+		}
+	}
+	deliveryService, err := s.querier.GetDeliveryServiceByID(ctx, order.DeliveryServiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch delivery service of order: %w", err)
+	}
+	if deliveryService.Courier == nil {
+		return nil, ErrNoCourier
+	}
+	c, ok := s.couriers.Get(*deliveryService.Courier)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCourier, *deliveryService.Courier)
+	}
+
+	req, err := s.shipmentRequest(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	created, err := c.CreateShipment(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("courier %s failed to create shipment: %w", *deliveryService.Courier, err)
+	}
+
+	err = s.withTx(ctx, func(txQuerier *db.Queries) error {
+		dbShipment, err := txQuerier.CreateShipment(ctx, db.CreateShipmentParams{
+			OrderID:         orderID,
+			Courier:         *deliveryService.Courier,
+			TrackingNumber:  created.TrackingNumber,
+			Status:          courier.StatusCreated,
+			StatusUpdatedAt: ToPgTimestamptz(created.CreatedAt),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record shipment: %w", err)
+		}
+		_, err = txQuerier.AddShipmentEvent(ctx, db.AddShipmentEventParams{
+			ShipmentID: dbShipment.ID,
+			Status:     courier.StatusCreated,
+			OccurredAt: ToPgTimestamptz(created.CreatedAt),
+			Source:     shipmentEventSourceAPI,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record shipment event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		// Withdraw the parcel rather than leave one we know nothing about with the courier
+		if cancelErr := c.CancelShipment(ctx, created.TrackingNumber); cancelErr != nil {
+			s.logger.Error("CRITICAL: Failed to cancel untracked courier shipment",
+				"order_id", orderID, "courier", *deliveryService.Courier, "tracking_number", created.TrackingNumber, "error", cancelErr)
+		}
+		return nil, err
+	}
+
+	if _, err := s.orders.UpdateOrderStatus(ctx, orderID, models.UpdateOrderStatusRequest{Status: "shipped"}); err != nil {
+		s.logger.Error("Shipment created but order could not be marked as shipped",
+			"order_id", orderID, "tracking_number", created.TrackingNumber, "error", err)
+		return nil, fmt.Errorf("shipment %s created, but failed to mark order as shipped: %w", created.TrackingNumber, err)
+	}
+	return s.GetShipment(ctx, orderID)
+}
+
+// GetShipment retrieves the latest shipment of an order along with its status history.
+func (s *ShipmentService) GetShipment(ctx context.Context, orderID uuid.UUID) (*models.Shipment, error) {
+	dbShipment, err := s.querier.GetShipmentByOrderID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrShipmentNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch shipment: %w", err)
+	}
+	dbEvents, err := s.querier.ListShipmentEvents(ctx, dbShipment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shipment events: %w", err)
+	}
+
+	shipment := toShipmentModel(dbShipment)
+	shipment.Events = make([]models.ShipmentEvent, len(dbEvents))
+	for i, e := range dbEvents {
+		shipment.Events[i] = models.ShipmentEvent{
+			Status:      e.Status,
+			Description: e.Description,
+			OccurredAt:  e.OccurredAt.Time,
+			Source:      e.Source,
+		}
+	}
+	return &shipment, nil
+}
+
+// GetShipmentForUser retrieves the latest shipment of one of a customer's orders.
+// Orders of other customers are reported as not found.
+func (s *ShipmentService) GetShipmentForUser(ctx context.Context, orderID, userID uuid.UUID) (*models.Shipment, error) {
+	order, err := s.querier.GetOrder(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch order: %w", err)
+	}
+	if order.UserID != userID {
+		return nil, ErrOrderNotFound
+	}
+	return s.GetShipment(ctx, orderID)
+}
+
+// GetLabel fetches the shipping label of an order's shipment from its courier.
+func (s *ShipmentService) GetLabel(ctx context.Context, orderID uuid.UUID) (*courier.Label, error) {
+	dbShipment, c, err := s.shipmentWithCourier(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	label, err := c.GetLabel(ctx, dbShipment.TrackingNumber)
+	if err != nil {
+		return nil, fmt.Errorf("courier %s failed to return label: %w", dbShipment.Courier, err)
+	}
+	return label, nil
+}
+
+// CancelShipment withdraws an order's shipment from its courier, which is only possible before the parcel
+// is picked up, and returns the order to confirmed so that it can be shipped again.
+func (s *ShipmentService) CancelShipment(ctx context.Context, orderID uuid.UUID) (*models.Shipment, error) {
+	dbShipment, c, err := s.shipmentWithCourier(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	models.SetAuditBefore(ctx, toShipmentModel(dbShipment))
+	if dbShipment.Status != courier.StatusCreated {
+		return nil, courier.ErrNotCancellable
+	}
+	if err := c.CancelShipment(ctx, dbShipment.TrackingNumber); err != nil {
+		return nil, fmt.Errorf("courier %s failed to cancel shipment: %w", dbShipment.Courier, err)
+	}
+
+	now := time.Now()
+	err = s.withTx(ctx, func(txQuerier *db.Queries) error {
+		if _, err := txQuerier.UpdateShipmentStatus(ctx, db.UpdateShipmentStatusParams{
+			ID:              dbShipment.ID,
+			Status:          courier.StatusCancelled,
+			StatusUpdatedAt: ToPgTimestamptz(now),
+		}); err != nil {
+			return fmt.Errorf("failed to update shipment status: %w", err)
+		}
+		if _, err := txQuerier.AddShipmentEvent(ctx, db.AddShipmentEventParams{
+			ShipmentID: dbShipment.ID,
+			Status:     courier.StatusCancelled,
+			OccurredAt: ToPgTimestamptz(now),
+			Source:     shipmentEventSourceAPI,
+		}); err != nil {
+			return fmt.Errorf("failed to record shipment event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.orders.ReturnShippedOrder(ctx, orderID); err != nil {
+		var statusErr *StatusTransitionError
+		if !errors.As(err, &statusErr) {
+			s.logger.Error("Shipment cancelled but order could not be returned to confirmed", "order_id", orderID, "error", err)
+			return nil, fmt.Errorf("shipment cancelled, but failed to return order to confirmed: %w", err)
+		}
+		// The order already moved on, e.g. it was cancelled; nothing to undo
+	}
+	return s.GetShipment(ctx, orderID)
+}
+
+// HandleWebhook applies the tracking events of a status notification sent by a courier.
+// It returns courier.ErrInvalidWebhook if the notification is not authentic.
+func (s *ShipmentService) HandleWebhook(ctx context.Context, courierName string, r *http.Request) error {
+	c, ok := s.couriers.Get(courierName)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownCourier, courierName)
+	}
+	events, err := c.ParseWebhook(r)
+	if err != nil {
+		return err
+	}
+
+	byTrackingNumber := make(map[string][]courier.TrackingEvent)
+	for _, event := range events {
+		byTrackingNumber[event.TrackingNumber] = append(byTrackingNumber[event.TrackingNumber], event)
+	}
+	for trackingNumber, shipmentEvents := range byTrackingNumber {
+		dbShipment, err := s.querier.GetShipmentByTrackingNumber(ctx, db.GetShipmentByTrackingNumberParams{
+			Courier:        courierName,
+			TrackingNumber: trackingNumber,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				s.logger.Warn("Courier webhook for unknown shipment ignored", "courier", courierName, "tracking_number", trackingNumber)
+				continue
+			}
+			return fmt.Errorf("failed to fetch shipment: %w", err)
+		}
+		if err := s.applyTrackingEvents(ctx, dbShipment, shipmentEvents, shipmentEventSourceWebhook); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PollShipments tracks the open shipments with their couriers and applies the events found.
+// It returns how many shipments were tracked.
+func (s *ShipmentService) PollShipments(ctx context.Context) (int, error) {
+	dbShipments, err := s.querier.ListOpenShipments(ctx, shipmentPollBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list open shipments: %w", err)
+	}
+
+	tracked := 0
+	for _, dbShipment := range dbShipments {
+		c, ok := s.couriers.Get(dbShipment.Courier)
+		if !ok {
+			s.logger.Warn("Shipment courier is not configured, skipping", "shipment_id", dbShipment.ID, "courier", dbShipment.Courier)
+			continue
+		}
+		events, err := c.Track(ctx, dbShipment.TrackingNumber)
+		if err != nil {
+			s.logger.Error("Failed to track shipment", "shipment_id", dbShipment.ID, "courier", dbShipment.Courier,
+				"tracking_number", dbShipment.TrackingNumber, "error", err)
+			continue
+		}
+		if err := s.applyTrackingEvents(ctx, dbShipment, events, shipmentEventSourcePoll); err != nil {
+			s.logger.Error("Failed to apply shipment tracking events", "shipment_id", dbShipment.ID, "error", err)
+			continue
+		}
+		tracked++
+	}
+	return tracked, nil
+}
+
+// StartTrackingPoller polls the open shipments at the configured interval until ctx is cancelled.
+// It does nothing when polling is disabled or no courier is configured.
+func (s *ShipmentService) StartTrackingPoller(ctx context.Context) {
+	if s.cfg.PollIntervalMinutes <= 0 || len(s.couriers.Names()) == 0 {
+		s.logger.Info("Shipment tracking poller disabled")
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(time.Duration(s.cfg.PollIntervalMinutes) * time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			tracked, err := s.PollShipments(ctx)
+			if err != nil {
+				s.logger.Error("Shipment tracking run failed", "error", err)
+			} else if tracked > 0 {
+				s.logger.Debug("Tracked open shipments", "shipments", tracked)
+			}
+		}
+	}()
+}
+
+// applyTrackingEvents records the events of a shipment not seen before and moves the shipment to the
+// status of the latest one. Once the shipment is delivered, its order is marked as delivered.
+func (s *ShipmentService) applyTrackingEvents(ctx context.Context, dbShipment db.Shipment, events []courier.TrackingEvent, source string) error {
+	sort.SliceStable(events, func(i, j int) bool { return events[i].OccurredAt.Before(events[j].OccurredAt) })
+
+	current := dbShipment
+	err := s.withTx(ctx, func(txQuerier *db.Queries) error {
+		for _, event := range events {
+			var description *string
+			if event.Description != "" {
+				description = &event.Description
+			}
+			added, err := txQuerier.AddShipmentEvent(ctx, db.AddShipmentEventParams{
+				ShipmentID:  current.ID,
+				Status:      event.Status,
+				Description: description,
+				OccurredAt:  ToPgTimestamptz(event.OccurredAt),
+				Source:      source,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to record shipment event: %w", err)
+			}
+			// Late or repeated events are kept in the history but do not move the shipment back
+			if added == 0 || courier.IsFinalStatus(current.Status) || event.OccurredAt.Before(current.StatusUpdatedAt.Time) {
+				continue
+			}
+			current, err = txQuerier.UpdateShipmentStatus(ctx, db.UpdateShipmentStatusParams{
+				ID:              current.ID,
+				Status:          event.Status,
+				StatusUpdatedAt: ToPgTimestamptz(event.OccurredAt),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update shipment status: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if current.Status != dbShipment.Status {
+		s.logger.Info("Shipment status changed", "shipment_id", current.ID, "order_id", current.OrderID,
+			"from", dbShipment.Status, "to", current.Status, "source", source)
+		if current.Status == courier.StatusReturned || current.Status == courier.StatusCancelled {
+			s.logger.Warn("Shipment will not be delivered, the order needs attention", "order_id", current.OrderID, "status", current.Status)
+		}
+	}
+	if current.Status == courier.StatusDelivered {
+		return s.markOrderDelivered(ctx, current.OrderID)
+	}
+	return nil
+}
+
+// markOrderDelivered moves a shipped order to delivered. Orders already moved on are left alone.
+func (s *ShipmentService) markOrderDelivered(ctx context.Context, orderID uuid.UUID) error {
+	order, err := s.querier.GetOrder(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch order of delivered shipment: %w", err)
+	}
+	if order.Status != "shipped" {
+		return nil
+	}
+	if _, err := s.orders.UpdateOrderStatus(ctx, orderID, models.UpdateOrderStatusRequest{Status: "delivered"}); err != nil {
+		return fmt.Errorf("failed to mark order of delivered shipment as delivered: %w", err)
+	}
+	s.logger.Info("Order delivered according to its courier", "order_id", orderID)
+	return nil
+}
+
+// shipmentRequest describes an order's parcel for its courier.
+func (s *ShipmentService) shipmentRequest(ctx context.Context, order db.Order) (courier.ShipmentRequest, error) {
+	items, err := s.querier.GetOrderItemsByOrderID(ctx, order.ID)
+	if err != nil {
+		return courier.ShipmentRequest{}, fmt.Errorf("failed to fetch order items: %w", err)
+	}
+	quantities := make(map[uuid.UUID]int64, len(items))
+	for _, item := range items {
+		quantities[item.ProductID] += int64(item.Quantity)
+	}
+	weightGrams, err := s.delivery.parcelWeightGrams(ctx, quantities)
+	if err != nil {
+		return courier.ShipmentRequest{}, err
+	}
+
+	req := courier.ShipmentRequest{
+		Reference:      order.ID.String(),
+		RecipientName:  order.UserFullName,
+		PhoneNumber1:   order.PhoneNumber1,
+		PhoneNumber2:   order.PhoneNumber2,
+		WilayaName:     order.Province,
+		CommuneName:    order.City,
+		DeliveryType:   order.DeliveryType,
+		WeightGrams:    weightGrams,
+		CODAmountCents: order.TotalAmountCents, // Orders are paid cash on delivery
+		Notes:          order.Notes,
+	}
+	if order.WilayaCode != nil {
+		req.WilayaCode = *order.WilayaCode
+	}
+	if order.CommuneCode != nil {
+		req.CommuneCode = *order.CommuneCode
+	}
+	return req, nil
+}
+
+func (s *ShipmentService) shipmentWithCourier(ctx context.Context, orderID uuid.UUID) (db.Shipment, courier.Courier, error) {
+	dbShipment, err := s.querier.GetShipmentByOrderID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.Shipment{}, nil, ErrShipmentNotFound
+		}
+		return db.Shipment{}, nil, fmt.Errorf("failed to fetch shipment: %w", err)
+	}
+	c, ok := s.couriers.Get(dbShipment.Courier)
+	if !ok {
+		return db.Shipment{}, nil, fmt.Errorf("%w: %q", ErrUnknownCourier, dbShipment.Courier)
+	}
+	return dbShipment, c, nil
+}
+
+func (s *ShipmentService) withTx(ctx context.Context, fn func(txQuerier *db.Queries) error) error {
+	queries, ok := s.querier.(*db.Queries)
+	if !ok {
+		return errors.New("querier type assertion to *db.Queries failed, cannot create transactional querier")
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin shipment transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			s.logger.Error("Error during shipment transaction rollback", "error", err)
+		}
+	}()
+
+	if err := fn(queries.WithTx(tx)); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit shipment transaction: %w", err)
+	}
+	return nil
+}
+
+func toShipmentModel(s db.Shipment) models.Shipment {
+	return models.Shipment{
+		ID:              s.ID,
+		OrderID:         s.OrderID,
+		Courier:         s.Courier,
+		TrackingNumber:  s.TrackingNumber,
+		Status:          s.Status,
+		StatusUpdatedAt: s.StatusUpdatedAt.Time,
+		CreatedAt:       s.CreatedAt.Time,
+		UpdatedAt:       s.UpdatedAt.Time,
+		Events:          []models.ShipmentEvent{},
+	}
+}
@@ -0,0 +1,325 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/MihoZaki/DzTech/internal/config"
+	"github.com/MihoZaki/DzTech/internal/db"
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/MihoZaki/DzTech/internal/utils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorNotPending     = errors.New("no two-factor enrolment to confirm")
+	ErrTwoFactorRequired       = errors.New("two-factor authentication is required for staff accounts")
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor code")
+)
+
+const (
+	recoveryCodeCount    = 10
+	recoveryCodeLength   = 10                                 // Characters, shown as two groups of five
+	recoveryCodeAlphabet = "abcdefghijkmnpqrstuvwxyz23456789" // 32 characters without l, o, 0 and 1
+)
+
+// TwoFactorService handles TOTP enrolment, verification and recovery codes.
+type TwoFactorService struct {
+	querier  db.Querier
+	pool     *pgxpool.Pool   // Need for transactions
+	sessions *SessionService // Signs out other sessions when 2FA is enabled
+	cfg      config.TwoFactor
+	logger   *slog.Logger
+}
+
+// NewTwoFactorService creates a new instance of TwoFactorService.
+func NewTwoFactorService(querier db.Querier, pool *pgxpool.Pool, sessions *SessionService, cfg config.TwoFactor, logger *slog.Logger) *TwoFactorService {
+	return &TwoFactorService{
+		querier:  querier,
+		pool:     pool,
+		sessions: sessions,
+		cfg:      cfg,
+		logger:   logger,
+	}
+}
+
+// Status reports whether a user has 2FA enabled and how many recovery codes are left.
+func (s *TwoFactorService) Status(ctx context.Context, userID uuid.UUID) (*models.TwoFactorStatus, error) {
+	totp, err := s.querier.GetUserTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &models.TwoFactorStatus{}, nil
+		}
+		return nil, fmt.Errorf("failed to fetch two-factor settings: %w", err)
+	}
+	remaining, err := s.querier.CountUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+	return &models.TwoFactorStatus{
+		Enabled:                totp.ConfirmedAt.Valid,
+		Pending:                !totp.ConfirmedAt.Valid,
+		RecoveryCodesRemaining: remaining,
+	}, nil
+}
+
+// IsEnabled reports whether a user has confirmed 2FA, and so must provide a code to sign in.
+func (s *TwoFactorService) IsEnabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	totp, err := s.querier.GetUserTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to fetch two-factor settings: %w", err)
+	}
+	return totp.ConfirmedAt.Valid, nil
+}
+
+// Enroll starts 2FA enrolment with a new secret. Enrolment restarted before confirmation replaces the secret.
+func (s *TwoFactorService) Enroll(ctx context.Context, userID uuid.UUID, email string) (*models.TwoFactorEnrollment, error) {
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.querier.UpsertPendingUserTOTP(ctx, db.UpsertPendingUserTOTPParams{
+		UserID: userID,
+		Secret: secret,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store two-factor secret: %w", err)
+	}
+	if stored == 0 {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	return &models.TwoFactorEnrollment{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(s.cfg.Issuer, email, secret),
+	}, nil
+}
+
+// Confirm enables 2FA once the user proves their authenticator app works, and returns their recovery codes.
+// Every session but currentSessionID is signed out, so sessions opened with the password alone do not
+// outlive the change.
+func (s *TwoFactorService) Confirm(ctx context.Context, userID, currentSessionID uuid.UUID, code string) ([]string, error) {
+	totp, err := s.querier.GetUserTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTwoFactorNotPending
+		}
+		return nil, fmt.Errorf("failed to fetch two-factor settings: %w", err)
+	}
+	if totp.ConfirmedAt.Valid {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	step, ok := utils.ValidateTOTP(totp.Secret, code, time.Now(), totp.LastUsedStep)
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	var recoveryCodes []string
+	var revokedSessionIDs []uuid.UUID
+	err = s.withTx(ctx, func(txQuerier *db.Queries) error {
+		confirmed, err := txQuerier.ConfirmUserTOTP(ctx, db.ConfirmUserTOTPParams{
+			UserID: userID,
+			Step:   step,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to confirm two-factor enrolment: %w", err)
+		}
+		if confirmed == 0 {
+			return ErrTwoFactorAlreadyEnabled
+		}
+		revokedSessionIDs, err = txQuerier.RevokeOtherUserSessions(ctx, db.RevokeOtherUserSessionsParams{
+			UserID:       userID,
+			KeepFamilyID: currentSessionID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to revoke other sessions: %w", err)
+		}
+		recoveryCodes, err = s.replaceRecoveryCodes(ctx, txQuerier, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.sessions.denySessions(ctx, revokedSessionIDs)
+
+	s.logger.Info("Two-factor authentication enabled", "user_id", userID, "revoked_sessions", len(revokedSessionIDs))
+	return recoveryCodes, nil
+}
+
+// VerifyCode checks a code from the user's authenticator app, or consumes one of their recovery codes.
+func (s *TwoFactorService) VerifyCode(ctx context.Context, userID uuid.UUID, code string) error {
+	totp, err := s.querier.GetUserTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrTwoFactorNotEnabled
+		}
+		return fmt.Errorf("failed to fetch two-factor settings: %w", err)
+	}
+	if !totp.ConfirmedAt.Valid {
+		return ErrTwoFactorNotEnabled
+	}
+
+	if step, ok := utils.ValidateTOTP(totp.Secret, code, time.Now(), totp.LastUsedStep); ok {
+		// Only one request can advance the step, so a code is accepted at most once
+		used, err := s.querier.UseUserTOTPStep(ctx, db.UseUserTOTPStepParams{
+			UserID: userID,
+			Step:   step,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record two-factor code use: %w", err)
+		}
+		if used == 0 {
+			return ErrInvalidTwoFactorCode
+		}
+		return nil
+	}
+
+	used, err := s.querier.UseUserRecoveryCode(ctx, db.UseUserRecoveryCodeParams{
+		UserID:   userID,
+		CodeHash: hashRecoveryCode(code),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	if used == 0 {
+		return ErrInvalidTwoFactorCode
+	}
+	s.logger.Info("Recovery code used", "user_id", userID)
+	return nil
+}
+
+// Disable turns 2FA off after verifying a code. Staff cannot disable it while it is mandatory for them.
+func (s *TwoFactorService) Disable(ctx context.Context, userID uuid.UUID, code string) error {
+	if s.cfg.RequiredForAdmins {
+		dbUser, err := s.querier.GetUser(ctx, userID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrUserNotFound
+			}
+			return fmt.Errorf("failed to fetch user: %w", err)
+		}
+		if dbUser.IsAdmin {
+			return ErrTwoFactorRequired
+		}
+	}
+	if err := s.VerifyCode(ctx, userID, code); err != nil {
+		return err
+	}
+
+	err := s.withTx(ctx, func(txQuerier *db.Queries) error {
+		if err := txQuerier.DeleteUserRecoveryCodes(ctx, userID); err != nil {
+			return fmt.Errorf("failed to delete recovery codes: %w", err)
+		}
+		if err := txQuerier.DeleteUserTOTP(ctx, userID); err != nil {
+			return fmt.Errorf("failed to delete two-factor settings: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("Two-factor authentication disabled", "user_id", userID)
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces all of a user's recovery codes after verifying a code.
+func (s *TwoFactorService) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	if err := s.VerifyCode(ctx, userID, code); err != nil {
+		return nil, err
+	}
+
+	var recoveryCodes []string
+	err := s.withTx(ctx, func(txQuerier *db.Queries) error {
+		var err error
+		recoveryCodes, err = s.replaceRecoveryCodes(ctx, txQuerier, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Recovery codes regenerated", "user_id", userID)
+	return recoveryCodes, nil
+}
+
+// replaceRecoveryCodes deletes a user's recovery codes and stores the hashes of new ones.
+func (s *TwoFactorService) replaceRecoveryCodes(ctx context.Context, querier db.Querier, userID uuid.UUID) ([]string, error) {
+	if err := querier.DeleteUserRecoveryCodes(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	codes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		if err := querier.CreateUserRecoveryCode(ctx, db.CreateUserRecoveryCodeParams{
+			UserID:   userID,
+			CodeHash: hashRecoveryCode(code),
+		}); err != nil {
+			return nil, fmt.Errorf("failed to store recovery code: %w", err)
+		}
+		codes[i] = code
+	}
+	return codes, nil
+}
+
+func (s *TwoFactorService) withTx(ctx context.Context, fn func(txQuerier *db.Queries) error) error {
+	queries, ok := s.querier.(*db.Queries)
+	if !ok {
+		return errors.New("querier type assertion to *db.Queries failed, cannot create transactional querier")
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin two-factor transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			s.logger.Error("Error during two-factor transaction rollback", "error", err)
+		}
+	}()
+
+	if err := fn(queries.WithTx(tx)); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit two-factor transaction: %w", err)
+	}
+	return nil
+}
+
+// generateRecoveryCode returns a random code such as "k7m2p-x9qrt".
+func generateRecoveryCode() (string, error) {
+	random := make([]byte, recoveryCodeLength)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate recovery code: %w", err)
+	}
+	code := make([]byte, recoveryCodeLength)
+	for i, b := range random {
+		code[i] = recoveryCodeAlphabet[b&31] // 256 is a multiple of 32, so every character is equally likely
+	}
+	half := recoveryCodeLength / 2
+	return string(code[:half]) + "-" + string(code[half:]), nil
+}
+
+// hashRecoveryCode hashes a recovery code, ignoring case, spaces and dashes.
+func hashRecoveryCode(code string) string {
+	normalised := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalised))
+	return hex.EncodeToString(sum[:])
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/MihoZaki/DzTech/internal/db"
@@ -64,18 +65,35 @@ func (s *UserService) Register(ctx context.Context, email, password, fullName st
 	return user.ID, nil
 }
 
+// ErrInvalidCredentials is returned by Authenticate for an unknown email and for a wrong password alike.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// dummyPasswordHash is compared against when the email is unknown, so that the response takes as long
+// as for an existing account and does not reveal which emails are registered.
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("not-a-real-password"), bcrypt.DefaultCost)
+	return hash
+})
+
 func (s *UserService) Authenticate(ctx context.Context, email, password string) (*models.User, error) {
 	dbUser, err := s.querier.GetUserByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, errors.New("invalid credentials")
+			_ = bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(password))
+			return nil, ErrInvalidCredentials
 		}
 		return nil, err
 	}
 
+	// Accounts created through social login have no password
+	if len(dbUser.PasswordHash) == 0 {
+		_ = bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(password))
+		return nil, ErrInvalidCredentials
+	}
+
 	// Compare the provided password with the hashed password from DB
 	if err := bcrypt.CompareHashAndPassword(dbUser.PasswordHash, []byte(password)); err != nil {
-		return nil, errors.New("invalid credentials")
+		return nil, ErrInvalidCredentials
 	}
 
 	// Convert database user to service user
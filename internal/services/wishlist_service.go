@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/MihoZaki/DzTech/internal/db"
+	"github.com/MihoZaki/DzTech/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrWishlistItemNotFound = errors.New("wishlist item not found")
+
+// WishlistService handles business logic for user and guest wishlists.
+type WishlistService struct {
+	querier     db.Querier
+	cartService *CartService // Required for moving wishlist items to the cart
+	logger      *slog.Logger
+}
+
+// NewWishlistService creates a new instance of WishlistService.
+func NewWishlistService(querier db.Querier, cartService *CartService, logger *slog.Logger) *WishlistService {
+	return &WishlistService{
+		querier:     querier,
+		cartService: cartService,
+		logger:      logger,
+	}
+}
+
+// wishlistOwner returns the owner arguments for the wishlist queries.
+// Authenticated users are identified by userID, guests by sessionID.
+func wishlistOwner(userID *uuid.UUID, sessionID string) (uuid.UUID, *string) {
+	if userID != nil {
+		return *userID, nil
+	}
+	return uuid.Nil, &sessionID
+}
+
+// GetWishlist returns the wishlist of a user or guest with live discounted prices and stock.
+func (s *WishlistService) GetWishlist(ctx context.Context, userID *uuid.UUID, sessionID string) (*models.Wishlist, error) {
+	ownerID, ownerSession := wishlistOwner(userID, sessionID)
+	rows, err := s.querier.ListWishlistItemsWithDiscounts(ctx, db.ListWishlistItemsWithDiscountsParams{
+		UserID:    ownerID,
+		SessionID: ownerSession,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wishlist items: %w", err)
+	}
+
+	items := make([]models.WishlistItem, len(rows))
+	for i, row := range rows {
+		var imageUrls []string
+		if row.ProductImageUrls != nil {
+			if err := json.Unmarshal(row.ProductImageUrls, &imageUrls); err != nil {
+				s.logger.Warn("Failed to unmarshal image URLs for wishlist item", "product_id", row.ProductID, "error", err)
+			}
+		}
+		items[i] = models.WishlistItem{
+			ID:                   row.ID,
+			ProductID:            row.ProductID,
+			Name:                 row.ProductName,
+			Slug:                 row.ProductSlug,
+			Brand:                row.ProductBrand,
+			ImageUrls:            imageUrls,
+			Status:               row.ProductStatus,
+			StockQuantity:        int(row.ProductStockQuantity),
+			InStock:              row.ProductStockQuantity > 0,
+			OriginalPriceCents:   row.OriginalPriceCents,
+			DiscountedPriceCents: row.DiscountedPriceCents,
+			HasActiveDiscount:    row.HasActiveDiscount,
+			AddedAt:              row.AddedAt.Time,
+		}
+	}
+
+	return &models.Wishlist{
+		Items:      items,
+		TotalItems: len(items),
+	}, nil
+}
+
+// AddItem adds a product to the wishlist and returns the updated wishlist.
+// Adding a product that is already on the wishlist is not an error.
+func (s *WishlistService) AddItem(ctx context.Context, userID *uuid.UUID, sessionID string, productID uuid.UUID) (*models.Wishlist, error) {
+	if _, err := s.querier.GetProduct(ctx, productID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to validate product %s: %w", productID, err)
+	}
+
+	ownerID, ownerSession := wishlistOwner(userID, sessionID)
+	err := s.querier.AddWishlistItem(ctx, db.AddWishlistItemParams{
+		UserID:    ownerID,
+		SessionID: ownerSession,
+		ProductID: productID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add item to wishlist: %w", err)
+	}
+
+	return s.GetWishlist(ctx, userID, sessionID)
+}
+
+// RemoveItem removes a product from the wishlist.
+func (s *WishlistService) RemoveItem(ctx context.Context, userID *uuid.UUID, sessionID string, productID uuid.UUID) error {
+	ownerID, ownerSession := wishlistOwner(userID, sessionID)
+	affected, err := s.querier.DeleteWishlistItem(ctx, db.DeleteWishlistItemParams{
+		ProductID: productID,
+		UserID:    ownerID,
+		SessionID: ownerSession,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove item from wishlist: %w", err)
+	}
+	if affected == 0 {
+		return ErrWishlistItemNotFound
+	}
+	return nil
+}
+
+// MoveItemToCart adds a wishlist product to the cart through CartService.AddItemToCart,
+// which enforces the stock checks, and then removes it from the wishlist.
+func (s *WishlistService) MoveItemToCart(ctx context.Context, userID *uuid.UUID, sessionID string, productID uuid.UUID, quantity int) (*db.CartItem, error) {
+	ownerID, ownerSession := wishlistOwner(userID, sessionID)
+	exists, err := s.querier.WishlistItemExists(ctx, db.WishlistItemExistsParams{
+		ProductID: productID,
+		UserID:    ownerID,
+		SessionID: ownerSession,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check wishlist item: %w", err)
+	}
+	if !exists {
+		return nil, ErrWishlistItemNotFound
+	}
+
+	cartItem, err := s.cartService.AddItemToCart(ctx, userID, sessionID, productID, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	// The product is in the cart at this point; failing to drop it from the wishlist is not worth failing the request.
+	if err := s.RemoveItem(ctx, userID, sessionID, productID); err != nil {
+		s.logger.Error("Failed to remove wishlist item after moving it to the cart", "user_id", userID, "session_id", sessionID, "product_id", productID, "error", err)
+	}
+
+	return cartItem, nil
+}
+
+// MergeGuestWishlist moves the items of a guest wishlist into the user's wishlist.
+// It mirrors CartService.SyncGuestCartToUserCart and is called on login and registration.
+func (s *WishlistService) MergeGuestWishlist(ctx context.Context, guestSessionID string, userID uuid.UUID) error {
+	if guestSessionID == "" {
+		return fmt.Errorf("guest session ID cannot be empty for wishlist merge")
+	}
+
+	merged, err := s.querier.MergeGuestWishlistIntoUserWishlist(ctx, db.MergeGuestWishlistIntoUserWishlistParams{
+		UserID:    userID,
+		SessionID: guestSessionID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to merge guest wishlist %s into user %s wishlist: %w", guestSessionID, userID, err)
+	}
+
+	s.logger.Debug("Guest wishlist merged into user wishlist", "user_id", userID, "session_id", guestSessionID, "merged_items", merged)
+	return nil
+}
@@ -0,0 +1,289 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/MihoZaki/DzTech/internal/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const minRSAKeyBits = 2048
+
+var (
+	ErrUnknownSigningKey     = errors.New("token signed with an unknown key")
+	ErrUnexpectedSigningAlgo = errors.New("unexpected signing method")
+)
+
+// JWTKeySet signs and verifies the JWTs issued by the API.
+// New tokens are signed with the active key and carry its kid in their header; tokens are verified with
+// whichever configured key their kid names, so keys can be rotated while older tokens are still valid.
+// Tokens without a kid are HS256 tokens signed with the shared secret, accepted while one is configured.
+type JWTKeySet struct {
+	signingKeyID  string
+	signingKey    crypto.Signer
+	signingMethod jwt.SigningMethod
+	verifyKeys    map[string]jwtVerifyKey
+	secret        []byte
+}
+
+type jwtVerifyKey struct {
+	method jwt.SigningMethod
+	key    crypto.PublicKey
+}
+
+// JSONWebKey is a public key in the JWK format (RFC 7517).
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"` // OKP and EC keys
+	X         string `json:"x,omitempty"`   // OKP and EC keys
+	Y         string `json:"y,omitempty"`   // EC keys
+	N         string `json:"n,omitempty"`   // RSA keys
+	E         string `json:"e,omitempty"`   // RSA keys
+}
+
+// PublicKey decodes an RSA, EC (P-256, P-384, P-521) or Ed25519 public key, as published by identity providers.
+func (k JSONWebKey) PublicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported EC curve %q", k.Curve)
+		}
+		x, errX := base64.RawURLEncoding.DecodeString(k.X)
+		y, errY := base64.RawURLEncoding.DecodeString(k.Y)
+		if errX != nil || errY != nil {
+			return nil, errors.New("invalid EC point")
+		}
+		// Encoded as an uncompressed point so the standard library validates it is on the curve
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) > size || len(y) > size {
+			return nil, errors.New("invalid EC point")
+		}
+		point := make([]byte, 1+2*size)
+		point[0] = 4
+		copy(point[1+size-len(x):1+size], x)
+		copy(point[1+2*size-len(y):], y)
+		pub, err := ecdsa.ParseUncompressedPublicKey(curve, point)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC point: %w", err)
+		}
+		return pub, nil
+	case "OKP":
+		if k.Curve != "Ed25519" {
+			return nil, fmt.Errorf("unsupported OKP curve %q", k.Curve)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.KeyType)
+	}
+}
+
+// JSONWebKeySet is the document served at /.well-known/jwks.json.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// LoadJWTKeySet builds the key set from the configuration: the keys in keys.KeysDir, the inline signing
+// key if any, and the HS256 secret. Without a signing key ID, tokens are signed with the secret.
+func LoadJWTKeySet(secret string, keys config.JWTKeys) (*JWTKeySet, error) {
+	ks := &JWTKeySet{
+		verifyKeys: make(map[string]jwtVerifyKey),
+	}
+	if secret != "" {
+		ks.secret = []byte(secret)
+	}
+
+	privateKeys := make(map[string]crypto.Signer)
+	if keys.KeysDir != "" {
+		paths, err := filepath.Glob(filepath.Join(keys.KeysDir, "*.pem"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list JWT keys: %w", err)
+		}
+		for _, path := range paths {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read JWT key %s: %w", path, err)
+			}
+			key, err := parsePEMKey(data)
+			if err != nil {
+				return nil, fmt.Errorf("invalid JWT key %s: %w", path, err)
+			}
+			kid := strings.TrimSuffix(filepath.Base(path), ".pem")
+			if signer, ok := key.(crypto.Signer); ok {
+				privateKeys[kid] = signer
+				key = signer.Public()
+			}
+			if err := ks.addVerifyKey(kid, key); err != nil {
+				return nil, fmt.Errorf("invalid JWT key %s: %w", path, err)
+			}
+		}
+	}
+
+	if keys.SigningKeyID == "" {
+		if ks.secret == nil {
+			return nil, errors.New("no JWT signing key or secret configured")
+		}
+		return ks, nil
+	}
+
+	signer, ok := privateKeys[keys.SigningKeyID]
+	if keys.SigningKey != "" {
+		// Env files cannot hold multi-line values, so the PEM may come with escaped newlines
+		key, err := parsePEMKey([]byte(strings.ReplaceAll(keys.SigningKey, `\n`, "\n")))
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWT signing key: %w", err)
+		}
+		if signer, ok = key.(crypto.Signer); !ok {
+			return nil, errors.New("JWT signing key is not a private key")
+		}
+		if err := ks.addVerifyKey(keys.SigningKeyID, signer.Public()); err != nil {
+			return nil, fmt.Errorf("invalid JWT signing key: %w", err)
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("no private key found for JWT signing key ID %q", keys.SigningKeyID)
+	}
+
+	ks.signingKeyID = keys.SigningKeyID
+	ks.signingKey = signer
+	ks.signingMethod = ks.verifyKeys[keys.SigningKeyID].method
+	return ks, nil
+}
+
+// Sign returns the signed token for claims.
+func (ks *JWTKeySet) Sign(claims jwt.Claims) (string, error) {
+	if ks.signingKey == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(ks.secret)
+	}
+	token := jwt.NewWithClaims(ks.signingMethod, claims)
+	token.Header["kid"] = ks.signingKeyID
+	return token.SignedString(ks.signingKey)
+}
+
+// Keyfunc returns the key verifying token, for use with jwt.Parse.
+// The algorithm must be the one of the key named by the kid, so a public key is never used as an HMAC secret.
+func (ks *JWTKeySet) Keyfunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok || ks.secret == nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnexpectedSigningAlgo, token.Header["alg"])
+		}
+		return ks.secret, nil
+	}
+	key, ok := ks.verifyKeys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSigningKey, kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("%w: %v", ErrUnexpectedSigningAlgo, token.Header["alg"])
+	}
+	return key.key, nil
+}
+
+// JWKS returns the public verification keys, ordered by kid. The HS256 secret is never published.
+func (ks *JWTKeySet) JWKS() JSONWebKeySet {
+	kids := make([]string, 0, len(ks.verifyKeys))
+	for kid := range ks.verifyKeys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	set := JSONWebKeySet{Keys: make([]JSONWebKey, 0, len(kids))}
+	for _, kid := range kids {
+		key := ks.verifyKeys[kid]
+		jwk := JSONWebKey{
+			KeyID:     kid,
+			Use:       "sig",
+			Algorithm: key.method.Alg(),
+		}
+		switch pub := key.key.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+func (ks *JWTKeySet) addVerifyKey(kid string, key crypto.PublicKey) error {
+	if kid == "" {
+		return errors.New("key ID is empty")
+	}
+	switch pub := key.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < minRSAKeyBits {
+			return fmt.Errorf("RSA key must be at least %d bits", minRSAKeyBits)
+		}
+		ks.verifyKeys[kid] = jwtVerifyKey{method: jwt.SigningMethodRS256, key: pub}
+	case ed25519.PublicKey:
+		ks.verifyKeys[kid] = jwtVerifyKey{method: jwt.SigningMethodEdDSA, key: pub}
+	default:
+		return fmt.Errorf("unsupported key type %T, use RSA or Ed25519", key)
+	}
+	return nil
+}
+
+// parsePEMKey parses a PKCS#8 or PKCS#1 private key, or a PKIX or PKCS#1 public key.
+func parsePEMKey(data []byte) (any, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+}
@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 test key of RFC 6238 ("12345678901234567890") in base32.
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestValidateTOTP(t *testing.T) {
+	// Expected codes are the last six digits of the RFC 6238 appendix B SHA-1 vectors.
+	tests := []struct {
+		name         string
+		secret       string
+		code         string
+		at           int64
+		lastUsedStep int64
+		wantStep     int64
+		wantOK       bool
+	}{
+		{name: "RFC vector at 59", secret: rfc6238Secret, code: "287082", at: 59, wantStep: 1, wantOK: true},
+		{name: "RFC vector at 1111111109", secret: rfc6238Secret, code: "081804", at: 1111111109, wantStep: 37037036, wantOK: true},
+		{name: "RFC vector at 1234567890", secret: rfc6238Secret, code: "005924", at: 1234567890, wantStep: 41152263, wantOK: true},
+		{name: "RFC vector at 2000000000", secret: rfc6238Secret, code: "279037", at: 2000000000, wantStep: 66666666, wantOK: true},
+		{name: "lowercase secret and spaced code", secret: strings.ToLower(rfc6238Secret), code: " 287 082 ", at: 59, wantStep: 1, wantOK: true},
+		{name: "previous step within skew", secret: rfc6238Secret, code: "287082", at: 89, wantStep: 1, wantOK: true},
+		{name: "next step within skew", secret: rfc6238Secret, code: "287082", at: 29, wantStep: 1, wantOK: true},
+		{name: "outside skew", secret: rfc6238Secret, code: "287082", at: 119},
+		{name: "replayed step", secret: rfc6238Secret, code: "287082", at: 59, lastUsedStep: 1},
+		{name: "wrong code", secret: rfc6238Secret, code: "123456", at: 59},
+		{name: "too short", secret: rfc6238Secret, code: "28708", at: 59},
+		{name: "too long", secret: rfc6238Secret, code: "9428708", at: 59},
+		{name: "invalid secret", secret: "not base32!", code: "287082", at: 59},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := ValidateTOTP(tt.secret, tt.code, time.Unix(tt.at, 0), tt.lastUsedStep)
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("ValidateTOTP() = (%d, %v), want (%d, %v)", step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret() error = %v", err)
+	}
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret() = %q, not unpadded base32: %v", secret, err)
+	}
+	if len(key) != totpKeyBytes {
+		t.Errorf("GenerateTOTPSecret() key has %d bytes, want %d", len(key), totpKeyBytes)
+	}
+
+	now := time.Now()
+	if _, ok := ValidateTOTP(secret, totpCode(key, now.Unix()/int64(totpPeriod.Seconds())), now, 0); !ok {
+		t.Error("a code computed from a generated secret should validate")
+	}
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	raw := TOTPProvisioningURI("DzTech Shop", "user@example.com", rfc6238Secret)
+	uri, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("TOTPProvisioningURI() = %q, not a URL: %v", raw, err)
+	}
+	if uri.Scheme != "otpauth" || uri.Host != "totp" {
+		t.Errorf("TOTPProvisioningURI() = %q, want an otpauth://totp/ URI", raw)
+	}
+	if uri.Path != "/DzTech Shop:user@example.com" {
+		t.Errorf("TOTPProvisioningURI() label = %q, want %q", uri.Path, "/DzTech Shop:user@example.com")
+	}
+
+	want := map[string]string{
+		"secret":    rfc6238Secret,
+		"issuer":    "DzTech Shop",
+		"algorithm": "SHA1",
+		"digits":    "6",
+		"period":    "30",
+	}
+	query := uri.Query()
+	for key, value := range want {
+		if got := query.Get(key); got != value {
+			t.Errorf("TOTPProvisioningURI() %s = %q, want %q", key, got, value)
+		}
+	}
+}
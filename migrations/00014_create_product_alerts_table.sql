@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE product_alerts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    alert_type VARCHAR(20) NOT NULL CHECK (alert_type IN ('back_in_stock', 'price_below')),
+    target_price_cents BIGINT CHECK (target_price_cents > 0), -- Only used by 'price_below' alerts
+    notified_at TIMESTAMPTZ, -- Set once the alert has fired; a fired alert never fires again
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_product_alerts_target_price CHECK (
+        (alert_type = 'price_below' AND target_price_cents IS NOT NULL)
+        OR (alert_type = 'back_in_stock' AND target_price_cents IS NULL)
+    )
+);
+
+-- A user can only have one pending alert of each type per product
+CREATE UNIQUE INDEX idx_product_alerts_pending_unique ON product_alerts(user_id, product_id, alert_type) WHERE notified_at IS NULL;
+
+CREATE INDEX idx_product_alerts_product_pending ON product_alerts(product_id) WHERE notified_at IS NULL;
+CREATE INDEX idx_product_alerts_user_id ON product_alerts(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS product_alerts;
+-- +goose StatementEnd
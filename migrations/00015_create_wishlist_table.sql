@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE wishlist_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    session_id TEXT, -- For guest users
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT wishlist_user_or_session_id CHECK (
+        (user_id IS NOT NULL AND session_id IS NULL) OR
+        (user_id IS NULL AND session_id IS NOT NULL)
+    )
+);
+
+-- A product appears at most once per wishlist
+CREATE UNIQUE INDEX idx_wishlist_items_user_product ON wishlist_items(user_id, product_id) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_wishlist_items_session_product ON wishlist_items(session_id, product_id) WHERE session_id IS NOT NULL;
+
+CREATE INDEX idx_wishlist_items_product_id ON wishlist_items(product_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS wishlist_items;
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE stock_movements (
+    id BIGSERIAL PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES products(id),
+    delta INTEGER NOT NULL CHECK (delta <> 0), -- Signed change applied to products.stock_quantity
+    resulting_quantity INTEGER NOT NULL CHECK (resulting_quantity >= 0), -- Stock right after this movement
+    reason VARCHAR(30) NOT NULL CHECK (reason IN ('sale', 'cancellation', 'manual_adjustment', 'return', 'restock')),
+    order_id UUID REFERENCES orders(id), -- Set for sales, cancellations and returns
+    actor_id UUID REFERENCES users(id), -- Admin who triggered the movement, if any
+    note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_stock_movements_product_id ON stock_movements(product_id, created_at DESC);
+CREATE INDEX idx_stock_movements_order_id ON stock_movements(order_id) WHERE order_id IS NOT NULL;
+
+-- The ledger is append-only
+CREATE OR REPLACE FUNCTION prevent_stock_movement_changes() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'stock_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_stock_movements_append_only
+BEFORE UPDATE OR DELETE ON stock_movements
+FOR EACH ROW EXECUTE FUNCTION prevent_stock_movement_changes();
+
+-- Opening balance, so that the ledger reconciles with the stock that existed before it
+INSERT INTO stock_movements (product_id, delta, resulting_quantity, reason, note)
+SELECT id, stock_quantity, stock_quantity, 'manual_adjustment', 'Opening balance'
+FROM products
+WHERE stock_quantity <> 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS stock_movements;
+DROP FUNCTION IF EXISTS prevent_stock_movement_changes();
+-- +goose StatementEnd
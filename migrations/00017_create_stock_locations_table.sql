@@ -0,0 +1,160 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE stock_locations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(50) NOT NULL UNIQUE, -- Short identifier, e.g. 'WAREHOUSE', 'SHOP-ALGIERS'
+    name VARCHAR(255) NOT NULL,
+    is_sellable BOOLEAN NOT NULL DEFAULT TRUE, -- Whether the stock here counts towards products.stock_quantity
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE, -- Location used when no location is given (e.g., product stock updates)
+    priority INTEGER NOT NULL DEFAULT 0, -- Lower values are allocated to orders first
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- At most one default location
+CREATE UNIQUE INDEX idx_stock_locations_default ON stock_locations(is_default) WHERE is_default;
+
+CREATE TABLE product_stock_levels (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    location_id UUID NOT NULL REFERENCES stock_locations(id),
+    quantity INTEGER NOT NULL DEFAULT 0 CONSTRAINT chk_product_stock_levels_quantity CHECK (quantity >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (product_id, location_id)
+);
+
+CREATE INDEX idx_product_stock_levels_location_id ON product_stock_levels(location_id);
+
+CREATE TABLE stock_transfers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id),
+    from_location_id UUID NOT NULL REFERENCES stock_locations(id),
+    to_location_id UUID NOT NULL REFERENCES stock_locations(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    actor_id UUID REFERENCES users(id),
+    note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_stock_transfers_locations CHECK (from_location_id <> to_location_id)
+);
+
+CREATE INDEX idx_stock_transfers_product_id ON stock_transfers(product_id, created_at DESC);
+
+-- Records which location each confirmed order item was taken from, so cancellations return the stock there
+CREATE TABLE order_item_allocations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    order_item_id UUID NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id),
+    location_id UUID NOT NULL REFERENCES stock_locations(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_order_item_allocations_order_id ON order_item_allocations(order_id);
+
+-- Ledger entries are now per location; transfers produce a transfer_out and a transfer_in movement
+ALTER TABLE stock_movements
+    ADD COLUMN location_id UUID REFERENCES stock_locations(id),
+    ADD COLUMN transfer_id UUID REFERENCES stock_transfers(id);
+ALTER TABLE stock_movements DROP CONSTRAINT stock_movements_reason_check;
+ALTER TABLE stock_movements ADD CONSTRAINT stock_movements_reason_check
+    CHECK (reason IN ('sale', 'cancellation', 'manual_adjustment', 'return', 'restock', 'transfer_in', 'transfer_out'));
+
+CREATE INDEX idx_stock_movements_location_id ON stock_movements(product_id, location_id);
+
+-- Keeps products.stock_quantity equal to the stock held at active, sellable locations
+CREATE OR REPLACE FUNCTION sync_product_stock_quantity() RETURNS TRIGGER AS $$
+DECLARE
+    target_product_id UUID;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        target_product_id := OLD.product_id;
+    ELSE
+        target_product_id := NEW.product_id;
+    END IF;
+
+    UPDATE products p
+    SET stock_quantity = COALESCE((
+        SELECT SUM(psl.quantity)
+        FROM product_stock_levels psl
+        JOIN stock_locations sl ON sl.id = psl.location_id
+        WHERE psl.product_id = p.id AND sl.is_sellable AND sl.is_active
+    ), 0)
+    WHERE p.id = target_product_id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_product_stock_levels_sync
+AFTER INSERT OR UPDATE OR DELETE ON product_stock_levels
+FOR EACH ROW EXECUTE FUNCTION sync_product_stock_quantity();
+
+-- Recomputes the stock of every product held at a location whose sellability changed
+CREATE OR REPLACE FUNCTION sync_location_stock_quantities() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE products p
+    SET stock_quantity = COALESCE((
+        SELECT SUM(psl.quantity)
+        FROM product_stock_levels psl
+        JOIN stock_locations sl ON sl.id = psl.location_id
+        WHERE psl.product_id = p.id AND sl.is_sellable AND sl.is_active
+    ), 0)
+    WHERE p.id IN (SELECT product_id FROM product_stock_levels WHERE location_id = NEW.id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_stock_locations_sync
+AFTER UPDATE OF is_sellable, is_active ON stock_locations
+FOR EACH ROW
+WHEN (OLD.is_sellable IS DISTINCT FROM NEW.is_sellable OR OLD.is_active IS DISTINCT FROM NEW.is_active)
+EXECUTE FUNCTION sync_location_stock_quantities();
+
+-- Existing stock moves to a default warehouse location
+INSERT INTO stock_locations (code, name, is_sellable, is_default, priority)
+VALUES ('WAREHOUSE', 'Main warehouse', TRUE, TRUE, 0);
+
+INSERT INTO product_stock_levels (product_id, location_id, quantity)
+SELECT p.id, sl.id, p.stock_quantity
+FROM products p
+CROSS JOIN stock_locations sl
+WHERE sl.is_default;
+
+-- Existing ledger entries belong to the default location (the ledger is otherwise append-only)
+ALTER TABLE stock_movements DISABLE TRIGGER trg_stock_movements_append_only;
+UPDATE stock_movements SET location_id = (SELECT id FROM stock_locations WHERE is_default);
+ALTER TABLE stock_movements ENABLE TRIGGER trg_stock_movements_append_only;
+ALTER TABLE stock_movements ALTER COLUMN location_id SET NOT NULL;
+
+-- Orders holding stock were served from the default location
+INSERT INTO order_item_allocations (order_id, order_item_id, product_id, location_id, quantity)
+SELECT oi.order_id, oi.id, oi.product_id, sl.id, oi.quantity
+FROM order_items oi
+JOIN orders o ON o.id = oi.order_id
+CROSS JOIN stock_locations sl
+WHERE sl.is_default AND o.status IN ('confirmed', 'shipped', 'delivered');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS trg_stock_locations_sync ON stock_locations;
+DROP TRIGGER IF EXISTS trg_product_stock_levels_sync ON product_stock_levels;
+DROP FUNCTION IF EXISTS sync_location_stock_quantities();
+DROP FUNCTION IF EXISTS sync_product_stock_quantity();
+
+-- All stock goes back into a single pool. The append-only ledger keeps its transfer movements, which net
+-- to zero per product, so it still reconciles with stock_quantity; the reason check keeps allowing them.
+UPDATE products p
+SET stock_quantity = COALESCE((SELECT SUM(psl.quantity) FROM product_stock_levels psl WHERE psl.product_id = p.id), 0)
+WHERE p.id IN (SELECT product_id FROM product_stock_levels);
+
+DROP INDEX IF EXISTS idx_stock_movements_location_id;
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS transfer_id;
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS location_id;
+
+DROP TABLE IF EXISTS order_item_allocations;
+DROP TABLE IF EXISTS stock_transfers;
+DROP TABLE IF EXISTS product_stock_levels;
+DROP TABLE IF EXISTS stock_locations;
+-- +goose StatementEnd
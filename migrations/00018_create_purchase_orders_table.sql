@@ -0,0 +1,81 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE suppliers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL UNIQUE,
+    contact_name VARCHAR(255),
+    email VARCHAR(255),
+    phone VARCHAR(50),
+    notes TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE purchase_orders (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    supplier_id UUID NOT NULL REFERENCES suppliers(id),
+    location_id UUID NOT NULL REFERENCES stock_locations(id), -- Where the goods are received
+    status VARCHAR(30) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'ordered', 'partially_received', 'received', 'cancelled')),
+    reference VARCHAR(100), -- Supplier's reference (quote, invoice number, ...)
+    notes TEXT,
+    expected_at TIMESTAMPTZ,
+    created_by UUID REFERENCES users(id),
+    ordered_at TIMESTAMPTZ,
+    received_at TIMESTAMPTZ, -- Set when the last line is fully received
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_purchase_orders_supplier_id ON purchase_orders(supplier_id);
+CREATE INDEX idx_purchase_orders_status ON purchase_orders(status);
+
+CREATE TABLE purchase_order_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    purchase_order_id UUID NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id),
+    quantity_ordered INTEGER NOT NULL CHECK (quantity_ordered > 0),
+    quantity_received INTEGER NOT NULL DEFAULT 0 CHECK (quantity_received >= 0),
+    unit_cost_cents BIGINT NOT NULL CHECK (unit_cost_cents >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_purchase_order_items_received CHECK (quantity_received <= quantity_ordered),
+    CONSTRAINT uq_purchase_order_items_product UNIQUE (purchase_order_id, product_id)
+);
+
+-- One row per received delivery of a line, with the cost actually paid
+CREATE TABLE purchase_order_receipts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    purchase_order_id UUID NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    purchase_order_item_id UUID NOT NULL REFERENCES purchase_order_items(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id),
+    location_id UUID NOT NULL REFERENCES stock_locations(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_cost_cents BIGINT NOT NULL CHECK (unit_cost_cents >= 0),
+    stock_movement_id BIGINT NOT NULL REFERENCES stock_movements(id),
+    received_by UUID REFERENCES users(id),
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_purchase_order_receipts_purchase_order_id ON purchase_order_receipts(purchase_order_id);
+
+-- Weighted average cost of each product's stock on hand, updated on every receipt
+CREATE TABLE product_costs (
+    product_id UUID PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    average_cost_cents BIGINT NOT NULL CHECK (average_cost_cents >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Cost of the units sold, snapshotted when the order is confirmed (NULL if the cost was unknown)
+ALTER TABLE order_items ADD COLUMN unit_cost_cents BIGINT CHECK (unit_cost_cents >= 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE order_items DROP COLUMN IF EXISTS unit_cost_cents;
+DROP TABLE IF EXISTS product_costs;
+DROP TABLE IF EXISTS purchase_order_receipts;
+DROP TABLE IF EXISTS purchase_order_items;
+DROP TABLE IF EXISTS purchase_orders;
+DROP TABLE IF EXISTS suppliers;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE reviews
+    ADD COLUMN title VARCHAR(200),
+    ADD COLUMN body TEXT,
+    ADD COLUMN pros TEXT,
+    ADD COLUMN cons TEXT,
+    -- Existing (rating-only) reviews are already public, so they start approved
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'approved' CHECK (status IN ('pending', 'approved', 'rejected')),
+    ADD COLUMN moderation_reason TEXT, -- Why the review was rejected, shown to its author
+    ADD COLUMN moderated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN moderated_at TIMESTAMPTZ;
+
+ALTER TABLE reviews ALTER COLUMN status SET DEFAULT 'pending';
+
+CREATE INDEX idx_reviews_status ON reviews(status, created_at) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_reviews_status;
+ALTER TABLE reviews
+    DROP COLUMN IF EXISTS moderated_at,
+    DROP COLUMN IF EXISTS moderated_by,
+    DROP COLUMN IF EXISTS moderation_reason,
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS cons,
+    DROP COLUMN IF EXISTS pros,
+    DROP COLUMN IF EXISTS body,
+    DROP COLUMN IF EXISTS title;
+-- +goose StatementEnd
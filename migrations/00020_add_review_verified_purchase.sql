@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Set when the reviewer has a delivered order containing the product
+ALTER TABLE reviews ADD COLUMN verified_purchase BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE reviews r
+SET verified_purchase = TRUE
+WHERE EXISTS (
+    SELECT 1
+    FROM orders o
+    JOIN order_items oi ON oi.order_id = o.id
+    WHERE o.user_id = r.user_id AND oi.product_id = r.product_id AND o.status = 'delivered'
+);
+
+-- Ratings of existing products were computed before moderation and verification; recompute them the way
+-- CalculateReviewStatsForProduct does with the default REVIEWS_VERIFIED_WEIGHT of 1
+UPDATE products p
+SET avg_rating = stats.avg_rating,
+    num_ratings = stats.num_ratings
+FROM (
+    SELECT
+        p2.id AS product_id,
+        AVG(r.rating)::NUMERIC(3,2) AS avg_rating,
+        COUNT(r.rating)::INTEGER AS num_ratings
+    FROM products p2
+    LEFT JOIN reviews r ON r.product_id = p2.id AND r.deleted_at IS NULL AND r.status = 'approved'
+    GROUP BY p2.id
+) stats
+WHERE p.id = stats.product_id
+  AND (p.avg_rating IS DISTINCT FROM stats.avg_rating OR p.num_ratings IS DISTINCT FROM stats.num_ratings);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE reviews DROP COLUMN IF EXISTS verified_purchase;
+-- +goose StatementEnd
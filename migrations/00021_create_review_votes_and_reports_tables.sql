@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE review_votes (
+    review_id UUID NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    is_helpful BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (review_id, user_id) -- One vote per user and review
+);
+
+CREATE TABLE review_reports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    review_id UUID NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ, -- Set when a moderator approves or rejects the review
+    resolved_by UUID REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- A user may have only one open report per review
+CREATE UNIQUE INDEX idx_review_reports_open_unique ON review_reports(review_id, user_id) WHERE resolved_at IS NULL;
+CREATE INDEX idx_review_reports_review_id ON review_reports(review_id);
+
+-- Vote totals, kept in sync by the service so listings can sort by helpfulness
+ALTER TABLE reviews
+    ADD COLUMN helpful_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN unhelpful_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_reviews_product_helpful ON reviews(product_id, helpful_count DESC) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_reviews_product_helpful;
+ALTER TABLE reviews
+    DROP COLUMN IF EXISTS unhelpful_count,
+    DROP COLUMN IF EXISTS helpful_count;
+DROP TABLE IF EXISTS review_reports;
+DROP TABLE IF EXISTS review_votes;
+-- +goose StatementEnd
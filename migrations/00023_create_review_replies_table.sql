@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE review_replies (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    review_id UUID NOT NULL UNIQUE REFERENCES reviews(id) ON DELETE CASCADE, -- One official reply per review
+    author_id UUID REFERENCES users(id) ON DELETE SET NULL, -- Staff member who wrote or last edited the reply
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS review_replies;
+-- +goose StatementEnd
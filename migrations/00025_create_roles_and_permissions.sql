@@ -0,0 +1,77 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE permissions (
+    code TEXT PRIMARY KEY, -- e.g. 'orders:write'
+    description TEXT NOT NULL
+);
+
+CREATE TABLE roles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE role_permissions (
+    role_id UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    permission_code TEXT NOT NULL REFERENCES permissions(code) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_code)
+);
+
+CREATE TABLE user_roles (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role_id UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    assigned_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    assigned_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, role_id)
+);
+
+CREATE INDEX idx_user_roles_role_id ON user_roles(role_id);
+
+INSERT INTO permissions (code, description) VALUES
+    ('products:write', 'Create, edit and delete products and categories'),
+    ('inventory:write', 'Manage stock, stock locations, suppliers and purchase orders'),
+    ('orders:read', 'View orders and their customers'),
+    ('orders:write', 'Update, cancel and fulfil orders'),
+    ('delivery:write', 'Manage delivery services'),
+    ('discounts:write', 'Create, edit and delete discounts'),
+    ('users:read', 'View customer accounts'),
+    ('users:write', 'Activate and deactivate customer accounts'),
+    ('roles:write', 'Assign roles to staff'),
+    ('reviews:moderate', 'Moderate and reply to reviews and product questions'),
+    ('analytics:read', 'View sales, inventory and margin reports');
+
+INSERT INTO roles (name, description) VALUES
+    ('superuser', 'Full access to the admin area'),
+    ('order_staff', 'Handles orders and deliveries'),
+    ('catalogue_editor', 'Maintains products, stock and discounts'),
+    ('moderator', 'Moderates reviews and product questions');
+
+INSERT INTO role_permissions (role_id, permission_code)
+SELECT r.id, p.code FROM roles r CROSS JOIN permissions p WHERE r.name = 'superuser';
+
+INSERT INTO role_permissions (role_id, permission_code)
+SELECT r.id, p.code FROM roles r
+JOIN (VALUES
+    ('order_staff', 'orders:read'),
+    ('order_staff', 'orders:write'),
+    ('order_staff', 'delivery:write'),
+    ('catalogue_editor', 'products:write'),
+    ('catalogue_editor', 'inventory:write'),
+    ('catalogue_editor', 'discounts:write'),
+    ('moderator', 'reviews:moderate')
+) AS p(role_name, code) ON p.role_name = r.name;
+
+-- Existing admins keep full access
+INSERT INTO user_roles (user_id, role_id)
+SELECT u.id, r.id FROM users u CROSS JOIN roles r
+WHERE u.is_admin AND r.name = 'superuser';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_roles;
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS roles;
+DROP TABLE IF EXISTS permissions;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE audit_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    actor_email TEXT NOT NULL DEFAULT '', -- Kept so entries stay readable after the actor is deleted
+    action TEXT NOT NULL, -- e.g. 'PUT /products/{id}'
+    entity_type TEXT NOT NULL, -- e.g. 'products'
+    entity_id TEXT NOT NULL DEFAULT '',
+    before JSONB,
+    after JSONB,
+    diff JSONB, -- Changed top-level fields: {"field": {"before": ..., "after": ...}}
+    request_id TEXT NOT NULL DEFAULT '',
+    ip_address TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_log_created_at ON audit_log(created_at DESC);
+CREATE INDEX idx_audit_log_entity ON audit_log(entity_type, entity_id);
+CREATE INDEX idx_audit_log_actor_id ON audit_log(actor_id);
+
+INSERT INTO permissions (code, description) VALUES
+    ('audit:read', 'View the admin audit log');
+
+INSERT INTO role_permissions (role_id, permission_code)
+SELECT r.id, 'audit:read' FROM roles r WHERE r.name = 'superuser';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS audit_log;
+DELETE FROM permissions WHERE code = 'audit:read';
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Access tokens carry the version they were issued with; bumping it invalidates every outstanding access token of the user.
+ALTER TABLE users ADD COLUMN token_version INT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN IF EXISTS token_version;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Every refresh token descends from a login; rotation keeps the family. Existing tokens each start their own family.
+ALTER TABLE refresh_tokens ADD COLUMN family_id UUID NOT NULL DEFAULT gen_random_uuid();
+ALTER TABLE refresh_tokens ALTER COLUMN family_id DROP DEFAULT;
+-- Why the token was revoked; only presenting a 'rotated' token again counts as reuse
+ALTER TABLE refresh_tokens ADD COLUMN revoked_reason VARCHAR(20);
+
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_refresh_tokens_family_id;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS revoked_reason;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS family_id;
+-- +goose StatementEnd
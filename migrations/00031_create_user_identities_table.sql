@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- External (OpenID Connect) identities a user signs in with, next to or instead of a password
+CREATE TABLE user_identities (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL, -- Configured provider name, e.g. google
+    subject VARCHAR(255) NOT NULL, -- The sub claim: the user ID at the provider, stable across email changes
+    email VARCHAR(255), -- Email reported by the provider when the identity was linked
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_login_at TIMESTAMPTZ,
+    UNIQUE (provider, subject)
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_identities;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Keys used by integrations (ERP, courier scripts) to call the admin API without a user session
+CREATE TABLE api_keys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL, -- Start of the key, shown in listings so admins can tell keys apart
+    key_hash CHAR(64) NOT NULL UNIQUE, -- SHA-256 of the key; the key itself is only shown once
+    scopes TEXT[] NOT NULL, -- Permission codes granted to the key
+    allowed_ips TEXT[] NOT NULL DEFAULT '{}', -- IP addresses or CIDR ranges; empty allows any client
+    expires_at TIMESTAMPTZ,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMPTZ,
+    request_count BIGINT NOT NULL DEFAULT 0,
+    revoked_at TIMESTAMPTZ
+);
+
+INSERT INTO permissions (code, description) VALUES
+    ('api_keys:manage', 'Create and revoke API keys for integrations');
+
+INSERT INTO role_permissions (role_id, permission_code)
+SELECT r.id, 'api_keys:manage' FROM roles r WHERE r.name = 'superuser';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS api_keys;
+DELETE FROM permissions WHERE code = 'api_keys:manage';
+-- +goose StatementEnd
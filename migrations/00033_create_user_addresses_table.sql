@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Saved shipping addresses of a customer. Orders keep their own copy, so editing or deleting an address
+-- never changes past orders.
+CREATE TABLE user_addresses (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    label VARCHAR(50), -- Optional name shown to the customer, e.g. Home or Work
+    full_name VARCHAR(255) NOT NULL,
+    phone_number_1 VARCHAR(255) NOT NULL,
+    phone_number_2 VARCHAR(255),
+    province VARCHAR(255) NOT NULL,
+    city VARCHAR(255) NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_user_addresses_user_id ON user_addresses(user_id);
+-- At most one default address per user
+CREATE UNIQUE INDEX idx_user_addresses_default ON user_addresses(user_id) WHERE is_default;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_addresses;
+-- +goose StatementEnd
@@ -0,0 +1,1651 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Reference data for Algerian addresses: the 58 wilayas (provinces) and their 1541 communes,
+-- as organised since the 2019 reform. Aliases hold other spellings customers commonly type.
+CREATE TABLE wilayas (
+    code SMALLINT PRIMARY KEY, -- Official wilaya number, 1 to 58
+    name VARCHAR(100) NOT NULL UNIQUE,
+    name_ar VARCHAR(100) NOT NULL,
+    aliases TEXT[] NOT NULL DEFAULT '{}'
+);
+
+CREATE TABLE communes (
+    code INTEGER PRIMARY KEY, -- Wilaya code followed by the two-digit number of the commune, e.g. 1601
+    wilaya_code SMALLINT NOT NULL REFERENCES wilayas(code),
+    name VARCHAR(100) NOT NULL,
+    aliases TEXT[] NOT NULL DEFAULT '{}',
+    UNIQUE (wilaya_code, name)
+);
+
+INSERT INTO wilayas (code, name, name_ar, aliases) VALUES
+    (1, 'Adrar', 'أدرار', '{}'),
+    (2, 'Chlef', 'الشلف', ARRAY['El Asnam']),
+    (3, 'Laghouat', 'الأغواط', '{}'),
+    (4, 'Oum El Bouaghi', 'أم البواقي', '{}'),
+    (5, 'Batna', 'باتنة', '{}'),
+    (6, 'Béjaïa', 'بجاية', ARRAY['Bougie', 'Bgayet']),
+    (7, 'Biskra', 'بسكرة', '{}'),
+    (8, 'Béchar', 'بشار', '{}'),
+    (9, 'Blida', 'البليدة', '{}'),
+    (10, 'Bouira', 'البويرة', '{}'),
+    (11, 'Tamanrasset', 'تمنراست', ARRAY['Tamanghasset']),
+    (12, 'Tébessa', 'تبسة', '{}'),
+    (13, 'Tlemcen', 'تلمسان', '{}'),
+    (14, 'Tiaret', 'تيارت', '{}'),
+    (15, 'Tizi Ouzou', 'تيزي وزو', '{}'),
+    (16, 'Alger', 'الجزائر', ARRAY['Algiers', 'El Djazair']),
+    (17, 'Djelfa', 'الجلفة', '{}'),
+    (18, 'Jijel', 'جيجل', '{}'),
+    (19, 'Sétif', 'سطيف', '{}'),
+    (20, 'Saïda', 'سعيدة', '{}'),
+    (21, 'Skikda', 'سكيكدة', '{}'),
+    (22, 'Sidi Bel Abbès', 'سيدي بلعباس', ARRAY['Bel Abbes']),
+    (23, 'Annaba', 'عنابة', ARRAY['Bone']),
+    (24, 'Guelma', 'قالمة', '{}'),
+    (25, 'Constantine', 'قسنطينة', ARRAY['Qacentina', 'Ksentina']),
+    (26, 'Médéa', 'المدية', '{}'),
+    (27, 'Mostaganem', 'مستغانم', '{}'),
+    (28, 'M''Sila', 'المسيلة', '{}'),
+    (29, 'Mascara', 'معسكر', '{}'),
+    (30, 'Ouargla', 'ورقلة', '{}'),
+    (31, 'Oran', 'وهران', ARRAY['Wahran']),
+    (32, 'El Bayadh', 'البيض', '{}'),
+    (33, 'Illizi', 'إليزي', '{}'),
+    (34, 'Bordj Bou Arréridj', 'برج بوعريريج', ARRAY['BBA']),
+    (35, 'Boumerdès', 'بومرداس', '{}'),
+    (36, 'El Tarf', 'الطارف', '{}'),
+    (37, 'Tindouf', 'تندوف', '{}'),
+    (38, 'Tissemsilt', 'تيسمسيلت', '{}'),
+    (39, 'El Oued', 'الوادي', '{}'),
+    (40, 'Khenchela', 'خنشلة', '{}'),
+    (41, 'Souk Ahras', 'سوق أهراس', '{}'),
+    (42, 'Tipaza', 'تيبازة', '{}'),
+    (43, 'Mila', 'ميلة', '{}'),
+    (44, 'Aïn Defla', 'عين الدفلى', '{}'),
+    (45, 'Naâma', 'النعامة', '{}'),
+    (46, 'Aïn Témouchent', 'عين تموشنت', '{}'),
+    (47, 'Ghardaïa', 'غرداية', '{}'),
+    (48, 'Relizane', 'غليزان', '{}'),
+    (49, 'Timimoun', 'تيميمون', '{}'),
+    (50, 'Bordj Badji Mokhtar', 'برج باجي مختار', '{}'),
+    (51, 'Ouled Djellal', 'أولاد جلال', '{}'),
+    (52, 'Béni Abbès', 'بني عباس', '{}'),
+    (53, 'In Salah', 'عين صالح', ARRAY['Ain Salah']),
+    (54, 'In Guezzam', 'عين قزام', ARRAY['Ain Guezzam']),
+    (55, 'Touggourt', 'تقرت', '{}'),
+    (56, 'Djanet', 'جانت', '{}'),
+    (57, 'El M''Ghair', 'المغير', '{}'),
+    (58, 'El Meniaa', 'المنيعة', '{}');
+
+INSERT INTO communes (code, wilaya_code, name, aliases) VALUES
+    (101, 1, 'Adrar', '{}'),
+    (102, 1, 'Tamest', '{}'),
+    (103, 1, 'Reggane', '{}'),
+    (104, 1, 'In Zghmir', '{}'),
+    (105, 1, 'Tit', '{}'),
+    (106, 1, 'Tsabit', '{}'),
+    (107, 1, 'Zaouiet Kounta', '{}'),
+    (108, 1, 'Aoulef', '{}'),
+    (109, 1, 'Timokten', '{}'),
+    (110, 1, 'Tamentit', '{}'),
+    (111, 1, 'Fenoughil', '{}'),
+    (112, 1, 'Sali', '{}'),
+    (113, 1, 'Akabli', '{}'),
+    (114, 1, 'Ouled Ahmed Timmi', '{}'),
+    (115, 1, 'Bouda', '{}'),
+    (116, 1, 'Sebaa', '{}'),
+    (201, 2, 'Chlef', '{}'),
+    (202, 2, 'Tenes', '{}'),
+    (203, 2, 'Benairia', '{}'),
+    (204, 2, 'El Karimia', '{}'),
+    (205, 2, 'Tadjena', '{}'),
+    (206, 2, 'Taougrite', '{}'),
+    (207, 2, 'Beni Haoua', '{}'),
+    (208, 2, 'Sobha', '{}'),
+    (209, 2, 'Harchoun', '{}'),
+    (210, 2, 'Ouled Fares', '{}'),
+    (211, 2, 'Sidi Akkacha', '{}'),
+    (212, 2, 'Boukadir', '{}'),
+    (213, 2, 'Beni Rached', '{}'),
+    (214, 2, 'Talassa', '{}'),
+    (215, 2, 'Herenfa', '{}'),
+    (216, 2, 'Oued Goussine', '{}'),
+    (217, 2, 'Dahra', '{}'),
+    (218, 2, 'Ouled Abbes', '{}'),
+    (219, 2, 'Sendjas', '{}'),
+    (220, 2, 'Zeboudja', '{}'),
+    (221, 2, 'Oued Sly', '{}'),
+    (222, 2, 'Abou El Hassane', '{}'),
+    (223, 2, 'El Marsa', '{}'),
+    (224, 2, 'Chettia', '{}'),
+    (225, 2, 'Sidi Abderrahmane', '{}'),
+    (226, 2, 'Moussadek', '{}'),
+    (227, 2, 'El Hadjadj', '{}'),
+    (228, 2, 'Labiod Medjadja', '{}'),
+    (229, 2, 'Oued Fodda', '{}'),
+    (230, 2, 'Ouled Ben Abdelkader', '{}'),
+    (231, 2, 'Bouzeghaia', '{}'),
+    (232, 2, 'Ain Merane', '{}'),
+    (233, 2, 'Oum Drou', '{}'),
+    (234, 2, 'Breira', '{}'),
+    (235, 2, 'Beni Bouateb', '{}'),
+    (301, 3, 'Laghouat', '{}'),
+    (302, 3, 'Ksar El Hirane', '{}'),
+    (303, 3, 'Benacer Benchohra', '{}'),
+    (304, 3, 'Sidi Makhlouf', '{}'),
+    (305, 3, 'Hassi Delaa', '{}'),
+    (306, 3, 'Hassi R''Mel', '{}'),
+    (307, 3, 'Ain Madhi', '{}'),
+    (308, 3, 'Tadjemout', '{}'),
+    (309, 3, 'Kheneg', '{}'),
+    (310, 3, 'Gueltat Sidi Saad', '{}'),
+    (311, 3, 'Ain Sidi Ali', '{}'),
+    (312, 3, 'Beidha', '{}'),
+    (313, 3, 'Brida', '{}'),
+    (314, 3, 'El Ghicha', '{}'),
+    (315, 3, 'Hadj Mechri', '{}'),
+    (316, 3, 'Sebgag', '{}'),
+    (317, 3, 'Taouiala', '{}'),
+    (318, 3, 'Tadjrouna', '{}'),
+    (319, 3, 'Aflou', '{}'),
+    (320, 3, 'El Assafia', '{}'),
+    (321, 3, 'Oued Morra', '{}'),
+    (322, 3, 'Oued M''Zi', '{}'),
+    (323, 3, 'El Haouaita', '{}'),
+    (324, 3, 'Sidi Bouzid', '{}'),
+    (401, 4, 'Oum El Bouaghi', '{}'),
+    (402, 4, 'Ain Beida', '{}'),
+    (403, 4, 'Ain M''Lila', '{}'),
+    (404, 4, 'Behir Chergui', '{}'),
+    (405, 4, 'El Amiria', '{}'),
+    (406, 4, 'Sigus', '{}'),
+    (407, 4, 'El Belala', '{}'),
+    (408, 4, 'Ain Babouche', '{}'),
+    (409, 4, 'Berriche', '{}'),
+    (410, 4, 'Ouled Hamla', '{}'),
+    (411, 4, 'Dhala', '{}'),
+    (412, 4, 'Ain Kercha', '{}'),
+    (413, 4, 'Hanchir Toumghani', '{}'),
+    (414, 4, 'El Djazia', '{}'),
+    (415, 4, 'Ain Diss', '{}'),
+    (416, 4, 'Fkirina', '{}'),
+    (417, 4, 'Souk Naamane', '{}'),
+    (418, 4, 'Zorg', '{}'),
+    (419, 4, 'El Fedjoudj Boughrara Saoudi', '{}'),
+    (420, 4, 'Ouled Zouai', '{}'),
+    (421, 4, 'Bir Chouhada', '{}'),
+    (422, 4, 'Ksar Sbahi', '{}'),
+    (423, 4, 'Oued Nini', '{}'),
+    (424, 4, 'Meskiana', '{}'),
+    (425, 4, 'Ain Fekroun', '{}'),
+    (426, 4, 'Rahia', '{}'),
+    (427, 4, 'Ain Zitoun', '{}'),
+    (428, 4, 'Ouled Gacem', '{}'),
+    (429, 4, 'El Harmilia', '{}'),
+    (501, 5, 'Batna', '{}'),
+    (502, 5, 'Ghassira', '{}'),
+    (503, 5, 'Maafa', '{}'),
+    (504, 5, 'Merouana', '{}'),
+    (505, 5, 'Seriana', '{}'),
+    (506, 5, 'Menaa', '{}'),
+    (507, 5, 'El Madher', '{}'),
+    (508, 5, 'Tazoult', '{}'),
+    (509, 5, 'N''Gaous', '{}'),
+    (510, 5, 'Guigba', '{}'),
+    (511, 5, 'Inoughissen', '{}'),
+    (512, 5, 'Ouyoun El Assafir', '{}'),
+    (513, 5, 'Djerma', '{}'),
+    (514, 5, 'Bitam', '{}'),
+    (515, 5, 'Abdelkader Azil', '{}'),
+    (516, 5, 'Arris', '{}'),
+    (517, 5, 'Kimmel', '{}'),
+    (518, 5, 'Tilatou', '{}'),
+    (519, 5, 'Ain Djasser', '{}'),
+    (520, 5, 'Ouled Sellam', '{}'),
+    (521, 5, 'Tigherghar', '{}'),
+    (522, 5, 'Ain Yagout', '{}'),
+    (523, 5, 'Fesdis', '{}'),
+    (524, 5, 'Sefiane', '{}'),
+    (525, 5, 'Rahbat', '{}'),
+    (526, 5, 'Tighanimine', '{}'),
+    (527, 5, 'Lemsane', '{}'),
+    (528, 5, 'Ksar Bellezma', '{}'),
+    (529, 5, 'Seggana', '{}'),
+    (530, 5, 'Ichmoul', '{}'),
+    (531, 5, 'Foum Toub', '{}'),
+    (532, 5, 'Beni Foudhala El Hakania', '{}'),
+    (533, 5, 'Oued El Ma', '{}'),
+    (534, 5, 'Talkhamt', '{}'),
+    (535, 5, 'Bouzina', '{}'),
+    (536, 5, 'Chemora', '{}'),
+    (537, 5, 'Oued Chaaba', '{}'),
+    (538, 5, 'Taxlent', '{}'),
+    (539, 5, 'Gosbat', '{}'),
+    (540, 5, 'Ouled Aouf', '{}'),
+    (541, 5, 'Boumagueur', '{}'),
+    (542, 5, 'Barika', '{}'),
+    (543, 5, 'Djezzar', '{}'),
+    (544, 5, 'T''Kout', '{}'),
+    (545, 5, 'Ain Touta', '{}'),
+    (546, 5, 'Hidoussa', '{}'),
+    (547, 5, 'Teniet El Abed', '{}'),
+    (548, 5, 'Oued Taga', '{}'),
+    (549, 5, 'Ouled Fadel', '{}'),
+    (550, 5, 'Timgad', '{}'),
+    (551, 5, 'Ras El Aioun', '{}'),
+    (552, 5, 'Chir', '{}'),
+    (553, 5, 'Ouled Si Slimane', '{}'),
+    (554, 5, 'Zanat El Beida', '{}'),
+    (555, 5, 'Amdoukal', '{}'),
+    (556, 5, 'Ouled Ammar', '{}'),
+    (557, 5, 'El Hassi', '{}'),
+    (558, 5, 'Lazrou', '{}'),
+    (559, 5, 'Boumia', '{}'),
+    (560, 5, 'Boulhilat', '{}'),
+    (561, 5, 'Larbaa', '{}'),
+    (601, 6, 'Bejaia', '{}'),
+    (602, 6, 'Amizour', '{}'),
+    (603, 6, 'Ferraoun', '{}'),
+    (604, 6, 'Taourirt Ighil', '{}'),
+    (605, 6, 'Chellata', '{}'),
+    (606, 6, 'Tamokra', '{}'),
+    (607, 6, 'Timezrit', '{}'),
+    (608, 6, 'Souk El Tenine', '{}'),
+    (609, 6, 'M''Cisna', '{}'),
+    (610, 6, 'Tinabdher', '{}'),
+    (611, 6, 'Tichy', '{}'),
+    (612, 6, 'Semaoun', '{}'),
+    (613, 6, 'Kendira', '{}'),
+    (614, 6, 'Tifra', '{}'),
+    (615, 6, 'Ighram', '{}'),
+    (616, 6, 'Amalou', '{}'),
+    (617, 6, 'Ighil Ali', '{}'),
+    (618, 6, 'Fenaia Ilmaten', '{}'),
+    (619, 6, 'Toudja', '{}'),
+    (620, 6, 'Darguina', '{}'),
+    (621, 6, 'Sidi Ayad', '{}'),
+    (622, 6, 'Aokas', '{}'),
+    (623, 6, 'Beni Djellil', '{}'),
+    (624, 6, 'Adekar', '{}'),
+    (625, 6, 'Akbou', '{}'),
+    (626, 6, 'Seddouk', '{}'),
+    (627, 6, 'Tazmalt', '{}'),
+    (628, 6, 'Ait R''Zine', '{}'),
+    (629, 6, 'Chemini', '{}'),
+    (630, 6, 'Souk Oufella', '{}'),
+    (631, 6, 'Taskriout', '{}'),
+    (632, 6, 'Tibane', '{}'),
+    (633, 6, 'Tala Hamza', '{}'),
+    (634, 6, 'Barbacha', '{}'),
+    (635, 6, 'Beni Ksila', '{}'),
+    (636, 6, 'Ouzellaguen', '{}'),
+    (637, 6, 'Bouhamza', '{}'),
+    (638, 6, 'Beni Melikeche', '{}'),
+    (639, 6, 'Sidi Aich', '{}'),
+    (640, 6, 'El Kseur', '{}'),
+    (641, 6, 'Melbou', '{}'),
+    (642, 6, 'Akfadou', '{}'),
+    (643, 6, 'Leflaye', '{}'),
+    (644, 6, 'Kherrata', '{}'),
+    (645, 6, 'Draa El Caid', '{}'),
+    (646, 6, 'Tamridjet', '{}'),
+    (647, 6, 'Ait Smail', '{}'),
+    (648, 6, 'Boukhelifa', '{}'),
+    (649, 6, 'Tizi N''Berber', '{}'),
+    (650, 6, 'Beni Maouche', '{}'),
+    (651, 6, 'Oued Ghir', '{}'),
+    (652, 6, 'Boudjellil', '{}'),
+    (701, 7, 'Biskra', '{}'),
+    (702, 7, 'Oumache', '{}'),
+    (703, 7, 'Branis', '{}'),
+    (704, 7, 'Chetma', '{}'),
+    (705, 7, 'Sidi Okba', '{}'),
+    (706, 7, 'M''Chouneche', '{}'),
+    (707, 7, 'El Haouch', '{}'),
+    (708, 7, 'Ain Naga', '{}'),
+    (709, 7, 'Zeribet El Oued', '{}'),
+    (710, 7, 'El Feidh', '{}'),
+    (711, 7, 'El Kantara', '{}'),
+    (712, 7, 'Ain Zaatout', '{}'),
+    (713, 7, 'El Outaya', '{}'),
+    (714, 7, 'Djemorah', '{}'),
+    (715, 7, 'Tolga', '{}'),
+    (716, 7, 'Lioua', '{}'),
+    (717, 7, 'Lichana', '{}'),
+    (718, 7, 'Ourlal', '{}'),
+    (719, 7, 'M''Lili', '{}'),
+    (720, 7, 'Foughala', '{}'),
+    (721, 7, 'Bordj Ben Azzouz', '{}'),
+    (722, 7, 'Meziraa', '{}'),
+    (723, 7, 'Bouchagroun', '{}'),
+    (724, 7, 'Mekhadma', '{}'),
+    (725, 7, 'El Ghrous', '{}'),
+    (726, 7, 'El Hadjeb', '{}'),
+    (727, 7, 'Khenguet Sidi Nadji', '{}'),
+    (801, 8, 'Bechar', '{}'),
+    (802, 8, 'Erg Ferradj', '{}'),
+    (803, 8, 'Meridja', '{}'),
+    (804, 8, 'Lahmar', '{}'),
+    (805, 8, 'Mechraa Houari Boumedienne', '{}'),
+    (806, 8, 'Kenadsa', '{}'),
+    (807, 8, 'Tabelbala', '{}'),
+    (808, 8, 'Taghit', '{}'),
+    (809, 8, 'Boukais', '{}'),
+    (810, 8, 'Mogheul', '{}'),
+    (811, 8, 'Abadla', '{}'),
+    (812, 8, 'Beni Ounif', '{}'),
+    (901, 9, 'Blida', '{}'),
+    (902, 9, 'Chebli', '{}'),
+    (903, 9, 'Bouinan', '{}'),
+    (904, 9, 'Oued El Alleug', '{}'),
+    (905, 9, 'Ouled Yaich', '{}'),
+    (906, 9, 'Chrea', '{}'),
+    (907, 9, 'El Affroun', '{}'),
+    (908, 9, 'Chiffa', '{}'),
+    (909, 9, 'Hammam Melouane', '{}'),
+    (910, 9, 'Ben Khellil', '{}'),
+    (911, 9, 'Soumaa', '{}'),
+    (912, 9, 'Mouzaia', '{}'),
+    (913, 9, 'Souhane', '{}'),
+    (914, 9, 'Meftah', '{}'),
+    (915, 9, 'Ouled Slama', '{}'),
+    (916, 9, 'Boufarik', '{}'),
+    (917, 9, 'Larbaa', '{}'),
+    (918, 9, 'Oued Djer', '{}'),
+    (919, 9, 'Beni Tamou', '{}'),
+    (920, 9, 'Bouarfa', '{}'),
+    (921, 9, 'Beni Mered', '{}'),
+    (922, 9, 'Bougara', '{}'),
+    (923, 9, 'Guerrouaou', '{}'),
+    (924, 9, 'Ain Romana', '{}'),
+    (925, 9, 'Djebabra', '{}'),
+    (1001, 10, 'Bouira', '{}'),
+    (1002, 10, 'El Asnam', '{}'),
+    (1003, 10, 'Guerrouma', '{}'),
+    (1004, 10, 'Souk El Khemis', '{}'),
+    (1005, 10, 'Kadiria', '{}'),
+    (1006, 10, 'Hanif', '{}'),
+    (1007, 10, 'Dirah', '{}'),
+    (1008, 10, 'Ait Laaziz', '{}'),
+    (1009, 10, 'Taghzout', '{}'),
+    (1010, 10, 'Raouraoua', '{}'),
+    (1011, 10, 'Mezdour', '{}'),
+    (1012, 10, 'Haizer', '{}'),
+    (1013, 10, 'Lakhdaria', '{}'),
+    (1014, 10, 'Maala', '{}'),
+    (1015, 10, 'El Hachimia', '{}'),
+    (1016, 10, 'Aomar', '{}'),
+    (1017, 10, 'Chorfa', '{}'),
+    (1018, 10, 'Bordj Okhriss', '{}'),
+    (1019, 10, 'El Adjiba', '{}'),
+    (1020, 10, 'El Hakimia', '{}'),
+    (1021, 10, 'El Khabouzia', '{}'),
+    (1022, 10, 'Ahl El Ksar', '{}'),
+    (1023, 10, 'Bouderbala', '{}'),
+    (1024, 10, 'Zbarbar', '{}'),
+    (1025, 10, 'Ain El Hadjar', '{}'),
+    (1026, 10, 'Djebahia', '{}'),
+    (1027, 10, 'Aghbalou', '{}'),
+    (1028, 10, 'Taguedit', '{}'),
+    (1029, 10, 'Ain Turk', '{}'),
+    (1030, 10, 'Saharidj', '{}'),
+    (1031, 10, 'Dechmia', '{}'),
+    (1032, 10, 'Ridane', '{}'),
+    (1033, 10, 'Bechloul', '{}'),
+    (1034, 10, 'Boukram', '{}'),
+    (1035, 10, 'Ain Bessem', '{}'),
+    (1036, 10, 'Bir Ghbalou', '{}'),
+    (1037, 10, 'Mchedallah', '{}'),
+    (1038, 10, 'Sour El Ghozlane', '{}'),
+    (1039, 10, 'Maamora', '{}'),
+    (1040, 10, 'Ouled Rached', '{}'),
+    (1041, 10, 'Ain Laloui', '{}'),
+    (1042, 10, 'Hadjera Zerga', '{}'),
+    (1043, 10, 'Ath Mansour', '{}'),
+    (1044, 10, 'El Mokrani', '{}'),
+    (1045, 10, 'Oued El Berdi', '{}'),
+    (1101, 11, 'Tamanrasset', '{}'),
+    (1102, 11, 'Abalessa', '{}'),
+    (1103, 11, 'Idles', '{}'),
+    (1104, 11, 'Tazrouk', '{}'),
+    (1105, 11, 'In Amguel', '{}'),
+    (1201, 12, 'Tebessa', '{}'),
+    (1202, 12, 'Bir El Ater', '{}'),
+    (1203, 12, 'Cheria', '{}'),
+    (1204, 12, 'Stah Guentis', '{}'),
+    (1205, 12, 'El Aouinet', '{}'),
+    (1206, 12, 'Lahouidjbet', '{}'),
+    (1207, 12, 'Safsaf El Ouesra', '{}'),
+    (1208, 12, 'Hammamet', '{}'),
+    (1209, 12, 'Negrine', '{}'),
+    (1210, 12, 'Bir Mokkadem', '{}'),
+    (1211, 12, 'El Kouif', '{}'),
+    (1212, 12, 'Morsott', '{}'),
+    (1213, 12, 'El Ogla', '{}'),
+    (1214, 12, 'Bir Dheheb', '{}'),
+    (1215, 12, 'El Ogla El Malha', '{}'),
+    (1216, 12, 'Gorriguer', '{}'),
+    (1217, 12, 'Bekkaria', '{}'),
+    (1218, 12, 'Boukhadra', '{}'),
+    (1219, 12, 'Ouenza', '{}'),
+    (1220, 12, 'El Ma El Abiodh', '{}'),
+    (1221, 12, 'Oum Ali', '{}'),
+    (1222, 12, 'Tlidjene', '{}'),
+    (1223, 12, 'Ain Zerga', '{}'),
+    (1224, 12, 'El Meridj', '{}'),
+    (1225, 12, 'Boulhaf Dyr', '{}'),
+    (1226, 12, 'Bedjene', '{}'),
+    (1227, 12, 'El Mezeraa', '{}'),
+    (1228, 12, 'Ferkane', '{}'),
+    (1301, 13, 'Tlemcen', '{}'),
+    (1302, 13, 'Beni Mester', '{}'),
+    (1303, 13, 'Ain Tallout', '{}'),
+    (1304, 13, 'Remchi', '{}'),
+    (1305, 13, 'El Fehoul', '{}'),
+    (1306, 13, 'Sabra', '{}'),
+    (1307, 13, 'Ghazaouet', '{}'),
+    (1308, 13, 'Souani', '{}'),
+    (1309, 13, 'Djebala', '{}'),
+    (1310, 13, 'El Gor', '{}'),
+    (1311, 13, 'Oued Chouly', '{}'),
+    (1312, 13, 'Ain Fezza', '{}'),
+    (1313, 13, 'Ouled Mimoun', '{}'),
+    (1314, 13, 'Amieur', '{}'),
+    (1315, 13, 'Ain Youcef', '{}'),
+    (1316, 13, 'Zenata', '{}'),
+    (1317, 13, 'Beni Snous', '{}'),
+    (1318, 13, 'Bab El Assa', '{}'),
+    (1319, 13, 'Dar Yaghmouracene', '{}'),
+    (1320, 13, 'Fellaoucene', '{}'),
+    (1321, 13, 'Azails', '{}'),
+    (1322, 13, 'Sebbaa Chioukh', '{}'),
+    (1323, 13, 'Terni Beni Hediel', '{}'),
+    (1324, 13, 'Bensekrane', '{}'),
+    (1325, 13, 'Ain Nehala', '{}'),
+    (1326, 13, 'Hennaya', '{}'),
+    (1327, 13, 'Maghnia', '{}'),
+    (1328, 13, 'Hammam Boughrara', '{}'),
+    (1329, 13, 'Souahlia', '{}'),
+    (1330, 13, 'Msirda Fouaga', '{}'),
+    (1331, 13, 'Ain Fetah', '{}'),
+    (1332, 13, 'El Aricha', '{}'),
+    (1333, 13, 'Souk Tlata', '{}'),
+    (1334, 13, 'Sidi Abdelli', '{}'),
+    (1335, 13, 'Sebdou', '{}'),
+    (1336, 13, 'Beni Ouarsous', '{}'),
+    (1337, 13, 'Sidi Medjahed', '{}'),
+    (1338, 13, 'Beni Boussaid', '{}'),
+    (1339, 13, 'Marsa Ben M''Hidi', '{}'),
+    (1340, 13, 'Nedroma', '{}'),
+    (1341, 13, 'Sidi Djillali', '{}'),
+    (1342, 13, 'Beni Bahdel', '{}'),
+    (1343, 13, 'El Bouihi', '{}'),
+    (1344, 13, 'Honaine', '{}'),
+    (1345, 13, 'Tianet', '{}'),
+    (1346, 13, 'Ouled Riyah', '{}'),
+    (1347, 13, 'Bouhlou', '{}'),
+    (1348, 13, 'Beni Khellad', '{}'),
+    (1349, 13, 'Ain Ghoraba', '{}'),
+    (1350, 13, 'Chetouane', '{}'),
+    (1351, 13, 'Mansourah', '{}'),
+    (1352, 13, 'Beni Semiel', '{}'),
+    (1353, 13, 'Ain Kebira', '{}'),
+    (1401, 14, 'Tiaret', '{}'),
+    (1402, 14, 'Medroussa', '{}'),
+    (1403, 14, 'Ain Bouchekif', '{}'),
+    (1404, 14, 'Sidi Ali Mellal', '{}'),
+    (1405, 14, 'Ain Zarit', '{}'),
+    (1406, 14, 'Ain Deheb', '{}'),
+    (1407, 14, 'Sidi Bakhti', '{}'),
+    (1408, 14, 'Medrissa', '{}'),
+    (1409, 14, 'Zmalet El Emir Abdelkader', '{}'),
+    (1410, 14, 'Madna', '{}'),
+    (1411, 14, 'Sebt', '{}'),
+    (1412, 14, 'Mellakou', '{}'),
+    (1413, 14, 'Dahmouni', '{}'),
+    (1414, 14, 'Rahouia', '{}'),
+    (1415, 14, 'Mahdia', '{}'),
+    (1416, 14, 'Sougueur', '{}'),
+    (1417, 14, 'Sidi Abdelghani', '{}'),
+    (1418, 14, 'Ain El Hadid', '{}'),
+    (1419, 14, 'Ouled Djerad', '{}'),
+    (1420, 14, 'Naima', '{}'),
+    (1421, 14, 'Meghila', '{}'),
+    (1422, 14, 'Guertoufa', '{}'),
+    (1423, 14, 'Sidi Hosni', '{}'),
+    (1424, 14, 'Djillali Ben Amar', '{}'),
+    (1425, 14, 'Sebaine', '{}'),
+    (1426, 14, 'Tousnina', '{}'),
+    (1427, 14, 'Frenda', '{}'),
+    (1428, 14, 'Ain Kermes', '{}'),
+    (1429, 14, 'Ksar Chellala', '{}'),
+    (1430, 14, 'Rechaiga', '{}'),
+    (1431, 14, 'Nadorah', '{}'),
+    (1432, 14, 'Tagdemt', '{}'),
+    (1433, 14, 'Oued Lilli', '{}'),
+    (1434, 14, 'Mechraa Safa', '{}'),
+    (1435, 14, 'Hamadia', '{}'),
+    (1436, 14, 'Chehaima', '{}'),
+    (1437, 14, 'Takhemaret', '{}'),
+    (1438, 14, 'Sidi Abderrahmane', '{}'),
+    (1439, 14, 'Serghine', '{}'),
+    (1440, 14, 'Bougara', '{}'),
+    (1441, 14, 'Faidja', '{}'),
+    (1442, 14, 'Tidda', '{}'),
+    (1501, 15, 'Tizi Ouzou', '{}'),
+    (1502, 15, 'Ain El Hammam', '{}'),
+    (1503, 15, 'Akbil', '{}'),
+    (1504, 15, 'Freha', '{}'),
+    (1505, 15, 'Souamaa', '{}'),
+    (1506, 15, 'Mechtras', '{}'),
+    (1507, 15, 'Irdjen', '{}'),
+    (1508, 15, 'Timizart', '{}'),
+    (1509, 15, 'Makouda', '{}'),
+    (1510, 15, 'Draa El Mizan', '{}'),
+    (1511, 15, 'Tizi Ghenif', '{}'),
+    (1512, 15, 'Bounouh', '{}'),
+    (1513, 15, 'Ait Chaffa', '{}'),
+    (1514, 15, 'Frikat', '{}'),
+    (1515, 15, 'Beni Aissi', '{}'),
+    (1516, 15, 'Beni Zmenzer', '{}'),
+    (1517, 15, 'Iferhounene', '{}'),
+    (1518, 15, 'Azazga', '{}'),
+    (1519, 15, 'Illoula Oumalou', '{}'),
+    (1520, 15, 'Yakouren', '{}'),
+    (1521, 15, 'Larbaa Nath Irathen', '{}'),
+    (1522, 15, 'Tizi Rached', '{}'),
+    (1523, 15, 'Zekri', '{}'),
+    (1524, 15, 'Ouaguenoun', '{}'),
+    (1525, 15, 'Ain Zaouia', '{}'),
+    (1526, 15, 'M''Kira', '{}'),
+    (1527, 15, 'Ait Yahia', '{}'),
+    (1528, 15, 'Ait Mahmoud', '{}'),
+    (1529, 15, 'Maatkas', '{}'),
+    (1530, 15, 'Ait Boumahdi', '{}'),
+    (1531, 15, 'Abi Youcef', '{}'),
+    (1532, 15, 'Beni Douala', '{}'),
+    (1533, 15, 'Illilten', '{}'),
+    (1534, 15, 'Bouzguen', '{}'),
+    (1535, 15, 'Ait Aggouacha', '{}'),
+    (1536, 15, 'Ouadhia', '{}'),
+    (1537, 15, 'Azeffoun', '{}'),
+    (1538, 15, 'Tigzirt', '{}'),
+    (1539, 15, 'Ait Aissa Mimoun', '{}'),
+    (1540, 15, 'Boghni', '{}'),
+    (1541, 15, 'Ifigha', '{}'),
+    (1542, 15, 'Ait Oumalou', '{}'),
+    (1543, 15, 'Tirmitine', '{}'),
+    (1544, 15, 'Akerrou', '{}'),
+    (1545, 15, 'Yatafen', '{}'),
+    (1546, 15, 'Beni Ziki', '{}'),
+    (1547, 15, 'Draa Ben Khedda', '{}'),
+    (1548, 15, 'Ouacif', '{}'),
+    (1549, 15, 'Idjeur', '{}'),
+    (1550, 15, 'Mekla', '{}'),
+    (1551, 15, 'Tizi N''Tleta', '{}'),
+    (1552, 15, 'Beni Yenni', '{}'),
+    (1553, 15, 'Aghrib', '{}'),
+    (1554, 15, 'Iflissen', '{}'),
+    (1555, 15, 'Boudjima', '{}'),
+    (1556, 15, 'Ait Yahia Moussa', '{}'),
+    (1557, 15, 'Souk El Thenine', '{}'),
+    (1558, 15, 'Ait Khellili', '{}'),
+    (1559, 15, 'Sidi Naamane', '{}'),
+    (1560, 15, 'Iboudraren', '{}'),
+    (1561, 15, 'Agouni Gueghrane', '{}'),
+    (1562, 15, 'Mizrana', '{}'),
+    (1563, 15, 'Imsouhal', '{}'),
+    (1564, 15, 'Tadmait', '{}'),
+    (1565, 15, 'Ait Bouaddou', '{}'),
+    (1566, 15, 'Assi Youcef', '{}'),
+    (1567, 15, 'Ait Toudert', '{}'),
+    (1601, 16, 'Alger Centre', ARRAY['Alger', 'Algiers']),
+    (1602, 16, 'Sidi M''Hamed', '{}'),
+    (1603, 16, 'El Madania', '{}'),
+    (1604, 16, 'Belouizdad', '{}'),
+    (1605, 16, 'Bab El Oued', '{}'),
+    (1606, 16, 'Bologhine', '{}'),
+    (1607, 16, 'Casbah', '{}'),
+    (1608, 16, 'Oued Koriche', '{}'),
+    (1609, 16, 'Bir Mourad Rais', '{}'),
+    (1610, 16, 'El Biar', '{}'),
+    (1611, 16, 'Bouzareah', '{}'),
+    (1612, 16, 'Birkhadem', '{}'),
+    (1613, 16, 'El Harrach', '{}'),
+    (1614, 16, 'Baraki', '{}'),
+    (1615, 16, 'Oued Smar', '{}'),
+    (1616, 16, 'Bourouba', '{}'),
+    (1617, 16, 'Hussein Dey', '{}'),
+    (1618, 16, 'Kouba', '{}'),
+    (1619, 16, 'Bachdjerrah', '{}'),
+    (1620, 16, 'Dar El Beida', '{}'),
+    (1621, 16, 'Bab Ezzouar', '{}'),
+    (1622, 16, 'Ben Aknoun', '{}'),
+    (1623, 16, 'Dely Ibrahim', '{}'),
+    (1624, 16, 'El Hammamet', '{}'),
+    (1625, 16, 'Rais Hamidou', '{}'),
+    (1626, 16, 'Djasr Kasentina', '{}'),
+    (1627, 16, 'El Mouradia', '{}'),
+    (1628, 16, 'Hydra', '{}'),
+    (1629, 16, 'Mohammadia', '{}'),
+    (1630, 16, 'Bordj El Kiffan', '{}'),
+    (1631, 16, 'El Magharia', '{}'),
+    (1632, 16, 'Beni Messous', '{}'),
+    (1633, 16, 'Les Eucalyptus', '{}'),
+    (1634, 16, 'Birtouta', '{}'),
+    (1635, 16, 'Tessala El Merdja', '{}'),
+    (1636, 16, 'Ouled Chebel', '{}'),
+    (1637, 16, 'Sidi Moussa', '{}'),
+    (1638, 16, 'Ain Taya', '{}'),
+    (1639, 16, 'Bordj El Bahri', '{}'),
+    (1640, 16, 'El Marsa', '{}'),
+    (1641, 16, 'H''Raoua', '{}'),
+    (1642, 16, 'Rouiba', '{}'),
+    (1643, 16, 'Reghaia', '{}'),
+    (1644, 16, 'Ain Benian', '{}'),
+    (1645, 16, 'Staoueli', '{}'),
+    (1646, 16, 'Zeralda', '{}'),
+    (1647, 16, 'Mahelma', '{}'),
+    (1648, 16, 'Rahmania', '{}'),
+    (1649, 16, 'Souidania', '{}'),
+    (1650, 16, 'Cheraga', '{}'),
+    (1651, 16, 'Ouled Fayet', '{}'),
+    (1652, 16, 'El Achour', '{}'),
+    (1653, 16, 'Draria', '{}'),
+    (1654, 16, 'Douera', '{}'),
+    (1655, 16, 'Baba Hassen', '{}'),
+    (1656, 16, 'Khraicia', '{}'),
+    (1657, 16, 'Saoula', '{}'),
+    (1701, 17, 'Djelfa', '{}'),
+    (1702, 17, 'Moudjbara', '{}'),
+    (1703, 17, 'El Guedid', '{}'),
+    (1704, 17, 'Hassi Bahbah', '{}'),
+    (1705, 17, 'Ain Maabed', '{}'),
+    (1706, 17, 'Sed Rahal', '{}'),
+    (1707, 17, 'Feidh El Botma', '{}'),
+    (1708, 17, 'Birine', '{}'),
+    (1709, 17, 'Bouira Lahdab', '{}'),
+    (1710, 17, 'Zaccar', '{}'),
+    (1711, 17, 'El Khemis', '{}'),
+    (1712, 17, 'Sidi Baizid', '{}'),
+    (1713, 17, 'M''Liliha', '{}'),
+    (1714, 17, 'El Idrissia', '{}'),
+    (1715, 17, 'Douis', '{}'),
+    (1716, 17, 'Hassi El Euch', '{}'),
+    (1717, 17, 'Messaad', '{}'),
+    (1718, 17, 'Guettara', '{}'),
+    (1719, 17, 'Sidi Ladjel', '{}'),
+    (1720, 17, 'Had Sahary', '{}'),
+    (1721, 17, 'Guernini', '{}'),
+    (1722, 17, 'Selmana', '{}'),
+    (1723, 17, 'Ain Chouhada', '{}'),
+    (1724, 17, 'Oum Laadham', '{}'),
+    (1725, 17, 'Dar Chioukh', '{}'),
+    (1726, 17, 'Charef', '{}'),
+    (1727, 17, 'Beni Yacoub', '{}'),
+    (1728, 17, 'Zaafrane', '{}'),
+    (1729, 17, 'Deldoul', '{}'),
+    (1730, 17, 'Ain El Ibel', '{}'),
+    (1731, 17, 'Ain Oussera', '{}'),
+    (1732, 17, 'Benhar', '{}'),
+    (1733, 17, 'Hassi Fedoul', '{}'),
+    (1734, 17, 'Amourah', '{}'),
+    (1735, 17, 'Ain Fekka', '{}'),
+    (1736, 17, 'Tadmit', '{}'),
+    (1801, 18, 'Jijel', '{}'),
+    (1802, 18, 'Erraguene', '{}'),
+    (1803, 18, 'El Aouana', '{}'),
+    (1804, 18, 'Ziama Mansouriah', '{}'),
+    (1805, 18, 'Taher', '{}'),
+    (1806, 18, 'Emir Abdelkader', '{}'),
+    (1807, 18, 'Chekfa', '{}'),
+    (1808, 18, 'Chahna', '{}'),
+    (1809, 18, 'El Milia', '{}'),
+    (1810, 18, 'Sidi Maarouf', '{}'),
+    (1811, 18, 'Settara', '{}'),
+    (1812, 18, 'El Ancer', '{}'),
+    (1813, 18, 'Sidi Abdelaziz', '{}'),
+    (1814, 18, 'Kaous', '{}'),
+    (1815, 18, 'Ghebala', '{}'),
+    (1816, 18, 'Bouraoui Belhadef', '{}'),
+    (1817, 18, 'Djimla', '{}'),
+    (1818, 18, 'Selma Benziada', '{}'),
+    (1819, 18, 'Boucif Ouled Askeur', '{}'),
+    (1820, 18, 'El Kennar Nouchfi', '{}'),
+    (1821, 18, 'Ouled Yahia Khedrouche', '{}'),
+    (1822, 18, 'Boudriaa Ben Yadjis', '{}'),
+    (1823, 18, 'Kemir Oued Adjoul', '{}'),
+    (1824, 18, 'Texenna', '{}'),
+    (1825, 18, 'Djemaa Beni Habibi', '{}'),
+    (1826, 18, 'Bordj Tahar', '{}'),
+    (1827, 18, 'Ouled Rabah', '{}'),
+    (1828, 18, 'Ouadjana', '{}'),
+    (1901, 19, 'Setif', '{}'),
+    (1902, 19, 'Ain El Kebira', '{}'),
+    (1903, 19, 'Beni Aziz', '{}'),
+    (1904, 19, 'Ouled Sidi Ahmed', '{}'),
+    (1905, 19, 'Boutaleb', '{}'),
+    (1906, 19, 'Ain Roua', '{}'),
+    (1907, 19, 'Draa Kebila', '{}'),
+    (1908, 19, 'Bir El Arch', '{}'),
+    (1909, 19, 'Beni Chebana', '{}'),
+    (1910, 19, 'Ouled Tebben', '{}'),
+    (1911, 19, 'Hamma', '{}'),
+    (1912, 19, 'Maaouia', '{}'),
+    (1913, 19, 'Ain Legraj', '{}'),
+    (1914, 19, 'Ain Abessa', '{}'),
+    (1915, 19, 'Dehamcha', '{}'),
+    (1916, 19, 'Babor', '{}'),
+    (1917, 19, 'Guidjel', '{}'),
+    (1918, 19, 'Ain Lahdjar', '{}'),
+    (1919, 19, 'Bousselam', '{}'),
+    (1920, 19, 'El Eulma', '{}'),
+    (1921, 19, 'Djemila', '{}'),
+    (1922, 19, 'Beni Ouartilane', '{}'),
+    (1923, 19, 'Rosfa', '{}'),
+    (1924, 19, 'Ouled Addouane', '{}'),
+    (1925, 19, 'Belaa', '{}'),
+    (1926, 19, 'Ain Arnat', '{}'),
+    (1927, 19, 'Amoucha', '{}'),
+    (1928, 19, 'Ain Oulmene', '{}'),
+    (1929, 19, 'Beidha Bordj', '{}'),
+    (1930, 19, 'Bouandas', '{}'),
+    (1931, 19, 'Bazer Sakhra', '{}'),
+    (1932, 19, 'Hammam Essokhna', '{}'),
+    (1933, 19, 'Mezloug', '{}'),
+    (1934, 19, 'Bir Haddada', '{}'),
+    (1935, 19, 'Serdj El Ghoul', '{}'),
+    (1936, 19, 'Harbil', '{}'),
+    (1937, 19, 'El Ouricia', '{}'),
+    (1938, 19, 'Tizi N''Bechar', '{}'),
+    (1939, 19, 'Salah Bey', '{}'),
+    (1940, 19, 'Ain Azal', '{}'),
+    (1941, 19, 'Guenzet', '{}'),
+    (1942, 19, 'Talaifacene', '{}'),
+    (1943, 19, 'Bougaa', '{}'),
+    (1944, 19, 'Beni Fouda', '{}'),
+    (1945, 19, 'Tachouda', '{}'),
+    (1946, 19, 'Beni Mouhli', '{}'),
+    (1947, 19, 'Ouled Sabor', '{}'),
+    (1948, 19, 'Guellal', '{}'),
+    (1949, 19, 'Ain Sebt', '{}'),
+    (1950, 19, 'Hammam Guergour', '{}'),
+    (1951, 19, 'Ait Naoual Mezada', '{}'),
+    (1952, 19, 'Ksar El Abtal', '{}'),
+    (1953, 19, 'Beni Hocine', '{}'),
+    (1954, 19, 'Ait Tizi', '{}'),
+    (1955, 19, 'Maouklane', '{}'),
+    (1956, 19, 'Guelta Zerka', '{}'),
+    (1957, 19, 'Oued El Barad', '{}'),
+    (1958, 19, 'Taya', '{}'),
+    (1959, 19, 'El Ouldja', '{}'),
+    (1960, 19, 'Tella', '{}'),
+    (2001, 20, 'Saida', '{}'),
+    (2002, 20, 'Doui Thabet', '{}'),
+    (2003, 20, 'Ain El Hadjar', '{}'),
+    (2004, 20, 'Ouled Khaled', '{}'),
+    (2005, 20, 'Moulay Larbi', '{}'),
+    (2006, 20, 'Youb', '{}'),
+    (2007, 20, 'Hounet', '{}'),
+    (2008, 20, 'Sidi Amar', '{}'),
+    (2009, 20, 'Sidi Boubekeur', '{}'),
+    (2010, 20, 'El Hassasna', '{}'),
+    (2011, 20, 'Maamora', '{}'),
+    (2012, 20, 'Sidi Ahmed', '{}'),
+    (2013, 20, 'Ain Sekhouna', '{}'),
+    (2014, 20, 'Ouled Brahim', '{}'),
+    (2015, 20, 'Tircine', '{}'),
+    (2016, 20, 'Ain Soltane', '{}'),
+    (2101, 21, 'Skikda', '{}'),
+    (2102, 21, 'Ain Zouit', '{}'),
+    (2103, 21, 'El Hadaik', '{}'),
+    (2104, 21, 'Azzaba', '{}'),
+    (2105, 21, 'Djendel Saadi Mohamed', '{}'),
+    (2106, 21, 'Ain Cherchar', '{}'),
+    (2107, 21, 'Bekkouche Lakhdar', '{}'),
+    (2108, 21, 'Benazouz', '{}'),
+    (2109, 21, 'Es Sebt', '{}'),
+    (2110, 21, 'Collo', '{}'),
+    (2111, 21, 'Beni Zid', '{}'),
+    (2112, 21, 'Kerkera', '{}'),
+    (2113, 21, 'Ouled Attia', '{}'),
+    (2114, 21, 'Oued Zehour', '{}'),
+    (2115, 21, 'Zitouna', '{}'),
+    (2116, 21, 'El Harrouch', '{}'),
+    (2117, 21, 'Zerdazas', '{}'),
+    (2118, 21, 'Ouled Hbaba', '{}'),
+    (2119, 21, 'Sidi Mezghiche', '{}'),
+    (2120, 21, 'Emdjez Edchich', '{}'),
+    (2121, 21, 'Beni Oulbane', '{}'),
+    (2122, 21, 'Ain Bouziane', '{}'),
+    (2123, 21, 'Ramdane Djamel', '{}'),
+    (2124, 21, 'Beni Bachir', '{}'),
+    (2125, 21, 'Salah Bouchaour', '{}'),
+    (2126, 21, 'Tamalous', '{}'),
+    (2127, 21, 'Ain Kechra', '{}'),
+    (2128, 21, 'Oum Toub', '{}'),
+    (2129, 21, 'Bein El Ouiden', '{}'),
+    (2130, 21, 'Fil Fila', '{}'),
+    (2131, 21, 'Cheraia', '{}'),
+    (2132, 21, 'Kanoua', '{}'),
+    (2133, 21, 'El Ghedir', '{}'),
+    (2134, 21, 'Bouchtata', '{}'),
+    (2135, 21, 'Ouldja Boulballout', '{}'),
+    (2136, 21, 'Kheneg Mayoum', '{}'),
+    (2137, 21, 'Hamadi Krouma', '{}'),
+    (2138, 21, 'El Marsa', '{}'),
+    (2201, 22, 'Sidi Bel Abbes', '{}'),
+    (2202, 22, 'Tessala', '{}'),
+    (2203, 22, 'Sidi Brahim', '{}'),
+    (2204, 22, 'Mostefa Ben Brahim', '{}'),
+    (2205, 22, 'Telagh', '{}'),
+    (2206, 22, 'Mezaourou', '{}'),
+    (2207, 22, 'Boukhanafis', '{}'),
+    (2208, 22, 'Sidi Ali Boussidi', '{}'),
+    (2209, 22, 'Badredine El Mokrani', '{}'),
+    (2210, 22, 'Marhoum', '{}'),
+    (2211, 22, 'Tafissour', '{}'),
+    (2212, 22, 'Amarnas', '{}'),
+    (2213, 22, 'Tilmouni', '{}'),
+    (2214, 22, 'Sidi Lahcene', '{}'),
+    (2215, 22, 'Ain Thrid', '{}'),
+    (2216, 22, 'Makedra', '{}'),
+    (2217, 22, 'Tenira', '{}'),
+    (2218, 22, 'Moulay Slissen', '{}'),
+    (2219, 22, 'El Hacaiba', '{}'),
+    (2220, 22, 'Hassi Zehana', '{}'),
+    (2221, 22, 'Tabia', '{}'),
+    (2222, 22, 'Merine', '{}'),
+    (2223, 22, 'Ras El Ma', '{}'),
+    (2224, 22, 'Ain Tindamine', '{}'),
+    (2225, 22, 'Ain Kada', '{}'),
+    (2226, 22, 'M''Cid', '{}'),
+    (2227, 22, 'Sidi Khaled', '{}'),
+    (2228, 22, 'Ain El Berd', '{}'),
+    (2229, 22, 'Sfisef', '{}'),
+    (2230, 22, 'Ain Adden', '{}'),
+    (2231, 22, 'Oued Taourira', '{}'),
+    (2232, 22, 'Dhaya', '{}'),
+    (2233, 22, 'Zerouala', '{}'),
+    (2234, 22, 'Lamtar', '{}'),
+    (2235, 22, 'Sidi Chaib', '{}'),
+    (2236, 22, 'Sidi Dahou Dezair', '{}'),
+    (2237, 22, 'Oued Sbaa', '{}'),
+    (2238, 22, 'Boudjebaa El Bordj', '{}'),
+    (2239, 22, 'Sehala Thaoura', '{}'),
+    (2240, 22, 'Sidi Yacoub', '{}'),
+    (2241, 22, 'Sidi Hamadouche', '{}'),
+    (2242, 22, 'Belarbi', '{}'),
+    (2243, 22, 'Oued Sefioun', '{}'),
+    (2244, 22, 'Teghalimet', '{}'),
+    (2245, 22, 'Ben Badis', '{}'),
+    (2246, 22, 'Sidi Ali Benyoub', '{}'),
+    (2247, 22, 'Chetouane Belaila', '{}'),
+    (2248, 22, 'Bir El Hammam', '{}'),
+    (2249, 22, 'Taoudmout', '{}'),
+    (2250, 22, 'Redjem Demouche', '{}'),
+    (2251, 22, 'Benachiba Chelia', '{}'),
+    (2252, 22, 'Hassi Dahou', '{}'),
+    (2301, 23, 'Annaba', '{}'),
+    (2302, 23, 'Berrahal', '{}'),
+    (2303, 23, 'El Hadjar', '{}'),
+    (2304, 23, 'Eulma', '{}'),
+    (2305, 23, 'El Bouni', '{}'),
+    (2306, 23, 'Oued El Aneb', '{}'),
+    (2307, 23, 'Cheurfa', '{}'),
+    (2308, 23, 'Seraidi', '{}'),
+    (2309, 23, 'Ain Berda', '{}'),
+    (2310, 23, 'Chetaibi', '{}'),
+    (2311, 23, 'Sidi Amar', '{}'),
+    (2312, 23, 'Treat', '{}'),
+    (2401, 24, 'Guelma', '{}'),
+    (2402, 24, 'Nechmaya', '{}'),
+    (2403, 24, 'Bouati Mahmoud', '{}'),
+    (2404, 24, 'Oued Zenati', '{}'),
+    (2405, 24, 'Tamlouka', '{}'),
+    (2406, 24, 'Oued Fragha', '{}'),
+    (2407, 24, 'Ain Sandel', '{}'),
+    (2408, 24, 'Ras El Agba', '{}'),
+    (2409, 24, 'Dahouara', '{}'),
+    (2410, 24, 'Belkheir', '{}'),
+    (2411, 24, 'Ben Djarah', '{}'),
+    (2412, 24, 'Bou Hamdane', '{}'),
+    (2413, 24, 'Ain Makhlouf', '{}'),
+    (2414, 24, 'Ain Ben Beida', '{}'),
+    (2415, 24, 'Khezaras', '{}'),
+    (2416, 24, 'Beni Mezline', '{}'),
+    (2417, 24, 'Bou Hachana', '{}'),
+    (2418, 24, 'Guelaat Bou Sbaa', '{}'),
+    (2419, 24, 'Hammam Maskhoutine', '{}'),
+    (2420, 24, 'El Fedjoudj', '{}'),
+    (2421, 24, 'Bordj Sabat', '{}'),
+    (2422, 24, 'Hammam N''Bail', '{}'),
+    (2423, 24, 'Ain Larbi', '{}'),
+    (2424, 24, 'Medjez Amar', '{}'),
+    (2425, 24, 'Bouchegouf', '{}'),
+    (2426, 24, 'Heliopolis', '{}'),
+    (2427, 24, 'Houari Boumediene', '{}'),
+    (2428, 24, 'Roknia', '{}'),
+    (2429, 24, 'Salaoua Announa', '{}'),
+    (2430, 24, 'Medjez Sfa', '{}'),
+    (2431, 24, 'Boumahra Ahmed', '{}'),
+    (2432, 24, 'Ain Reggada', '{}'),
+    (2433, 24, 'Oued Cheham', '{}'),
+    (2434, 24, 'Djeballah Khemissi', '{}'),
+    (2501, 25, 'Constantine', '{}'),
+    (2502, 25, 'Hamma Bouziane', '{}'),
+    (2503, 25, 'Ibn Badis', '{}'),
+    (2504, 25, 'Zighoud Youcef', '{}'),
+    (2505, 25, 'Didouche Mourad', '{}'),
+    (2506, 25, 'El Khroub', '{}'),
+    (2507, 25, 'Ain Abid', '{}'),
+    (2508, 25, 'Beni Hamiden', '{}'),
+    (2509, 25, 'Ouled Rahmoune', '{}'),
+    (2510, 25, 'Ain Smara', '{}'),
+    (2511, 25, 'Messaoud Boudjeriou', '{}'),
+    (2512, 25, 'Ibn Ziad', '{}'),
+    (2601, 26, 'Medea', '{}'),
+    (2602, 26, 'Ouzera', '{}'),
+    (2603, 26, 'Ouled Maaref', '{}'),
+    (2604, 26, 'Ain Boucif', '{}'),
+    (2605, 26, 'Aissaouia', '{}'),
+    (2606, 26, 'Ouled Deide', '{}'),
+    (2607, 26, 'El Omaria', '{}'),
+    (2608, 26, 'Derrag', '{}'),
+    (2609, 26, 'El Guelbelkebir', '{}'),
+    (2610, 26, 'Bouaiche', '{}'),
+    (2611, 26, 'Mezerena', '{}'),
+    (2612, 26, 'Ouled Brahim', '{}'),
+    (2613, 26, 'Damiat', '{}'),
+    (2614, 26, 'Sidi Ziane', '{}'),
+    (2615, 26, 'Tamesguida', '{}'),
+    (2616, 26, 'El Hamdania', '{}'),
+    (2617, 26, 'Kef Lakhdar', '{}'),
+    (2618, 26, 'Chelalet El Adhaoura', '{}'),
+    (2619, 26, 'Bouskene', '{}'),
+    (2620, 26, 'Rebaia', '{}'),
+    (2621, 26, 'Bouchrahil', '{}'),
+    (2622, 26, 'Ouled Hellal', '{}'),
+    (2623, 26, 'Tafraout', '{}'),
+    (2624, 26, 'Baata', '{}'),
+    (2625, 26, 'Boghar', '{}'),
+    (2626, 26, 'Sidi Naamane', '{}'),
+    (2627, 26, 'Ouled Bouachra', '{}'),
+    (2628, 26, 'Sidi Zahar', '{}'),
+    (2629, 26, 'Oued Harbil', '{}'),
+    (2630, 26, 'Benchicao', '{}'),
+    (2631, 26, 'Sidi Damed', '{}'),
+    (2632, 26, 'Aziz', '{}'),
+    (2633, 26, 'Souagui', '{}'),
+    (2634, 26, 'Zoubiria', '{}'),
+    (2635, 26, 'Ksar El Boukhari', '{}'),
+    (2636, 26, 'El Azizia', '{}'),
+    (2637, 26, 'Djouab', '{}'),
+    (2638, 26, 'Chahbounia', '{}'),
+    (2639, 26, 'Meghraoua', '{}'),
+    (2640, 26, 'Cheniguel', '{}'),
+    (2641, 26, 'Ain Ouksir', '{}'),
+    (2642, 26, 'Oum El Djalil', '{}'),
+    (2643, 26, 'Ouamri', '{}'),
+    (2644, 26, 'Si Mahdjoub', '{}'),
+    (2645, 26, 'Tlatet Eddouair', '{}'),
+    (2646, 26, 'Beni Slimane', '{}'),
+    (2647, 26, 'Berrouaghia', '{}'),
+    (2648, 26, 'Seghouane', '{}'),
+    (2649, 26, 'Meftaha', '{}'),
+    (2650, 26, 'Mihoub', '{}'),
+    (2651, 26, 'Boughezoul', '{}'),
+    (2652, 26, 'Tablat', '{}'),
+    (2653, 26, 'Deux Bassins', '{}'),
+    (2654, 26, 'Draa Essamar', '{}'),
+    (2655, 26, 'Sidi Errabia', '{}'),
+    (2656, 26, 'Bir Ben Laabed', '{}'),
+    (2657, 26, 'El Ouinet', '{}'),
+    (2658, 26, 'Ouled Antar', '{}'),
+    (2659, 26, 'Bouaichoune', '{}'),
+    (2660, 26, 'Hannacha', '{}'),
+    (2661, 26, 'Sedraia', '{}'),
+    (2662, 26, 'Medjebar', '{}'),
+    (2663, 26, 'Khams Djouamaa', '{}'),
+    (2664, 26, 'Saneg', '{}'),
+    (2701, 27, 'Mostaganem', '{}'),
+    (2702, 27, 'Sayada', '{}'),
+    (2703, 27, 'Fornaka', '{}'),
+    (2704, 27, 'Stidia', '{}'),
+    (2705, 27, 'Ain Nouissy', '{}'),
+    (2706, 27, 'Hassi Maameche', '{}'),
+    (2707, 27, 'Ain Tadles', '{}'),
+    (2708, 27, 'Sour', '{}'),
+    (2709, 27, 'Oued El Kheir', '{}'),
+    (2710, 27, 'Sidi Bellater', '{}'),
+    (2711, 27, 'Kheiredine', '{}'),
+    (2712, 27, 'Sidi Ali', '{}'),
+    (2713, 27, 'Abdelmalek Ramdane', '{}'),
+    (2714, 27, 'Hadjadj', '{}'),
+    (2715, 27, 'Nekmaria', '{}'),
+    (2716, 27, 'Sidi Lakhdar', '{}'),
+    (2717, 27, 'Achaacha', '{}'),
+    (2718, 27, 'Khadra', '{}'),
+    (2719, 27, 'Bouguirat', '{}'),
+    (2720, 27, 'Sirat', '{}'),
+    (2721, 27, 'Ain Sidi Cherif', '{}'),
+    (2722, 27, 'Mesra', '{}'),
+    (2723, 27, 'Mansourah', '{}'),
+    (2724, 27, 'Souaflia', '{}'),
+    (2725, 27, 'Ouled Boughalem', '{}'),
+    (2726, 27, 'Ouled Maallah', '{}'),
+    (2727, 27, 'Mazagran', '{}'),
+    (2728, 27, 'Ain Boudinar', '{}'),
+    (2729, 27, 'Tazgait', '{}'),
+    (2730, 27, 'Safsaf', '{}'),
+    (2731, 27, 'Touahria', '{}'),
+    (2732, 27, 'El Hassiane', '{}'),
+    (2801, 28, 'M''Sila', '{}'),
+    (2802, 28, 'Maadid', '{}'),
+    (2803, 28, 'Hammam Dhalaa', '{}'),
+    (2804, 28, 'Ouled Derradj', '{}'),
+    (2805, 28, 'Tarmount', '{}'),
+    (2806, 28, 'Mtarfa', '{}'),
+    (2807, 28, 'Khoubana', '{}'),
+    (2808, 28, 'M''Cif', '{}'),
+    (2809, 28, 'Chellal', '{}'),
+    (2810, 28, 'Ouled Madhi', '{}'),
+    (2811, 28, 'Magra', '{}'),
+    (2812, 28, 'Berhoum', '{}'),
+    (2813, 28, 'Ain Khadra', '{}'),
+    (2814, 28, 'Ouled Addi Guebala', '{}'),
+    (2815, 28, 'Belaiba', '{}'),
+    (2816, 28, 'Sidi Aissa', '{}'),
+    (2817, 28, 'Ain El Hadjel', '{}'),
+    (2818, 28, 'Sidi Hadjeres', '{}'),
+    (2819, 28, 'Ouanougha', '{}'),
+    (2820, 28, 'Bou Saada', '{}'),
+    (2821, 28, 'Ouled Sidi Brahim', '{}'),
+    (2822, 28, 'Sidi Ameur', '{}'),
+    (2823, 28, 'Tamsa', '{}'),
+    (2824, 28, 'Ben Srour', '{}'),
+    (2825, 28, 'Ouled Slimane', '{}'),
+    (2826, 28, 'El Houamed', '{}'),
+    (2827, 28, 'El Hamel', '{}'),
+    (2828, 28, 'Ouled Mansour', '{}'),
+    (2829, 28, 'Maarif', '{}'),
+    (2830, 28, 'Dehahna', '{}'),
+    (2831, 28, 'Bouti Sayah', '{}'),
+    (2832, 28, 'Khettouti Sed El Djir', '{}'),
+    (2833, 28, 'Zarzour', '{}'),
+    (2834, 28, 'Oued Chair', '{}'),
+    (2835, 28, 'Benzouh', '{}'),
+    (2836, 28, 'Bir Foda', '{}'),
+    (2837, 28, 'Ain Fares', '{}'),
+    (2838, 28, 'Sidi M''Hamed', '{}'),
+    (2839, 28, 'Ouled Atia', '{}'),
+    (2840, 28, 'Souamaa', '{}'),
+    (2841, 28, 'Ain El Melh', '{}'),
+    (2842, 28, 'Medjedel', '{}'),
+    (2843, 28, 'Slim', '{}'),
+    (2844, 28, 'Ain Errich', '{}'),
+    (2845, 28, 'Beni Ilmane', '{}'),
+    (2846, 28, 'Oultene', '{}'),
+    (2847, 28, 'Djebel Messaad', '{}'),
+    (2901, 29, 'Mascara', '{}'),
+    (2902, 29, 'Bou Hanifia', '{}'),
+    (2903, 29, 'Tizi', '{}'),
+    (2904, 29, 'Hacine', '{}'),
+    (2905, 29, 'Maoussa', '{}'),
+    (2906, 29, 'Teghennif', '{}'),
+    (2907, 29, 'El Hachem', '{}'),
+    (2908, 29, 'Sidi Kada', '{}'),
+    (2909, 29, 'Zelmata', '{}'),
+    (2910, 29, 'Oued El Abtal', '{}'),
+    (2911, 29, 'Ain Ferah', '{}'),
+    (2912, 29, 'Ghriss', '{}'),
+    (2913, 29, 'Froha', '{}'),
+    (2914, 29, 'Matemore', '{}'),
+    (2915, 29, 'Makdha', '{}'),
+    (2916, 29, 'Sidi Boussaid', '{}'),
+    (2917, 29, 'El Bordj', '{}'),
+    (2918, 29, 'Ain Fekan', '{}'),
+    (2919, 29, 'Benian', '{}'),
+    (2920, 29, 'Khalouia', '{}'),
+    (2921, 29, 'El Menaouer', '{}'),
+    (2922, 29, 'Oued Taria', '{}'),
+    (2923, 29, 'Aouf', '{}'),
+    (2924, 29, 'Ain Fares', '{}'),
+    (2925, 29, 'Ain Frass', '{}'),
+    (2926, 29, 'Sig', '{}'),
+    (2927, 29, 'Oggaz', '{}'),
+    (2928, 29, 'Alaimia', '{}'),
+    (2929, 29, 'El Gaada', '{}'),
+    (2930, 29, 'Zahana', '{}'),
+    (2931, 29, 'Mohammadia', '{}'),
+    (2932, 29, 'Sidi Abdelmoumene', '{}'),
+    (2933, 29, 'Ferraguig', '{}'),
+    (2934, 29, 'El Ghomri', '{}'),
+    (2935, 29, 'Sedjerara', '{}'),
+    (2936, 29, 'Mocta Douz', '{}'),
+    (2937, 29, 'Bou Henni', '{}'),
+    (2938, 29, 'El Gueitena', '{}'),
+    (2939, 29, 'Mamounia', '{}'),
+    (2940, 29, 'El Keurt', '{}'),
+    (2941, 29, 'Gharrous', '{}'),
+    (2942, 29, 'Gherdjoum', '{}'),
+    (2943, 29, 'Chorfa', '{}'),
+    (2944, 29, 'Ras Ain Amirouche', '{}'),
+    (2945, 29, 'Nesmot', '{}'),
+    (2946, 29, 'Sidi Abdeldjebar', '{}'),
+    (2947, 29, 'Sehailia', '{}'),
+    (3001, 30, 'Ouargla', '{}'),
+    (3002, 30, 'Ain Beida', '{}'),
+    (3003, 30, 'N''Goussa', '{}'),
+    (3004, 30, 'Hassi Messaoud', '{}'),
+    (3005, 30, 'Rouissat', '{}'),
+    (3006, 30, 'Sidi Khouiled', '{}'),
+    (3007, 30, 'Hassi Ben Abdellah', '{}'),
+    (3008, 30, 'El Hadjira', '{}'),
+    (3009, 30, 'El Allia', '{}'),
+    (3010, 30, 'El Borma', '{}'),
+    (3101, 31, 'Oran', '{}'),
+    (3102, 31, 'Gdyel', '{}'),
+    (3103, 31, 'Bir El Djir', '{}'),
+    (3104, 31, 'Hassi Bounif', '{}'),
+    (3105, 31, 'Es Senia', '{}'),
+    (3106, 31, 'Arzew', '{}'),
+    (3107, 31, 'Bethioua', '{}'),
+    (3108, 31, 'Marsat El Hadjadj', '{}'),
+    (3109, 31, 'Ain Turk', '{}'),
+    (3110, 31, 'El Ancar', '{}'),
+    (3111, 31, 'Oued Tlelat', '{}'),
+    (3112, 31, 'Tafraoui', '{}'),
+    (3113, 31, 'Sidi Chami', '{}'),
+    (3114, 31, 'Boufatis', '{}'),
+    (3115, 31, 'Mers El Kebir', '{}'),
+    (3116, 31, 'Bousfer', '{}'),
+    (3117, 31, 'El Kerma', '{}'),
+    (3118, 31, 'El Braya', '{}'),
+    (3119, 31, 'Hassi Ben Okba', '{}'),
+    (3120, 31, 'Ben Freha', '{}'),
+    (3121, 31, 'Hassi Mefsoukh', '{}'),
+    (3122, 31, 'Sidi Ben Yebka', '{}'),
+    (3123, 31, 'Misserghin', '{}'),
+    (3124, 31, 'Boutlelis', '{}'),
+    (3125, 31, 'Ain Kerma', '{}'),
+    (3126, 31, 'Ain Biya', '{}'),
+    (3201, 32, 'El Bayadh', '{}'),
+    (3202, 32, 'Rogassa', '{}'),
+    (3203, 32, 'Stitten', '{}'),
+    (3204, 32, 'Brezina', '{}'),
+    (3205, 32, 'Ghassoul', '{}'),
+    (3206, 32, 'Boualem', '{}'),
+    (3207, 32, 'El Abiodh Sidi Cheikh', '{}'),
+    (3208, 32, 'Ain El Orak', '{}'),
+    (3209, 32, 'Arbaouat', '{}'),
+    (3210, 32, 'Bougtoub', '{}'),
+    (3211, 32, 'El Kheither', '{}'),
+    (3212, 32, 'Kef El Ahmar', '{}'),
+    (3213, 32, 'Boussemghoun', '{}'),
+    (3214, 32, 'Chellala', '{}'),
+    (3215, 32, 'Krakda', '{}'),
+    (3216, 32, 'El Bnoud', '{}'),
+    (3217, 32, 'Cheguig', '{}'),
+    (3218, 32, 'Sidi Ameur', '{}'),
+    (3219, 32, 'El Mehara', '{}'),
+    (3220, 32, 'Tousmouline', '{}'),
+    (3221, 32, 'Sidi Slimane', '{}'),
+    (3222, 32, 'Sidi Tifour', '{}'),
+    (3301, 33, 'Illizi', '{}'),
+    (3302, 33, 'Debdeb', '{}'),
+    (3303, 33, 'Bordj Omar Driss', '{}'),
+    (3304, 33, 'In Amenas', '{}'),
+    (3401, 34, 'Bordj Bou Arreridj', '{}'),
+    (3402, 34, 'Ras El Oued', '{}'),
+    (3403, 34, 'Bordj Zemoura', '{}'),
+    (3404, 34, 'Mansoura', '{}'),
+    (3405, 34, 'El M''Hir', '{}'),
+    (3406, 34, 'Ben Daoud', '{}'),
+    (3407, 34, 'El Achir', '{}'),
+    (3408, 34, 'Ain Taghrout', '{}'),
+    (3409, 34, 'Bordj Ghedir', '{}'),
+    (3410, 34, 'Sidi Embarek', '{}'),
+    (3411, 34, 'El Hamadia', '{}'),
+    (3412, 34, 'Belimour', '{}'),
+    (3413, 34, 'Medjana', '{}'),
+    (3414, 34, 'Teniet En Nasr', '{}'),
+    (3415, 34, 'Djaafra', '{}'),
+    (3416, 34, 'El Main', '{}'),
+    (3417, 34, 'Ouled Brahem', '{}'),
+    (3418, 34, 'Ouled Dahmane', '{}'),
+    (3419, 34, 'Hasnaoua', '{}'),
+    (3420, 34, 'Khelil', '{}'),
+    (3421, 34, 'Taglait', '{}'),
+    (3422, 34, 'Ksour', '{}'),
+    (3423, 34, 'Ouled Sidi Brahim', '{}'),
+    (3424, 34, 'Tafreg', '{}'),
+    (3425, 34, 'Colla', '{}'),
+    (3426, 34, 'Tixter', '{}'),
+    (3427, 34, 'El Euch', '{}'),
+    (3428, 34, 'El Anseur', '{}'),
+    (3429, 34, 'Tesmart', '{}'),
+    (3430, 34, 'Ain Tesra', '{}'),
+    (3431, 34, 'Bir Kasdali', '{}'),
+    (3432, 34, 'Ghilassa', '{}'),
+    (3433, 34, 'Rabta', '{}'),
+    (3434, 34, 'Haraza', '{}'),
+    (3501, 35, 'Boumerdes', '{}'),
+    (3502, 35, 'Boudouaou', '{}'),
+    (3503, 35, 'Afir', '{}'),
+    (3504, 35, 'Bordj Menaiel', '{}'),
+    (3505, 35, 'Baghlia', '{}'),
+    (3506, 35, 'Sidi Daoud', '{}'),
+    (3507, 35, 'Naciria', '{}'),
+    (3508, 35, 'Djinet', '{}'),
+    (3509, 35, 'Isser', '{}'),
+    (3510, 35, 'Zemmouri', '{}'),
+    (3511, 35, 'Si Mustapha', '{}'),
+    (3512, 35, 'Tidjelabine', '{}'),
+    (3513, 35, 'Chabet El Ameur', '{}'),
+    (3514, 35, 'Thenia', '{}'),
+    (3515, 35, 'Timezrit', '{}'),
+    (3516, 35, 'Corso', '{}'),
+    (3517, 35, 'Ouled Moussa', '{}'),
+    (3518, 35, 'Larbatache', '{}'),
+    (3519, 35, 'Bouzegza Keddara', '{}'),
+    (3520, 35, 'Taourga', '{}'),
+    (3521, 35, 'Ouled Aissa', '{}'),
+    (3522, 35, 'Ben Choud', '{}'),
+    (3523, 35, 'Dellys', '{}'),
+    (3524, 35, 'Ammal', '{}'),
+    (3525, 35, 'Beni Amrane', '{}'),
+    (3526, 35, 'Souk El Had', '{}'),
+    (3527, 35, 'Boudouaou El Bahri', '{}'),
+    (3528, 35, 'Ouled Hedadj', '{}'),
+    (3529, 35, 'Laghata', '{}'),
+    (3530, 35, 'Hammedi', '{}'),
+    (3531, 35, 'Khemis El Khechna', '{}'),
+    (3532, 35, 'El Kharrouba', '{}'),
+    (3601, 36, 'El Tarf', '{}'),
+    (3602, 36, 'Bouhadjar', '{}'),
+    (3603, 36, 'Ben M''Hidi', '{}'),
+    (3604, 36, 'Bougous', '{}'),
+    (3605, 36, 'El Kala', '{}'),
+    (3606, 36, 'Ain El Assel', '{}'),
+    (3607, 36, 'El Aioun', '{}'),
+    (3608, 36, 'Bouteldja', '{}'),
+    (3609, 36, 'Souarekh', '{}'),
+    (3610, 36, 'Berrihane', '{}'),
+    (3611, 36, 'Lac Des Oiseaux', '{}'),
+    (3612, 36, 'Chefia', '{}'),
+    (3613, 36, 'Drean', '{}'),
+    (3614, 36, 'Chihani', '{}'),
+    (3615, 36, 'Chebaita Mokhtar', '{}'),
+    (3616, 36, 'Besbes', '{}'),
+    (3617, 36, 'Asfour', '{}'),
+    (3618, 36, 'Echatt', '{}'),
+    (3619, 36, 'Zerizer', '{}'),
+    (3620, 36, 'Zitouna', '{}'),
+    (3621, 36, 'Ain Kerma', '{}'),
+    (3622, 36, 'Oued Zitoun', '{}'),
+    (3623, 36, 'Hammam Beni Salah', '{}'),
+    (3624, 36, 'Raml Souk', '{}'),
+    (3701, 37, 'Tindouf', '{}'),
+    (3702, 37, 'Oum El Assel', '{}'),
+    (3801, 38, 'Tissemsilt', '{}'),
+    (3802, 38, 'Bordj Bou Naama', '{}'),
+    (3803, 38, 'Theniet El Had', '{}'),
+    (3804, 38, 'Lazharia', '{}'),
+    (3805, 38, 'Beni Chaib', '{}'),
+    (3806, 38, 'Lardjem', '{}'),
+    (3807, 38, 'Melaab', '{}'),
+    (3808, 38, 'Sidi Lantri', '{}'),
+    (3809, 38, 'Bordj El Emir Abdelkader', '{}'),
+    (3810, 38, 'Layoune', '{}'),
+    (3811, 38, 'Khemisti', '{}'),
+    (3812, 38, 'Ouled Bessem', '{}'),
+    (3813, 38, 'Ammari', '{}'),
+    (3814, 38, 'Youssoufia', '{}'),
+    (3815, 38, 'Sidi Boutouchent', '{}'),
+    (3816, 38, 'Larbaa', '{}'),
+    (3817, 38, 'Maasem', '{}'),
+    (3818, 38, 'Sidi Abed', '{}'),
+    (3819, 38, 'Tamalaht', '{}'),
+    (3820, 38, 'Sidi Slimane', '{}'),
+    (3821, 38, 'Boucaid', '{}'),
+    (3822, 38, 'Beni Lahcene', '{}'),
+    (3901, 39, 'El Oued', '{}'),
+    (3902, 39, 'Robbah', '{}'),
+    (3903, 39, 'Oued El Alenda', '{}'),
+    (3904, 39, 'Bayadha', '{}'),
+    (3905, 39, 'Nakhla', '{}'),
+    (3906, 39, 'Guemar', '{}'),
+    (3907, 39, 'Kouinine', '{}'),
+    (3908, 39, 'Reguiba', '{}'),
+    (3909, 39, 'Hamraia', '{}'),
+    (3910, 39, 'Taghzout', '{}'),
+    (3911, 39, 'Debila', '{}'),
+    (3912, 39, 'Hassani Abdelkrim', '{}'),
+    (3913, 39, 'Hassi Khelifa', '{}'),
+    (3914, 39, 'Taleb Larbi', '{}'),
+    (3915, 39, 'Douar El Ma', '{}'),
+    (3916, 39, 'Sidi Aoun', '{}'),
+    (3917, 39, 'Trifaoui', '{}'),
+    (3918, 39, 'Magrane', '{}'),
+    (3919, 39, 'Beni Guecha', '{}'),
+    (3920, 39, 'Ourmes', '{}'),
+    (3921, 39, 'El Ogla', '{}'),
+    (3922, 39, 'Mih Ouensa', '{}'),
+    (4001, 40, 'Khenchela', '{}'),
+    (4002, 40, 'M''Toussa', '{}'),
+    (4003, 40, 'Kais', '{}'),
+    (4004, 40, 'Baghai', '{}'),
+    (4005, 40, 'El Hamma', '{}'),
+    (4006, 40, 'Ain Touila', '{}'),
+    (4007, 40, 'Taouzianat', '{}'),
+    (4008, 40, 'Bouhmama', '{}'),
+    (4009, 40, 'El Oueldja', '{}'),
+    (4010, 40, 'Remila', '{}'),
+    (4011, 40, 'Cherchar', '{}'),
+    (4012, 40, 'Djellal', '{}'),
+    (4013, 40, 'Babar', '{}'),
+    (4014, 40, 'Tamza', '{}'),
+    (4015, 40, 'Ensigha', '{}'),
+    (4016, 40, 'Ouled Rechache', '{}'),
+    (4017, 40, 'El Mahmal', '{}'),
+    (4018, 40, 'M''Sara', '{}'),
+    (4019, 40, 'Yabous', '{}'),
+    (4020, 40, 'Khirane', '{}'),
+    (4021, 40, 'Chelia', '{}'),
+    (4101, 41, 'Souk Ahras', '{}'),
+    (4102, 41, 'Sedrata', '{}'),
+    (4103, 41, 'Hanancha', '{}'),
+    (4104, 41, 'Mechroha', '{}'),
+    (4105, 41, 'Ouled Driss', '{}'),
+    (4106, 41, 'Tiffech', '{}'),
+    (4107, 41, 'Zaarouria', '{}'),
+    (4108, 41, 'Taoura', '{}'),
+    (4109, 41, 'Drea', '{}'),
+    (4110, 41, 'Haddada', '{}'),
+    (4111, 41, 'Khedara', '{}'),
+    (4112, 41, 'Merahna', '{}'),
+    (4113, 41, 'Ouled Moumen', '{}'),
+    (4114, 41, 'Bir Bouhouche', '{}'),
+    (4115, 41, 'M''Daourouch', '{}'),
+    (4116, 41, 'Oum El Adhaim', '{}'),
+    (4117, 41, 'Ain Zana', '{}'),
+    (4118, 41, 'Ain Soltane', '{}'),
+    (4119, 41, 'Ouillen', '{}'),
+    (4120, 41, 'Sidi Fredj', '{}'),
+    (4121, 41, 'Safel El Ouiden', '{}'),
+    (4122, 41, 'Ragouba', '{}'),
+    (4123, 41, 'Khemissa', '{}'),
+    (4124, 41, 'Oued Keberit', '{}'),
+    (4125, 41, 'Terraguelt', '{}'),
+    (4126, 41, 'Zouabi', '{}'),
+    (4201, 42, 'Tipaza', '{}'),
+    (4202, 42, 'Menaceur', '{}'),
+    (4203, 42, 'Larhat', '{}'),
+    (4204, 42, 'Douaouda', '{}'),
+    (4205, 42, 'Bourkika', '{}'),
+    (4206, 42, 'Khemisti', '{}'),
+    (4207, 42, 'Aghabal', '{}'),
+    (4208, 42, 'Hadjout', '{}'),
+    (4209, 42, 'Sidi Amar', '{}'),
+    (4210, 42, 'Gouraya', '{}'),
+    (4211, 42, 'Nador', '{}'),
+    (4212, 42, 'Chaiba', '{}'),
+    (4213, 42, 'Ain Tagourait', '{}'),
+    (4214, 42, 'Cherchell', '{}'),
+    (4215, 42, 'Damous', '{}'),
+    (4216, 42, 'Meurad', '{}'),
+    (4217, 42, 'Fouka', '{}'),
+    (4218, 42, 'Bou Ismail', '{}'),
+    (4219, 42, 'Ahmer El Ain', '{}'),
+    (4220, 42, 'Bou Haroun', '{}'),
+    (4221, 42, 'Sidi Ghiles', '{}'),
+    (4222, 42, 'Messelmoun', '{}'),
+    (4223, 42, 'Sidi Rached', '{}'),
+    (4224, 42, 'Kolea', '{}'),
+    (4225, 42, 'Attatba', '{}'),
+    (4226, 42, 'Sidi Semiane', '{}'),
+    (4227, 42, 'Beni Milleuk', '{}'),
+    (4228, 42, 'Hattatba', '{}'),
+    (4301, 43, 'Mila', '{}'),
+    (4302, 43, 'Ferdjioua', '{}'),
+    (4303, 43, 'Chelghoum Laid', '{}'),
+    (4304, 43, 'Oued Athmania', '{}'),
+    (4305, 43, 'Ain Mellouk', '{}'),
+    (4306, 43, 'Telerghma', '{}'),
+    (4307, 43, 'Oued Seguen', '{}'),
+    (4308, 43, 'Tadjenanet', '{}'),
+    (4309, 43, 'Benyahia Abderrahmane', '{}'),
+    (4310, 43, 'Oued Endja', '{}'),
+    (4311, 43, 'Ahmed Rachedi', '{}'),
+    (4312, 43, 'Ouled Khalouf', '{}'),
+    (4313, 43, 'Tiberguent', '{}'),
+    (4314, 43, 'Bouhatem', '{}'),
+    (4315, 43, 'Rouached', '{}'),
+    (4316, 43, 'Tessala Lamatai', '{}'),
+    (4317, 43, 'Grarem Gouga', '{}'),
+    (4318, 43, 'Sidi Merouane', '{}'),
+    (4319, 43, 'Tassadane Haddada', '{}'),
+    (4320, 43, 'Derradji Bousselah', '{}'),
+    (4321, 43, 'Minar Zarza', '{}'),
+    (4322, 43, 'Amira Arras', '{}'),
+    (4323, 43, 'Terrai Bainen', '{}'),
+    (4324, 43, 'Hamala', '{}'),
+    (4325, 43, 'Ain Tine', '{}'),
+    (4326, 43, 'El Mechira', '{}'),
+    (4327, 43, 'Sidi Khelifa', '{}'),
+    (4328, 43, 'Zeghaia', '{}'),
+    (4329, 43, 'Elayadi Barbes', '{}'),
+    (4330, 43, 'Ain Beida Harriche', '{}'),
+    (4331, 43, 'Yahia Beniguecha', '{}'),
+    (4332, 43, 'Chigara', '{}'),
+    (4401, 44, 'Ain Defla', '{}'),
+    (4402, 44, 'Miliana', '{}'),
+    (4403, 44, 'Boumedfaa', '{}'),
+    (4404, 44, 'Khemis Miliana', '{}'),
+    (4405, 44, 'Hammam Righa', '{}'),
+    (4406, 44, 'Arib', '{}'),
+    (4407, 44, 'Djelida', '{}'),
+    (4408, 44, 'El Amra', '{}'),
+    (4409, 44, 'Bourached', '{}'),
+    (4410, 44, 'El Attaf', '{}'),
+    (4411, 44, 'El Abadia', '{}'),
+    (4412, 44, 'Djendel', '{}'),
+    (4413, 44, 'Oued Chorfa', '{}'),
+    (4414, 44, 'Ain Lechiakh', '{}'),
+    (4415, 44, 'Oued Djemaa', '{}'),
+    (4416, 44, 'Rouina', '{}'),
+    (4417, 44, 'Zeddine', '{}'),
+    (4418, 44, 'El Hassania', '{}'),
+    (4419, 44, 'Bir Ould Khelifa', '{}'),
+    (4420, 44, 'Ain Soltane', '{}'),
+    (4421, 44, 'Tarik Ibn Ziad', '{}'),
+    (4422, 44, 'Bordj Emir Khaled', '{}'),
+    (4423, 44, 'Ain Torki', '{}'),
+    (4424, 44, 'Sidi Lakhdar', '{}'),
+    (4425, 44, 'Ben Allal', '{}'),
+    (4426, 44, 'Ain Benian', '{}'),
+    (4427, 44, 'Hoceinia', '{}'),
+    (4428, 44, 'Barbouche', '{}'),
+    (4429, 44, 'Djemaa Ouled Cheikh', '{}'),
+    (4430, 44, 'Mekhatria', '{}'),
+    (4431, 44, 'Bathia', '{}'),
+    (4432, 44, 'Tachta Zegagha', '{}'),
+    (4433, 44, 'Ain Bouyahia', '{}'),
+    (4434, 44, 'El Maine', '{}'),
+    (4435, 44, 'Tiberkanine', '{}'),
+    (4436, 44, 'Belaas', '{}'),
+    (4501, 45, 'Naama', '{}'),
+    (4502, 45, 'Mecheria', '{}'),
+    (4503, 45, 'Ain Sefra', '{}'),
+    (4504, 45, 'Tiout', '{}'),
+    (4505, 45, 'Sfissifa', '{}'),
+    (4506, 45, 'Moghrar', '{}'),
+    (4507, 45, 'Assela', '{}'),
+    (4508, 45, 'Djeniane Bourzeg', '{}'),
+    (4509, 45, 'Ain Ben Khelil', '{}'),
+    (4510, 45, 'Makman Ben Amer', '{}'),
+    (4511, 45, 'Kasdir', '{}'),
+    (4512, 45, 'El Biod', '{}'),
+    (4601, 46, 'Ain Temouchent', '{}'),
+    (4602, 46, 'Chaabet El Ham', '{}'),
+    (4603, 46, 'Ain Kihal', '{}'),
+    (4604, 46, 'Hammam Bouhadjar', '{}'),
+    (4605, 46, 'Bou Zedjar', '{}'),
+    (4606, 46, 'Oued Berkeche', '{}'),
+    (4607, 46, 'Aghlal', '{}'),
+    (4608, 46, 'Terga', '{}'),
+    (4609, 46, 'Ain El Arbaa', '{}'),
+    (4610, 46, 'Tamzoura', '{}'),
+    (4611, 46, 'Chentouf', '{}'),
+    (4612, 46, 'Sidi Ben Adda', '{}'),
+    (4613, 46, 'Aoubellil', '{}'),
+    (4614, 46, 'El Malah', '{}'),
+    (4615, 46, 'Sidi Boumediene', '{}'),
+    (4616, 46, 'Oued Sabah', '{}'),
+    (4617, 46, 'Ouled Boudjemaa', '{}'),
+    (4618, 46, 'Ain Tolba', '{}'),
+    (4619, 46, 'El Amria', '{}'),
+    (4620, 46, 'Hassi El Ghella', '{}'),
+    (4621, 46, 'Hassasna', '{}'),
+    (4622, 46, 'Ouled Kihal', '{}'),
+    (4623, 46, 'Beni Saf', '{}'),
+    (4624, 46, 'Sidi Safi', '{}'),
+    (4625, 46, 'Oulhaca El Gheraba', '{}'),
+    (4626, 46, 'Tadmaya', '{}'),
+    (4627, 46, 'El Emir Abdelkader', '{}'),
+    (4628, 46, 'El Messaid', '{}'),
+    (4701, 47, 'Ghardaia', '{}'),
+    (4702, 47, 'Dhayet Bendhahoua', '{}'),
+    (4703, 47, 'Berriane', '{}'),
+    (4704, 47, 'Metlili', '{}'),
+    (4705, 47, 'El Guerrara', '{}'),
+    (4706, 47, 'El Atteuf', '{}'),
+    (4707, 47, 'Zelfana', '{}'),
+    (4708, 47, 'Sebseb', '{}'),
+    (4709, 47, 'Bounoura', '{}'),
+    (4710, 47, 'Mansoura', '{}'),
+    (4801, 48, 'Relizane', '{}'),
+    (4802, 48, 'Oued Rhiou', '{}'),
+    (4803, 48, 'Belaassel Bouzegza', '{}'),
+    (4804, 48, 'Sidi Saada', '{}'),
+    (4805, 48, 'Ouled Aiche', '{}'),
+    (4806, 48, 'Sidi Lazreg', '{}'),
+    (4807, 48, 'El Hamadna', '{}'),
+    (4808, 48, 'Sidi M''Hamed Ben Ali', '{}'),
+    (4809, 48, 'Mediouna', '{}'),
+    (4810, 48, 'Sidi Khettab', '{}'),
+    (4811, 48, 'Ammi Moussa', '{}'),
+    (4812, 48, 'Zemmoura', '{}'),
+    (4813, 48, 'Beni Dergoun', '{}'),
+    (4814, 48, 'Djidiouia', '{}'),
+    (4815, 48, 'El Guettar', '{}'),
+    (4816, 48, 'Hamri', '{}'),
+    (4817, 48, 'El Matmar', '{}'),
+    (4818, 48, 'Sidi M''Hamed Ben Aouda', '{}'),
+    (4819, 48, 'Ain Tarek', '{}'),
+    (4820, 48, 'Oued Essalem', '{}'),
+    (4821, 48, 'Ouarizane', '{}'),
+    (4822, 48, 'Mazouna', '{}'),
+    (4823, 48, 'Kalaa', '{}'),
+    (4824, 48, 'Ain Rahma', '{}'),
+    (4825, 48, 'Yellel', '{}'),
+    (4826, 48, 'Oued El Djemaa', '{}'),
+    (4827, 48, 'Ramka', '{}'),
+    (4828, 48, 'Mendes', '{}'),
+    (4829, 48, 'Lahlef', '{}'),
+    (4830, 48, 'Beni Zentis', '{}'),
+    (4831, 48, 'Souk El Haad', '{}'),
+    (4832, 48, 'Dar Ben Abdellah', '{}'),
+    (4833, 48, 'El Hassi', '{}'),
+    (4834, 48, 'Had Echkalla', '{}'),
+    (4835, 48, 'Bendaoud', '{}'),
+    (4836, 48, 'El Ouldja', '{}'),
+    (4837, 48, 'Merdja Sidi Abed', '{}'),
+    (4838, 48, 'Ouled Sidi Mihoub', '{}'),
+    (4901, 49, 'Timimoun', '{}'),
+    (4902, 49, 'Ouled Said', '{}'),
+    (4903, 49, 'Metarfa', '{}'),
+    (4904, 49, 'Tinerkouk', '{}'),
+    (4905, 49, 'Ksar Kaddour', '{}'),
+    (4906, 49, 'Ouled Aissa', '{}'),
+    (4907, 49, 'Deldoul', '{}'),
+    (4908, 49, 'Charouine', '{}'),
+    (4909, 49, 'Talmine', '{}'),
+    (4910, 49, 'Aougrout', '{}'),
+    (5001, 50, 'Bordj Badji Mokhtar', '{}'),
+    (5002, 50, 'Timiaouine', '{}'),
+    (5101, 51, 'Ouled Djellal', '{}'),
+    (5102, 51, 'Ras El Miaad', '{}'),
+    (5103, 51, 'Besbes', '{}'),
+    (5104, 51, 'Sidi Khaled', '{}'),
+    (5105, 51, 'Doucen', '{}'),
+    (5106, 51, 'Chaiba', '{}'),
+    (5201, 52, 'Beni Abbes', '{}'),
+    (5202, 52, 'Ouled Khoudir', '{}'),
+    (5203, 52, 'Timoudi', '{}'),
+    (5204, 52, 'Beni Ikhlef', '{}'),
+    (5205, 52, 'Igli', '{}'),
+    (5206, 52, 'El Ouata', '{}'),
+    (5207, 52, 'Kerzaz', '{}'),
+    (5208, 52, 'Ksabi', '{}'),
+    (5209, 52, 'Tamtert', '{}'),
+    (5301, 53, 'In Salah', '{}'),
+    (5302, 53, 'Foggaret Ezzaouia', '{}'),
+    (5303, 53, 'In Ghar', '{}'),
+    (5401, 54, 'In Guezzam', '{}'),
+    (5402, 54, 'Tin Zaouatine', '{}'),
+    (5501, 55, 'Touggourt', '{}'),
+    (5502, 55, 'Nezla', '{}'),
+    (5503, 55, 'Tebesbest', '{}'),
+    (5504, 55, 'Zaouia El Abidia', '{}'),
+    (5505, 55, 'Tamacine', '{}'),
+    (5506, 55, 'Blidet Amor', '{}'),
+    (5507, 55, 'Megarine', '{}'),
+    (5508, 55, 'Sidi Slimane', '{}'),
+    (5509, 55, 'Taibet', '{}'),
+    (5510, 55, 'Benaceur', '{}'),
+    (5511, 55, 'M''Naguer', '{}'),
+    (5601, 56, 'Djanet', '{}'),
+    (5602, 56, 'Bordj El Haouas', '{}'),
+    (5701, 57, 'El M''Ghair', '{}'),
+    (5702, 57, 'Oum Touyour', '{}'),
+    (5703, 57, 'Sidi Khellil', '{}'),
+    (5704, 57, 'Still', '{}'),
+    (5705, 57, 'Djamaa', '{}'),
+    (5706, 57, 'Sidi Amrane', '{}'),
+    (5707, 57, 'M''Rara', '{}'),
+    (5708, 57, 'Tendla', '{}'),
+    (5801, 58, 'El Meniaa', '{}'),
+    (5802, 58, 'Hassi Gara', '{}'),
+    (5803, 58, 'Hassi Fehal', '{}');
+
+-- Orders keep the names they were placed with; the codes are what reporting groups by.
+-- They are NULL for orders whose free-text address could not be matched.
+ALTER TABLE orders
+    ADD COLUMN wilaya_code SMALLINT REFERENCES wilayas(code),
+    ADD COLUMN commune_code INTEGER REFERENCES communes(code);
+
+CREATE INDEX idx_orders_wilaya_code ON orders(wilaya_code);
+
+-- Match existing orders whose names are spelled exactly as in the reference data
+UPDATE orders o
+SET wilaya_code = w.code
+FROM wilayas w
+WHERE LOWER(TRIM(o.province)) = LOWER(w.name);
+
+UPDATE orders o
+SET commune_code = c.code
+FROM communes c
+WHERE c.wilaya_code = o.wilaya_code
+  AND LOWER(TRIM(o.city)) = LOWER(c.name);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS commune_code,
+    DROP COLUMN IF EXISTS wilaya_code;
+DROP TABLE IF EXISTS communes;
+DROP TABLE IF EXISTS wilayas;
+-- +goose StatementEnd
@@ -0,0 +1,57 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE products ADD COLUMN weight_grams INTEGER CHECK (weight_grams > 0);
+
+-- Orders at or above the threshold ship free; a zone may set its own threshold
+ALTER TABLE delivery_services ADD COLUMN free_shipping_min_cents BIGINT CHECK (free_shipping_min_cents >= 0);
+
+-- A zone groups the wilayas a delivery service charges the same rates for
+CREATE TABLE delivery_zones (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    delivery_service_id UUID NOT NULL REFERENCES delivery_services(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    free_shipping_min_cents BIGINT CHECK (free_shipping_min_cents >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (delivery_service_id, name),
+    UNIQUE (id, delivery_service_id)
+);
+
+-- Carries the service ID so that a wilaya belongs to at most one zone of each service
+CREATE TABLE delivery_zone_wilayas (
+    zone_id UUID NOT NULL,
+    delivery_service_id UUID NOT NULL,
+    wilaya_code SMALLINT NOT NULL REFERENCES wilayas(code),
+    PRIMARY KEY (delivery_service_id, wilaya_code),
+    FOREIGN KEY (zone_id, delivery_service_id) REFERENCES delivery_zones(id, delivery_service_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_delivery_zone_wilayas_zone_id ON delivery_zone_wilayas(zone_id);
+
+-- Weight brackets: a parcel is charged the rate with the smallest max_weight_grams it fits under,
+-- or the open-ended rate (NULL max_weight_grams) if it fits under none
+CREATE TABLE delivery_rates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    zone_id UUID NOT NULL REFERENCES delivery_zones(id) ON DELETE CASCADE,
+    delivery_type TEXT NOT NULL CHECK (delivery_type IN ('home', 'stop_desk')),
+    max_weight_grams INTEGER CHECK (max_weight_grams > 0),
+    price_cents BIGINT NOT NULL CHECK (price_cents >= 0),
+    UNIQUE NULLS NOT DISTINCT (zone_id, delivery_type, max_weight_grams)
+);
+
+-- What the customer was charged for delivery; unknown for orders placed before rate tables
+ALTER TABLE orders
+    ADD COLUMN delivery_type TEXT NOT NULL DEFAULT 'home' CHECK (delivery_type IN ('home', 'stop_desk')),
+    ADD COLUMN delivery_fee_cents BIGINT CHECK (delivery_fee_cents >= 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS delivery_fee_cents,
+    DROP COLUMN IF EXISTS delivery_type;
+DROP TABLE IF EXISTS delivery_rates;
+DROP TABLE IF EXISTS delivery_zone_wilayas;
+DROP TABLE IF EXISTS delivery_zones;
+ALTER TABLE delivery_services DROP COLUMN IF EXISTS free_shipping_min_cents;
+ALTER TABLE products DROP COLUMN IF EXISTS weight_grams;
+-- +goose StatementEnd
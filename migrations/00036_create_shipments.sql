@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Name of the courier integration that ships this service's parcels; NULL for services shipped by hand
+ALTER TABLE delivery_services ADD COLUMN courier TEXT;
+
+CREATE TABLE shipments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    courier TEXT NOT NULL,
+    tracking_number TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'created'
+        CHECK (status IN ('created', 'in_transit', 'out_for_delivery', 'delivered', 'failed_attempt', 'returned', 'cancelled')),
+    status_updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(), -- When the courier reported the current status
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (courier, tracking_number)
+);
+
+-- An order has at most one live shipment; a cancelled one can be replaced
+CREATE UNIQUE INDEX idx_shipments_active_order ON shipments(order_id) WHERE status <> 'cancelled';
+-- Shipments the tracking poller still has to follow
+CREATE INDEX idx_shipments_open ON shipments(status_updated_at) WHERE status NOT IN ('delivered', 'returned', 'cancelled');
+
+-- Status history, as reported by the courier's webhooks and tracking API
+CREATE TABLE shipment_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    shipment_id UUID NOT NULL REFERENCES shipments(id) ON DELETE CASCADE,
+    status TEXT NOT NULL
+        CHECK (status IN ('created', 'in_transit', 'out_for_delivery', 'delivered', 'failed_attempt', 'returned', 'cancelled')),
+    description TEXT,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    source TEXT NOT NULL CHECK (source IN ('api', 'webhook', 'poll')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (shipment_id, status, occurred_at) -- The same event may arrive by webhook and by polling
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS shipment_events;
+DROP TABLE IF EXISTS shipments;
+ALTER TABLE delivery_services DROP COLUMN IF EXISTS courier;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Set on every token of a family whose login was completed with a second factor; refreshes carry it over.
+-- Existing sessions start without it, so staff sign in again with 2FA before using the admin area.
+ALTER TABLE refresh_tokens ADD COLUMN mfa BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS mfa;
+-- +goose StatementEnd